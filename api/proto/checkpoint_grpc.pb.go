@@ -20,7 +20,30 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	CheckpointService_Checkpoint_FullMethodName               = "/checkpoint.CheckpointService/Checkpoint"
+	CheckpointService_CheckpointPod_FullMethodName            = "/checkpoint.CheckpointService/CheckpointPod"
 	CheckpointService_ConvertCheckpointToImage_FullMethodName = "/checkpoint.CheckpointService/ConvertCheckpointToImage"
+	CheckpointService_VerifyRestore_FullMethodName            = "/checkpoint.CheckpointService/VerifyRestore"
+	CheckpointService_InspectCheckpoint_FullMethodName        = "/checkpoint.CheckpointService/InspectCheckpoint"
+	CheckpointService_SummarizeCheckpoint_FullMethodName      = "/checkpoint.CheckpointService/SummarizeCheckpoint"
+	CheckpointService_DiffCheckpoints_FullMethodName          = "/checkpoint.CheckpointService/DiffCheckpoints"
+	CheckpointService_EstimateMigration_FullMethodName        = "/checkpoint.CheckpointService/EstimateMigration"
+	CheckpointService_PreflightContainers_FullMethodName      = "/checkpoint.CheckpointService/PreflightContainers"
+	CheckpointService_ProbeConnectivity_FullMethodName        = "/checkpoint.CheckpointService/ProbeConnectivity"
+	CheckpointService_ProbeBandwidth_FullMethodName           = "/checkpoint.CheckpointService/ProbeBandwidth"
+	CheckpointService_ReceiveProbe_FullMethodName             = "/checkpoint.CheckpointService/ReceiveProbe"
+	CheckpointService_OpenTransfer_FullMethodName             = "/checkpoint.CheckpointService/OpenTransfer"
+	CheckpointService_GetNodeClock_FullMethodName             = "/checkpoint.CheckpointService/GetNodeClock"
+	CheckpointService_RestoreVolumes_FullMethodName           = "/checkpoint.CheckpointService/RestoreVolumes"
+	CheckpointService_FreezePod_FullMethodName                = "/checkpoint.CheckpointService/FreezePod"
+	CheckpointService_ConfigureCriu_FullMethodName            = "/checkpoint.CheckpointService/ConfigureCriu"
+	CheckpointService_CheckSecurityProfiles_FullMethodName    = "/checkpoint.CheckpointService/CheckSecurityProfiles"
+	CheckpointService_PullImage_FullMethodName                = "/checkpoint.CheckpointService/PullImage"
+	CheckpointService_CheckRebase_FullMethodName              = "/checkpoint.CheckpointService/CheckRebase"
+	CheckpointService_GetCheckpointRecord_FullMethodName      = "/checkpoint.CheckpointService/GetCheckpointRecord"
+	CheckpointService_DeleteCheckpoint_FullMethodName         = "/checkpoint.CheckpointService/DeleteCheckpoint"
+	CheckpointService_PullCheckpoint_FullMethodName           = "/checkpoint.CheckpointService/PullCheckpoint"
+	CheckpointService_PushCheckpoint_FullMethodName           = "/checkpoint.CheckpointService/PushCheckpoint"
+	CheckpointService_TransferCheckpoint_FullMethodName       = "/checkpoint.CheckpointService/TransferCheckpoint"
 	CheckpointService_Health_FullMethodName                   = "/checkpoint.CheckpointService/Health"
 )
 
@@ -32,8 +55,77 @@ const (
 type CheckpointServiceClient interface {
 	// Checkpoint creates a checkpoint of a container
 	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	// CheckpointPod checkpoints several containers of one pod in a single
+	// call, dumping them in parallel
+	CheckpointPod(ctx context.Context, in *CheckpointPodRequest, opts ...grpc.CallOption) (*CheckpointPodResponse, error)
 	// ConvertCheckpointToImage converts a checkpoint tar file to OCI image format
 	ConvertCheckpointToImage(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// VerifyRestore reports whether a running container was restored from a
+	// checkpoint by the runtime rather than started fresh from its image
+	VerifyRestore(ctx context.Context, in *VerifyRestoreRequest, opts ...grpc.CallOption) (*VerifyRestoreResponse, error)
+	// InspectCheckpoint opens a checkpoint archive and summarizes its contents
+	InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error)
+	// SummarizeCheckpoint extracts the process tree and file descriptor tables
+	// of a checkpoint archive into a small summary stored next to it, which
+	// InspectCheckpoint then answers from without reading the archive
+	SummarizeCheckpoint(ctx context.Context, in *SummarizeCheckpointRequest, opts ...grpc.CallOption) (*SummarizeCheckpointResponse, error)
+	// DiffCheckpoints compares two checkpoint archives of the same container
+	DiffCheckpoints(ctx context.Context, in *DiffCheckpointsRequest, opts ...grpc.CallOption) (*DiffCheckpointsResponse, error)
+	// EstimateMigration measures the inputs of a migration time prediction:
+	// container memory, memory dirty rate, and shared storage throughput
+	EstimateMigration(ctx context.Context, in *EstimateMigrationRequest, opts ...grpc.CallOption) (*EstimateMigrationResponse, error)
+	// PreflightContainers reports properties of running containers that decide
+	// whether CRIU can checkpoint and restore them
+	PreflightContainers(ctx context.Context, in *PreflightContainersRequest, opts ...grpc.CallOption) (*PreflightContainersResponse, error)
+	// ProbeConnectivity opens TCP connections from the agent's node, e.g. to
+	// check a restored pod is reachable
+	ProbeConnectivity(ctx context.Context, in *ProbeConnectivityRequest, opts ...grpc.CallOption) (*ProbeConnectivityResponse, error)
+	// ProbeBandwidth streams data from the agent's node to the agent at
+	// target_endpoint for a short time and reports the throughput
+	ProbeBandwidth(ctx context.Context, in *ProbeBandwidthRequest, opts ...grpc.CallOption) (*ProbeBandwidthResponse, error)
+	// ReceiveProbe discards the data another agent's ProbeBandwidth streams
+	// and reports how much arrived over how long
+	ReceiveProbe(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ProbeChunk, ReceiveProbeResponse], error)
+	// OpenTransfer generates the key the agent receives the streams of a
+	// transfer from another agent with, and returns its certificate for the
+	// sending agent to pin
+	OpenTransfer(ctx context.Context, in *OpenTransferRequest, opts ...grpc.CallOption) (*OpenTransferResponse, error)
+	// GetNodeClock reads the node's clocks and whether its kernel supports time
+	// namespaces
+	GetNodeClock(ctx context.Context, in *GetNodeClockRequest, opts ...grpc.CallOption) (*GetNodeClockResponse, error)
+	// RestoreVolumes fills the memory-backed emptyDir volumes of a restored
+	// pod from the archives taken at checkpoint time
+	RestoreVolumes(ctx context.Context, in *RestoreVolumesRequest, opts ...grpc.CallOption) (*RestoreVolumesResponse, error)
+	// FreezePod freezes or thaws every container of a pod at once through the
+	// pod's cgroup
+	FreezePod(ctx context.Context, in *FreezePodRequest, opts ...grpc.CallOption) (*FreezePodResponse, error)
+	// ConfigureCriu adds options to the node's CRIU configuration for runc,
+	// which the runtime uses for every dump and restore
+	ConfigureCriu(ctx context.Context, in *ConfigureCriuRequest, opts ...grpc.CallOption) (*ConfigureCriuResponse, error)
+	// CheckSecurityProfiles reports which seccomp and AppArmor profiles are
+	// missing on the node and which security modules it runs
+	CheckSecurityProfiles(ctx context.Context, in *CheckSecurityProfilesRequest, opts ...grpc.CallOption) (*CheckSecurityProfilesResponse, error)
+	// PullImage pulls a checkpoint image from the checkpoint registry into
+	// the node's container storage
+	PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (*PullImageResponse, error)
+	// CheckRebase checks whether a checkpoint can be restored on top of
+	// another base image than the one it was taken on
+	CheckRebase(ctx context.Context, in *CheckRebaseRequest, opts ...grpc.CallOption) (*CheckRebaseResponse, error)
+	// GetCheckpointRecord reports what the agent's ledger recorded for a
+	// checkpoint request, also across agent restarts
+	GetCheckpointRecord(ctx context.Context, in *GetCheckpointRecordRequest, opts ...grpc.CallOption) (*GetCheckpointRecordResponse, error)
+	// DeleteCheckpoint removes checkpoint archives from the node or shared
+	// storage
+	DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error)
+	// PullCheckpoint streams a checkpoint archive the agent holds, on its node
+	// or in shared storage, in chunks
+	PullCheckpoint(ctx context.Context, in *PullCheckpointRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckpointChunk], error)
+	// PushCheckpoint receives a checkpoint archive streamed in chunks, e.g.
+	// pulled from another node's agent, and keeps it on the agent's node
+	PushCheckpoint(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CheckpointChunk, PushCheckpointResponse], error)
+	// TransferCheckpoint pushes a checkpoint archive the agent holds straight
+	// to the agent at target_endpoint, encrypted with its transfer key
+	TransferCheckpoint(ctx context.Context, in *TransferCheckpointRequest, opts ...grpc.CallOption) (*TransferCheckpointResponse, error)
 	// Health check for the service
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
@@ -56,6 +148,16 @@ func (c *checkpointServiceClient) Checkpoint(ctx context.Context, in *Checkpoint
 	return out, nil
 }
 
+func (c *checkpointServiceClient) CheckpointPod(ctx context.Context, in *CheckpointPodRequest, opts ...grpc.CallOption) (*CheckpointPodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckpointPodResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_CheckpointPod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *checkpointServiceClient) ConvertCheckpointToImage(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ConvertResponse)
@@ -66,6 +168,241 @@ func (c *checkpointServiceClient) ConvertCheckpointToImage(ctx context.Context,
 	return out, nil
 }
 
+func (c *checkpointServiceClient) VerifyRestore(ctx context.Context, in *VerifyRestoreRequest, opts ...grpc.CallOption) (*VerifyRestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyRestoreResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_VerifyRestore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) InspectCheckpoint(ctx context.Context, in *InspectCheckpointRequest, opts ...grpc.CallOption) (*InspectCheckpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InspectCheckpointResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_InspectCheckpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) SummarizeCheckpoint(ctx context.Context, in *SummarizeCheckpointRequest, opts ...grpc.CallOption) (*SummarizeCheckpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummarizeCheckpointResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_SummarizeCheckpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) DiffCheckpoints(ctx context.Context, in *DiffCheckpointsRequest, opts ...grpc.CallOption) (*DiffCheckpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffCheckpointsResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_DiffCheckpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) EstimateMigration(ctx context.Context, in *EstimateMigrationRequest, opts ...grpc.CallOption) (*EstimateMigrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateMigrationResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_EstimateMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) PreflightContainers(ctx context.Context, in *PreflightContainersRequest, opts ...grpc.CallOption) (*PreflightContainersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreflightContainersResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_PreflightContainers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) ProbeConnectivity(ctx context.Context, in *ProbeConnectivityRequest, opts ...grpc.CallOption) (*ProbeConnectivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbeConnectivityResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_ProbeConnectivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) ProbeBandwidth(ctx context.Context, in *ProbeBandwidthRequest, opts ...grpc.CallOption) (*ProbeBandwidthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProbeBandwidthResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_ProbeBandwidth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) ReceiveProbe(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ProbeChunk, ReceiveProbeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CheckpointService_ServiceDesc.Streams[0], CheckpointService_ReceiveProbe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ProbeChunk, ReceiveProbeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_ReceiveProbeClient = grpc.ClientStreamingClient[ProbeChunk, ReceiveProbeResponse]
+
+func (c *checkpointServiceClient) OpenTransfer(ctx context.Context, in *OpenTransferRequest, opts ...grpc.CallOption) (*OpenTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenTransferResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_OpenTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) GetNodeClock(ctx context.Context, in *GetNodeClockRequest, opts ...grpc.CallOption) (*GetNodeClockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNodeClockResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_GetNodeClock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) RestoreVolumes(ctx context.Context, in *RestoreVolumesRequest, opts ...grpc.CallOption) (*RestoreVolumesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreVolumesResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_RestoreVolumes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) FreezePod(ctx context.Context, in *FreezePodRequest, opts ...grpc.CallOption) (*FreezePodResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreezePodResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_FreezePod_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) ConfigureCriu(ctx context.Context, in *ConfigureCriuRequest, opts ...grpc.CallOption) (*ConfigureCriuResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigureCriuResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_ConfigureCriu_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) CheckSecurityProfiles(ctx context.Context, in *CheckSecurityProfilesRequest, opts ...grpc.CallOption) (*CheckSecurityProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckSecurityProfilesResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_CheckSecurityProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) PullImage(ctx context.Context, in *PullImageRequest, opts ...grpc.CallOption) (*PullImageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PullImageResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_PullImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) CheckRebase(ctx context.Context, in *CheckRebaseRequest, opts ...grpc.CallOption) (*CheckRebaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckRebaseResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_CheckRebase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) GetCheckpointRecord(ctx context.Context, in *GetCheckpointRecordRequest, opts ...grpc.CallOption) (*GetCheckpointRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCheckpointRecordResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_GetCheckpointRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCheckpointResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_DeleteCheckpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointServiceClient) PullCheckpoint(ctx context.Context, in *PullCheckpointRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckpointChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CheckpointService_ServiceDesc.Streams[1], CheckpointService_PullCheckpoint_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PullCheckpointRequest, CheckpointChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_PullCheckpointClient = grpc.ServerStreamingClient[CheckpointChunk]
+
+func (c *checkpointServiceClient) PushCheckpoint(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CheckpointChunk, PushCheckpointResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CheckpointService_ServiceDesc.Streams[2], CheckpointService_PushCheckpoint_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CheckpointChunk, PushCheckpointResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_PushCheckpointClient = grpc.ClientStreamingClient[CheckpointChunk, PushCheckpointResponse]
+
+func (c *checkpointServiceClient) TransferCheckpoint(ctx context.Context, in *TransferCheckpointRequest, opts ...grpc.CallOption) (*TransferCheckpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferCheckpointResponse)
+	err := c.cc.Invoke(ctx, CheckpointService_TransferCheckpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *checkpointServiceClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthResponse)
@@ -84,8 +421,77 @@ func (c *checkpointServiceClient) Health(ctx context.Context, in *HealthRequest,
 type CheckpointServiceServer interface {
 	// Checkpoint creates a checkpoint of a container
 	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
+	// CheckpointPod checkpoints several containers of one pod in a single
+	// call, dumping them in parallel
+	CheckpointPod(context.Context, *CheckpointPodRequest) (*CheckpointPodResponse, error)
 	// ConvertCheckpointToImage converts a checkpoint tar file to OCI image format
 	ConvertCheckpointToImage(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// VerifyRestore reports whether a running container was restored from a
+	// checkpoint by the runtime rather than started fresh from its image
+	VerifyRestore(context.Context, *VerifyRestoreRequest) (*VerifyRestoreResponse, error)
+	// InspectCheckpoint opens a checkpoint archive and summarizes its contents
+	InspectCheckpoint(context.Context, *InspectCheckpointRequest) (*InspectCheckpointResponse, error)
+	// SummarizeCheckpoint extracts the process tree and file descriptor tables
+	// of a checkpoint archive into a small summary stored next to it, which
+	// InspectCheckpoint then answers from without reading the archive
+	SummarizeCheckpoint(context.Context, *SummarizeCheckpointRequest) (*SummarizeCheckpointResponse, error)
+	// DiffCheckpoints compares two checkpoint archives of the same container
+	DiffCheckpoints(context.Context, *DiffCheckpointsRequest) (*DiffCheckpointsResponse, error)
+	// EstimateMigration measures the inputs of a migration time prediction:
+	// container memory, memory dirty rate, and shared storage throughput
+	EstimateMigration(context.Context, *EstimateMigrationRequest) (*EstimateMigrationResponse, error)
+	// PreflightContainers reports properties of running containers that decide
+	// whether CRIU can checkpoint and restore them
+	PreflightContainers(context.Context, *PreflightContainersRequest) (*PreflightContainersResponse, error)
+	// ProbeConnectivity opens TCP connections from the agent's node, e.g. to
+	// check a restored pod is reachable
+	ProbeConnectivity(context.Context, *ProbeConnectivityRequest) (*ProbeConnectivityResponse, error)
+	// ProbeBandwidth streams data from the agent's node to the agent at
+	// target_endpoint for a short time and reports the throughput
+	ProbeBandwidth(context.Context, *ProbeBandwidthRequest) (*ProbeBandwidthResponse, error)
+	// ReceiveProbe discards the data another agent's ProbeBandwidth streams
+	// and reports how much arrived over how long
+	ReceiveProbe(grpc.ClientStreamingServer[ProbeChunk, ReceiveProbeResponse]) error
+	// OpenTransfer generates the key the agent receives the streams of a
+	// transfer from another agent with, and returns its certificate for the
+	// sending agent to pin
+	OpenTransfer(context.Context, *OpenTransferRequest) (*OpenTransferResponse, error)
+	// GetNodeClock reads the node's clocks and whether its kernel supports time
+	// namespaces
+	GetNodeClock(context.Context, *GetNodeClockRequest) (*GetNodeClockResponse, error)
+	// RestoreVolumes fills the memory-backed emptyDir volumes of a restored
+	// pod from the archives taken at checkpoint time
+	RestoreVolumes(context.Context, *RestoreVolumesRequest) (*RestoreVolumesResponse, error)
+	// FreezePod freezes or thaws every container of a pod at once through the
+	// pod's cgroup
+	FreezePod(context.Context, *FreezePodRequest) (*FreezePodResponse, error)
+	// ConfigureCriu adds options to the node's CRIU configuration for runc,
+	// which the runtime uses for every dump and restore
+	ConfigureCriu(context.Context, *ConfigureCriuRequest) (*ConfigureCriuResponse, error)
+	// CheckSecurityProfiles reports which seccomp and AppArmor profiles are
+	// missing on the node and which security modules it runs
+	CheckSecurityProfiles(context.Context, *CheckSecurityProfilesRequest) (*CheckSecurityProfilesResponse, error)
+	// PullImage pulls a checkpoint image from the checkpoint registry into
+	// the node's container storage
+	PullImage(context.Context, *PullImageRequest) (*PullImageResponse, error)
+	// CheckRebase checks whether a checkpoint can be restored on top of
+	// another base image than the one it was taken on
+	CheckRebase(context.Context, *CheckRebaseRequest) (*CheckRebaseResponse, error)
+	// GetCheckpointRecord reports what the agent's ledger recorded for a
+	// checkpoint request, also across agent restarts
+	GetCheckpointRecord(context.Context, *GetCheckpointRecordRequest) (*GetCheckpointRecordResponse, error)
+	// DeleteCheckpoint removes checkpoint archives from the node or shared
+	// storage
+	DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*DeleteCheckpointResponse, error)
+	// PullCheckpoint streams a checkpoint archive the agent holds, on its node
+	// or in shared storage, in chunks
+	PullCheckpoint(*PullCheckpointRequest, grpc.ServerStreamingServer[CheckpointChunk]) error
+	// PushCheckpoint receives a checkpoint archive streamed in chunks, e.g.
+	// pulled from another node's agent, and keeps it on the agent's node
+	PushCheckpoint(grpc.ClientStreamingServer[CheckpointChunk, PushCheckpointResponse]) error
+	// TransferCheckpoint pushes a checkpoint archive the agent holds straight
+	// to the agent at target_endpoint, encrypted with its transfer key
+	TransferCheckpoint(context.Context, *TransferCheckpointRequest) (*TransferCheckpointResponse, error)
 	// Health check for the service
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 	mustEmbedUnimplementedCheckpointServiceServer()
@@ -101,9 +507,78 @@ type UnimplementedCheckpointServiceServer struct{}
 func (UnimplementedCheckpointServiceServer) Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
 }
+func (UnimplementedCheckpointServiceServer) CheckpointPod(context.Context, *CheckpointPodRequest) (*CheckpointPodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointPod not implemented")
+}
 func (UnimplementedCheckpointServiceServer) ConvertCheckpointToImage(context.Context, *ConvertRequest) (*ConvertResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConvertCheckpointToImage not implemented")
 }
+func (UnimplementedCheckpointServiceServer) VerifyRestore(context.Context, *VerifyRestoreRequest) (*VerifyRestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyRestore not implemented")
+}
+func (UnimplementedCheckpointServiceServer) InspectCheckpoint(context.Context, *InspectCheckpointRequest) (*InspectCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectCheckpoint not implemented")
+}
+func (UnimplementedCheckpointServiceServer) SummarizeCheckpoint(context.Context, *SummarizeCheckpointRequest) (*SummarizeCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeCheckpoint not implemented")
+}
+func (UnimplementedCheckpointServiceServer) DiffCheckpoints(context.Context, *DiffCheckpointsRequest) (*DiffCheckpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffCheckpoints not implemented")
+}
+func (UnimplementedCheckpointServiceServer) EstimateMigration(context.Context, *EstimateMigrationRequest) (*EstimateMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateMigration not implemented")
+}
+func (UnimplementedCheckpointServiceServer) PreflightContainers(context.Context, *PreflightContainersRequest) (*PreflightContainersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreflightContainers not implemented")
+}
+func (UnimplementedCheckpointServiceServer) ProbeConnectivity(context.Context, *ProbeConnectivityRequest) (*ProbeConnectivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeConnectivity not implemented")
+}
+func (UnimplementedCheckpointServiceServer) ProbeBandwidth(context.Context, *ProbeBandwidthRequest) (*ProbeBandwidthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeBandwidth not implemented")
+}
+func (UnimplementedCheckpointServiceServer) ReceiveProbe(grpc.ClientStreamingServer[ProbeChunk, ReceiveProbeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveProbe not implemented")
+}
+func (UnimplementedCheckpointServiceServer) OpenTransfer(context.Context, *OpenTransferRequest) (*OpenTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenTransfer not implemented")
+}
+func (UnimplementedCheckpointServiceServer) GetNodeClock(context.Context, *GetNodeClockRequest) (*GetNodeClockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeClock not implemented")
+}
+func (UnimplementedCheckpointServiceServer) RestoreVolumes(context.Context, *RestoreVolumesRequest) (*RestoreVolumesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreVolumes not implemented")
+}
+func (UnimplementedCheckpointServiceServer) FreezePod(context.Context, *FreezePodRequest) (*FreezePodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezePod not implemented")
+}
+func (UnimplementedCheckpointServiceServer) ConfigureCriu(context.Context, *ConfigureCriuRequest) (*ConfigureCriuResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureCriu not implemented")
+}
+func (UnimplementedCheckpointServiceServer) CheckSecurityProfiles(context.Context, *CheckSecurityProfilesRequest) (*CheckSecurityProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckSecurityProfiles not implemented")
+}
+func (UnimplementedCheckpointServiceServer) PullImage(context.Context, *PullImageRequest) (*PullImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PullImage not implemented")
+}
+func (UnimplementedCheckpointServiceServer) CheckRebase(context.Context, *CheckRebaseRequest) (*CheckRebaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRebase not implemented")
+}
+func (UnimplementedCheckpointServiceServer) GetCheckpointRecord(context.Context, *GetCheckpointRecordRequest) (*GetCheckpointRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheckpointRecord not implemented")
+}
+func (UnimplementedCheckpointServiceServer) DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*DeleteCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCheckpoint not implemented")
+}
+func (UnimplementedCheckpointServiceServer) PullCheckpoint(*PullCheckpointRequest, grpc.ServerStreamingServer[CheckpointChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method PullCheckpoint not implemented")
+}
+func (UnimplementedCheckpointServiceServer) PushCheckpoint(grpc.ClientStreamingServer[CheckpointChunk, PushCheckpointResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PushCheckpoint not implemented")
+}
+func (UnimplementedCheckpointServiceServer) TransferCheckpoint(context.Context, *TransferCheckpointRequest) (*TransferCheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferCheckpoint not implemented")
+}
 func (UnimplementedCheckpointServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
@@ -146,6 +621,24 @@ func _CheckpointService_Checkpoint_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CheckpointService_CheckpointPod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointPodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).CheckpointPod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_CheckpointPod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).CheckpointPod(ctx, req.(*CheckpointPodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CheckpointService_ConvertCheckpointToImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConvertRequest)
 	if err := dec(in); err != nil {
@@ -164,6 +657,373 @@ func _CheckpointService_ConvertCheckpointToImage_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CheckpointService_VerifyRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).VerifyRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_VerifyRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).VerifyRestore(ctx, req.(*VerifyRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_InspectCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).InspectCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_InspectCheckpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).InspectCheckpoint(ctx, req.(*InspectCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_SummarizeCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummarizeCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).SummarizeCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_SummarizeCheckpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).SummarizeCheckpoint(ctx, req.(*SummarizeCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_DiffCheckpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffCheckpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).DiffCheckpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_DiffCheckpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).DiffCheckpoints(ctx, req.(*DiffCheckpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_EstimateMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).EstimateMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_EstimateMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).EstimateMigration(ctx, req.(*EstimateMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_PreflightContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreflightContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).PreflightContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_PreflightContainers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).PreflightContainers(ctx, req.(*PreflightContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_ProbeConnectivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeConnectivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).ProbeConnectivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_ProbeConnectivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).ProbeConnectivity(ctx, req.(*ProbeConnectivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_ProbeBandwidth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeBandwidthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).ProbeBandwidth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_ProbeBandwidth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).ProbeBandwidth(ctx, req.(*ProbeBandwidthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_ReceiveProbe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CheckpointServiceServer).ReceiveProbe(&grpc.GenericServerStream[ProbeChunk, ReceiveProbeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_ReceiveProbeServer = grpc.ClientStreamingServer[ProbeChunk, ReceiveProbeResponse]
+
+func _CheckpointService_OpenTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).OpenTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_OpenTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).OpenTransfer(ctx, req.(*OpenTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_GetNodeClock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeClockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).GetNodeClock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_GetNodeClock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).GetNodeClock(ctx, req.(*GetNodeClockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_RestoreVolumes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreVolumesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).RestoreVolumes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_RestoreVolumes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).RestoreVolumes(ctx, req.(*RestoreVolumesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_FreezePod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezePodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).FreezePod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_FreezePod_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).FreezePod(ctx, req.(*FreezePodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_ConfigureCriu_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureCriuRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).ConfigureCriu(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_ConfigureCriu_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).ConfigureCriu(ctx, req.(*ConfigureCriuRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_CheckSecurityProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckSecurityProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).CheckSecurityProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_CheckSecurityProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).CheckSecurityProfiles(ctx, req.(*CheckSecurityProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_PullImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).PullImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_PullImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).PullImage(ctx, req.(*PullImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_CheckRebase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRebaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).CheckRebase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_CheckRebase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).CheckRebase(ctx, req.(*CheckRebaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_GetCheckpointRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCheckpointRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).GetCheckpointRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_GetCheckpointRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).GetCheckpointRecord(ctx, req.(*GetCheckpointRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).DeleteCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_DeleteCheckpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).DeleteCheckpoint(ctx, req.(*DeleteCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointService_PullCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PullCheckpointRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CheckpointServiceServer).PullCheckpoint(m, &grpc.GenericServerStream[PullCheckpointRequest, CheckpointChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_PullCheckpointServer = grpc.ServerStreamingServer[CheckpointChunk]
+
+func _CheckpointService_PushCheckpoint_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CheckpointServiceServer).PushCheckpoint(&grpc.GenericServerStream[CheckpointChunk, PushCheckpointResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckpointService_PushCheckpointServer = grpc.ClientStreamingServer[CheckpointChunk, PushCheckpointResponse]
+
+func _CheckpointService_TransferCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointServiceServer).TransferCheckpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckpointService_TransferCheckpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointServiceServer).TransferCheckpoint(ctx, req.(*TransferCheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CheckpointService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(HealthRequest)
 	if err := dec(in); err != nil {
@@ -193,15 +1053,111 @@ var CheckpointService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Checkpoint",
 			Handler:    _CheckpointService_Checkpoint_Handler,
 		},
+		{
+			MethodName: "CheckpointPod",
+			Handler:    _CheckpointService_CheckpointPod_Handler,
+		},
 		{
 			MethodName: "ConvertCheckpointToImage",
 			Handler:    _CheckpointService_ConvertCheckpointToImage_Handler,
 		},
+		{
+			MethodName: "VerifyRestore",
+			Handler:    _CheckpointService_VerifyRestore_Handler,
+		},
+		{
+			MethodName: "InspectCheckpoint",
+			Handler:    _CheckpointService_InspectCheckpoint_Handler,
+		},
+		{
+			MethodName: "SummarizeCheckpoint",
+			Handler:    _CheckpointService_SummarizeCheckpoint_Handler,
+		},
+		{
+			MethodName: "DiffCheckpoints",
+			Handler:    _CheckpointService_DiffCheckpoints_Handler,
+		},
+		{
+			MethodName: "EstimateMigration",
+			Handler:    _CheckpointService_EstimateMigration_Handler,
+		},
+		{
+			MethodName: "PreflightContainers",
+			Handler:    _CheckpointService_PreflightContainers_Handler,
+		},
+		{
+			MethodName: "ProbeConnectivity",
+			Handler:    _CheckpointService_ProbeConnectivity_Handler,
+		},
+		{
+			MethodName: "ProbeBandwidth",
+			Handler:    _CheckpointService_ProbeBandwidth_Handler,
+		},
+		{
+			MethodName: "OpenTransfer",
+			Handler:    _CheckpointService_OpenTransfer_Handler,
+		},
+		{
+			MethodName: "GetNodeClock",
+			Handler:    _CheckpointService_GetNodeClock_Handler,
+		},
+		{
+			MethodName: "RestoreVolumes",
+			Handler:    _CheckpointService_RestoreVolumes_Handler,
+		},
+		{
+			MethodName: "FreezePod",
+			Handler:    _CheckpointService_FreezePod_Handler,
+		},
+		{
+			MethodName: "ConfigureCriu",
+			Handler:    _CheckpointService_ConfigureCriu_Handler,
+		},
+		{
+			MethodName: "CheckSecurityProfiles",
+			Handler:    _CheckpointService_CheckSecurityProfiles_Handler,
+		},
+		{
+			MethodName: "PullImage",
+			Handler:    _CheckpointService_PullImage_Handler,
+		},
+		{
+			MethodName: "CheckRebase",
+			Handler:    _CheckpointService_CheckRebase_Handler,
+		},
+		{
+			MethodName: "GetCheckpointRecord",
+			Handler:    _CheckpointService_GetCheckpointRecord_Handler,
+		},
+		{
+			MethodName: "DeleteCheckpoint",
+			Handler:    _CheckpointService_DeleteCheckpoint_Handler,
+		},
+		{
+			MethodName: "TransferCheckpoint",
+			Handler:    _CheckpointService_TransferCheckpoint_Handler,
+		},
 		{
 			MethodName: "Health",
 			Handler:    _CheckpointService_Health_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReceiveProbe",
+			Handler:       _CheckpointService_ReceiveProbe_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PullCheckpoint",
+			Handler:       _CheckpointService_PullCheckpoint_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PushCheckpoint",
+			Handler:       _CheckpointService_PushCheckpoint_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "api/proto/checkpoint.proto",
 }
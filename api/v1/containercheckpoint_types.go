@@ -18,6 +18,7 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type ContainerCheckpointPhase string
@@ -36,6 +37,26 @@ const (
 type ContainerCheckpointSpec struct {
 	PodName       string `json:"podName"`
 	ContainerName string `json:"containerName"`
+
+	// TerminateExecSessions kills the container's kubectl exec sessions
+	// before it is dumped instead of failing the checkpoint.
+	// +optional
+	TerminateExecSessions bool `json:"terminateExecSessions,omitempty"`
+
+	// PodUID, if set, is the UID the pod must have; the checkpoint fails
+	// rather than dump a pod recreated under PodName.
+	// +optional
+	PodUID types.UID `json:"podUID,omitempty"`
+
+	// Compression of the archive copied to shared storage. Empty copies it
+	// uncompressed.
+	// +optional
+	Compression CheckpointCompression `json:"compression,omitempty"`
+
+	// ArtifactTransfer is how the archive reaches the node it is restored
+	// on. Direct keeps it on the node it was taken on.
+	// +optional
+	ArtifactTransfer CheckpointTransfer `json:"artifactTransfer,omitempty"`
 }
 
 // ContainerCheckpointStatus defines the observed state of ContainerCheckpoint.
@@ -50,6 +71,7 @@ type ContainerCheckpointStatus struct {
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
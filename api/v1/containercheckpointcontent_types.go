@@ -36,6 +36,38 @@ type ContainerCheckpointContentSpec struct {
 
 	// ArtifactURI: path/URI to the checkpoint bundle (runtime-defined).
 	ArtifactURI string `json:"artifactURI"`
+
+	// NodeName: node the container ran on when it was checkpointed, which
+	// holds the bundle when it is not in shared storage.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// ArtifactDigest: sha256 digest of the bundle, in algorithm:hex form.
+	// +optional
+	ArtifactDigest string `json:"artifactDigest,omitempty"`
+
+	// ArtifactSizeBytes: size of the bundle.
+	// +optional
+	ArtifactSizeBytes int64 `json:"artifactSizeBytes,omitempty"`
+
+	// Compression of the bundle; empty if it is not compressed.
+	// +optional
+	Compression CheckpointCompression `json:"compression,omitempty"`
+
+	// ArtifactTransfer is how the bundle reaches the node it is restored
+	// on; Direct for one kept on its node to be pushed by its agent.
+	// +optional
+	ArtifactTransfer CheckpointTransfer `json:"artifactTransfer,omitempty"`
+
+	// VolumesArtifactURI: archive of the memory-backed emptyDir volumes
+	// first mounted by this container, taken right after its checkpoint.
+	// +optional
+	VolumesArtifactURI string `json:"volumesArtifactURI,omitempty"`
+
+	// CriuOptions the container was dumped with, which restoring it needs
+	// too (e.g. shell-job for a container with a TTY).
+	// +optional
+	CriuOptions []string `json:"criuOptions,omitempty"`
 }
 
 // ContainerCheckpointContentStatus defines the observed state of ContainerCheckpointContent.
@@ -44,8 +76,31 @@ type ContainerCheckpointContentStatus struct {
 	Ready        bool         `json:"ready,omitempty"`
 	Message      string       `json:"message,omitempty"`
 	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// ImageReference: OCI image the bundle was last converted to for a
+	// restore. For a bundle moved through a checkpoint registry, the
+	// reference it was pushed to, which later restores pull rather than
+	// convert it again.
+	// +optional
+	ImageReference string `json:"imageReference,omitempty"`
+
+	// Redactions: redaction hooks the agent ran on the bundle, in order,
+	// before it left the node.
+	// +optional
+	Redactions []CheckpointRedaction `json:"redactions,omitempty"`
+}
+
+// CheckpointRedaction is a redaction hook the agent ran on a bundle.
+type CheckpointRedaction struct {
+	// Name of the hook: Paths, Env, or Exec:<executable>.
+	Name string `json:"name"`
+	// Detail of what the hook removed.
+	// +optional
+	Detail string `json:"detail,omitempty"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
@@ -0,0 +1,344 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalStage is where a migration waits for approval.
+// +kubebuilder:validation:Enum=BeforeCheckpoint;BeforeRestore
+type ApprovalStage string
+
+const (
+	// ApprovalStageBeforeCheckpoint waits after preflight, before the pod
+	// is checkpointed.
+	ApprovalStageBeforeCheckpoint ApprovalStage = "BeforeCheckpoint"
+	// ApprovalStageBeforeRestore waits once the checkpoint is taken, before
+	// the pod is restored on the target node.
+	ApprovalStageBeforeRestore ApprovalStage = "BeforeRestore"
+)
+
+// OrphanedRestoredPodPolicy decides what happens to a restored pod left
+// behind by a migration that failed or was deleted, e.g. after a controller
+// crash.
+// +kubebuilder:validation:Enum=Delete;Adopt;Keep
+type OrphanedRestoredPodPolicy string
+
+const (
+	// OrphanedRestoredPodDelete deletes the restored pod while its source
+	// pod is still there. A restored pod whose source pod is gone is the
+	// only copy left and is adopted instead.
+	OrphanedRestoredPodDelete OrphanedRestoredPodPolicy = "Delete"
+	// OrphanedRestoredPodAdopt records the restored pod as the result of a
+	// new, succeeded PodMigration, which deletes the source pod.
+	OrphanedRestoredPodAdopt OrphanedRestoredPodPolicy = "Adopt"
+	// OrphanedRestoredPodKeep leaves the restored pod alone.
+	OrphanedRestoredPodKeep OrphanedRestoredPodPolicy = "Keep"
+)
+
+// CriticalPodPolicy decides whether pods with a system critical priority,
+// such as cluster add-ons, may be checkpointed and migrated.
+// +kubebuilder:validation:Enum=Refuse;Allow
+type CriticalPodPolicy string
+
+const (
+	// CriticalPodsRefuse refuses to checkpoint or migrate them.
+	CriticalPodsRefuse CriticalPodPolicy = "Refuse"
+	// CriticalPodsAllow checkpoints and migrates them like any other pod.
+	CriticalPodsAllow CriticalPodPolicy = "Allow"
+)
+
+// MigrationTriggerType is a node condition that moves the pods a policy
+// selects off the node.
+// +kubebuilder:validation:Enum=NodeCordoned;MemoryPressure;DiskPressure;PIDPressure
+type MigrationTriggerType string
+
+const (
+	// MigrationTriggerNodeCordoned fires when the node is marked
+	// unschedulable, e.g. by kubectl cordon or drain.
+	MigrationTriggerNodeCordoned MigrationTriggerType = "NodeCordoned"
+	// MigrationTriggerMemoryPressure fires while the node reports the
+	// MemoryPressure condition.
+	MigrationTriggerMemoryPressure MigrationTriggerType = "MemoryPressure"
+	// MigrationTriggerDiskPressure fires while the node reports the
+	// DiskPressure condition.
+	MigrationTriggerDiskPressure MigrationTriggerType = "DiskPressure"
+	// MigrationTriggerPIDPressure fires while the node reports the
+	// PIDPressure condition.
+	MigrationTriggerPIDPressure MigrationTriggerType = "PIDPressure"
+)
+
+// MigrationPolicyLabel on a PodMigration names the MigrationPolicy whose
+// trigger created it. The policy does not own the migration, so deleting the
+// policy leaves it to finish.
+const MigrationPolicyLabel = "lpm.my.domain/migration-policy"
+
+// MigrationTriggerLabel on a PodMigration is the type of the trigger that
+// created it.
+const MigrationTriggerLabel = "lpm.my.domain/migration-trigger"
+
+// MigrationTrigger is a node condition on which the selected pods on the
+// node are migrated away.
+type MigrationTrigger struct {
+	// Type is the node condition.
+	Type MigrationTriggerType `json:"type"`
+
+	// For is how long a pressure condition must hold before the pods are
+	// migrated, so that a short spike moves nothing. A cordon fires at once.
+	// +optional
+	For *metav1.Duration `json:"for,omitempty"`
+}
+
+// MigrationPolicySpec defines the desired state of MigrationPolicy.
+type MigrationPolicySpec struct {
+	// NamespaceSelector selects the namespaces of the pods the policy applies
+	// to. Unset selects every namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// PodSelector selects the pods the policy applies to within those
+	// namespaces. Unset selects every pod.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// TargetNodes restricts the nodes the selected pods may be restored on.
+	// +optional
+	TargetNodes *TargetNodePolicy `json:"targetNodes,omitempty"`
+
+	// RequireApproval pauses migrations of the selected pods at the stage
+	// until their Approved condition is set to True. When several policies
+	// require approval, the earliest stage applies.
+	// +optional
+	RequireApproval ApprovalStage `json:"requireApproval,omitempty"`
+
+	// Notifications are sent as migrations of the selected pods start and
+	// end.
+	// +optional
+	Notifications []NotificationSink `json:"notifications,omitempty"`
+
+	// OrphanedRestoredPods decides what happens to restored pods of the
+	// selected pods whose migration failed or is gone. Defaults to Delete;
+	// when several policies set it, the first by name applies.
+	// +optional
+	OrphanedRestoredPods OrphanedRestoredPodPolicy `json:"orphanedRestoredPods,omitempty"`
+
+	// MaxCheckpointAge is the age of the oldest checkpoint of the selected
+	// pods that migrations and PodRestores restore, unless their checkpoint
+	// reference sets allowStale. Their PodCheckpoints report the Fresh
+	// condition against it. When several policies set it, the shortest
+	// applies.
+	// +optional
+	MaxCheckpointAge *metav1.Duration `json:"maxCheckpointAge,omitempty"`
+
+	// CriticalPods decides whether selected pods with a system critical
+	// priority class may be checkpointed and migrated. Defaults to Refuse;
+	// they are only allowed when a policy allows them and none refuses them.
+	// +optional
+	CriticalPods CriticalPodPolicy `json:"criticalPods,omitempty"`
+
+	// TargetPreemption lets migrations of the selected pods evict pods of
+	// lower priority from a target node that cannot fit them otherwise.
+	// Unset, a migration never evicts other pods.
+	// +optional
+	TargetPreemption *TargetPreemptionPolicy `json:"targetPreemption,omitempty"`
+
+	// Triggers migrate the selected pods off a node as soon as one of them
+	// fires on it, without anyone creating PodMigrations: the controller
+	// creates one per pod, with no target node, so that the pod goes to a
+	// node TargetNodes allows. Pods a drain would leave on the node, pods
+	// already being migrated and nodes under a NodeMaintenance are skipped.
+	// +optional
+	Triggers []MigrationTrigger `json:"triggers,omitempty"`
+
+	// MaxConcurrentMigrations is how many migrations the triggers of the
+	// policy run at the same time. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentMigrations int32 `json:"maxConcurrentMigrations,omitempty"`
+}
+
+// TargetPreemptionPolicy limits the pods a migration may evict from its
+// target node to make room for the restored pod, as the scheduler would
+// preempt them for a pod it places: only pods of lower priority than the
+// migrated pod are evicted, through the eviction API so that their
+// PodDisruptionBudgets hold, and only if the pod's preemptionPolicy is not
+// Never.
+type TargetPreemptionPolicy struct {
+	// MaxVictimPriority is the highest priority of the pods that may be
+	// evicted. Pods above it, and pods with a system critical priority,
+	// are never touched. When several policies set it, the lowest applies.
+	// +kubebuilder:default=0
+	// +optional
+	MaxVictimPriority int32 `json:"maxVictimPriority,omitempty"`
+}
+
+// TargetNodePolicy restricts migration destinations. A node must pass every
+// selector and list that is set.
+type TargetNodePolicy struct {
+	// AllowedSelector selects the only nodes pods may be restored on.
+	// +optional
+	AllowedSelector *metav1.LabelSelector `json:"allowedSelector,omitempty"`
+
+	// DeniedSelector selects nodes pods must never be restored on, e.g.
+	// control plane or GPU nodes.
+	// +optional
+	DeniedSelector *metav1.LabelSelector `json:"deniedSelector,omitempty"`
+
+	// DeniedNodes names nodes pods must never be restored on.
+	// +optional
+	DeniedNodes []string `json:"deniedNodes,omitempty"`
+
+	// TopologyPreference orders the candidates when a migration of a
+	// selected pod has no target node and sets no preference itself.
+	// +optional
+	TopologyPreference TopologyPreference `json:"topologyPreference,omitempty"`
+}
+
+// MigrationEvent is a point in a migration's life that notifications are
+// sent for.
+// +kubebuilder:validation:Enum=Started;Succeeded;Failed;RolledBack
+type MigrationEvent string
+
+const (
+	// MigrationEventStarted is sent when the pod starts being checkpointed.
+	MigrationEventStarted MigrationEvent = "Started"
+	// MigrationEventSucceeded is sent when the restored pod took over.
+	MigrationEventSucceeded MigrationEvent = "Succeeded"
+	// MigrationEventFailed is sent when the migration failed before a
+	// restored pod was created.
+	MigrationEventFailed MigrationEvent = "Failed"
+	// MigrationEventRolledBack is sent when the migration failed after the
+	// restored pod was created: it was removed and the source pod kept.
+	MigrationEventRolledBack MigrationEvent = "RolledBack"
+)
+
+// NotificationSink is where migration notifications are sent. Exactly one of
+// Webhook, Slack and Email is set.
+type NotificationSink struct {
+	// Events to send; every event if empty.
+	// +optional
+	Events []MigrationEvent `json:"events,omitempty"`
+
+	// Webhook posts each event as JSON.
+	// +optional
+	Webhook *WebhookSink `json:"webhook,omitempty"`
+
+	// Slack posts a summary of each event to an incoming webhook.
+	// +optional
+	Slack *SlackSink `json:"slack,omitempty"`
+
+	// Email mails a summary of each event.
+	// +optional
+	Email *EmailSink `json:"email,omitempty"`
+}
+
+// WebhookSink posts events to an HTTP endpoint.
+type WebhookSink struct {
+	// URL the events are posted to.
+	URL string `json:"url"`
+}
+
+// SlackSink posts events to a Slack incoming webhook.
+type SlackSink struct {
+	// URLSecret holds the incoming webhook URL.
+	URLSecret SecretKeyReference `json:"urlSecret"`
+}
+
+// EmailSink mails events through an SMTP server.
+type EmailSink struct {
+	// SMTPAddress is the host:port of the SMTP server. STARTTLS is used when
+	// the server offers it.
+	SMTPAddress string `json:"smtpAddress"`
+
+	// From is the sender address.
+	From string `json:"from"`
+
+	// To are the recipient addresses.
+	// +kubebuilder:validation:MinItems=1
+	To []string `json:"to"`
+
+	// Username authenticates to the server with PasswordSecret, when set.
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecret holds the password of Username.
+	// +optional
+	PasswordSecret *SecretKeyReference `json:"passwordSecret,omitempty"`
+}
+
+// SecretKeyReference selects a key of a Secret.
+type SecretKeyReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// MigrationPolicyStatus defines the observed state of MigrationPolicy.
+type MigrationPolicyStatus struct {
+	// TriggeredNodes are the nodes a trigger of the policy currently fires
+	// on, by name.
+	// +optional
+	TriggeredNodes []string `json:"triggeredNodes,omitempty"`
+
+	// ActiveMigrations is the number of migrations created by the policy's
+	// triggers that have not ended yet.
+	// +optional
+	ActiveMigrations int32 `json:"activeMigrations,omitempty"`
+
+	// PendingPods is the number of pods on the triggered nodes waiting for
+	// a migration, e.g. for MaxConcurrentMigrations.
+	// +optional
+	PendingPods int32 `json:"pendingPods,omitempty"`
+
+	// LastTriggerTime is when the policy last created a migration.
+	// +optional
+	LastTriggerTime *metav1.Time `json:"lastTriggerTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.activeMigrations`
+// +kubebuilder:printcolumn:name="Pending",type=integer,JSONPath=`.status.pendingPods`
+// +kubebuilder:printcolumn:name="Last Trigger",type=date,JSONPath=`.status.lastTriggerTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// MigrationPolicy is the Schema for the migrationpolicies API. Every policy
+// selecting a migrated pod applies to its migration.
+type MigrationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MigrationPolicySpec   `json:"spec,omitempty"`
+	Status MigrationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MigrationPolicyList contains a list of MigrationPolicy.
+type MigrationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MigrationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MigrationPolicy{}, &MigrationPolicyList{})
+}
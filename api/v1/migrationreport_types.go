@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrationReportKey is the key of the report in the ConfigMap named by
+// PodMigrationStatus.ReportConfigMap.
+const MigrationReportKey = "report.json"
+
+// MigrationReport is the record of an ended migration, written as JSON to a
+// ConfigMap so it can be attached to incident and change tickets.
+type MigrationReport struct {
+	Namespace  string `json:"namespace"`
+	Migration  string `json:"migration"`
+	Pod        string `json:"pod"`
+	SourceNode string `json:"sourceNode,omitempty"`
+	TargetNode string `json:"targetNode,omitempty"`
+
+	// RestoredPod is the name of the restored pod, which was deleted again
+	// if the migration failed.
+	RestoredPod string `json:"restoredPod,omitempty"`
+
+	Phase   PodMigrationPhase `json:"phase"`
+	Reason  string            `json:"reason,omitempty"`
+	Message string            `json:"message,omitempty"`
+
+	// StartTime is when the migration was created and CompletionTime when
+	// it ended.
+	StartTime      metav1.Time     `json:"startTime"`
+	CompletionTime metav1.Time     `json:"completionTime"`
+	Duration       metav1.Duration `json:"duration"`
+
+	// Phases are the phases the migration went through, in order.
+	Phases []PhaseReport `json:"phases,omitempty"`
+
+	// Downtime is the time from the checkpoint to the end of the migration,
+	// during which the source pod's state changes are lost.
+	Downtime          *metav1.Duration `json:"downtime,omitempty"`
+	PredictedDowntime *metav1.Duration `json:"predictedDowntime,omitempty"`
+
+	StrategyDecision   *StrategyDecision    `json:"strategyDecision,omitempty"`
+	Simulation         *MigrationSimulation `json:"simulation,omitempty"`
+	TransferEncryption *TransferEncryption  `json:"transferEncryption,omitempty"`
+
+	// Checkpoint names the PodCheckpoint restored and Generation its
+	// generation.
+	Checkpoint string `json:"checkpoint,omitempty"`
+	Generation int64  `json:"generation,omitempty"`
+
+	// Containers describe the checkpoint of each container.
+	Containers []ContainerReport `json:"containers,omitempty"`
+
+	// BytesTransferred is the total size of the container checkpoints.
+	BytesTransferred int64 `json:"bytesTransferred"`
+
+	// Hooks are the outcomes of the checkpoint's quiesce and resume hooks.
+	Hooks []CheckpointHookResult `json:"hooks,omitempty"`
+
+	Warnings          []string           `json:"warnings,omitempty"`
+	MigrationPolicies []string           `json:"migrationPolicies,omitempty"`
+	Conditions        []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PhaseReport is how long a migration spent in a phase.
+type PhaseReport struct {
+	Phase     PodMigrationPhase `json:"phase"`
+	StartTime metav1.Time       `json:"startTime"`
+	// Duration is unset for the terminal phase.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// ContainerReport describes the checkpoint of one container.
+type ContainerReport struct {
+	Name              string `json:"name"`
+	Content           string `json:"content"`
+	ArtifactURI       string `json:"artifactURI"`
+	ArtifactDigest    string `json:"artifactDigest,omitempty"`
+	ArtifactSizeBytes int64  `json:"artifactSizeBytes,omitempty"`
+	// Compression of the artifact; empty if it is not compressed.
+	Compression CheckpointCompression `json:"compression,omitempty"`
+	// Image is the checkpoint image the container was restored from.
+	Image string `json:"image,omitempty"`
+}
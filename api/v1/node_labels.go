@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// Labels the checkpoint agent sets on its Node with what it detected there,
+// at startup and periodically. A node without them has no agent, or one
+// that does not label.
+const (
+	// NodeCRIUVersionLabel is the version of the node's CRIU, "none" when it
+	// is not installed, or "unknown" when the agent could not run it.
+	NodeCRIUVersionLabel = "lpm.my.domain/criu-version"
+	// NodeContainerRuntimeLabel is the node's container runtime, "cri-o" or
+	// "containerd", or "unknown".
+	NodeContainerRuntimeLabel = "lpm.my.domain/container-runtime"
+	// NodeLazyPagesLabel is "true" when the node's CRIU and kernel support
+	// lazy page restores (userfaultfd).
+	NodeLazyPagesLabel = "lpm.my.domain/lazy-pages"
+	// NodeSharedStorageLabel is "true" when the agent has the shared
+	// checkpoint storage mounted.
+	NodeSharedStorageLabel = "lpm.my.domain/shared-storage"
+	// NodeCheckpointStreamingLabel is "true" when the agent streams
+	// checkpoint archives to and from other nodes' agents, which moves them
+	// between nodes without shared storage.
+	NodeCheckpointStreamingLabel = "lpm.my.domain/checkpoint-streaming"
+	// NodeKubeletCheckpointLabel is whether the node's kubelet serves the
+	// checkpoint API to the agent: "enabled", "disabled" when its
+	// ContainerCheckpoint feature gate is off, "forbidden" when it refuses
+	// the agent's credentials, or "unknown".
+	NodeKubeletCheckpointLabel = "lpm.my.domain/kubelet-checkpoint"
+
+	// NodeCapabilitiesUpdatedAnnotation is when the agent last refreshed the
+	// labels, in RFC 3339 form.
+	NodeCapabilitiesUpdatedAnnotation = "lpm.my.domain/capabilities-updated"
+)
+
+// Values of the node capability labels.
+const (
+	NodeCapabilityNone    = "none"
+	NodeCapabilityUnknown = "unknown"
+	NodeRuntimeCRIO       = "cri-o"
+	NodeRuntimeContainerd = "containerd"
+
+	NodeKubeletCheckpointEnabled   = "enabled"
+	NodeKubeletCheckpointDisabled  = "disabled"
+	NodeKubeletCheckpointForbidden = "forbidden"
+)
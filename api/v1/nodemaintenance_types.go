@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NodeMaintenancePhase string
+
+const (
+	// NodeMaintenancePhasePlanning simulates the migration of each pod on
+	// the node to build the plan.
+	NodeMaintenancePhasePlanning NodeMaintenancePhase = "Planning"
+	// NodeMaintenancePhaseAwaitingApproval waits for the plan's Approved
+	// condition.
+	NodeMaintenancePhaseAwaitingApproval NodeMaintenancePhase = "AwaitingApproval"
+	// NodeMaintenancePhaseDraining cordons the node and carries out the plan.
+	NodeMaintenancePhaseDraining  NodeMaintenancePhase = "Draining"
+	NodeMaintenancePhaseSucceeded NodeMaintenancePhase = "Succeeded"
+	NodeMaintenancePhaseFailed    NodeMaintenancePhase = "Failed"
+)
+
+// MaintenanceAction is what a NodeMaintenance does with a pod on its node.
+// +kubebuilder:validation:Enum=Migrate;Evict;Skip
+type MaintenanceAction string
+
+const (
+	// MaintenanceActionMigrate live-migrates the pod to its planned target.
+	MaintenanceActionMigrate MaintenanceAction = "Migrate"
+	// MaintenanceActionEvict evicts the pod through the Eviction API,
+	// honoring its PodDisruptionBudgets, since it cannot be migrated.
+	MaintenanceActionEvict MaintenanceAction = "Evict"
+	// MaintenanceActionSkip leaves the pod alone, as kubectl drain does
+	// with DaemonSet and static pods.
+	MaintenanceActionSkip MaintenanceAction = "Skip"
+)
+
+// NodeMaintenanceLabel on a PodMigration names the NodeMaintenance that
+// created it, to plan or to carry out its drain. The maintenance owns only
+// its simulations, so deleting it leaves its migrations to finish.
+const NodeMaintenanceLabel = "lpm.my.domain/node-maintenance"
+
+// NodeMaintenanceSpec defines the desired state of NodeMaintenance.
+type NodeMaintenanceSpec struct {
+	// NodeName is the node to drain.
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+
+	// TargetNode is the node to migrate the pods to. Unset, the controller
+	// picks a node for each pod while planning.
+	// +optional
+	TargetNode string `json:"targetNode,omitempty"`
+
+	// Selector restricts the drain to the pods it matches; the others are
+	// skipped.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Parallel is the number of migrations run at the same time.
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Parallel int32 `json:"parallel,omitempty"`
+}
+
+// MaintenancePodPlan is what a NodeMaintenance does with one pod, and how
+// it went.
+type MaintenancePodPlan struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+
+	// Action is unset until the pod's simulation ends.
+	// +optional
+	Action MaintenanceAction `json:"action,omitempty"`
+
+	// Reason says why the pod is evicted or skipped rather than migrated.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// TargetNode is the node the pod is migrated to.
+	// +optional
+	TargetNode string `json:"targetNode,omitempty"`
+
+	// PredictedDuration and PredictedDowntime are the simulation's
+	// prediction of the pod's migration.
+	// +optional
+	PredictedDuration *metav1.Duration `json:"predictedDuration,omitempty"`
+	// +optional
+	PredictedDowntime *metav1.Duration `json:"predictedDowntime,omitempty"`
+
+	// Simulation names the simulated PodMigration the plan of the pod was
+	// made with, and Migration the PodMigration carrying it out.
+	// +optional
+	Simulation string `json:"simulation,omitempty"`
+	// +optional
+	Migration string `json:"migration,omitempty"`
+
+	// Phase is the phase of Migration, or Evicted once an evicted pod is
+	// gone.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message is what went wrong carrying out the plan of the pod.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NodeMaintenanceStatus defines the observed state of NodeMaintenance.
+type NodeMaintenanceStatus struct {
+	Phase   NodeMaintenancePhase `json:"phase,omitempty"`
+	Message string               `json:"message,omitempty"`
+
+	// Pods is the plan of each pod on the node when planning ended.
+	// +optional
+	Pods []MaintenancePodPlan `json:"pods,omitempty"`
+
+	// MigratePods, EvictPods and SkipPods count the pods of each action.
+	MigratePods int32 `json:"migratePods,omitempty"`
+	EvictPods   int32 `json:"evictPods,omitempty"`
+	SkipPods    int32 `json:"skipPods,omitempty"`
+
+	// PredictedDuration is how long the migrations are predicted to take,
+	// running spec.parallel at a time.
+	// +optional
+	PredictedDuration *metav1.Duration `json:"predictedDuration,omitempty"`
+
+	// Conditions hold Approved, which a user or external system sets to
+	// True to carry out the plan, or to False to reject it.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// WasCordoned records whether the node was cordoned before the drain.
+	// +optional
+	WasCordoned bool `json:"wasCordoned,omitempty"`
+
+	// StartTime is when the drain was approved, CompletionTime when it
+	// succeeded or failed.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Migrate",type=integer,JSONPath=`.status.migratePods`
+// +kubebuilder:printcolumn:name="Evict",type=integer,JSONPath=`.status.evictPods`
+// +kubebuilder:printcolumn:name="Predicted",type=string,JSONPath=`.status.predictedDuration`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NodeMaintenance is the Schema for the nodemaintenances API. It drains a
+// node in two steps: the controller first simulates the migration of each
+// of its pods and records the plan in its status, then carries the plan
+// out once it is approved.
+type NodeMaintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeMaintenanceSpec   `json:"spec,omitempty"`
+	Status NodeMaintenanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeMaintenanceList contains a list of NodeMaintenance.
+type NodeMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeMaintenance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeMaintenance{}, &NodeMaintenanceList{})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrationDirection is whether a migration moves a pod off or onto a node.
+type MigrationDirection string
+
+const (
+	MigrationDirectionOutgoing MigrationDirection = "Outgoing"
+	MigrationDirectionIncoming MigrationDirection = "Incoming"
+)
+
+// NodeMigrationStatusSpec is empty; the controller maintains one
+// NodeMigrationStatus per node.
+type NodeMigrationStatusSpec struct {
+}
+
+// NodeMigration is an unfinished migration involving a node.
+type NodeMigration struct {
+	Namespace string             `json:"namespace"`
+	Name      string             `json:"name"`
+	Pod       string             `json:"pod"`
+	Direction MigrationDirection `json:"direction"`
+	Phase     PodMigrationPhase  `json:"phase,omitempty"`
+}
+
+// NodeMigrationStatusStatus summarizes what live pod migration is doing on
+// a node.
+type NodeMigrationStatusStatus struct {
+	// SafeToReboot is true when no migration, restore or checkpoint
+	// involves the node.
+	SafeToReboot bool `json:"safeToReboot"`
+
+	// Reasons say why the node is not safe to reboot.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Migrations are the unfinished migrations moving a pod off or onto the
+	// node, and InFlightMigrations their number.
+	// +optional
+	Migrations         []NodeMigration `json:"migrations,omitempty"`
+	InFlightMigrations int32           `json:"inFlightMigrations"`
+
+	// Restores are the unfinished PodRestores onto the node, as
+	// namespace/name.
+	// +optional
+	Restores []string `json:"restores,omitempty"`
+
+	// QueuedCheckpoints and RunningCheckpoints count the ContainerCheckpoints
+	// of the node's pods waiting for and being dumped.
+	QueuedCheckpoints  int32 `json:"queuedCheckpoints"`
+	RunningCheckpoints int32 `json:"runningCheckpoints"`
+
+	// LocalCheckpoints and LocalCheckpointBytes count the checkpoint archives
+	// kept only on the node's disk rather than in shared storage.
+	LocalCheckpoints     int32 `json:"localCheckpoints"`
+	LocalCheckpointBytes int64 `json:"localCheckpointBytes"`
+
+	// AgentHealthy reports whether the node's checkpoint agent answered its
+	// health check, and AgentMessage what it or the failed call said.
+	AgentHealthy bool   `json:"agentHealthy"`
+	AgentMessage string `json:"agentMessage,omitempty"`
+
+	// LastUpdateTime is when the summary was taken.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Safe",type=boolean,JSONPath=`.status.safeToReboot`
+// +kubebuilder:printcolumn:name="Migrations",type=integer,JSONPath=`.status.inFlightMigrations`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queuedCheckpoints`
+// +kubebuilder:printcolumn:name="Local Bytes",type=integer,JSONPath=`.status.localCheckpointBytes`
+// +kubebuilder:printcolumn:name="Agent",type=boolean,JSONPath=`.status.agentHealthy`
+// +kubebuilder:printcolumn:name="Updated",type=date,JSONPath=`.status.lastUpdateTime`
+
+// NodeMigrationStatus is the Schema for the nodemigrationstatuses API. It is
+// named after its node and read-only: the controller keeps its status up to
+// date so operators can tell whether the node is safe to reboot.
+type NodeMigrationStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeMigrationStatusSpec   `json:"spec,omitempty"`
+	Status NodeMigrationStatusStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeMigrationStatusList contains a list of NodeMigrationStatus.
+type NodeMigrationStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeMigrationStatus `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeMigrationStatus{}, &NodeMigrationStatusList{})
+}
@@ -18,6 +18,7 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type PodCheckpointPhase string
@@ -29,9 +30,206 @@ const (
 	PodCheckpointPhaseFailed    PodCheckpointPhase = "Failed"
 )
 
+// PodCheckpointConditionFresh is True while the latest retained generation
+// is no older than the maxCheckpointAge of the pod's MigrationPolicies. It is
+// not reported when none of them sets one.
+const PodCheckpointConditionFresh = "Fresh"
+
+// Reasons of the Fresh condition.
+const (
+	PodCheckpointReasonFresh = "CheckpointFresh"
+	PodCheckpointReasonStale = "CheckpointStale"
+)
+
+// CheckpointConsistency is the consistency contract of a PodCheckpoint.
+// +kubebuilder:validation:Enum=Crash;Application
+type CheckpointConsistency string
+
+const (
+	// CheckpointConsistencyCrash dumps the containers immediately. The result
+	// is equivalent to the state after a power loss: in-flight writes and
+	// unflushed buffers may be inconsistent.
+	CheckpointConsistencyCrash CheckpointConsistency = "Crash"
+	// CheckpointConsistencyApplication runs the quiesce hooks first and fails
+	// the checkpoint unless every hook succeeds.
+	CheckpointConsistencyApplication CheckpointConsistency = "Application"
+)
+
+// CheckpointScope selects which containers of a PodCheckpoint are captured
+// at the same instant.
+// +kubebuilder:validation:Enum=Container;Pod
+type CheckpointScope string
+
+const (
+	// CheckpointScopeContainer dumps each container on its own while the
+	// others keep running, so their states are taken at slightly different
+	// times.
+	CheckpointScopeContainer CheckpointScope = "Container"
+	// CheckpointScopePod freezes the whole pod sandbox in one operation
+	// before the first container is dumped and thaws it once the last one
+	// is, so every container is captured at the same instant. The pod is
+	// paused for as long as all its dumps take.
+	CheckpointScopePod CheckpointScope = "Pod"
+)
+
+// ExecSessionPolicy decides what happens to processes started in a container
+// by kubectl exec when it is checkpointed. They are not descendants of the
+// container's init process, so CRIU cannot dump them with it.
+// +kubebuilder:validation:Enum=Fail;Terminate
+type ExecSessionPolicy string
+
+const (
+	// ExecSessionPolicyFail fails the checkpoint of a container with exec
+	// sessions.
+	ExecSessionPolicyFail ExecSessionPolicy = "Fail"
+	// ExecSessionPolicyTerminate kills the sessions' processes right before
+	// the container is dumped; their clients see the session end.
+	ExecSessionPolicyTerminate ExecSessionPolicy = "Terminate"
+)
+
+// CheckpointCompression is how checkpoint archives are compressed when they
+// are copied to shared storage.
+// +kubebuilder:validation:Enum=None;LZ4;Zstd
+type CheckpointCompression string
+
+const (
+	// CheckpointCompressionNone copies the archives as the runtime wrote them.
+	CheckpointCompressionNone CheckpointCompression = "None"
+	// CheckpointCompressionLZ4 compresses fast at a modest ratio, adding
+	// little to the downtime of a migration.
+	CheckpointCompressionLZ4 CheckpointCompression = "LZ4"
+	// CheckpointCompressionZstd compresses harder at a higher CPU cost, for
+	// checkpoints kept in storage.
+	CheckpointCompressionZstd CheckpointCompression = "Zstd"
+)
+
+// CheckpointTransfer is how checkpoint archives reach the node they are
+// restored on.
+// +kubebuilder:validation:Enum=SharedStorage;Direct
+type CheckpointTransfer string
+
+const (
+	// CheckpointTransferSharedStorage copies the archives to shared storage
+	// if the agent has it, otherwise keeps them on the node for the
+	// checkpoint registry or streaming.
+	CheckpointTransferSharedStorage CheckpointTransfer = "SharedStorage"
+	// CheckpointTransferDirect keeps the archives on the node they were
+	// taken on, whose agent pushes them straight to the restoring node's.
+	CheckpointTransferDirect CheckpointTransfer = "Direct"
+)
+
+// ArtifactGroupAnnotation on a namespace is the numeric group ID the
+// archives of its pods in shared storage are readable by. Without it only
+// the agents can read them.
+const ArtifactGroupAnnotation = "lpm.my.domain/artifact-gid"
+
+// CheckpointHook is a command executed inside one of the pod's containers.
+type CheckpointHook struct {
+	// Container to run the command in.
+	Container string `json:"container"`
+
+	// Command is executed directly, not through a shell.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// TimeoutSeconds bounds the command. Defaults to 30.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// CheckpointHookStage is when a hook runs.
+type CheckpointHookStage string
+
+const (
+	CheckpointHookStageQuiesce CheckpointHookStage = "Quiesce"
+	CheckpointHookStageResume  CheckpointHookStage = "Resume"
+)
+
+// CheckpointHookResult is the outcome of one run of a hook.
+type CheckpointHookResult struct {
+	Stage CheckpointHookStage `json:"stage"`
+
+	// Index of the hook in spec.quiesceHooks or spec.resumeHooks.
+	Index int32 `json:"index"`
+
+	Container string `json:"container"`
+
+	Succeeded bool `json:"succeeded"`
+
+	// Error is why the hook failed.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Time is when the hook finished.
+	Time metav1.Time `json:"time"`
+}
+
 // PodCheckpointSpec defines the desired state of PodCheckpoint.
 type PodCheckpointSpec struct {
 	PodName *string `json:"podName"`
+
+	// Consistency selects between an immediate crash-consistent dump and an
+	// application-consistent one gated on QuiesceHooks.
+	// +kubebuilder:default=Crash
+	// +optional
+	Consistency CheckpointConsistency `json:"consistency,omitempty"`
+
+	// Scope selects between independent container dumps and a pod-wide
+	// freeze giving consistent state across tightly coupled containers.
+	// +kubebuilder:default=Container
+	// +optional
+	Scope CheckpointScope `json:"scope,omitempty"`
+
+	// ExecSessionPolicy decides whether containers with kubectl exec
+	// sessions fail to checkpoint or have their sessions terminated.
+	// +kubebuilder:default=Fail
+	// +optional
+	ExecSessionPolicy ExecSessionPolicy `json:"execSessionPolicy,omitempty"`
+
+	// Compression of the archives copied to shared storage. Archives kept on
+	// the node for the checkpoint registry are not compressed.
+	// +kubebuilder:default=Zstd
+	// +optional
+	Compression CheckpointCompression `json:"compression,omitempty"`
+
+	// ArtifactTransfer is how the archives reach the node they are restored
+	// on. Direct keeps them off shared storage.
+	// +kubebuilder:default=SharedStorage
+	// +optional
+	ArtifactTransfer CheckpointTransfer `json:"artifactTransfer,omitempty"`
+
+	// QuiesceHooks run, in order, before any container is checkpointed (e.g.
+	// flush buffers, pause intake). Only used in Application mode, which
+	// requires at least one hook.
+	// +optional
+	QuiesceHooks []CheckpointHook `json:"quiesceHooks,omitempty"`
+
+	// ResumeHooks run, in order, after all containers are checkpointed to undo
+	// the quiesce. Failures are reported but do not fail the checkpoint.
+	// +optional
+	ResumeHooks []CheckpointHook `json:"resumeHooks,omitempty"`
+
+	// DesiredGeneration requests another checkpoint of the pod when raised
+	// above status.generation. Earlier generations are kept in status.history
+	// so they can still be restored.
+	// +optional
+	DesiredGeneration int64 `json:"desiredGeneration,omitempty"`
+
+	// HistoryLimit is the number of completed generations to retain. Older
+	// generations and their content objects are deleted. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+}
+
+// PodCheckpointGeneration records one completed checkpoint of the pod.
+type PodCheckpointGeneration struct {
+	Generation int64 `json:"generation"`
+
+	// ContentName names the PodCheckpointContent holding this generation.
+	ContentName string `json:"contentName"`
+
+	CreationTime metav1.Time `json:"creationTime"`
 }
 
 // PodCheckpointStatus defines the observed state of PodCheckpoint.
@@ -44,12 +242,56 @@ type PodCheckpointStatus struct {
 	// materializes this checkpoint. Empty until bound.
 	BoundContentName string `json:"boundContentName,omitempty"`
 
+	// Generation is the checkpoint generation being taken, or the last one
+	// taken once the phase is terminal. Generations start at 1.
+	Generation int64 `json:"generation,omitempty"`
+
+	// History lists retained completed generations, oldest first. The last
+	// entry is the one BoundContentName points to.
+	// +optional
+	History []PodCheckpointGeneration `json:"history,omitempty"`
+
+	// Quiesced is true while the quiesce hooks have run and the resume hooks
+	// have not.
+	Quiesced bool `json:"quiesced,omitempty"`
+
+	// HookResults are the outcomes of the hooks run for the current
+	// generation, in the order they ran.
+	// +optional
+	HookResults []CheckpointHookResult `json:"hookResults,omitempty"`
+
+	// Frozen is true while a Pod scope checkpoint holds the pod frozen.
+	Frozen bool `json:"frozen,omitempty"`
+
+	// PodUID is the UID of the pod when the first generation was taken.
+	// Generations are only taken of that pod, not of one recreated under
+	// its name.
+	// +optional
+	PodUID types.UID `json:"podUID,omitempty"`
+
+	// LastCheckpointTime is when the latest retained generation was taken.
+	// +optional
+	LastCheckpointTime *metav1.Time `json:"lastCheckpointTime,omitempty"`
+
+	// Conditions report the freshness of the latest retained generation.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	CreationTime   *metav1.Time `json:"creationTime,omitempty"`   // when checkpoint captured
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"` // when phase terminal
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.spec.podName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Generation",type=integer,JSONPath=`.status.generation`
+// +kubebuilder:printcolumn:name="Last Checkpoint",type=date,JSONPath=`.status.lastCheckpointTime`
+// +kubebuilder:printcolumn:name="Fresh",type=string,JSONPath=`.status.conditions[?(@.type=="Fresh")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PodCheckpoint is the Schema for the podcheckpoints API.
 type PodCheckpoint struct {
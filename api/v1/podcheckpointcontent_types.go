@@ -19,6 +19,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -37,6 +38,13 @@ type PodCheckpointContentSpec struct {
 	// ContainerContents: list of cluster-scoped ContainerCheckpointContent object names
 	// (kind is implied; group/version same API group).
 	ContainerContents []corev1.LocalObjectReference `json:"containerContents"`
+
+	// SourcePod is the pod as it was checkpointed, which a PodRestore
+	// restores from once the pod is gone.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	SourcePod *runtime.RawExtension `json:"sourcePod,omitempty"`
 }
 
 // PodCheckpointContentStatus defines the observed state of PodCheckpointContent.
@@ -46,6 +54,7 @@ type PodCheckpointContentStatus struct {
 	CreationTime *metav1.Time `json:"creationTime,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced
@@ -19,13 +19,15 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type PodMigrationPhase string
 
 const (
 	MigrationPhasePending            PodMigrationPhase = "Pending"
-	MigrationPhaseCheckpointing      PodMigrationPhase = "Checkpointing" 
+	MigrationPhaseCheckpointing      PodMigrationPhase = "Checkpointing"
 	MigrationPhaseCheckpointComplete PodMigrationPhase = "CheckpointComplete"
 	MigrationPhasePreparingImages    PodMigrationPhase = "PreparingImages"
 	MigrationPhaseRestoring          PodMigrationPhase = "Restoring"
@@ -33,13 +35,806 @@ const (
 	MigrationPhaseFailed             PodMigrationPhase = "Failed"
 )
 
+// ExternalResourcePolicy decides what a migration does about state outside
+// the checkpointed containers.
+// +kubebuilder:validation:Enum=Fail;Warn
+type ExternalResourcePolicy string
+
+const (
+	// ExternalResourcePolicyFail rejects the migration in preflight.
+	ExternalResourcePolicyFail ExternalResourcePolicy = "Fail"
+	// ExternalResourcePolicyWarn records the findings in status.warnings and
+	// migrates anyway.
+	ExternalResourcePolicyWarn ExternalResourcePolicy = "Warn"
+)
+
+// ClockHandling decides what restored processes see of CLOCK_MONOTONIC and
+// CLOCK_BOOTTIME, which are per node and restart from the target node's
+// values unless CRIU restores them in a time namespace.
+// +kubebuilder:validation:Enum=Ignore;Preserve
+type ClockHandling string
+
+const (
+	// ClockHandlingIgnore lets the clocks jump to the target node's values.
+	// The jump is recorded in status.clockJump, and a backwards jump, which
+	// delays timers, in status.warnings.
+	ClockHandlingIgnore ClockHandling = "Ignore"
+	// ClockHandlingPreserve requires the containers to run in time
+	// namespaces and the target kernel to support them, so CRIU restores the
+	// clocks continuing from their checkpointed values.
+	ClockHandlingPreserve ClockHandling = "Preserve"
+)
+
+// HostNetworkPolicy decides whether a pod sharing its node's network
+// namespace is migrated. Its sockets are those of the node, which CRIU
+// cannot carry to another node.
+// +kubebuilder:validation:Enum=Fail;BestEffort
+type HostNetworkPolicy string
+
+const (
+	// HostNetworkPolicyFail rejects host network pods in preflight.
+	HostNetworkPolicyFail HostNetworkPolicy = "Fail"
+	// HostNetworkPolicyBestEffort migrates them without their network
+	// state: established connections are closed, listening sockets are
+	// rebound on the target node, and sockets bound to an address of the
+	// source node still fail preflight.
+	HostNetworkPolicyBestEffort HostNetworkPolicy = "BestEffort"
+)
+
+// TargetTaintPolicy decides what happens when the restored pod does not
+// tolerate a NoSchedule or NoExecute taint of the target node. The restored
+// pod is bound to the node directly, bypassing the scheduler's taint check.
+// +kubebuilder:validation:Enum=Fail;Tolerate
+type TargetTaintPolicy string
+
+const (
+	// TargetTaintPolicyFail rejects the migration in preflight.
+	TargetTaintPolicyFail TargetTaintPolicy = "Fail"
+	// TargetTaintPolicyTolerate adds tolerations of the taints to the
+	// restored pod and lists them in status.warnings.
+	TargetTaintPolicyTolerate TargetTaintPolicy = "Tolerate"
+)
+
+// ReplicaSetAdoptionPolicy decides who controls the restored pod of a pod
+// controlled by a ReplicaSet once the migration succeeded.
+// +kubebuilder:validation:Enum=None;Adopt
+type ReplicaSetAdoptionPolicy string
+
+const (
+	// ReplicaSetAdoptionNone leaves the restored pod controlled by the
+	// PodMigration; the ReplicaSet replaces the deleted source pod with a
+	// new replica started from its template.
+	ReplicaSetAdoptionNone ReplicaSetAdoptionPolicy = "None"
+	// ReplicaSetAdoptionAdopt hands the restored pod to the ReplicaSet in
+	// place of the source pod, which keeps the replica count.
+	ReplicaSetAdoptionAdopt ReplicaSetAdoptionPolicy = "Adopt"
+)
+
+// OwnerHandlingPolicy decides how the migration coordinates with the
+// controller of the source pod.
+// +kubebuilder:validation:Enum=None;CoordinateScale
+type OwnerHandlingPolicy string
+
+const (
+	// OwnerHandlingNone leaves the source pod's controller alone, apart
+	// from the autoscaler locks every migration takes.
+	OwnerHandlingNone OwnerHandlingPolicy = "None"
+	// OwnerHandlingCoordinateScale pauses the rollouts of the source pod's
+	// Deployment for the migration and has its ReplicaSet adopt the
+	// restored pod, so deleting the source pod is absorbed by the
+	// ReplicaSet scaling in rather than replaced by a new replica.
+	OwnerHandlingCoordinateScale OwnerHandlingPolicy = "CoordinateScale"
+)
+
+// PlacementConstraintPolicy decides what happens when restoring a pod on its
+// target node would violate the pod's placement constraints.
+// +kubebuilder:validation:Enum=Fail;Warn
+type PlacementConstraintPolicy string
+
+const (
+	// PlacementConstraintPolicyFail rejects the migration in preflight.
+	PlacementConstraintPolicyFail PlacementConstraintPolicy = "Fail"
+	// PlacementConstraintPolicyWarn records the violations in
+	// status.warnings and migrates anyway.
+	PlacementConstraintPolicyWarn PlacementConstraintPolicy = "Warn"
+)
+
+// TopologyPreference decides which nodes are tried first when the target
+// node is picked automatically. Closer nodes transfer the checkpoint faster
+// and can attach the pod's zonal volumes.
+// +kubebuilder:validation:Enum=Zone;Region;None
+type TopologyPreference string
+
+const (
+	// TopologyPreferenceZone tries nodes in the zone of the pod's volumes,
+	// or of the source node if they have none, then nodes in the same
+	// region, then the others.
+	TopologyPreferenceZone TopologyPreference = "Zone"
+	// TopologyPreferenceRegion tries nodes in the same region first.
+	TopologyPreferenceRegion TopologyPreference = "Region"
+	// TopologyPreferenceNone tries the nodes by name.
+	TopologyPreferenceNone TopologyPreference = "None"
+)
+
+// AdoptedPodAnnotation on a PodMigration names a restored pod left behind by
+// an earlier migration that the migration records as its result instead of
+// migrating the pod again.
+const AdoptedPodAnnotation = "lpm.my.domain/adopted-pod"
+
+// Annotations the controller keeps on a migrated pod, and on the pod restored
+// from it, describing the pod's latest migration, so it can be followed by
+// those without access to PodMigrations.
+const (
+	// MigrationAnnotation names the PodMigration.
+	MigrationAnnotation = "lpm.my.domain/migration"
+	// MigrationStatusAnnotation is the phase of the migration.
+	MigrationStatusAnnotation = "lpm.my.domain/migration-status"
+	// MigrationMessageAnnotation is the migration's status message.
+	MigrationMessageAnnotation = "lpm.my.domain/migration-message"
+	// MigrationTargetNodeAnnotation is the node the pod is migrated to.
+	MigrationTargetNodeAnnotation = "lpm.my.domain/migration-target-node"
+	// MigrationRestoredPodAnnotation names the restored pod once created.
+	MigrationRestoredPodAnnotation = "lpm.my.domain/migration-restored-pod"
+)
+
+// MigrationLockAnnotation on a pod names the PodMigration migrating it. A
+// migration takes the lock before touching the pod and holds it until it
+// succeeds or fails, so no two migrations of a pod run at the same time.
+const MigrationLockAnnotation = "lpm.my.domain/migration-lock"
+
+// DrainNodeLabel on a PodMigration names the node "lpmctl drain" created it
+// to move the pod off.
+const DrainNodeLabel = "lpm.my.domain/drain-node"
+
+// MigrationIDLabel on the PodCheckpoints and ContainerCheckpoints a
+// migration creates is the UID of the PodMigration. The controller and the
+// agents log it as migrationID, so the logs of one migration can be found
+// across both.
+const MigrationIDLabel = "lpm.my.domain/migration-id"
+
+// MigrationStrategy is how the pod's memory is moved to the target node.
+type MigrationStrategy string
+
+const (
+	// MigrationStrategyStopAndCopy checkpoints the pod's memory in one dump
+	// and restores it; the pod is down for the whole transfer.
+	MigrationStrategyStopAndCopy MigrationStrategy = "StopAndCopy"
+	// MigrationStrategyPreCopy copies the pod's memory in rounds while it
+	// runs, then stops it to copy the pages dirtied in the last round.
+	MigrationStrategyPreCopy MigrationStrategy = "PreCopy"
+	// MigrationStrategyPostCopy restores the pod right away and faults its
+	// memory in from the source node as it is touched.
+	MigrationStrategyPostCopy MigrationStrategy = "PostCopy"
+	// MigrationStrategyAuto lets the controller pick the strategy from
+	// measurements of the pod and the transfer bandwidth.
+	MigrationStrategyAuto MigrationStrategy = "Auto"
+)
+
+// Condition types reported in PodMigrationStatus.Conditions.
+const (
+	// MigrationConditionRestoreVerified is True once the agent on the target
+	// node confirmed every restored container was started from its checkpoint.
+	MigrationConditionRestoreVerified = "RestoreVerified"
+	// MigrationConditionNetworkVerified is True once the restored pod is
+	// selected by the same NetworkPolicies as the source and passed the
+	// configured connectivity checks.
+	MigrationConditionNetworkVerified = "NetworkVerified"
+	// MigrationConditionServiceAccountTokenVerified is True once the service
+	// account tokens projected into the restored pod authenticate and are
+	// bound to it, and Unknown when they could not be read.
+	MigrationConditionServiceAccountTokenVerified = "ServiceAccountTokenVerified"
+	// MigrationConditionIdentityRefreshed is True once spec.identityRefresh
+	// ran against the restored pod.
+	MigrationConditionIdentityRefreshed = "IdentityRefreshed"
+	// MigrationConditionAutoscalersLocked is True while the autoscalers of
+	// the source pod's workload are held back from scaling it down or
+	// evicting it for new resources, and cluster autoscalers from removing
+	// the migration's pods and target node.
+	MigrationConditionAutoscalersLocked = "AutoscalersLocked"
+	// MigrationConditionStatefulSetAdopted is True once the recreated
+	// StatefulSet adopted the restored pod and its headless Service
+	// publishes the pod's DNS name.
+	MigrationConditionStatefulSetAdopted = "StatefulSetAdopted"
+	// MigrationConditionApproved is set by a user or external system to
+	// approve (True) or reject (False) a migration waiting for approval. The
+	// controller sets it to Unknown while it waits.
+	MigrationConditionApproved = "Approved"
+	// MigrationConditionSourcePodLocked is True while the migration holds
+	// the source pod's migration lock, and False while it waits for another
+	// migration of the pod to end.
+	MigrationConditionSourcePodLocked = "SourcePodLocked"
+)
+
+// Reasons set in PodMigrationStatus.Reason and on conditions.
+const (
+	// MigrationReasonRestoreNotApplied means the restored pod is running but the
+	// runtime booted it from the image instead of restoring the checkpoint.
+	MigrationReasonRestoreNotApplied = "RestoreNotApplied"
+	// MigrationReasonRestoreVerified means the runtime reported a CRIU restore.
+	MigrationReasonRestoreVerified = "RestoreVerified"
+	// MigrationReasonRestoreUnverified means the agent on the target node
+	// cannot verify restores; the migration went on without the check.
+	MigrationReasonRestoreUnverified = "RestoreUnverified"
+	// MigrationReasonSimulated means a simulated migration finished; see
+	// status.simulation for the prediction.
+	MigrationReasonSimulated = "Simulated"
+	// MigrationReasonTargetDoesNotFit means the target node cannot run the pod.
+	MigrationReasonTargetDoesNotFit = "TargetDoesNotFit"
+	// MigrationReasonUnsupportedConfiguration means a preflight check found
+	// something about the pod that cannot be checkpointed or restored.
+	MigrationReasonUnsupportedConfiguration = "UnsupportedConfiguration"
+	// MigrationReasonDaemonSetPod means the pod belongs to a DaemonSet, which
+	// runs one pod per node and would replace it on the source node.
+	MigrationReasonDaemonSetPod = "DaemonSetPod"
+	// MigrationReasonStaticPod means the pod is the mirror of a static pod
+	// the kubelet runs from a manifest on the source node.
+	MigrationReasonStaticPod = "StaticPod"
+	// MigrationReasonCriticalPod means the pod has a system critical
+	// priority, such as a cluster add-on, that no MigrationPolicy allows
+	// migrating.
+	MigrationReasonCriticalPod = "CriticalPod"
+	// MigrationReasonHostNetworkUnsupported means the pod uses the node's
+	// network namespace and spec.hostNetworkPolicy is Fail, or one of its
+	// sockets is bound to an address only the source node has.
+	MigrationReasonHostNetworkUnsupported = "HostNetworkUnsupported"
+	// MigrationReasonExecSessions means a container has kubectl exec
+	// sessions and spec.execSessionPolicy is Fail.
+	MigrationReasonExecSessions = "ExecSessions"
+	// MigrationReasonSecurityProfileMissing means the target node lacks a
+	// seccomp or AppArmor profile, or the SELinux support, the pod uses.
+	MigrationReasonSecurityProfileMissing = "SecurityProfileMissing"
+	// MigrationReasonNodeCapabilityMissing means the agent of the source or
+	// target node reported the node cannot checkpoint or restore the pod.
+	MigrationReasonNodeCapabilityMissing = "NodeCapabilityMissing"
+	// MigrationReasonUserNamespaceMismatch means the restored pod's user
+	// namespace does not map every ID the source pod used.
+	MigrationReasonUserNamespaceMismatch = "UserNamespaceMismatch"
+	// MigrationReasonPodSecurityViolation means the restored pod would be
+	// rejected by the namespace's Pod Security Admission level.
+	MigrationReasonPodSecurityViolation = "PodSecurityViolation"
+	// MigrationReasonNetworkVerified means the restored pod's networking
+	// checks passed.
+	MigrationReasonNetworkVerified = "NetworkVerified"
+	// MigrationReasonNetworkPolicyMismatch means the restored pod is not
+	// selected by the same NetworkPolicies as the source pod.
+	MigrationReasonNetworkPolicyMismatch = "NetworkPolicyMismatch"
+	// MigrationReasonNetworkUnreachable means a connectivity check of the
+	// restored pod kept failing until spec.networkCheck.timeoutSeconds.
+	MigrationReasonNetworkUnreachable = "NetworkUnreachable"
+	// MigrationReasonExternalResources means preflight found state outside
+	// the pod's containers, such as a connection to a host daemon, and
+	// spec.externalResourcePolicy is Fail.
+	MigrationReasonExternalResources = "ExternalResources"
+	// MigrationReasonServiceAccountTokenVerified means the restored pod's
+	// service account tokens were reviewed successfully.
+	MigrationReasonServiceAccountTokenVerified = "ServiceAccountTokenVerified"
+	// MigrationReasonServiceAccountTokenNotVerified means the restored pod's
+	// service account tokens could not be read for review.
+	MigrationReasonServiceAccountTokenNotVerified = "ServiceAccountTokenNotVerified"
+	// MigrationReasonServiceAccountTokenInvalid means a service account token
+	// of the restored pod does not authenticate or is bound to another pod.
+	MigrationReasonServiceAccountTokenInvalid = "ServiceAccountTokenInvalid"
+	// MigrationReasonIdentityRefreshed means spec.identityRefresh ran.
+	MigrationReasonIdentityRefreshed = "IdentityRefreshed"
+	// MigrationReasonAutoscalersLocked means the workload's autoscalers are
+	// locked for the migration.
+	MigrationReasonAutoscalersLocked = "AutoscalersLocked"
+	// MigrationReasonAutoscalersReleased means the migration ended and gave
+	// up its autoscaler locks.
+	MigrationReasonAutoscalersReleased = "AutoscalersReleased"
+	// MigrationReasonStatefulSetAdopted means the StatefulSet adopted the
+	// restored pod.
+	MigrationReasonStatefulSetAdopted = "StatefulSetAdopted"
+	// MigrationReasonDNSNotPublished means the headless Service did not
+	// publish the restored pod in time.
+	MigrationReasonDNSNotPublished = "DNSNotPublished"
+	// MigrationReasonTargetNotAllowed means a MigrationPolicy of the pod
+	// forbids restoring it on the target node.
+	MigrationReasonTargetNotAllowed = "TargetNotAllowed"
+	// MigrationReasonNoTargetNode means no target node was given and no node
+	// the pod fits on is allowed by its MigrationPolicies.
+	MigrationReasonNoTargetNode = "NoTargetNode"
+	// MigrationReasonAwaitingApproval means the migration waits for its
+	// Approved condition.
+	MigrationReasonAwaitingApproval = "AwaitingApproval"
+	// MigrationReasonApprovalDenied means the Approved condition was set to
+	// False.
+	MigrationReasonApprovalDenied = "ApprovalDenied"
+	// MigrationReasonSLOExceeded means the predicted or measured downtime
+	// exceeded spec.maxDowntimeMs.
+	MigrationReasonSLOExceeded = "SLOExceeded"
+	// MigrationReasonRestoreArtifactError means the restored pod's
+	// containers could not be created from their checkpoint images, even
+	// after the images were converted again.
+	MigrationReasonRestoreArtifactError = "RestoreArtifactError"
+	// MigrationReasonAdopted means the migration recorded a restored pod
+	// left behind by an earlier migration.
+	MigrationReasonAdopted = "Adopted"
+	// MigrationReasonInvalidOverrides means spec.restoredPodOverrides do not
+	// apply to the pod.
+	MigrationReasonInvalidOverrides = "InvalidOverrides"
+	// MigrationReasonRebaseIncompatible means a checkpoint cannot be restored
+	// on top of the image spec.rebase names.
+	MigrationReasonRebaseIncompatible = "RebaseIncompatible"
+	// MigrationReasonPlacementViolation means restoring the pod on the
+	// target node would violate its placement constraints.
+	MigrationReasonPlacementViolation = "PlacementViolation"
+	// MigrationReasonCheckpointStale means the migration restores a referenced
+	// checkpoint older than its MigrationPolicies allow.
+	MigrationReasonCheckpointStale = "CheckpointStale"
+	// MigrationReasonSourcePodReplaced means the source pod was deleted and
+	// recreated under the same name during the migration.
+	MigrationReasonSourcePodReplaced = "SourcePodReplaced"
+	// MigrationReasonQuotaExceeded means the restored pod, which runs next
+	// to the source pod for a while, would exceed a ResourceQuota of the
+	// namespace or violate one of its LimitRanges.
+	MigrationReasonQuotaExceeded = "QuotaExceeded"
+	// MigrationReasonSourcePodLocked means the migration holds the source
+	// pod's migration lock.
+	MigrationReasonSourcePodLocked = "SourcePodLocked"
+	// MigrationReasonWaitingForMigration means another migration of the
+	// source pod is in flight; the migration stays Pending until it ends.
+	MigrationReasonWaitingForMigration = "WaitingForMigration"
+	// MigrationReasonSourcePodReleased means the migration ended and gave up
+	// the source pod's migration lock.
+	MigrationReasonSourcePodReleased = "SourcePodReleased"
+)
+
 // PodMigrationSpec defines the desired state of PodMigration.
 type PodMigrationSpec struct {
 	// Name of the Pod to migrate (required).
 	PodName string `json:"podName"`
 
 	// TargetNode is the name of the node where the Pod should be restored.
-	TargetNode string `json:"targetNode"`
+	// Unset, the controller picks a node the pod fits on that its
+	// MigrationPolicies allow and records it here.
+	// +optional
+	TargetNode string `json:"targetNode,omitempty"`
+
+	// TopologyPreference orders the candidates when targetNode is unset.
+	// Unset, the first MigrationPolicy of the pod setting one decides, and
+	// otherwise Zone.
+	// +optional
+	TopologyPreference TopologyPreference `json:"topologyPreference,omitempty"`
+
+	// CheckpointRef restores from an existing PodCheckpoint of the pod instead
+	// of taking a new one, allowing a point-in-time restore.
+	// +optional
+	CheckpointRef *CheckpointReference `json:"checkpointRef,omitempty"`
+
+	// Simulate predicts the migration instead of performing it: the source
+	// pod's memory and dirty rate, shared storage throughput, and the target
+	// node's fit are measured and the predicted timeline is reported in
+	// status.simulation. Nothing is checkpointed or restored.
+	// +optional
+	Simulate bool `json:"simulate,omitempty"`
+
+	// Strategy is how the pod's memory is moved. Auto measures the pod's
+	// size and dirty rate and the transfer bandwidth, and records the choice
+	// and its inputs in status.strategyDecision.
+	// +kubebuilder:validation:Enum=StopAndCopy;Auto
+	// +kubebuilder:default=StopAndCopy
+	// +optional
+	Strategy MigrationStrategy `json:"strategy,omitempty"`
+
+	// MaxDowntimeMs is the longest the migration may take from the
+	// checkpoint to the cutover to the restored pod, in milliseconds. A
+	// migration whose predicted downtime exceeds it fails before the pod is
+	// checkpointed, and one that runs over it is aborted before the source
+	// pod is deleted, both with reason SLOExceeded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDowntimeMs *int64 `json:"maxDowntimeMs,omitempty"`
+
+	// NetworkCheck adds connectivity checks the restored pod must pass
+	// before the migration succeeds and the source pod is deleted.
+	// +optional
+	NetworkCheck *NetworkCheck `json:"networkCheck,omitempty"`
+
+	// ExternalResourcePolicy decides what happens when preflight finds state
+	// the checkpoint cannot carry, such as unix sockets connected to host
+	// daemons or hostPath volumes. Fail rejects the migration; Warn migrates
+	// anyway and lists the findings in status.warnings.
+	// +kubebuilder:default=Fail
+	// +optional
+	ExternalResourcePolicy ExternalResourcePolicy `json:"externalResourcePolicy,omitempty"`
+
+	// ClockHandling decides whether restored processes keep their monotonic
+	// and boot time clocks. Preserve fails preflight unless CRIU can restore
+	// them.
+	// +kubebuilder:default=Ignore
+	// +optional
+	ClockHandling ClockHandling `json:"clockHandling,omitempty"`
+
+	// CheckpointScope is the scope of the PodCheckpoint the migration
+	// creates; Pod captures all containers at the same instant. Ignored with
+	// checkpointRef.
+	// +kubebuilder:default=Container
+	// +optional
+	CheckpointScope CheckpointScope `json:"checkpointScope,omitempty"`
+
+	// ExecSessionPolicy decides whether a pod with kubectl exec sessions
+	// fails preflight or has the sessions terminated when it is
+	// checkpointed. Ignored with checkpointRef.
+	// +kubebuilder:default=Fail
+	// +optional
+	ExecSessionPolicy ExecSessionPolicy `json:"execSessionPolicy,omitempty"`
+
+	// Compression of the checkpoint archives copied to shared storage.
+	// Defaults by strategy: LZ4 for StopAndCopy, whose downtime includes
+	// the copy. Ignored with checkpointRef.
+	// +optional
+	Compression CheckpointCompression `json:"compression,omitempty"`
+
+	// ArtifactTransfer is how the checkpoint archives reach the target node.
+	// Direct has the source node's agent push them to the target node's
+	// agent rather than through shared storage. Ignored with checkpointRef.
+	// +kubebuilder:default=SharedStorage
+	// +optional
+	ArtifactTransfer CheckpointTransfer `json:"artifactTransfer,omitempty"`
+
+	// HostNetworkPolicy decides whether a pod with hostNetwork is migrated.
+	// Fail rejects it; BestEffort restores it without its network state and
+	// lists the connections it loses in status.warnings.
+	// +kubebuilder:default=Fail
+	// +optional
+	HostNetworkPolicy HostNetworkPolicy `json:"hostNetworkPolicy,omitempty"`
+
+	// TargetTaints decides what happens when the restored pod, which keeps
+	// the source pod's tolerations, does not tolerate a NoSchedule or
+	// NoExecute taint of the target node.
+	// +kubebuilder:default=Fail
+	// +optional
+	TargetTaints TargetTaintPolicy `json:"targetTaints,omitempty"`
+
+	// PlacementConstraints decides what happens when restoring the pod on
+	// targetNode would violate its nodeSelector, required node affinity,
+	// required pod affinity or anti-affinity, DoNotSchedule topology spread
+	// constraints or its namespace's node selector, or the required
+	// anti-affinity of pods already running. The restored pod is bound to
+	// the node directly, so the scheduler never checks them. Unmet
+	// preferences are listed in status.warnings either way.
+	// +kubebuilder:default=Fail
+	// +optional
+	PlacementConstraints PlacementConstraintPolicy `json:"placementConstraints,omitempty"`
+
+	// ReplicaSetAdoption decides whether the ReplicaSet controlling the
+	// source pod adopts the restored pod once the migration succeeded,
+	// instead of creating an extra replica to replace the source pod.
+	// +kubebuilder:default=None
+	// +optional
+	ReplicaSetAdoption ReplicaSetAdoptionPolicy `json:"replicaSetAdoption,omitempty"`
+
+	// OwnerHandling decides how the migration coordinates with the
+	// Deployment controlling the source pod. CoordinateScale implies
+	// replicaSetAdoption Adopt.
+	// +kubebuilder:default=None
+	// +optional
+	OwnerHandling OwnerHandlingPolicy `json:"ownerHandling,omitempty"`
+
+	// IdentityRefresh brings containers that read the pod's name, UID, node
+	// or IPs through the downward API up to date with the restored pod.
+	// Without it, such values are listed in status.warnings.
+	// +optional
+	IdentityRefresh *IdentityRefresh `json:"identityRefresh,omitempty"`
+
+	// RestoreStartupProbe tunes the startup probe of restored containers,
+	// which holds off their liveness probes while a large restore completes.
+	// When set, it also replaces the timing of existing startup probes.
+	// Defaults to probing every 10 seconds for up to five minutes, and only
+	// for containers with a liveness probe but no startup probe.
+	// +optional
+	RestoreStartupProbe *RestoreStartupProbe `json:"restoreStartupProbe,omitempty"`
+
+	// RestoredPodOverrides change the restored pod from the source pod, e.g.
+	// to tolerate the target node's taints or to raise memory limits after
+	// an incident. The target node must fit the pod with the overrides.
+	// +optional
+	RestoredPodOverrides *RestoredPodOverrides `json:"restoredPodOverrides,omitempty"`
+
+	// Rebase restores containers on top of newer images than they were
+	// checkpointed on, e.g. to apply a patch without losing their in-memory
+	// state. The checkpoint images are rebuilt on the new images; a
+	// migration whose processes use files that differ in them fails with
+	// reason RebaseIncompatible before anything is restored.
+	// +optional
+	// +listType=map
+	// +listMapKey=container
+	Rebase []ContainerRebase `json:"rebase,omitempty"`
+}
+
+// ContainerRebase names the image a container is restored on top of.
+type ContainerRebase struct {
+	// Container is the name of the container.
+	Container string `json:"container"`
+
+	// Image replaces the container's image. Pin it by digest so every node
+	// resolves it to the same image.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+}
+
+// ContainerRebaseStatus is the outcome of the compatibility check of a
+// container's rebase.
+type ContainerRebaseStatus struct {
+	Container string `json:"container"`
+	Image     string `json:"image"`
+	// ImageID is the ID of Image on the node the check ran on.
+	ImageID string `json:"imageID,omitempty"`
+	// FromImage is the image the container was checkpointed on.
+	FromImage string `json:"fromImage,omitempty"`
+	// CheckedFiles is the number of files the checkpointed processes use
+	// from the image that were compared.
+	CheckedFiles int32 `json:"checkedFiles"`
+}
+
+// RestoredPodOverrides are changes applied to the restored pod. The restored
+// processes keep the state they were checkpointed with: they don't see added
+// environment variables until they are restarted, and a memory limit below
+// what they use gets them OOM-killed.
+type RestoredPodOverrides struct {
+	// Labels are added to the restored pod's, replacing those with the same
+	// keys. Changing labels its owner selects on orphans the pod.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to the restored pod's, replacing those with the
+	// same keys, except for the annotations the controller sets.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// NodeSelector replaces the restored pod's node selector.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the restored pod's.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Containers override containers of the restored pod by name.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Containers []ContainerOverride `json:"containers,omitempty"`
+}
+
+// ContainerOverride changes a container of the restored pod.
+type ContainerOverride struct {
+	// Name of the container.
+	Name string `json:"name"`
+
+	// Resources replace the container's resource requests and limits.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env is added to the container's environment, replacing variables with
+	// the same names.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// RestoreStartupProbe is the timing of the startup probe injected into
+// restored containers. The probe runs the container's startup or liveness
+// check.
+type RestoreStartupProbe struct {
+	// PeriodSeconds between probes.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes after
+	// which the kubelet restarts the container.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// IdentityRefresh remediates downward API values that are stale in the
+// restored processes, which read them on the source pod.
+type IdentityRefresh struct {
+	// RestartContainers are switched back to their original image once the
+	// restore is verified, so the kubelet restarts them fresh with the
+	// restored pod's environment. Their in-memory state is lost, and the
+	// image must be present on the target node.
+	// +optional
+	RestartContainers []string `json:"restartContainers,omitempty"`
+
+	// Hooks run in the restored pod once the restore is verified, e.g. to
+	// rewrite files derived from downward API values or to signal the
+	// application to reload them.
+	// +optional
+	Hooks []CheckpointHook `json:"hooks,omitempty"`
+}
+
+// NetworkCheck verifies a restored pod's network plumbing. A restored pod
+// whose CNI or NetworkPolicy setup is broken runs but cannot be reached.
+type NetworkCheck struct {
+	// Ports of the restored pod that the agent on the target node must be
+	// able to open a TCP connection to.
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+
+	// Exec runs a command in a container of the restored pod, e.g. to reach a
+	// dependency through egress policies. It must exit with status 0.
+	// +optional
+	Exec *CheckpointHook `json:"exec,omitempty"`
+
+	// TimeoutSeconds is how long after the restored pod started the checks
+	// may keep failing before the migration fails.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// MigrationSimulation is the outcome of a simulated migration.
+type MigrationSimulation struct {
+	// EstimatedCheckpointBytes is the expected size of the pod's checkpoint.
+	EstimatedCheckpointBytes int64 `json:"estimatedCheckpointBytes"`
+
+	// DirtyBytesPerSecond is the rate at which the pod wrote to memory while
+	// sampled.
+	DirtyBytesPerSecond int64 `json:"dirtyBytesPerSecond"`
+
+	// StorageWriteBytesPerSecond and StorageReadBytesPerSecond are the
+	// measured throughput of the shared checkpoint storage.
+	StorageWriteBytesPerSecond int64 `json:"storageWriteBytesPerSecond"`
+	StorageReadBytesPerSecond  int64 `json:"storageReadBytesPerSecond"`
+
+	// NetworkBytesPerSecond is the measured throughput from the source
+	// node's agent to the target node's.
+	// +optional
+	NetworkBytesPerSecond int64 `json:"networkBytesPerSecond,omitempty"`
+
+	// TargetFits reports whether the target node can run the pod; when it
+	// cannot, TargetFitMessage says why.
+	TargetFits       bool   `json:"targetFits"`
+	TargetFitMessage string `json:"targetFitMessage,omitempty"`
+
+	// PredictedDuration is the expected end-to-end time of the migration.
+	PredictedDuration metav1.Duration `json:"predictedDuration"`
+
+	// PredictedDowntime is the expected time between the checkpoint and the
+	// restored pod running, during which the source's state changes are lost.
+	PredictedDowntime metav1.Duration `json:"predictedDowntime"`
+}
+
+// CheckpointReference selects a generation of a PodCheckpoint in the same
+// namespace.
+type CheckpointReference struct {
+	// Name of the PodCheckpoint.
+	Name string `json:"name"`
+
+	// Generation to restore. Defaults to the latest completed generation.
+	// +optional
+	Generation *int64 `json:"generation,omitempty"`
+
+	// AllowStale restores the generation even if it is older than the
+	// maxCheckpointAge of the pod's MigrationPolicies.
+	// +optional
+	AllowStale bool `json:"allowStale,omitempty"`
+}
+
+// IDMapping is one range of a user namespace's UID or GID map.
+type IDMapping struct {
+	// ContainerID is the first ID inside the user namespace.
+	ContainerID int64 `json:"containerID"`
+	// HostID is the ID ContainerID maps to on the node.
+	HostID int64 `json:"hostID"`
+	// Size is the number of IDs in the range.
+	Size int64 `json:"size"`
+}
+
+// UserNamespaceStatus records the user namespace a pod's containers ran in.
+type UserNamespaceStatus struct {
+	// +optional
+	UIDMappings []IDMapping `json:"uidMappings,omitempty"`
+	// +optional
+	GIDMappings []IDMapping `json:"gidMappings,omitempty"`
+}
+
+// SecurityContextStatus records the Linux security module labels a pod's
+// containers ran with, which CRIU restores their processes under.
+type SecurityContextStatus struct {
+	// SELinuxLevel is the MCS level of the containers' SELinux label. The
+	// runtime picks one at random unless the pod sets it, so it is set on the
+	// restored pod for the restored files and processes to keep their level.
+	// +optional
+	SELinuxLevel string `json:"seLinuxLevel,omitempty"`
+	// AppArmorProfiles are the AppArmor profiles the containers ran under,
+	// which the target node must have loaded.
+	// +optional
+	AppArmorProfiles []string `json:"appArmorProfiles,omitempty"`
+}
+
+// StatefulSetHandover records how a StatefulSet pod is handed over to its
+// restored copy. The restored pod keeps the source pod's ordinal name, so the
+// StatefulSet is deleted with its pods orphaned while the source pod is
+// replaced, and recreated to adopt the restored pod.
+type StatefulSetHandover struct {
+	// Name of the StatefulSet controlling the source pod.
+	Name string `json:"name"`
+
+	// Orphaned is set while the StatefulSet is deleted.
+	// +optional
+	Orphaned bool `json:"orphaned,omitempty"`
+
+	// Manifest is the StatefulSet as it was before it was deleted, used to
+	// recreate it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	Manifest *runtime.RawExtension `json:"manifest,omitempty"`
+
+	// SourcePod is the source pod as it was before it was deleted to free
+	// its name for the restored pod.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	// +optional
+	SourcePod *runtime.RawExtension `json:"sourcePod,omitempty"`
+}
+
+// TopologySpreadReport is how a migration changes the spread of a group of
+// pods over the domains of a topology key.
+type TopologySpreadReport struct {
+	// Subject is what selects the pods: one of the pod's topology spread
+	// constraints, e.g. "topologySpreadConstraints[0]", or the workload
+	// controlling the pod, e.g. "ReplicaSet/web-7d4b9c6f5", whose zone
+	// balance is reported when no constraint spreads it by zone.
+	Subject string `json:"subject"`
+
+	// TopologyKey is the node label the pods are spread by.
+	TopologyKey string `json:"topologyKey"`
+
+	// MaxSkew is the constraint's maxSkew, unset for a workload.
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+
+	// SourceDomain and TargetDomain are the values of the topology key on
+	// the source and target nodes.
+	// +optional
+	SourceDomain string `json:"sourceDomain,omitempty"`
+	// +optional
+	TargetDomain string `json:"targetDomain,omitempty"`
+
+	// SkewBefore and SkewAfter are the difference between the most and
+	// the fewest pods in a domain, with the pod on the source node and on
+	// the target node.
+	SkewBefore int32 `json:"skewBefore"`
+	SkewAfter  int32 `json:"skewAfter"`
+
+	// Domains counts the pods in each domain after the migration.
+	// +optional
+	Domains []TopologyDomainCount `json:"domains,omitempty"`
+}
+
+// TopologyDomainCount is the number of pods in a topology domain.
+type TopologyDomainCount struct {
+	Domain string `json:"domain"`
+	Pods   int32  `json:"pods"`
+}
+
+// ReplicaSetHandover records how the restored pod was handed to the
+// ReplicaSet controlling the source pod.
+type ReplicaSetHandover struct {
+	// Name of the ReplicaSet controlling the source pod.
+	Name string `json:"name"`
+
+	// Adopted is set once the ReplicaSet controls the restored pod.
+	// +optional
+	Adopted bool `json:"adopted,omitempty"`
+
+	// Replicas is the ReplicaSet's spec.replicas when the restored pod was
+	// handed to it.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Message says how the handover went, or why the restored pod was left
+	// to the migration.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // PodMigrationStatus defines the observed state of PodMigration.
@@ -51,16 +846,193 @@ type PodMigrationStatus struct {
 	// or error.
 	Message string `json:"message,omitempty"`
 
+	// Reason is a CamelCase, machine-readable explanation for a Failed phase.
+	Reason string `json:"reason,omitempty"`
+
+	// Conditions report the outcome of individual migration steps.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// PodCheckpointRef lets PodMigration track the checkpoint it spawned/bound.
 	PodCheckpointRef *corev1.LocalObjectReference `json:"podCheckpointRef,omitempty"`
-	
+
+	// CheckpointGeneration is the PodCheckpoint generation being restored.
+	CheckpointGeneration int64 `json:"checkpointGeneration,omitempty"`
+
 	// RestoredPodName is the name of the restored pod after migration.
 	RestoredPodName string `json:"restoredPodName,omitempty"`
-	
+
 	// CheckpointImages maps container names to their prepared OCI checkpoint image references.
 	CheckpointImages map[string]string `json:"checkpointImages,omitempty"`
+
+	// Simulation holds the prediction of a simulated migration.
+	// +optional
+	Simulation *MigrationSimulation `json:"simulation,omitempty"`
+
+	// SourceUserNamespace is the user namespace of the source pod, recorded
+	// when it does not use the host's. The restored pod must map the same
+	// container IDs.
+	// +optional
+	SourceUserNamespace *UserNamespaceStatus `json:"sourceUserNamespace,omitempty"`
+
+	// SourceSecurityContext holds the security module labels of the source
+	// pod's containers, when it ran under SELinux or AppArmor.
+	// +optional
+	SourceSecurityContext *SecurityContextStatus `json:"sourceSecurityContext,omitempty"`
+
+	// ClockJump is how far CLOCK_MONOTONIC moves for the restored processes
+	// when their clocks are not preserved: the target node's clock minus the
+	// source node's at the same wall clock time. Negative values delay timers
+	// armed against the monotonic clock.
+	// +optional
+	ClockJump *metav1.Duration `json:"clockJump,omitempty"`
+
+	// StatefulSet is set when the source pod belongs to a StatefulSet.
+	// +optional
+	StatefulSet *StatefulSetHandover `json:"statefulSet,omitempty"`
+
+	// ReplicaSet is set when spec.replicaSetAdoption is Adopt and the source
+	// pod belongs to a ReplicaSet.
+	// +optional
+	ReplicaSet *ReplicaSetHandover `json:"replicaSet,omitempty"`
+
+	// Warnings lists what preflight found that may not survive the
+	// migration but was allowed to proceed.
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+
+	// TopologySpread is how moving the pod to the target node changes the
+	// skew of its topology spread constraints and its workload's zone
+	// balance, computed in preflight.
+	// +optional
+	TopologySpread []TopologySpreadReport `json:"topologySpread,omitempty"`
+
+	// ApprovalStage is where the migration waits for its Approved
+	// condition, as required by the pod's MigrationPolicies.
+	// +optional
+	ApprovalStage ApprovalStage `json:"approvalStage,omitempty"`
+
+	// SourceNode is the node the source pod ran on.
+	// +optional
+	SourceNode string `json:"sourceNode,omitempty"`
+
+	// SourcePodUID is the UID of the source pod when the migration started.
+	// A pod recreated under the same name is neither checkpointed nor
+	// deleted by the migration.
+	// +optional
+	SourcePodUID types.UID `json:"sourcePodUID,omitempty"`
+
+	// MigrationPolicies are the names of the MigrationPolicies that apply to
+	// the migration.
+	// +optional
+	MigrationPolicies []string `json:"migrationPolicies,omitempty"`
+
+	// NotifiedEvents are the events notifications were sent for.
+	// +optional
+	NotifiedEvents []MigrationEvent `json:"notifiedEvents,omitempty"`
+
+	// CheckpointStartTime is when the checkpoint the migration restores was
+	// requested; the source pod's state changes after it are lost.
+	// +optional
+	CheckpointStartTime *metav1.Time `json:"checkpointStartTime,omitempty"`
+
+	// PredictedDowntime is the downtime predicted before the checkpoint
+	// from the pod's memory and the storage throughput, when
+	// spec.maxDowntimeMs is set.
+	// +optional
+	PredictedDowntime *metav1.Duration `json:"predictedDowntime,omitempty"`
+
+	// StrategyDecision is how the controller chose the strategy of a
+	// migration with strategy Auto.
+	// +optional
+	StrategyDecision *StrategyDecision `json:"strategyDecision,omitempty"`
+
+	// TransferEncryption is how the data the source node's agent streamed
+	// directly to the target node's was protected. Unset until it streamed
+	// any.
+	// +optional
+	TransferEncryption *TransferEncryption `json:"transferEncryption,omitempty"`
+
+	// PhaseTransitions records when the migration entered each phase.
+	// +optional
+	PhaseTransitions []PhaseTransition `json:"phaseTransitions,omitempty"`
+
+	// PreemptedPods are the pods evicted from the target node to make room
+	// for the restored pod, as allowed by the pod's MigrationPolicies.
+	// +optional
+	PreemptedPods []string `json:"preemptedPods,omitempty"`
+
+	// RestoreRetries counts the restores retried with freshly converted
+	// checkpoint images after the restored pod failed on its images.
+	// +optional
+	RestoreRetries int32 `json:"restoreRetries,omitempty"`
+
+	// Rebase records the compatibility checks of spec.rebase that passed.
+	// +optional
+	Rebase []ContainerRebaseStatus `json:"rebase,omitempty"`
+
+	// ReportConfigMap names the ConfigMap, in the migration's namespace,
+	// holding the MigrationReport written once the migration ended.
+	// +optional
+	ReportConfigMap string `json:"reportConfigMap,omitempty"`
+}
+
+// PhaseTransition is when a migration entered a phase.
+type PhaseTransition struct {
+	Phase PodMigrationPhase `json:"phase"`
+	Time  metav1.Time       `json:"time"`
+}
+
+// TransferEncryption records whether the streams between the agents of a
+// migration were encrypted, with TLS to a key the target node's agent
+// generated for the migration.
+type TransferEncryption struct {
+	Encrypted bool `json:"encrypted"`
+
+	// Protocol is the TLS version, e.g. TLS 1.3.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// CipherSuite is the TLS cipher suite the streams were encrypted with.
+	// +optional
+	CipherSuite string `json:"cipherSuite,omitempty"`
+
+	// Message explains why the streams were not encrypted.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// StrategyDecision records the strategy picked for a migration and the
+// measurements it was picked from.
+type StrategyDecision struct {
+	// Recommended is the strategy the measurements favour.
+	Recommended MigrationStrategy `json:"recommended"`
+
+	// Strategy is the strategy the migration uses: Recommended if the
+	// controller implements it, StopAndCopy otherwise.
+	Strategy MigrationStrategy `json:"strategy"`
+
+	// Message explains the choice.
+	Message string `json:"message"`
+
+	// CheckpointBytes is the expected size of the pod's checkpoint.
+	CheckpointBytes int64 `json:"checkpointBytes"`
+
+	// DirtyBytesPerSecond is the rate at which the pod wrote to memory while
+	// sampled.
+	DirtyBytesPerSecond int64 `json:"dirtyBytesPerSecond"`
+
+	// BandwidthBytesPerSecond is the measured throughput from the source
+	// node's agent to the target node's, or 0 if it could not be probed.
+	BandwidthBytesPerSecond int64 `json:"bandwidthBytesPerSecond"`
+
+	// TargetLazyPages reports whether the target node can restore memory
+	// lazily, which post-copy needs.
+	TargetLazyPages bool `json:"targetLazyPages"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PodRestorePhase string
+
+const (
+	RestorePhasePending         PodRestorePhase = "Pending"
+	RestorePhasePreparingImages PodRestorePhase = "PreparingImages"
+	RestorePhaseRestoring       PodRestorePhase = "Restoring"
+	RestorePhaseSucceeded       PodRestorePhase = "Succeeded"
+	RestorePhaseFailed          PodRestorePhase = "Failed"
+)
+
+// AutoRestoreAnnotation set to "true" on a pod has the controller restore
+// it on a healthy node from its latest recent checkpoint when its node fails.
+const AutoRestoreAnnotation = "lpm.my.domain/auto-restore"
+
+// AutoRestoreNodeLabel on a PodRestore names the failed node the controller
+// created it for.
+const AutoRestoreNodeLabel = "lpm.my.domain/auto-restore-node"
+
+// QuarantineLabel marks the namespaces quarantined restores create and the
+// pods restored in them.
+const QuarantineLabel = "lpm.my.domain/quarantine"
+
+// QuarantinedFromAnnotation on a quarantined pod is the namespace/name of
+// the pod it is a copy of.
+const QuarantinedFromAnnotation = "lpm.my.domain/quarantined-from"
+
+// DefaultQuarantineNamespace is the namespace quarantined restores go to
+// unless they name one.
+const DefaultQuarantineNamespace = "lpm-quarantine"
+
+// RestoreQuarantine restores a copy of a pod to debug it, cut off from the
+// network and from everything the pod is given access to.
+type RestoreQuarantine struct {
+	// Namespace the pod is restored in, created if it doesn't exist. All
+	// traffic to and from its pods is denied by a NetworkPolicy.
+	// +kubebuilder:default=lpm-quarantine
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PodRestoreSpec defines the desired state of PodRestore.
+type PodRestoreSpec struct {
+	// CheckpointRef selects the PodCheckpoint, in the PodRestore's
+	// namespace, and the generation to restore.
+	CheckpointRef CheckpointReference `json:"checkpointRef"`
+
+	// NodeName is the node the pod is restored on.
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+
+	// PodName names the restored pod. Defaults to the name of the
+	// checkpointed pod, which must then no longer exist.
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// Quarantine restores the pod in a network-isolated namespace without
+	// its labels, annotations, service account, Secrets, ConfigMaps or
+	// volumes, so it can be debugged without serving traffic or reaching
+	// the pod's dependencies. Pods on the host network cannot be isolated
+	// and are not restored.
+	// +optional
+	Quarantine *RestoreQuarantine `json:"quarantine,omitempty"`
+}
+
+// PodRestoreStatus defines the observed state of PodRestore.
+type PodRestoreStatus struct {
+	Phase   PodRestorePhase `json:"phase,omitempty"`
+	Message string          `json:"message,omitempty"`
+
+	// SourcePod is the name of the checkpointed pod.
+	SourcePod string `json:"sourcePod,omitempty"`
+
+	// Generation is the PodCheckpoint generation being restored.
+	Generation int64 `json:"generation,omitempty"`
+
+	// RestoredPodName is the name of the restored pod once created.
+	RestoredPodName string `json:"restoredPodName,omitempty"`
+
+	// CheckpointImages maps container names to their prepared OCI checkpoint
+	// image references.
+	CheckpointImages map[string]string `json:"checkpointImages,omitempty"`
+
+	// CompletionTime is when the restore succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Checkpoint",type=string,JSONPath=`.spec.checkpointRef.name`
+// +kubebuilder:printcolumn:name="Node",type=string,JSONPath=`.spec.nodeName`
+// +kubebuilder:printcolumn:name="Pod",type=string,JSONPath=`.status.restoredPodName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PodRestore is the Schema for the podrestores API. It starts a new pod on
+// a node from a PodCheckpoint, leaving the checkpointed pod, if it still
+// exists, alone.
+type PodRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PodRestoreSpec   `json:"spec,omitempty"`
+	Status PodRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PodRestoreList contains a list of PodRestore.
+type PodRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PodRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PodRestore{}, &PodRestoreList{})
+}
@@ -22,9 +22,86 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointHook) DeepCopyInto(out *CheckpointHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointHook.
+func (in *CheckpointHook) DeepCopy() *CheckpointHook {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointHookResult) DeepCopyInto(out *CheckpointHookResult) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointHookResult.
+func (in *CheckpointHookResult) DeepCopy() *CheckpointHookResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointHookResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointRedaction) DeepCopyInto(out *CheckpointRedaction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointRedaction.
+func (in *CheckpointRedaction) DeepCopy() *CheckpointRedaction {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointRedaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckpointReference) DeepCopyInto(out *CheckpointReference) {
+	*out = *in
+	if in.Generation != nil {
+		in, out := &in.Generation, &out.Generation
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointReference.
+func (in *CheckpointReference) DeepCopy() *CheckpointReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckpointReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerCheckpoint) DeepCopyInto(out *ContainerCheckpoint) {
 	*out = *in
@@ -57,7 +134,7 @@ func (in *ContainerCheckpointContent) DeepCopyInto(out *ContainerCheckpointConte
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -115,6 +192,11 @@ func (in *ContainerCheckpointContentList) DeepCopyObject() runtime.Object {
 func (in *ContainerCheckpointContentSpec) DeepCopyInto(out *ContainerCheckpointContentSpec) {
 	*out = *in
 	out.ContainerCheckpointRef = in.ContainerCheckpointRef
+	if in.CriuOptions != nil {
+		in, out := &in.CriuOptions, &out.CriuOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerCheckpointContentSpec.
@@ -134,6 +216,11 @@ func (in *ContainerCheckpointContentStatus) DeepCopyInto(out *ContainerCheckpoin
 		in, out := &in.CreationTime, &out.CreationTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Redactions != nil {
+		in, out := &in.Redactions, &out.Redactions
+		*out = make([]CheckpointRedaction, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerCheckpointContentStatus.
@@ -213,227 +300,190 @@ func (in *ContainerCheckpointStatus) DeepCopy() *ContainerCheckpointStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpoint) DeepCopyInto(out *PodCheckpoint) {
+func (in *ContainerOverride) DeepCopyInto(out *ContainerOverride) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpoint.
-func (in *PodCheckpoint) DeepCopy() *PodCheckpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOverride.
+func (in *ContainerOverride) DeepCopy() *ContainerOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpoint)
+	out := new(ContainerOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodCheckpoint) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointContent) DeepCopyInto(out *PodCheckpointContent) {
+func (in *ContainerRebase) DeepCopyInto(out *ContainerRebase) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContent.
-func (in *PodCheckpointContent) DeepCopy() *PodCheckpointContent {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRebase.
+func (in *ContainerRebase) DeepCopy() *ContainerRebase {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointContent)
+	out := new(ContainerRebase)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodCheckpointContent) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointContentList) DeepCopyInto(out *PodCheckpointContentList) {
+func (in *ContainerRebaseStatus) DeepCopyInto(out *ContainerRebaseStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PodCheckpointContent, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentList.
-func (in *PodCheckpointContentList) DeepCopy() *PodCheckpointContentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRebaseStatus.
+func (in *ContainerRebaseStatus) DeepCopy() *ContainerRebaseStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointContentList)
+	out := new(ContainerRebaseStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodCheckpointContentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointContentSpec) DeepCopyInto(out *PodCheckpointContentSpec) {
+func (in *ContainerReport) DeepCopyInto(out *ContainerReport) {
 	*out = *in
-	out.PodCheckpointRef = in.PodCheckpointRef
-	if in.ContainerContents != nil {
-		in, out := &in.ContainerContents, &out.ContainerContents
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentSpec.
-func (in *PodCheckpointContentSpec) DeepCopy() *PodCheckpointContentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerReport.
+func (in *ContainerReport) DeepCopy() *ContainerReport {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointContentSpec)
+	out := new(ContainerReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointContentStatus) DeepCopyInto(out *PodCheckpointContentStatus) {
+func (in *EmailSink) DeepCopyInto(out *EmailSink) {
 	*out = *in
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(SecretKeyReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentStatus.
-func (in *PodCheckpointContentStatus) DeepCopy() *PodCheckpointContentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailSink.
+func (in *EmailSink) DeepCopy() *EmailSink {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointContentStatus)
+	out := new(EmailSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointList) DeepCopyInto(out *PodCheckpointList) {
+func (in *IDMapping) DeepCopyInto(out *IDMapping) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PodCheckpoint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointList.
-func (in *PodCheckpointList) DeepCopy() *PodCheckpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IDMapping.
+func (in *IDMapping) DeepCopy() *IDMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointList)
+	out := new(IDMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodCheckpointList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointSpec) DeepCopyInto(out *PodCheckpointSpec) {
+func (in *IdentityRefresh) DeepCopyInto(out *IdentityRefresh) {
 	*out = *in
-	if in.PodName != nil {
-		in, out := &in.PodName, &out.PodName
-		*out = new(string)
-		**out = **in
+	if in.RestartContainers != nil {
+		in, out := &in.RestartContainers, &out.RestartContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]CheckpointHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointSpec.
-func (in *PodCheckpointSpec) DeepCopy() *PodCheckpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityRefresh.
+func (in *IdentityRefresh) DeepCopy() *IdentityRefresh {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointSpec)
+	out := new(IdentityRefresh)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCheckpointStatus) DeepCopyInto(out *PodCheckpointStatus) {
+func (in *MaintenancePodPlan) DeepCopyInto(out *MaintenancePodPlan) {
 	*out = *in
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
+	if in.PredictedDuration != nil {
+		in, out := &in.PredictedDuration, &out.PredictedDuration
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
+	if in.PredictedDowntime != nil {
+		in, out := &in.PredictedDowntime, &out.PredictedDowntime
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointStatus.
-func (in *PodCheckpointStatus) DeepCopy() *PodCheckpointStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenancePodPlan.
+func (in *MaintenancePodPlan) DeepCopy() *MaintenancePodPlan {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCheckpointStatus)
+	out := new(MaintenancePodPlan)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMigration) DeepCopyInto(out *PodMigration) {
+func (in *MigrationPolicy) DeepCopyInto(out *MigrationPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigration.
-func (in *PodMigration) DeepCopy() *PodMigration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationPolicy.
+func (in *MigrationPolicy) DeepCopy() *MigrationPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMigration)
+	out := new(MigrationPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodMigration) DeepCopyObject() runtime.Object {
+func (in *MigrationPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -441,31 +491,31 @@ func (in *PodMigration) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMigrationList) DeepCopyInto(out *PodMigrationList) {
+func (in *MigrationPolicyList) DeepCopyInto(out *MigrationPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PodMigration, len(*in))
+		*out = make([]MigrationPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationList.
-func (in *PodMigrationList) DeepCopy() *PodMigrationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationPolicyList.
+func (in *MigrationPolicyList) DeepCopy() *MigrationPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMigrationList)
+	out := new(MigrationPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodMigrationList) DeepCopyObject() runtime.Object {
+func (in *MigrationPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -473,36 +523,1482 @@ func (in *PodMigrationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMigrationSpec) DeepCopyInto(out *PodMigrationSpec) {
+func (in *MigrationPolicySpec) DeepCopyInto(out *MigrationPolicySpec) {
 	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetNodes != nil {
+		in, out := &in.TargetNodes, &out.TargetNodes
+		*out = new(TargetNodePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxCheckpointAge != nil {
+		in, out := &in.MaxCheckpointAge, &out.MaxCheckpointAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TargetPreemption != nil {
+		in, out := &in.TargetPreemption, &out.TargetPreemption
+		*out = new(TargetPreemptionPolicy)
+		**out = **in
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]MigrationTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationSpec.
-func (in *PodMigrationSpec) DeepCopy() *PodMigrationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationPolicySpec.
+func (in *MigrationPolicySpec) DeepCopy() *MigrationPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMigrationSpec)
+	out := new(MigrationPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMigrationStatus) DeepCopyInto(out *PodMigrationStatus) {
+func (in *MigrationPolicyStatus) DeepCopyInto(out *MigrationPolicyStatus) {
 	*out = *in
-	if in.PodCheckpointRef != nil {
-		in, out := &in.PodCheckpointRef, &out.PodCheckpointRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.TriggeredNodes != nil {
+		in, out := &in.TriggeredNodes, &out.TriggeredNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTriggerTime != nil {
+		in, out := &in.LastTriggerTime, &out.LastTriggerTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationStatus.
-func (in *PodMigrationStatus) DeepCopy() *PodMigrationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationPolicyStatus.
+func (in *MigrationPolicyStatus) DeepCopy() *MigrationPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMigrationStatus)
+	out := new(MigrationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationReport) DeepCopyInto(out *MigrationReport) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	out.Duration = in.Duration
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]PhaseReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Downtime != nil {
+		in, out := &in.Downtime, &out.Downtime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PredictedDowntime != nil {
+		in, out := &in.PredictedDowntime, &out.PredictedDowntime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StrategyDecision != nil {
+		in, out := &in.StrategyDecision, &out.StrategyDecision
+		*out = new(StrategyDecision)
+		**out = **in
+	}
+	if in.Simulation != nil {
+		in, out := &in.Simulation, &out.Simulation
+		*out = new(MigrationSimulation)
+		**out = **in
+	}
+	if in.TransferEncryption != nil {
+		in, out := &in.TransferEncryption, &out.TransferEncryption
+		*out = new(TransferEncryption)
+		**out = **in
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerReport, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]CheckpointHookResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrationPolicies != nil {
+		in, out := &in.MigrationPolicies, &out.MigrationPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationReport.
+func (in *MigrationReport) DeepCopy() *MigrationReport {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationSimulation) DeepCopyInto(out *MigrationSimulation) {
+	*out = *in
+	out.PredictedDuration = in.PredictedDuration
+	out.PredictedDowntime = in.PredictedDowntime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationSimulation.
+func (in *MigrationSimulation) DeepCopy() *MigrationSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationTrigger) DeepCopyInto(out *MigrationTrigger) {
+	*out = *in
+	if in.For != nil {
+		in, out := &in.For, &out.For
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationTrigger.
+func (in *MigrationTrigger) DeepCopy() *MigrationTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkCheck) DeepCopyInto(out *NetworkCheck) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(CheckpointHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkCheck.
+func (in *NetworkCheck) DeepCopy() *NetworkCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenance) DeepCopyInto(out *NodeMaintenance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenance.
+func (in *NodeMaintenance) DeepCopy() *NodeMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeMaintenance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceList) DeepCopyInto(out *NodeMaintenanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceList.
+func (in *NodeMaintenanceList) DeepCopy() *NodeMaintenanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeMaintenanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceSpec) DeepCopyInto(out *NodeMaintenanceSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceSpec.
+func (in *NodeMaintenanceSpec) DeepCopy() *NodeMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMaintenanceStatus) DeepCopyInto(out *NodeMaintenanceStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]MaintenancePodPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PredictedDuration != nil {
+		in, out := &in.PredictedDuration, &out.PredictedDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceStatus.
+func (in *NodeMaintenanceStatus) DeepCopy() *NodeMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMigration) DeepCopyInto(out *NodeMigration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMigration.
+func (in *NodeMigration) DeepCopy() *NodeMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMigrationStatus) DeepCopyInto(out *NodeMigrationStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMigrationStatus.
+func (in *NodeMigrationStatus) DeepCopy() *NodeMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeMigrationStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMigrationStatusList) DeepCopyInto(out *NodeMigrationStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeMigrationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMigrationStatusList.
+func (in *NodeMigrationStatusList) DeepCopy() *NodeMigrationStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMigrationStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeMigrationStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMigrationStatusSpec) DeepCopyInto(out *NodeMigrationStatusSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMigrationStatusSpec.
+func (in *NodeMigrationStatusSpec) DeepCopy() *NodeMigrationStatusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMigrationStatusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeMigrationStatusStatus) DeepCopyInto(out *NodeMigrationStatusStatus) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = make([]NodeMigration, len(*in))
+		copy(*out, *in)
+	}
+	if in.Restores != nil {
+		in, out := &in.Restores, &out.Restores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMigrationStatusStatus.
+func (in *NodeMigrationStatusStatus) DeepCopy() *NodeMigrationStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeMigrationStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]MigrationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookSink)
+		**out = **in
+	}
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackSink)
+		**out = **in
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailSink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseReport) DeepCopyInto(out *PhaseReport) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseReport.
+func (in *PhaseReport) DeepCopy() *PhaseReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseTransition) DeepCopyInto(out *PhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseTransition.
+func (in *PhaseTransition) DeepCopy() *PhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpoint) DeepCopyInto(out *PodCheckpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpoint.
+func (in *PodCheckpoint) DeepCopy() *PodCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodCheckpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointContent) DeepCopyInto(out *PodCheckpointContent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContent.
+func (in *PodCheckpointContent) DeepCopy() *PodCheckpointContent {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointContent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodCheckpointContent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointContentList) DeepCopyInto(out *PodCheckpointContentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodCheckpointContent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentList.
+func (in *PodCheckpointContentList) DeepCopy() *PodCheckpointContentList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointContentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodCheckpointContentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointContentSpec) DeepCopyInto(out *PodCheckpointContentSpec) {
+	*out = *in
+	out.PodCheckpointRef = in.PodCheckpointRef
+	if in.ContainerContents != nil {
+		in, out := &in.ContainerContents, &out.ContainerContents
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourcePod != nil {
+		in, out := &in.SourcePod, &out.SourcePod
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentSpec.
+func (in *PodCheckpointContentSpec) DeepCopy() *PodCheckpointContentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointContentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointContentStatus) DeepCopyInto(out *PodCheckpointContentStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointContentStatus.
+func (in *PodCheckpointContentStatus) DeepCopy() *PodCheckpointContentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointContentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointGeneration) DeepCopyInto(out *PodCheckpointGeneration) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointGeneration.
+func (in *PodCheckpointGeneration) DeepCopy() *PodCheckpointGeneration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointGeneration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointList) DeepCopyInto(out *PodCheckpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodCheckpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointList.
+func (in *PodCheckpointList) DeepCopy() *PodCheckpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodCheckpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointSpec) DeepCopyInto(out *PodCheckpointSpec) {
+	*out = *in
+	if in.PodName != nil {
+		in, out := &in.PodName, &out.PodName
+		*out = new(string)
+		**out = **in
+	}
+	if in.QuiesceHooks != nil {
+		in, out := &in.QuiesceHooks, &out.QuiesceHooks
+		*out = make([]CheckpointHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResumeHooks != nil {
+		in, out := &in.ResumeHooks, &out.ResumeHooks
+		*out = make([]CheckpointHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointSpec.
+func (in *PodCheckpointSpec) DeepCopy() *PodCheckpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCheckpointStatus) DeepCopyInto(out *PodCheckpointStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]PodCheckpointGeneration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HookResults != nil {
+		in, out := &in.HookResults, &out.HookResults
+		*out = make([]CheckpointHookResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCheckpointTime != nil {
+		in, out := &in.LastCheckpointTime, &out.LastCheckpointTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCheckpointStatus.
+func (in *PodCheckpointStatus) DeepCopy() *PodCheckpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCheckpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMigration) DeepCopyInto(out *PodMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigration.
+func (in *PodMigration) DeepCopy() *PodMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMigrationList) DeepCopyInto(out *PodMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationList.
+func (in *PodMigrationList) DeepCopy() *PodMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMigrationSpec) DeepCopyInto(out *PodMigrationSpec) {
+	*out = *in
+	if in.CheckpointRef != nil {
+		in, out := &in.CheckpointRef, &out.CheckpointRef
+		*out = new(CheckpointReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxDowntimeMs != nil {
+		in, out := &in.MaxDowntimeMs, &out.MaxDowntimeMs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NetworkCheck != nil {
+		in, out := &in.NetworkCheck, &out.NetworkCheck
+		*out = new(NetworkCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdentityRefresh != nil {
+		in, out := &in.IdentityRefresh, &out.IdentityRefresh
+		*out = new(IdentityRefresh)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreStartupProbe != nil {
+		in, out := &in.RestoreStartupProbe, &out.RestoreStartupProbe
+		*out = new(RestoreStartupProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoredPodOverrides != nil {
+		in, out := &in.RestoredPodOverrides, &out.RestoredPodOverrides
+		*out = new(RestoredPodOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rebase != nil {
+		in, out := &in.Rebase, &out.Rebase
+		*out = make([]ContainerRebase, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationSpec.
+func (in *PodMigrationSpec) DeepCopy() *PodMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMigrationStatus) DeepCopyInto(out *PodMigrationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodCheckpointRef != nil {
+		in, out := &in.PodCheckpointRef, &out.PodCheckpointRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CheckpointImages != nil {
+		in, out := &in.CheckpointImages, &out.CheckpointImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Simulation != nil {
+		in, out := &in.Simulation, &out.Simulation
+		*out = new(MigrationSimulation)
+		**out = **in
+	}
+	if in.SourceUserNamespace != nil {
+		in, out := &in.SourceUserNamespace, &out.SourceUserNamespace
+		*out = new(UserNamespaceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourceSecurityContext != nil {
+		in, out := &in.SourceSecurityContext, &out.SourceSecurityContext
+		*out = new(SecurityContextStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClockJump != nil {
+		in, out := &in.ClockJump, &out.ClockJump
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(StatefulSetHandover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicaSet != nil {
+		in, out := &in.ReplicaSet, &out.ReplicaSet
+		*out = new(ReplicaSetHandover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = make([]TopologySpreadReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MigrationPolicies != nil {
+		in, out := &in.MigrationPolicies, &out.MigrationPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotifiedEvents != nil {
+		in, out := &in.NotifiedEvents, &out.NotifiedEvents
+		*out = make([]MigrationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.CheckpointStartTime != nil {
+		in, out := &in.CheckpointStartTime, &out.CheckpointStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PredictedDowntime != nil {
+		in, out := &in.PredictedDowntime, &out.PredictedDowntime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StrategyDecision != nil {
+		in, out := &in.StrategyDecision, &out.StrategyDecision
+		*out = new(StrategyDecision)
+		**out = **in
+	}
+	if in.TransferEncryption != nil {
+		in, out := &in.TransferEncryption, &out.TransferEncryption
+		*out = new(TransferEncryption)
+		**out = **in
+	}
+	if in.PhaseTransitions != nil {
+		in, out := &in.PhaseTransitions, &out.PhaseTransitions
+		*out = make([]PhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreemptedPods != nil {
+		in, out := &in.PreemptedPods, &out.PreemptedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rebase != nil {
+		in, out := &in.Rebase, &out.Rebase
+		*out = make([]ContainerRebaseStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMigrationStatus.
+func (in *PodMigrationStatus) DeepCopy() *PodMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRestore) DeepCopyInto(out *PodRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRestore.
+func (in *PodRestore) DeepCopy() *PodRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRestoreList) DeepCopyInto(out *PodRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRestoreList.
+func (in *PodRestoreList) DeepCopy() *PodRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRestoreSpec) DeepCopyInto(out *PodRestoreSpec) {
+	*out = *in
+	in.CheckpointRef.DeepCopyInto(&out.CheckpointRef)
+	if in.Quarantine != nil {
+		in, out := &in.Quarantine, &out.Quarantine
+		*out = new(RestoreQuarantine)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRestoreSpec.
+func (in *PodRestoreSpec) DeepCopy() *PodRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRestoreStatus) DeepCopyInto(out *PodRestoreStatus) {
+	*out = *in
+	if in.CheckpointImages != nil {
+		in, out := &in.CheckpointImages, &out.CheckpointImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRestoreStatus.
+func (in *PodRestoreStatus) DeepCopy() *PodRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSetHandover) DeepCopyInto(out *ReplicaSetHandover) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSetHandover.
+func (in *ReplicaSetHandover) DeepCopy() *ReplicaSetHandover {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSetHandover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreQuarantine) DeepCopyInto(out *RestoreQuarantine) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreQuarantine.
+func (in *RestoreQuarantine) DeepCopy() *RestoreQuarantine {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreQuarantine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreStartupProbe) DeepCopyInto(out *RestoreStartupProbe) {
+	*out = *in
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStartupProbe.
+func (in *RestoreStartupProbe) DeepCopy() *RestoreStartupProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStartupProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoredPodOverrides) DeepCopyInto(out *RestoredPodOverrides) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoredPodOverrides.
+func (in *RestoredPodOverrides) DeepCopy() *RestoredPodOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoredPodOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityContextStatus) DeepCopyInto(out *SecurityContextStatus) {
+	*out = *in
+	if in.AppArmorProfiles != nil {
+		in, out := &in.AppArmorProfiles, &out.AppArmorProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityContextStatus.
+func (in *SecurityContextStatus) DeepCopy() *SecurityContextStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityContextStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackSink) DeepCopyInto(out *SlackSink) {
+	*out = *in
+	out.URLSecret = in.URLSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackSink.
+func (in *SlackSink) DeepCopy() *SlackSink {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSetHandover) DeepCopyInto(out *StatefulSetHandover) {
+	*out = *in
+	if in.Manifest != nil {
+		in, out := &in.Manifest, &out.Manifest
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SourcePod != nil {
+		in, out := &in.SourcePod, &out.SourcePod
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSetHandover.
+func (in *StatefulSetHandover) DeepCopy() *StatefulSetHandover {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSetHandover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StrategyDecision) DeepCopyInto(out *StrategyDecision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StrategyDecision.
+func (in *StrategyDecision) DeepCopy() *StrategyDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(StrategyDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetNodePolicy) DeepCopyInto(out *TargetNodePolicy) {
+	*out = *in
+	if in.AllowedSelector != nil {
+		in, out := &in.AllowedSelector, &out.AllowedSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeniedSelector != nil {
+		in, out := &in.DeniedSelector, &out.DeniedSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeniedNodes != nil {
+		in, out := &in.DeniedNodes, &out.DeniedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetNodePolicy.
+func (in *TargetNodePolicy) DeepCopy() *TargetNodePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetNodePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetPreemptionPolicy) DeepCopyInto(out *TargetPreemptionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetPreemptionPolicy.
+func (in *TargetPreemptionPolicy) DeepCopy() *TargetPreemptionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetPreemptionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologyDomainCount) DeepCopyInto(out *TopologyDomainCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologyDomainCount.
+func (in *TopologyDomainCount) DeepCopy() *TopologyDomainCount {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologyDomainCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadReport) DeepCopyInto(out *TopologySpreadReport) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]TopologyDomainCount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadReport.
+func (in *TopologySpreadReport) DeepCopy() *TopologySpreadReport {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferEncryption) DeepCopyInto(out *TransferEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferEncryption.
+func (in *TransferEncryption) DeepCopy() *TransferEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserNamespaceStatus) DeepCopyInto(out *UserNamespaceStatus) {
+	*out = *in
+	if in.UIDMappings != nil {
+		in, out := &in.UIDMappings, &out.UIDMappings
+		*out = make([]IDMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.GIDMappings != nil {
+		in, out := &in.GIDMappings, &out.GIDMappings
+		*out = make([]IDMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserNamespaceStatus.
+func (in *UserNamespaceStatus) DeepCopy() *UserNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookSink) DeepCopyInto(out *WebhookSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookSink.
+func (in *WebhookSink) DeepCopy() *WebhookSink {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookSink)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// Modes of what the agent writes to shared storage. All agents write as the
+// same user (root, or the user the NFS server squashes it to), so by default
+// only they can read the archives: one tenant's memory dumps are not
+// readable by another's pods, nor by anyone else mounting the storage.
+const (
+	artifactFileMode = 0o600
+	artifactDirMode  = 0o700
+
+	// A namespace's group can read its archives and list the directories
+	// of the layout from the namespace's down, which pass the group on
+	groupArtifactFileMode = 0o640
+	groupArtifactDirMode  = 0o750 | fs.ModeSetgid
+	// Directories namespaces share can be passed through, not listed
+	sharedDirMode = 0o711
+)
+
+// artifactAccess is who may read the archives of a namespace.
+type artifactAccess struct {
+	namespace string
+	// gid is the group of lpmv1.ArtifactGroupAnnotation, -1 for none
+	gid int
+}
+
+// inClusterClientset returns the agent's client of the API server.
+var inClusterClientset = sync.OnceValues(func() (kubernetes.Interface, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+})
+
+// artifactAccessFor looks up who may read the archives of namespace. When
+// the namespace cannot be read or its annotation is invalid, only the
+// agents may.
+func artifactAccessFor(ctx context.Context, namespace string) artifactAccess {
+	access := artifactAccess{namespace: namespace, gid: -1}
+	logger := log.FromContext(ctx)
+	clientset, err := inClusterClientset()
+	if err != nil {
+		logger.Error(err, "Failed to create client, keeping archives private to the agents")
+		return access
+	}
+	ns, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		logger.Error(err, "Failed to get namespace, keeping its archives private to the agents", "namespace", namespace)
+		return access
+	}
+	value, ok := ns.Annotations[lpmv1.ArtifactGroupAnnotation]
+	if !ok {
+		return access
+	}
+	gid, err := strconv.Atoi(value)
+	if err != nil || gid < 0 {
+		logger.Error(err, "Invalid artifact group, keeping the namespace's archives private to the agents",
+			"namespace", namespace, "annotation", lpmv1.ArtifactGroupAnnotation, "value", value)
+		return access
+	}
+	access.gid = gid
+	return access
+}
+
+// mkdirs creates the directories of dir, relative to the shared storage,
+// and gives each of them, created or not, the mode and group of the
+// access. The directories from the one named after the namespace down are
+// the namespace's: private to the agents, or listable by its group. Those
+// above, and all of them if the layout has no namespace directory, are
+// shared by namespaces and only passed through.
+func (a artifactAccess) mkdirs(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	elements := strings.Split(dir, string(filepath.Separator))
+	owned := a.namespaceDir(elements)
+	path := sharedCheckpointDir
+	for i, element := range elements {
+		path = filepath.Join(path, element)
+		if err := os.Mkdir(path, artifactDirMode); err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+		mode := fs.FileMode(sharedDirMode)
+		switch {
+		case i < owned:
+		case a.gid < 0:
+			mode = artifactDirMode
+		default:
+			if err := os.Chown(path, -1, a.gid); err != nil {
+				return err
+			}
+			mode = groupArtifactDirMode
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namespaceDir returns the index of the directory named after the namespace
+// in the elements of a path, or their count if there is none. It is where
+// the layout puts the namespace, not wherever an element happens to match
+// it, e.g. a pod named after another namespace.
+func (a artifactAccess) namespaceDir(elements []string) int {
+	if i := layoutNamespaceDir(); i >= 0 && i < len(elements) && elements[i] == a.namespace {
+		return i
+	}
+	return len(elements)
+}
+
+// shares reports whether the directory dir, relative to the shared storage,
+// may hold the files of other namespaces.
+func (a artifactAccess) shares(dir string) bool {
+	elements := strings.Split(dir, string(filepath.Separator))
+	return dir == "." || a.namespaceDir(elements) == len(elements)
+}
+
+// apply gives the file at path the mode and group of the access.
+func (a artifactAccess) apply(path string) error {
+	if a.gid < 0 {
+		return os.Chmod(path, artifactFileMode)
+	}
+	if err := os.Chown(path, -1, a.gid); err != nil {
+		return err
+	}
+	return os.Chmod(path, groupArtifactFileMode)
+}
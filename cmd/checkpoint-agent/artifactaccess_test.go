@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("artifactAccess", func() {
+	access := artifactAccess{namespace: "team-a", gid: -1}
+
+	// withLayout parses layout as --artifact-layout for the spec.
+	withLayout := func(layout string) {
+		tmpl, err := parseArtifactLayout(layout)
+		Expect(err).NotTo(HaveOccurred())
+		saved := artifactLayoutTemplate
+		artifactLayoutTemplate = tmpl
+		DeferCleanup(func() { artifactLayoutTemplate = saved })
+	}
+
+	It("finds the namespace directory where the layout puts it", func() {
+		withLayout("checkpoints/{{.Namespace}}/{{.Pod}}/{{.Timestamp}}")
+		Expect(access.namespaceDir(strings.Split("checkpoints/team-a/web", "/"))).To(Equal(1))
+		Expect(access.shares("checkpoints")).To(BeTrue())
+		Expect(access.shares("checkpoints/team-a")).To(BeFalse())
+		Expect(access.shares("checkpoints/team-a/web")).To(BeFalse())
+	})
+
+	It("ignores elements elsewhere that happen to match the namespace", func() {
+		withLayout("{{.Namespace}}/{{.Pod}}/{{.Timestamp}}")
+		// A pod of another namespace named after this one
+		Expect(access.namespaceDir(strings.Split("team-b/team-a", "/"))).To(Equal(2))
+		Expect(access.shares("team-b/team-a")).To(BeTrue())
+	})
+
+	It("finds no namespace directory in layouts without one", func() {
+		withLayout("{{.Pod}}/{{.Namespace}}-{{.Timestamp}}")
+		Expect(access.shares("team-a")).To(BeTrue())
+
+		withLayout("{{.PodUID}}-{{.Container}}-{{.Timestamp}}")
+		Expect(layoutNamespaceDir()).To(Equal(-1))
+	})
+})
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	defaultBandwidthProbeDuration = 2 * time.Second
+	maxBandwidthProbeDuration     = 10 * time.Second
+
+	// bandwidthProbeChunkBytes is the size of each message streamed by a
+	// bandwidth probe.
+	bandwidthProbeChunkBytes = 1024 * 1024
+)
+
+// ProbeBandwidth streams random data to the agent at req.TargetEndpoint for
+// the requested duration and reports the throughput that agent received.
+// Random data keeps compression on the path from inflating the result. With
+// req.Transfer the stream is encrypted like the transfer's, so its cost is
+// measured too.
+func (s *CheckpointServer) ProbeBandwidth(ctx context.Context, req *pb.ProbeBandwidthRequest) (*pb.ProbeBandwidthResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Bandwidth probe request", "target", req.TargetEndpoint, "durationMs", req.DurationMs)
+
+	duration := min(time.Duration(req.DurationMs)*time.Millisecond, maxBandwidthProbeDuration)
+	if duration == 0 {
+		duration = defaultBandwidthProbeDuration
+	}
+
+	creds := insecure.NewCredentials()
+	var encryption *pb.TransferEncryption
+	if req.Transfer != nil {
+		var err error
+		creds, err = transferClientCredentials(req.Transfer, func(state tls.ConnectionState) {
+			encryption = transferEncryption(state)
+		})
+		if err != nil {
+			return &pb.ProbeBandwidthResponse{Error: err.Error()}, nil
+		}
+	}
+	conn, err := grpc.NewClient(req.TargetEndpoint,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(maxMessageSize)),
+	)
+	if err != nil {
+		return &pb.ProbeBandwidthResponse{Error: fmt.Sprintf("failed to dial %s: %v", req.TargetEndpoint, err)}, nil
+	}
+	defer conn.Close()
+
+	// Leave the target time to answer once the stream is closed
+	ctx, cancel := context.WithTimeout(ctx, duration+defaultProbeTimeout)
+	defer cancel()
+	stream, err := pb.NewCheckpointServiceClient(conn).ReceiveProbe(ctx)
+	if err != nil {
+		return &pb.ProbeBandwidthResponse{Error: fmt.Sprintf("failed to open probe stream: %v", err)}, nil
+	}
+
+	chunk := &pb.ProbeChunk{Data: make([]byte, bandwidthProbeChunkBytes)}
+	if _, err := rand.Read(chunk.Data); err != nil {
+		return &pb.ProbeBandwidthResponse{Error: fmt.Sprintf("failed to generate probe data: %v", err)}, nil
+	}
+	for deadline := time.Now().Add(duration); time.Now().Before(deadline); {
+		if err := stream.Send(chunk); err != nil {
+			// The actual error is returned by CloseAndRecv
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return &pb.ProbeBandwidthResponse{Error: fmt.Sprintf("failed to send probe data: %v", err)}, nil
+		}
+	}
+	result, err := stream.CloseAndRecv()
+	if err != nil {
+		return &pb.ProbeBandwidthResponse{Error: fmt.Sprintf("probe failed: %v", err)}, nil
+	}
+	if result.DurationMs <= 0 {
+		return &pb.ProbeBandwidthResponse{Error: "target received the probe data too quickly to time it"}, nil
+	}
+
+	bytesPerSecond := result.Bytes * 1000 / result.DurationMs
+	logger.Info("Measured bandwidth", "target", req.TargetEndpoint, "bytes", result.Bytes, "durationMs", result.DurationMs,
+		"bytesPerSecond", bytesPerSecond, "encrypted", encryption != nil)
+	return &pb.ProbeBandwidthResponse{
+		Success:        true,
+		Bytes:          result.Bytes,
+		DurationMs:     result.DurationMs,
+		BytesPerSecond: bytesPerSecond,
+		Encryption:     encryption,
+	}, nil
+}
+
+// ReceiveProbe discards the data streamed by another agent's ProbeBandwidth.
+// A stream running well past the longest probe is cut off.
+func (s *CheckpointServer) ReceiveProbe(stream pb.CheckpointService_ReceiveProbeServer) error {
+	var received int64
+	var start time.Time
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		// Time from the first chunk, which therefore does not count
+		if start.IsZero() {
+			start = time.Now()
+			continue
+		}
+		if time.Since(start) > maxBandwidthProbeDuration+defaultProbeTimeout {
+			return errors.New("probe stream exceeded the maximum probe duration")
+		}
+		received += int64(len(chunk.Data))
+	}
+
+	var elapsed time.Duration
+	if !start.IsZero() {
+		elapsed = time.Since(start)
+	}
+	return stream.SendAndClose(&pb.ReceiveProbeResponse{Bytes: received, DurationMs: elapsed.Milliseconds()})
+}
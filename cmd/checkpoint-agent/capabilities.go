@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var capabilityInterval = flag.Duration("capability-interval", 5*time.Minute,
+	"How often the agent refreshes the capability labels of its Node; 0 labels it only at startup.")
+
+// runtimeSockets identifies the node's container runtime by its socket.
+var runtimeSockets = []struct{ runtime, socket string }{
+	{lpmv1.NodeRuntimeCRIO, "/var/run/crio/crio.sock"},
+	{lpmv1.NodeRuntimeContainerd, "/run/containerd/containerd.sock"},
+}
+
+// hostCommand runs a command in the host's mount namespace, where the
+// runtime finds CRIU. It needs hostPID.
+func hostCommand(name string, args ...string) *exec.Cmd {
+	return exec.Command("nsenter", append([]string{"--target", "1", "--mount", "--", name}, args...)...)
+}
+
+// detectCapabilities returns the capability labels of this node.
+func detectCapabilities(ctx context.Context, nodeName string) map[string]string {
+	labels := map[string]string{
+		lpmv1.NodeCRIUVersionLabel:         criuVersion(),
+		lpmv1.NodeContainerRuntimeLabel:    lpmv1.NodeCapabilityUnknown,
+		lpmv1.NodeLazyPagesLabel:           "false",
+		lpmv1.NodeSharedStorageLabel:       "false",
+		lpmv1.NodeCheckpointStreamingLabel: "true",
+	}
+	for _, rs := range runtimeSockets {
+		if info, err := os.Stat(rs.socket); err == nil && info.Mode()&os.ModeSocket != 0 {
+			labels[lpmv1.NodeContainerRuntimeLabel] = rs.runtime
+			break
+		}
+	}
+	if labels[lpmv1.NodeCRIUVersionLabel] != lpmv1.NodeCapabilityNone &&
+		hostCommand("criu", "check", "--feature", "uffd-noncoop").Run() == nil {
+		labels[lpmv1.NodeLazyPagesLabel] = "true"
+	}
+	if *sharedStorage && checkWritableDir("/mnt/checkpoints") == nil {
+		labels[lpmv1.NodeSharedStorageLabel] = "true"
+	}
+	state, answer := probeKubeletCheckpoint(ctx, nodeName)
+	if state != lpmv1.NodeKubeletCheckpointEnabled {
+		log.FromContext(ctx).Info("Kubelet checkpoint API is not enabled", "state", state, "answer", answer)
+	}
+	labels[lpmv1.NodeKubeletCheckpointLabel] = state
+	return labels
+}
+
+// criuVersion returns the version of the host's CRIU as a label value.
+func criuVersion() string {
+	output, err := hostCommand("criu", "--version").Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 127 {
+		// nsenter could not find criu
+		return lpmv1.NodeCapabilityNone
+	}
+	if err != nil {
+		agentLog.Error(err, "Failed to run criu --version")
+		return lpmv1.NodeCapabilityUnknown
+	}
+	// "Version: 4.1.1"
+	for _, line := range strings.Split(string(output), "\n") {
+		if version, ok := strings.CutPrefix(line, "Version:"); ok {
+			version = strings.TrimSpace(version)
+			if len(validation.IsValidLabelValue(version)) == 0 {
+				return version
+			}
+		}
+	}
+	return lpmv1.NodeCapabilityUnknown
+}
+
+// labelNode sets the capability labels on nodeName with the agent's service
+// account. Labels are only patched when they changed.
+func labelNode(ctx context.Context, clientset kubernetes.Interface, nodeName string) error {
+	node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	labels := detectCapabilities(ctx, nodeName)
+	changed := false
+	for key, value := range labels {
+		if node.Labels[key] != value {
+			changed = true
+		}
+	}
+	patch := map[string]any{"metadata": map[string]any{
+		"annotations": map[string]string{lpmv1.NodeCapabilitiesUpdatedAnnotation: time.Now().UTC().Format(time.RFC3339)},
+	}}
+	if changed {
+		log.FromContext(ctx).Info("Labeling node with its capabilities", "node", nodeName, "labels", labels)
+		patch["metadata"].(map[string]any)["labels"] = labels
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// runCapabilityLabeler labels the agent's Node at startup and then every
+// --capability-interval. Without in-cluster credentials it only logs.
+func runCapabilityLabeler(nodeName string) {
+	if nodeName == "" {
+		agentLog.Info("NODE_NAME is not set, not labeling the node with its capabilities")
+		return
+	}
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		agentLog.Error(err, "Not labeling node with its capabilities", "node", nodeName)
+		return
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		agentLog.Error(err, "Not labeling node with its capabilities", "node", nodeName)
+		return
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		if err := labelNode(ctx, clientset, nodeName); err != nil {
+			agentLog.Error(err, "Failed to label node with its capabilities", "node", nodeName)
+		}
+		cancel()
+		if *capabilityInterval <= 0 {
+			return
+		}
+		time.Sleep(*capabilityInterval)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// CheckpointPod checkpoints several containers of one pod at once. The
+// containers are dumped in parallel, so their states are taken closer
+// together than with one Checkpoint call each, and each gets its own result:
+// a container that fails to checkpoint doesn't fail the others. Requests
+// with an ID go through the ledger as with Checkpoint.
+func (s *CheckpointServer) CheckpointPod(ctx context.Context, req *pb.CheckpointPodRequest) (*pb.CheckpointPodResponse, error) {
+	resp := &pb.CheckpointPodResponse{Containers: make([]*pb.CheckpointResponse, len(req.Containers))}
+	if len(req.Containers) == 0 {
+		return resp, nil
+	}
+	first := req.Containers[0]
+	log.FromContext(ctx).Info("Checkpoint pod request", "namespace", first.PodNamespace, "pod", first.PodName,
+		"podUID", first.PodUid, "containers", len(req.Containers))
+
+	var wg sync.WaitGroup
+	for i, container := range req.Containers {
+		if container.PodNamespace != first.PodNamespace || container.PodName != first.PodName || container.PodUid != first.PodUid {
+			resp.Containers[i] = &pb.CheckpointResponse{
+				Success: false,
+				Error:   fmt.Sprintf("container %s is not of pod %s/%s", container.ContainerName, first.PodNamespace, first.PodName),
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := s.Checkpoint(ctx, container)
+			if err != nil {
+				result = &pb.CheckpointResponse{
+					Success: false,
+					Error:   fmt.Sprintf("checkpoint failed: %v", err),
+				}
+			}
+			resp.Containers[i] = result
+		}()
+	}
+	wg.Wait()
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// artifactRoots are the directories checkpoint archives are written to; the
+// agent deletes nothing outside them.
+var artifactRoots = []string{checkpointDir, sharedCheckpointDir}
+
+// DeleteCheckpoint removes the archives of a checkpoint. A node-local
+// archive can only be removed by the agent of its node; a shared one by any.
+func (s *CheckpointServer) DeleteCheckpoint(ctx context.Context, req *pb.DeleteCheckpointRequest) (*pb.DeleteCheckpointResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Delete checkpoint request", "artifacts", req.ArtifactUris)
+
+	resp := &pb.DeleteCheckpointResponse{}
+	for _, uri := range req.ArtifactUris {
+		if uri == "" {
+			continue
+		}
+		path, err := artifactPath(uri)
+		if err != nil {
+			resp.Error = err.Error()
+			return resp, nil
+		}
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err == nil && !info.Mode().IsRegular() {
+			err = fmt.Errorf("not a regular file")
+		}
+		if err == nil {
+			err = os.Remove(path)
+		}
+		if err == nil {
+			err = removeSummary(path)
+		}
+		if err != nil {
+			logger.Error(err, "Failed to delete checkpoint archive", "path", path)
+			resp.Error = fmt.Sprintf("failed to delete %s: %v", uri, err)
+			return resp, nil
+		}
+		if strings.HasPrefix(path, sharedCheckpointDir+"/") {
+			if err := unindexArtifact(path); err != nil {
+				logger.Error(err, "Failed to remove deleted archive from its index", "path", path)
+			}
+		}
+		resp.DeletedUris = append(resp.DeletedUris, uri)
+		resp.FreedBytes += info.Size()
+	}
+
+	logger.Info("Deleted checkpoint archives", "archives", len(resp.DeletedUris), "freedBytes", resp.FreedBytes)
+	resp.Success = true
+	return resp, nil
+}
+
+// artifactPath resolves a checkpoint archive URI to its path, rejecting
+// paths outside artifactRoots.
+func artifactPath(uri string) (string, error) {
+	path := filepath.Clean(resolveCheckpointPath(uri))
+	for _, root := range artifactRoots {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a checkpoint archive", uri)
+}
+
+// removeCancelledCheckpoint removes the archives the kubelet wrote for req
+// since started: files, if the kubelet returned before the cancellation,
+// and any other archive of the container from that time, which it may have
+// finished writing after the agent stopped waiting.
+func removeCancelledCheckpoint(ctx context.Context, req *pb.CheckpointRequest, started time.Time, files []string) {
+	logger := log.FromContext(ctx)
+	pattern := filepath.Join(checkpointDir, fmt.Sprintf("checkpoint-%s_%s-%s-*.tar", req.PodName, req.PodNamespace, req.ContainerName))
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		logger.Error(err, "Failed to list archives of cancelled checkpoint")
+	}
+	for _, path := range matches {
+		if info, err := os.Stat(path); err == nil && !info.ModTime().Before(started) {
+			files = append(files, path)
+		}
+	}
+	for _, path := range files {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Failed to remove archive of cancelled checkpoint", "path", path)
+			continue
+		}
+		logger.Info("Removed archive of cancelled checkpoint", "path", path)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	expectedClientCIDRs = flag.String("expected-client-cidrs", envOr("AGENT_EXPECTED_CLIENT_CIDRS", ""),
+		"Comma-separated CIDRs the agent's callers connect from, e.g. the node and pod CIDRs. Connections from elsewhere "+
+			"are counted as unexpected in lpm_agent_connections_total. Unset, sources are not checked.")
+	logDeniedSources = flag.Bool("log-denied-sources", os.Getenv("AGENT_LOG_DENIED_SOURCES") == "true",
+		"Log the address of every call --client-authorization refuses and of every connection from outside --expected-client-cidrs.")
+)
+
+var (
+	deniedCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lpm_agent_denied_calls_total",
+		Help: "Calls refused by --client-authorization, by method and gRPC code: Unauthenticated without a valid bearer token, PermissionDenied when its identity may not make the call.",
+	}, []string{"method", "code"})
+	agentConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "lpm_agent_connections_total",
+		Help: "Connections to the agent's gRPC port, by whether their source is in --expected-client-cidrs (expected, unexpected), or unchecked without it.",
+	}, []string{"source"})
+)
+
+func init() {
+	prometheus.MustRegister(deniedCalls, agentConnections)
+}
+
+// clientSources are the networks of --expected-client-cidrs, nil if sources
+// are not checked.
+var clientSources []netip.Prefix
+
+// parseClientSources parses --expected-client-cidrs.
+func parseClientSources(cidrs string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, cidr := range strings.Split(cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// recordConnection counts a connection from addr by whether its source is
+// expected.
+func recordConnection(addr net.Addr) {
+	if clientSources == nil {
+		agentConnections.WithLabelValues("unchecked").Inc()
+		return
+	}
+	if source, err := netip.ParseAddrPort(addr.String()); err == nil {
+		for _, prefix := range clientSources {
+			if prefix.Contains(source.Addr().Unmap()) {
+				agentConnections.WithLabelValues("expected").Inc()
+				return
+			}
+		}
+	}
+	agentConnections.WithLabelValues("unexpected").Inc()
+	if *logDeniedSources {
+		agentLog.Info("Connection from an unexpected source", "source", addr.String())
+	}
+}
+
+// recordDenied counts a call to method that authorization refused with err.
+func recordDenied(ctx context.Context, method string, err error) {
+	code := status.Code(err)
+	if code != codes.Unauthenticated && code != codes.PermissionDenied {
+		// The API server could not be asked; the caller did nothing wrong
+		return
+	}
+	deniedCalls.WithLabelValues(method, code.String()).Inc()
+	if *logDeniedSources {
+		source := "unknown"
+		if p, ok := peer.FromContext(ctx); ok {
+			source = p.Addr.String()
+		}
+		agentLog.Info("Denied call", "method", method, "code", code.String(), "source", source,
+			"reason", status.Convert(err).Message())
+	}
+}
@@ -0,0 +1,449 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// Whether the agent authorizes its clients.
+const (
+	// clientAuthorizationNone serves every caller that reaches the agent.
+	clientAuthorizationNone = "none"
+	// clientAuthorizationToken requires a Kubernetes bearer token, reviewed
+	// with the API server, whose identity may create PodCheckpoints in the
+	// namespace of the pod a call is about.
+	clientAuthorizationToken = "token"
+
+	// clientDecisionTTL is how long the verdict on a token is reused.
+	clientDecisionTTL = time.Minute
+)
+
+var clientAuthorization = flag.String("client-authorization", envOr("AGENT_CLIENT_AUTHORIZATION", clientAuthorizationNone),
+	"How the agent authorizes its callers: none, or token (a Kubernetes bearer token allowed to create PodCheckpoints in the pod's namespace).")
+
+// clientAuthorizer authorizes the agent's callers against Kubernetes RBAC, so
+// a controller limited to some namespaces can only checkpoint, freeze and
+// restore pods in those, and only read, convert or delete their archives. A
+// call about a pod or an archive needs create on podcheckpoints in the
+// pod's namespace; a call about no particular pod, such as a probe, needs
+// get on the agent's Node.
+type clientAuthorizer struct {
+	clientset kubernetes.Interface
+	nodeName  string
+
+	mu        sync.Mutex
+	decisions map[clientDecisionKey]clientDecision
+}
+
+type clientDecisionKey struct {
+	token     [sha256.Size]byte
+	namespace string
+}
+
+type clientDecision struct {
+	err     error
+	expires time.Time
+}
+
+// newClientAuthorizer creates an authorizer reviewing tokens with the API
+// server the agent runs in.
+func newClientAuthorizer(nodeName string) (*clientAuthorizer, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &clientAuthorizer{
+		clientset: clientset,
+		nodeName:  nodeName,
+		decisions: map[clientDecisionKey]clientDecision{},
+	}, nil
+}
+
+// serverOptions returns the interceptors authorizing the server's calls.
+func (a *clientAuthorizer) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(a.unaryInterceptor),
+		grpc.ChainStreamInterceptor(a.streamInterceptor),
+	}
+}
+
+// unauthorizedMethods are served to anyone: the kubelet's gRPC health probe
+// and reflection, which describes the API but acts on nothing.
+var unauthorizedMethods = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+func isUnauthorizedMethod(method string) bool {
+	for _, prefix := range unauthorizedMethods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *clientAuthorizer) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if isUnauthorizedMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	if err := a.authorizeRequest(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor authorizes a stream by its first message, which names
+// the pod or archive a stream is about, before the handler sees it.
+func (a *clientAuthorizer) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isUnauthorizedMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+	return handler(srv, &authorizingStream{ServerStream: ss, authorize: func(req any) error {
+		return a.authorizeRequest(ss.Context(), info.FullMethod, req)
+	}})
+}
+
+// authorizingStream authorizes a stream when its first message is received.
+// Nothing is sent before that.
+type authorizingStream struct {
+	grpc.ServerStream
+	authorize  func(req any) error
+	authorized bool
+}
+
+func (s *authorizingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if s.authorized {
+		return err
+	}
+	if errors.Is(err, io.EOF) {
+		// A stream without messages is about no pod
+		m = nil
+	} else if err != nil {
+		return err
+	}
+	if authErr := s.authorize(m); authErr != nil {
+		return authErr
+	}
+	s.authorized = true
+	return err
+}
+
+func (s *authorizingStream) SendMsg(m any) error {
+	if !s.authorized {
+		return status.Error(codes.PermissionDenied, "the stream has not been authorized")
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// authorizeRequest checks the caller may make the call req, with method.
+func (a *clientAuthorizer) authorizeRequest(ctx context.Context, method string, req any) error {
+	namespaces, err := a.requestNamespaces(ctx, req)
+	if code := status.Code(err); code == codes.PermissionDenied || code == codes.InvalidArgument {
+		recordDenied(ctx, method, err)
+		return err
+	}
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to find the namespace of the request: %v", err)
+	}
+	if err := a.authorize(ctx, method, namespaces); err != nil {
+		recordDenied(ctx, method, err)
+		return err
+	}
+	return nil
+}
+
+// requestNamespaces returns the namespaces of the pods req is about, or nil
+// for a request about no pod or about a pod not on this node. A request
+// about archives needs the namespaces of their pods; it is refused if one
+// of them has none.
+func (a *clientAuthorizer) requestNamespaces(ctx context.Context, req any) ([]string, error) {
+	switch r := req.(type) {
+	case *pb.ConvertRequest:
+		return archiveNamespaces(r.CheckpointPath)
+	case *pb.InspectCheckpointRequest:
+		return archiveNamespaces(r.CheckpointPath)
+	case *pb.SummarizeCheckpointRequest:
+		return archiveNamespaces(r.ArtifactUri)
+	case *pb.DiffCheckpointsRequest:
+		return archiveNamespaces(r.BaseCheckpointPath, r.CheckpointPath)
+	case *pb.CheckRebaseRequest:
+		return archiveNamespaces(r.CheckpointPath)
+	case *pb.DeleteCheckpointRequest:
+		return archiveNamespaces(r.ArtifactUris...)
+	case *pb.PullCheckpointRequest:
+		return archiveNamespaces(r.ArtifactUri)
+	case *pb.TransferCheckpointRequest:
+		return archiveNamespaces(r.ArtifactUri)
+	case *pb.CheckpointChunk:
+		// The pushing agent names the namespace; PushCheckpoint only keeps
+		// an archive of it
+		if r.Header == nil || r.Header.Namespace == "" {
+			return nil, status.Error(codes.PermissionDenied, "pushed archive names no namespace")
+		}
+		return []string{r.Header.Namespace}, nil
+	case *pb.RestoreVolumesRequest:
+		namespaces, err := archiveNamespaces(r.ArchiveUris...)
+		if err != nil {
+			return nil, err
+		}
+		namespace, err := a.podNamespace(ctx, types.UID(r.PodUid))
+		if err != nil {
+			return nil, err
+		}
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+		return namespaces, nil
+	case *pb.CheckpointPodRequest:
+		var namespaces []string
+		for _, container := range r.Containers {
+			namespaces = append(namespaces, container.PodNamespace)
+		}
+		return namespaces, nil
+	case interface{ GetPodNamespace() string }:
+		return []string{r.GetPodNamespace()}, nil
+	case interface{ GetPodUid() string }:
+		namespace, err := a.podNamespace(ctx, types.UID(r.GetPodUid()))
+		if err != nil || namespace == "" {
+			return nil, err
+		}
+		return []string{namespace}, nil
+	}
+	return nil, nil
+}
+
+// archiveNamespaces returns the namespaces of the pods whose archives uris
+// name. Archives that do not exist hold no pod's data and need none.
+func archiveNamespaces(uris ...string) ([]string, error) {
+	var namespaces []string
+	for _, uri := range uris {
+		if uri == "" {
+			continue
+		}
+		path, err := artifactPath(uri)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		namespace, err := archiveNamespace(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "the namespace of %s is unknown: %v", uri, err)
+		}
+		if namespace == "" {
+			return nil, status.Errorf(codes.PermissionDenied, "the namespace of %s is unknown", uri)
+		}
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+// podNamespaceAnnotation is the annotation of a container's OCI spec that
+// names the namespace of its pod.
+const podNamespaceAnnotation = "io.kubernetes.pod.namespace"
+
+// errArchiveWalkDone stops a walk of an archive early.
+var errArchiveWalkDone = errors.New("archive walk done")
+
+// archiveNamespace returns the namespace of the pod of the archive at path:
+// the one the index of its directory records for an archive in shared
+// storage, or else the one of the container spec the archive carries. It
+// returns "" if neither names one.
+func archiveNamespace(path string) (string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(path, sharedCheckpointDir+"/") {
+		entry, ok, err := indexedArtifact(path)
+		if err != nil {
+			return "", err
+		}
+		if ok && entry.Namespace != "" {
+			return entry.Namespace, nil
+		}
+	}
+
+	var namespace string
+	err := walkArchive(path, func(name string, _ *tar.Header, r io.Reader) error {
+		if name != "spec.dump" {
+			return nil
+		}
+		var spec struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		if err := json.NewDecoder(r).Decode(&spec); err != nil {
+			return fmt.Errorf("failed to decode spec.dump: %w", err)
+		}
+		namespace = spec.Annotations[podNamespaceAnnotation]
+		return errArchiveWalkDone
+	})
+	if err != nil && !errors.Is(err, errArchiveWalkDone) {
+		return "", err
+	}
+	return namespace, nil
+}
+
+// podNamespace returns the namespace of the pod uid on this node, or "" if
+// no pod on the node has that UID.
+func (a *clientAuthorizer) podNamespace(ctx context.Context, uid types.UID) (string, error) {
+	if uid == "" {
+		return "", nil
+	}
+	pods, err := a.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + a.nodeName})
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.UID == uid {
+			return pod.Namespace, nil
+		}
+	}
+	return "", nil
+}
+
+// authorize checks the caller's token grants access to each of namespaces,
+// or to the node if there are none.
+func (a *clientAuthorizer) authorize(ctx context.Context, method string, namespaces []string) error {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "%s requires a bearer token", method)
+	}
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+	for _, namespace := range namespaces {
+		if err := a.decide(ctx, token, namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bearerToken returns the bearer token of the call's authorization header.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+// decide returns whether token grants access to namespace, reusing verdicts
+// for clientDecisionTTL. Failures to reach the API server are not kept.
+func (a *clientAuthorizer) decide(ctx context.Context, token, namespace string) error {
+	key := clientDecisionKey{token: sha256.Sum256([]byte(token)), namespace: namespace}
+	now := time.Now()
+
+	a.mu.Lock()
+	decision, ok := a.decisions[key]
+	a.mu.Unlock()
+	if ok && now.Before(decision.expires) {
+		return decision.err
+	}
+
+	verdict, err := a.review(ctx, token, namespace)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to review the caller's token: %v", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for k, d := range a.decisions {
+		if now.After(d.expires) {
+			delete(a.decisions, k)
+		}
+	}
+	a.decisions[key] = clientDecision{err: verdict.Err(), expires: now.Add(clientDecisionTTL)}
+	return verdict.Err()
+}
+
+// review authenticates token with a TokenReview and checks its user's access
+// with a SubjectAccessReview. It returns why the call is refused, nil if it
+// is allowed, or an error if the API server could not be asked.
+func (a *clientAuthorizer) review(ctx context.Context, token, namespace string) (*status.Status, error) {
+	tokenReview, err := a.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !tokenReview.Status.Authenticated {
+		return status.New(codes.Unauthenticated, "invalid bearer token"), nil
+	}
+	user := tokenReview.Status.User
+
+	attributes := &authorizationv1.ResourceAttributes{Verb: "get", Resource: "nodes", Name: a.nodeName}
+	if namespace != "" {
+		attributes = &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "create",
+			Group:     lpmv1.GroupVersion.Group,
+			Resource:  "podcheckpoints",
+		}
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview, err := a.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !accessReview.Status.Allowed {
+		if namespace != "" {
+			return status.Newf(codes.PermissionDenied, "%s may not create podcheckpoints in namespace %s", user.Username, namespace), nil
+		}
+		return status.Newf(codes.PermissionDenied, "%s may not get node %s", user.Username, a.nodeName), nil
+	}
+	return nil, nil
+}
+
+// newClientAuthorizerFor returns the authorizer --client-authorization asks
+// for, or nil if the agent serves every caller.
+func newClientAuthorizerFor(nodeName string) (*clientAuthorizer, error) {
+	switch *clientAuthorization {
+	case clientAuthorizationNone:
+		return nil, nil
+	case clientAuthorizationToken:
+		return newClientAuthorizer(nodeName)
+	}
+	return nil, fmt.Errorf("unknown client authorization %q, want none or token", *clientAuthorization)
+}
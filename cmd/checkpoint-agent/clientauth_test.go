@@ -0,0 +1,177 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// writeSpecArchive writes a checkpoint archive to path whose container spec
+// carries annotations.
+func writeSpecArchive(path string, annotations map[string]string) {
+	spec, err := json.Marshal(map[string]any{"annotations": annotations})
+	Expect(err).NotTo(HaveOccurred())
+	f, err := os.Create(path)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	for name, content := range map[string][]byte{"config.dump": []byte("{}"), "spec.dump": spec} {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))})).To(Succeed())
+		_, err := tw.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+}
+
+// recordingStream is a server stream delivering msgs, then io.EOF, and
+// recording what is sent.
+type recordingStream struct {
+	grpc.ServerStream
+	msgs []*pb.CheckpointChunk
+	sent int
+}
+
+func (s *recordingStream) Context() context.Context { return context.Background() }
+
+func (s *recordingStream) RecvMsg(m any) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(*pb.CheckpointChunk), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func (s *recordingStream) SendMsg(any) error {
+	s.sent++
+	return nil
+}
+
+var _ = Describe("archiveNamespace", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("reads the namespace from the archive's container spec", func() {
+		path := filepath.Join(dir, "checkpoint.tar")
+		writeSpecArchive(path, map[string]string{podNamespaceAnnotation: "team-a"})
+		Expect(archiveNamespace(path)).To(Equal("team-a"))
+	})
+
+	It("returns no namespace for an archive whose spec names none", func() {
+		path := filepath.Join(dir, "checkpoint.tar")
+		writeSpecArchive(path, nil)
+		Expect(archiveNamespace(path)).To(BeEmpty())
+	})
+
+	It("reports a missing archive", func() {
+		_, err := archiveNamespace(filepath.Join(dir, "missing.tar"))
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("finds archives and their volumes in the index of their directory", func() {
+		path := filepath.Join(dir, "web-app.tar")
+		entry := artifactIndexEntry{URI: "file://" + path, VolumesURI: "file://" + filepath.Join(dir, "web-app.volumes.tar"), Namespace: "team-a"}
+		line, err := json.Marshal(entry)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(dir, artifactIndexName), append(append([]byte("not json\n"), line...), '\n'), 0o600)).To(Succeed())
+
+		found, ok, err := indexedArtifact(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found.Namespace).To(Equal("team-a"))
+		found, ok, err = indexedArtifact(filepath.Join(dir, "web-app.volumes.tar"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found.Namespace).To(Equal("team-a"))
+		_, ok, err = indexedArtifact(filepath.Join(dir, "other.tar"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("requestNamespaces", func() {
+	a := &clientAuthorizer{}
+
+	It("refuses archive paths outside the artifact roots", func() {
+		_, err := a.requestNamespaces(context.Background(), &pb.InspectCheckpointRequest{CheckpointPath: "file:///etc/shadow"})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		_, err = a.requestNamespaces(context.Background(), &pb.DeleteCheckpointRequest{ArtifactUris: []string{"shared://../../etc/shadow"}})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("needs no namespace for archives that do not exist", func() {
+		namespaces, err := a.requestNamespaces(context.Background(), &pb.DeleteCheckpointRequest{ArtifactUris: []string{"shared://gone.tar", ""}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaces).To(BeEmpty())
+	})
+
+	It("authorizes pushes against the namespace they name", func() {
+		namespaces, err := a.requestNamespaces(context.Background(), &pb.CheckpointChunk{Header: &pb.CheckpointChunkHeader{Name: "a.tar", Namespace: "team-a"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaces).To(Equal([]string{"team-a"}))
+
+		_, err = a.requestNamespaces(context.Background(), &pb.CheckpointChunk{Header: &pb.CheckpointChunkHeader{Name: "a.tar"}})
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		_, err = a.requestNamespaces(context.Background(), &pb.CheckpointChunk{Data: []byte("data")})
+		Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	})
+})
+
+var _ = Describe("authorizingStream", func() {
+	It("authorizes the stream by its first message", func() {
+		var authorized []any
+		inner := &recordingStream{msgs: []*pb.CheckpointChunk{
+			{Header: &pb.CheckpointChunkHeader{Namespace: "team-a"}},
+			{Data: []byte("data")},
+		}}
+		stream := &authorizingStream{ServerStream: inner, authorize: func(req any) error {
+			authorized = append(authorized, req)
+			return nil
+		}}
+
+		Expect(stream.SendMsg(&pb.PushCheckpointResponse{})).To(MatchError(ContainSubstring("not been authorized")))
+		var chunk pb.CheckpointChunk
+		Expect(stream.RecvMsg(&chunk)).To(Succeed())
+		Expect(stream.RecvMsg(&chunk)).To(Succeed())
+		Expect(stream.RecvMsg(&chunk)).To(MatchError(io.EOF))
+		Expect(authorized).To(HaveLen(1))
+		Expect(stream.SendMsg(&pb.PushCheckpointResponse{})).To(Succeed())
+		Expect(inner.sent).To(Equal(1))
+	})
+
+	It("refuses a stream whose first message is denied", func() {
+		stream := &authorizingStream{
+			ServerStream: &recordingStream{msgs: []*pb.CheckpointChunk{{}}},
+			authorize:    func(any) error { return status.Error(codes.PermissionDenied, "denied") },
+		}
+		var chunk pb.CheckpointChunk
+		Expect(status.Code(stream.RecvMsg(&chunk))).To(Equal(codes.PermissionDenied))
+		Expect(status.Code(stream.SendMsg(&pb.PushCheckpointResponse{}))).To(Equal(codes.PermissionDenied))
+	})
+
+	It("authorizes a stream without messages as one about no pod", func() {
+		var authorized []any
+		stream := &authorizingStream{ServerStream: &recordingStream{}, authorize: func(req any) error {
+			authorized = append(authorized, req)
+			return status.Error(codes.PermissionDenied, "denied")
+		}}
+		var chunk pb.CheckpointChunk
+		Expect(status.Code(stream.RecvMsg(&chunk))).To(Equal(codes.PermissionDenied))
+		Expect(authorized).To(Equal([]any{nil}))
+	})
+})
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// GetNodeClock reads the node's clocks so the controller can tell how far a
+// restored process's monotonic clock jumps.
+func (s *CheckpointServer) GetNodeClock(_ context.Context, _ *pb.GetNodeClockRequest) (*pb.GetNodeClockResponse, error) {
+	var realtime, monotonic, boottime unix.Timespec
+	for _, clock := range []struct {
+		id int32
+		ts *unix.Timespec
+	}{
+		{unix.CLOCK_REALTIME, &realtime},
+		{unix.CLOCK_MONOTONIC, &monotonic},
+		{unix.CLOCK_BOOTTIME, &boottime},
+	} {
+		if err := unix.ClockGettime(clock.id, clock.ts); err != nil {
+			return &pb.GetNodeClockResponse{Error: fmt.Sprintf("failed to read clock %d: %v", clock.id, err)}, nil
+		}
+	}
+
+	_, err := os.Stat("/proc/self/ns/time")
+	return &pb.GetNodeClockResponse{
+		Success:        true,
+		RealtimeNs:     realtime.Nano(),
+		MonotonicNs:    monotonic.Nano(),
+		BoottimeNs:     boottime.Nano(),
+		TimeNamespaces: err == nil,
+	}, nil
+}
+
+// inOwnTimeNamespace reports whether pid runs in a time namespace other than
+// the host's.
+func inOwnTimeNamespace(pid int) bool {
+	ns, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/time", pid))
+	if err != nil {
+		return false
+	}
+	hostNS, err := os.Readlink("/proc/1/ns/time")
+	return err == nil && ns != hostNS
+}
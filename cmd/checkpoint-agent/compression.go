@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// compressor is the tool compressing archives with one compression.
+type compressor struct {
+	tool string
+	// extension is appended to the names of the archives it compresses
+	extension string
+	// magic starts every stream it writes
+	magic []byte
+	// args select its level and threads when compressing
+	args []string
+}
+
+// compressors are the compressions an archive can be copied with. LZ4 at
+// its fastest level keeps up with most storage; Zstd trades CPU on all the
+// node's cores for a smaller archive.
+var compressors = map[lpmv1.CheckpointCompression]compressor{
+	lpmv1.CheckpointCompressionLZ4:  {tool: "lz4", extension: ".lz4", magic: []byte{0x04, 0x22, 0x4d, 0x18}, args: []string{"-1"}},
+	lpmv1.CheckpointCompressionZstd: {tool: "zstd", extension: ".zst", magic: []byte{0x28, 0xb5, 0x2f, 0xfd}, args: []string{"-10", "-T0"}},
+}
+
+// compressorFor returns the compressor of compression, or nil for an
+// uncompressed copy.
+func compressorFor(compression string) (*compressor, error) {
+	switch lpmv1.CheckpointCompression(compression) {
+	case "", lpmv1.CheckpointCompressionNone:
+		return nil, nil
+	}
+	c, ok := compressors[lpmv1.CheckpointCompression(compression)]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression %q, want None, LZ4 or Zstd", compression)
+	}
+	return &c, nil
+}
+
+// compressArchive compresses the archive at path next to it and returns the
+// path of the compressed archive. That name only appears once the archive is
+// complete, so an archive compressed before is reused, letting its copy
+// resume.
+func (c *compressor) compressArchive(ctx context.Context, path string) (string, error) {
+	compressed := path + c.extension
+	if _, err := os.Stat(compressed); err == nil {
+		return compressed, nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(compressed)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.tool, append(c.args, "-q", "-c", path)...)
+	cmd.Stdout = tmp
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w, output: %s", c.tool, err, stderr.String())
+	}
+	if err := tmp.Sync(); err != nil {
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return compressed, os.Rename(tmp.Name(), compressed)
+}
+
+// compressorOf returns the compressor of a stream starting with magic, or
+// nil if none of them wrote it.
+func compressorOf(magic []byte) *compressor {
+	for _, c := range compressors {
+		if bytes.HasPrefix(magic, c.magic) {
+			return &c
+		}
+	}
+	return nil
+}
+
+// decompress returns the stream r decompresses to. Closing it stops the
+// tool, and reports whether r was a valid stream if it was read to the end.
+func (c *compressor) decompress(r io.Reader) (io.ReadCloser, error) {
+	d := &decompressor{tool: c.tool}
+	d.cmd = exec.Command(c.tool, "-d", "-q", "-c")
+	d.cmd.Stdin = r
+	d.cmd.Stderr = &d.stderr
+	stdout, err := d.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", c.tool, err)
+	}
+	d.ReadCloser = stdout
+	return d, nil
+}
+
+// decompressor is the output of a running decompression tool.
+type decompressor struct {
+	io.ReadCloser
+	tool   string
+	cmd    *exec.Cmd
+	stderr bytes.Buffer
+	closed bool
+	err    error
+}
+
+func (d *decompressor) Close() error {
+	if d.closed {
+		return d.err
+	}
+	d.closed = true
+	// Unread output makes the tool fail writing it rather than block
+	d.ReadCloser.Close()
+	if err := d.cmd.Wait(); err != nil {
+		d.err = fmt.Errorf("%s failed: %w, output: %s", d.tool, err, d.stderr.String())
+	}
+	return d.err
+}
+
+// decompressedArchive returns the path of the archive at path decompressed
+// into the node's checkpoint directory, for tools that only read plain tars,
+// and a function removing it. An archive that isn't compressed is returned
+// as is.
+func decompressedArchive(path string) (string, func(), error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", nil, err
+	}
+	c := compressorOf(magic)
+	if c == nil {
+		return path, func() {}, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.CreateTemp(checkpointDir, ".decompressed-*.tar")
+	if err != nil {
+		return "", nil, err
+	}
+	remove := func() { os.Remove(tmp.Name()) }
+	defer tmp.Close()
+	r, err := c.decompress(f)
+	if err == nil {
+		_, err = io.Copy(tmp, r)
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		remove()
+		return "", nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	return tmp.Name(), remove, nil
+}
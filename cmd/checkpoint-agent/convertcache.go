@@ -0,0 +1,169 @@
+package main
+
+import (
+	"container/list"
+	"flag"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	conversionCacheEntries = flag.Int("conversion-cache-entries", int(envInt("AGENT_CONVERSION_CACHE_ENTRIES", 32)),
+		"Number of checkpoint images the agent keeps to answer conversions of the same checkpoint again. 0 disables the cache.")
+	conversionCacheBytes = flag.Int64("conversion-cache-bytes", envInt("AGENT_CONVERSION_CACHE_BYTES", 10<<30),
+		"Total size of the checkpoint archives behind the cached images, past which the least recently used are removed. 0 leaves it unbounded.")
+)
+
+// envInt returns the environment variable key as an integer, or def if it
+// is unset or invalid.
+func envInt(key string, def int64) int64 {
+	n, err := strconv.ParseInt(envOr(key, strconv.FormatInt(def, 10)), 10, 64)
+	if err != nil {
+		agentLog.Error(err, "Ignoring invalid environment variable", "name", key)
+		return def
+	}
+	return n
+}
+
+var conversionCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lpm_agent_conversion_cache_requests_total",
+	Help: "Checkpoint to image conversions by whether a cached image answered them (hit) or buildah ran (miss).",
+}, []string{"result"})
+
+var conversionCacheSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "lpm_agent_conversion_cache_size",
+	Help: "Images in the conversion cache (entries) and the size of their checkpoint archives (bytes).",
+}, []string{"unit"})
+
+func init() {
+	prometheus.MustRegister(conversionCacheRequests, conversionCacheSize)
+}
+
+// conversionKey identifies the image a conversion builds: the same archive
+// restored on the same base image always gives the same image.
+type conversionKey struct {
+	digest      string
+	container   string
+	baseImageID string
+}
+
+// cachedImage is an image a conversion built.
+type cachedImage struct {
+	key conversionKey
+	// names are the names the image was committed and tagged with
+	names []string
+	size  int64
+}
+
+// conversionCache keeps the images built from checkpoint archives, so
+// converting an archive again, on a retried restore or another restore of
+// the same checkpoint, tags the image built before rather than running
+// buildah from scratch. The least recently used images are removed from the
+// node's container storage past the entry and size limits.
+type conversionCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	// lru holds *cachedImage, most recently used first
+	lru   *list.List
+	items map[conversionKey]*list.Element
+}
+
+// newConversionCache returns a cache of at most maxEntries images built from
+// at most maxBytes of archives; a maxBytes of 0 leaves the size unbounded.
+func newConversionCache(maxEntries int, maxBytes int64) *conversionCache {
+	return &conversionCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		items:      map[conversionKey]*list.Element{},
+	}
+}
+
+// lookup returns the image cached for key under imageName, tagging it with
+// that name if it was built under another one. An image gone from the
+// node's storage is dropped and reported as a miss.
+func (c *conversionCache) lookup(key conversionKey, imageName string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		conversionCacheRequests.WithLabelValues("miss").Inc()
+		return "", false
+	}
+	image := elem.Value.(*cachedImage)
+	if output, err := runBuildah("inspect", "--type", "image", image.names[0]); err != nil {
+		agentLog.Error(err, "Cached image is gone, converting again", "image", image.names[0], "output", string(output))
+		c.remove(elem, false)
+		conversionCacheRequests.WithLabelValues("miss").Inc()
+		return "", false
+	}
+	if !slices.Contains(image.names, imageName) {
+		if output, err := runBuildah("tag", image.names[0], imageName); err != nil {
+			agentLog.Error(err, "Failed to tag cached image, converting again", "image", image.names[0], "tag", imageName, "output", string(output))
+			conversionCacheRequests.WithLabelValues("miss").Inc()
+			return "", false
+		}
+		image.names = append(image.names, imageName)
+	}
+	c.lru.MoveToFront(elem)
+	conversionCacheRequests.WithLabelValues("hit").Inc()
+	return imageName, true
+}
+
+// add records that imageName was built for key from an archive of size
+// bytes, then evicts images past the cache limits.
+func (c *conversionCache) add(key conversionKey, imageName string, size int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		// Rebuilt after its image went missing or could not be tagged
+		image := elem.Value.(*cachedImage)
+		if !slices.Contains(image.names, imageName) {
+			image.names = append(image.names, imageName)
+		}
+		c.lru.MoveToFront(elem)
+	} else {
+		c.items[key] = c.lru.PushFront(&cachedImage{key: key, names: []string{imageName}, size: size})
+		c.bytes += size
+	}
+	// The image just built stays even if it alone exceeds maxBytes
+	for c.lru.Len() > 1 && (c.lru.Len() > c.maxEntries || (c.maxBytes > 0 && c.bytes > c.maxBytes)) {
+		c.remove(c.lru.Back(), true)
+	}
+	c.updateMetrics()
+}
+
+// remove drops elem from the cache, removing its image from the node's
+// storage if removeImage. Images still used by a container are left there.
+func (c *conversionCache) remove(elem *list.Element, removeImage bool) {
+	image := c.lru.Remove(elem).(*cachedImage)
+	delete(c.items, image.key)
+	c.bytes -= image.size
+	if removeImage {
+		agentLog.Info("Evicting checkpoint image from the conversion cache", "image", image.names[0])
+		for _, name := range image.names {
+			if output, err := runBuildah("rmi", name); err != nil {
+				agentLog.Error(err, "Failed to remove image", "image", name, "output", string(output))
+			}
+		}
+	}
+	c.updateMetrics()
+}
+
+func (c *conversionCache) updateMetrics() {
+	conversionCacheSize.WithLabelValues("entries").Set(float64(c.lru.Len()))
+	conversionCacheSize.WithLabelValues("bytes").Set(float64(c.bytes))
+}
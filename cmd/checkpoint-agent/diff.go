@@ -0,0 +1,308 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// pagemap_entry flags
+const (
+	criuPageInParent = 1 << 0
+	criuPageLazy     = 1 << 1
+	criuPagePresent  = 1 << 2
+)
+
+// pageRun is a range of consecutive pages of one process stored in a
+// pages-<id>.img file.
+type pageRun struct {
+	pid   int32
+	vaddr uint64
+	pages uint64
+}
+
+type pageKey struct {
+	pid   int32
+	vaddr uint64
+}
+
+// checkpointContents is the content-level view of an archive used for diffs.
+type checkpointContents struct {
+	archive   *checkpointArchive
+	processes []*pb.CheckpointProcess
+	// pages maps every page stored in the archive to a hash of its contents.
+	pages map[pageKey]uint64
+	// files maps each path of the writable layer to a hash of its contents.
+	files   map[string]uint64
+	deleted map[string]bool
+}
+
+// DiffCheckpoints compares two checkpoints of the same container.
+func (s *CheckpointServer) DiffCheckpoints(ctx context.Context, req *pb.DiffCheckpointsRequest) (*pb.DiffCheckpointsResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Diff request", "base", req.BaseCheckpointPath, "checkpoint", req.CheckpointPath)
+
+	if req.BaseCheckpointPath == "" || req.CheckpointPath == "" {
+		return &pb.DiffCheckpointsResponse{Error: "both checkpoint paths are required"}, nil
+	}
+
+	basePath, err := artifactPath(req.BaseCheckpointPath)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	checkpointPath, err := artifactPath(req.CheckpointPath)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := diffArchives(basePath, checkpointPath)
+	if err != nil {
+		logger.Error(err, "Failed to diff checkpoints")
+		return &pb.DiffCheckpointsResponse{Error: fmt.Sprintf("diff failed: %v", err)}, nil
+	}
+	resp.Success = true
+	return resp, nil
+}
+
+func diffArchives(basePath, checkpointPath string) (*pb.DiffCheckpointsResponse, error) {
+	base, err := readCheckpointContents(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("base checkpoint: %w", err)
+	}
+	current, err := readCheckpointContents(checkpointPath)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint: %w", err)
+	}
+
+	if baseName, name := base.archive.config.Name, current.archive.config.Name; baseName != "" && name != "" && baseName != name {
+		return nil, fmt.Errorf("checkpoints are of different containers (%s and %s)", baseName, name)
+	}
+
+	resp := &pb.DiffCheckpointsResponse{}
+
+	pageSize := int64(os.Getpagesize())
+	for key, hash := range current.pages {
+		resp.TotalMemoryBytes += pageSize
+		if baseHash, ok := base.pages[key]; !ok || baseHash != hash {
+			resp.ChangedMemoryBytes += pageSize
+		}
+	}
+
+	for name, hash := range current.files {
+		baseHash, ok := base.files[name]
+		switch {
+		case !ok:
+			resp.AddedFiles = append(resp.AddedFiles, name)
+		case baseHash != hash:
+			resp.ModifiedFiles = append(resp.ModifiedFiles, name)
+		}
+	}
+	for name := range base.files {
+		if _, ok := current.files[name]; !ok && !current.deleted[name] {
+			resp.RemovedFiles = append(resp.RemovedFiles, name)
+		}
+	}
+	for name := range current.deleted {
+		if !base.deleted[name] {
+			resp.RemovedFiles = append(resp.RemovedFiles, name)
+		}
+	}
+	sort.Strings(resp.AddedFiles)
+	sort.Strings(resp.ModifiedFiles)
+	sort.Strings(resp.RemovedFiles)
+
+	resp.NewProcesses = processesMissingFrom(current.processes, base.processes)
+	resp.ExitedProcesses = processesMissingFrom(base.processes, current.processes)
+
+	return resp, nil
+}
+
+// processesMissingFrom returns the processes of a that are not in b. A PID
+// running a different command counts as a different process.
+func processesMissingFrom(a, b []*pb.CheckpointProcess) []*pb.CheckpointProcess {
+	seen := map[string]bool{}
+	for _, process := range b {
+		seen[fmt.Sprintf("%d/%s", process.Pid, process.Command)] = true
+	}
+	var missing []*pb.CheckpointProcess
+	for _, process := range a {
+		if !seen[fmt.Sprintf("%d/%s", process.Pid, process.Command)] {
+			missing = append(missing, process)
+		}
+	}
+	return missing
+}
+
+// readCheckpointContents loads an archive's metadata, then walks it a second
+// time to hash the memory pages and writable-layer files.
+func readCheckpointContents(archivePath string) (*checkpointContents, error) {
+	archive, err := loadCheckpointArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	processes, err := decodeProcessTree(archive.images)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := pageRuns(archive.images)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &checkpointContents{
+		archive:   archive,
+		processes: processes,
+		pages:     map[pageKey]uint64{},
+		files:     map[string]uint64{},
+		deleted:   map[string]bool{},
+	}
+
+	err = walkArchive(archivePath, func(name string, _ *tar.Header, r io.Reader) error {
+		switch {
+		case name == "rootfs-diff.tar":
+			return hashRootfsDiff(r, contents.files)
+		case name == "deleted.files":
+			var deleted []string
+			if err := json.NewDecoder(r).Decode(&deleted); err != nil {
+				return fmt.Errorf("failed to parse deleted.files: %w", err)
+			}
+			for _, name := range deleted {
+				contents.deleted[path.Clean("/"+name)] = true
+			}
+		case strings.HasPrefix(name, "checkpoint/pages-") && strings.HasSuffix(name, ".img"):
+			id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, "checkpoint/pages-"), ".img"), 10, 64)
+			if err != nil {
+				return nil
+			}
+			return hashPages(r, runs[id], contents.pages)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// pageRuns maps each pages image id to the page ranges it stores, in file
+// order, from the pagemap-<pid>.img images.
+func pageRuns(images map[string][]byte) (map[uint64][]pageRun, error) {
+	runs := map[uint64][]pageRun{}
+	for name, image := range images {
+		if !strings.HasPrefix(name, "pagemap-") {
+			continue
+		}
+		pid, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, "pagemap-"), ".img"), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		var pagesID uint64
+		first := true
+		err = forEachCriuEntry(image, func(entry []byte) error {
+			if first { // pagemap_head
+				first = false
+				return decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+					if num == 1 { // pagemap_head.pages_id
+						pagesID = v
+					}
+				})
+			}
+
+			run := pageRun{pid: int32(pid)}
+			var inParent, hasFlags bool
+			var flags uint64
+			err := decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+				switch num {
+				case 1:
+					run.vaddr = v
+				case 2:
+					run.pages = v
+				case 3:
+					inParent = v != 0
+				case 4:
+					hasFlags, flags = true, v
+				}
+			})
+			if err != nil {
+				return err
+			}
+
+			// Only pages present in this image are stored in the pages file.
+			stored := !inParent
+			if hasFlags {
+				stored = flags&criuPagePresent != 0 && flags&(criuPageInParent|criuPageLazy) == 0
+			}
+			if stored {
+				runs[pagesID] = append(runs[pagesID], run)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	}
+	return runs, nil
+}
+
+func hashPages(r io.Reader, runs []pageRun, pages map[pageKey]uint64) error {
+	pageSize := uint64(os.Getpagesize())
+	br := bufio.NewReaderSize(r, 1<<20)
+	page := make([]byte, pageSize)
+	for _, run := range runs {
+		for i := uint64(0); i < run.pages; i++ {
+			if _, err := io.ReadFull(br, page); err != nil {
+				return fmt.Errorf("pages image shorter than its pagemap: %w", err)
+			}
+			h := fnv.New64a()
+			h.Write(page)
+			pages[pageKey{pid: run.pid, vaddr: run.vaddr + i*pageSize}] = h.Sum64()
+		}
+	}
+	return nil
+}
+
+// hashRootfsDiff hashes the regular files and symlinks of the nested
+// rootfs-diff.tar.
+func hashRootfsDiff(r io.Reader, files map[string]uint64) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read rootfs-diff.tar: %w", err)
+		}
+
+		h := fnv.New64a()
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			if _, err := io.Copy(h, tr); err != nil {
+				return fmt.Errorf("failed to read rootfs-diff.tar: %w", err)
+			}
+		case tar.TypeSymlink, tar.TypeLink:
+			h.Write([]byte(hdr.Linkname))
+		default:
+			continue
+		}
+		files[path.Clean("/"+hdr.Name)] = h.Sum64()
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	defaultDirtySampleSeconds = 5
+	maxDirtySampleSeconds     = 60
+	defaultStorageProbeBytes  = 64 * 1024 * 1024
+	maxStorageProbeBytes      = 1024 * 1024 * 1024
+
+	// softDirtyBit is bit 55 of a /proc/<pid>/pagemap entry.
+	softDirtyBit = 1 << 55
+)
+
+// EstimateMigration measures what a migration of the given containers would
+// have to move and how fast shared storage can move it. The workload is not
+// checkpointed; the only side effect is resetting the kernel's soft-dirty
+// bits of the containers' processes.
+func (s *CheckpointServer) EstimateMigration(ctx context.Context, req *pb.EstimateMigrationRequest) (*pb.EstimateMigrationResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Estimate request", "containers", req.ContainerIds, "sampleSeconds", req.SampleSeconds, "probeBytes", req.ProbeBytes)
+
+	sample := time.Duration(min(req.SampleSeconds, maxDirtySampleSeconds)) * time.Second
+	if sample == 0 {
+		sample = defaultDirtySampleSeconds * time.Second
+	}
+	probeBytes := min(req.ProbeBytes, maxStorageProbeBytes)
+	if probeBytes <= 0 {
+		probeBytes = defaultStorageProbeBytes
+	}
+
+	resp := &pb.EstimateMigrationResponse{}
+
+	// Resolve every container's processes first so all of them are sampled
+	// over the same window.
+	pidsByContainer := make([][]int, len(req.ContainerIds))
+	for i, id := range req.ContainerIds {
+		containerID := trimContainerID(id)
+		state, err := readContainerState(containerID)
+		if err != nil {
+			return &pb.EstimateMigrationResponse{Error: err.Error()}, nil
+		}
+		cgroupDir, err := containerCgroupDir(state.Pid)
+		if err != nil {
+			return &pb.EstimateMigrationResponse{Error: fmt.Sprintf("container %s: %v", containerID, err)}, nil
+		}
+		memory, err := cgroupAnonMemory(cgroupDir)
+		if err != nil {
+			return &pb.EstimateMigrationResponse{Error: fmt.Sprintf("container %s: %v", containerID, err)}, nil
+		}
+		pids, err := cgroupPids(cgroupDir)
+		if err != nil {
+			return &pb.EstimateMigrationResponse{Error: fmt.Sprintf("container %s: %v", containerID, err)}, nil
+		}
+		pidsByContainer[i] = pids
+		resp.Containers = append(resp.Containers, &pb.ContainerEstimate{
+			ContainerId: containerID,
+			MemoryBytes: memory,
+		})
+	}
+
+	for _, pids := range pidsByContainer {
+		for _, pid := range pids {
+			// Processes may exit while sampling; they simply don't count.
+			if err := os.WriteFile(fmt.Sprintf("/proc/%d/clear_refs", pid), []byte("4"), 0); err != nil {
+				logger.Error(err, "Failed to clear soft-dirty bits", "pid", pid)
+			}
+		}
+	}
+	select {
+	case <-time.After(sample):
+	case <-ctx.Done():
+		return &pb.EstimateMigrationResponse{Error: ctx.Err().Error()}, nil
+	}
+	pageSize := int64(os.Getpagesize())
+	for i, pids := range pidsByContainer {
+		var dirtyPages int64
+		for _, pid := range pids {
+			n, err := countSoftDirtyPages(pid)
+			if err != nil {
+				logger.Error(err, "Failed to read soft-dirty pages", "pid", pid)
+				continue
+			}
+			dirtyPages += n
+		}
+		resp.Containers[i].DirtyBytesPerSecond = int64(float64(dirtyPages*pageSize) / sample.Seconds())
+	}
+
+	write, read, err := s.probeSharedStorage(ctx, probeBytes)
+	if err != nil {
+		return &pb.EstimateMigrationResponse{Error: fmt.Sprintf("storage probe failed: %v", err)}, nil
+	}
+	resp.StorageWriteBytesPerSecond = write
+	resp.StorageReadBytesPerSecond = read
+
+	resp.Success = true
+	return resp, nil
+}
+
+// containerCgroupDir returns the memory cgroup directory of a process,
+// supporting both the unified (v2) and the legacy (v1) hierarchy.
+func containerCgroupDir(pid int) (string, error) {
+	if pid == 0 {
+		return "", fmt.Errorf("container is not running")
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			return filepath.Join("/sys/fs/cgroup", parts[2]), nil
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			if controller == "memory" {
+				return filepath.Join("/sys/fs/cgroup/memory", parts[2]), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no memory cgroup found for pid %d", pid)
+}
+
+// cgroupAnonMemory reads the anonymous memory of a cgroup ("anon" on v2,
+// "total_rss" on v1), which is what CRIU has to dump.
+func cgroupAnonMemory(cgroupDir string) (int64, error) {
+	f, err := os.Open(filepath.Join(cgroupDir, "memory.stat"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || (key != "anon" && key != "total_rss") {
+			continue
+		}
+		return strconv.ParseInt(value, 10, 64)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("memory.stat in %s has no anonymous memory counter", cgroupDir)
+}
+
+func cgroupPids(cgroupDir string) ([]int, error) {
+	data, err := os.ReadFile(filepath.Join(cgroupDir, "cgroup.procs"))
+	if err != nil {
+		return nil, err
+	}
+	var pids []int
+	for _, field := range strings.Fields(string(data)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pid %q in cgroup.procs", field)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// countSoftDirtyPages counts the pages of the writable private mappings of a
+// process written since its soft-dirty bits were cleared.
+func countSoftDirtyPages(pid int) (int64, error) {
+	maps, err := os.ReadFile(fmt.Sprintf("/proc/%d/maps", pid))
+	if err != nil {
+		return 0, err
+	}
+	pagemap, err := os.Open(fmt.Sprintf("/proc/%d/pagemap", pid))
+	if err != nil {
+		return 0, err
+	}
+	defer pagemap.Close()
+
+	pageSize := uint64(os.Getpagesize())
+	buf := make([]byte, 8*4096)
+	var dirty int64
+	for _, line := range strings.Split(string(maps), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.HasPrefix(fields[1], "rw") || !strings.HasSuffix(fields[1], "p") {
+			continue
+		}
+		start, end, ok := strings.Cut(fields[0], "-")
+		if !ok {
+			continue
+		}
+		startAddr, err1 := strconv.ParseUint(start, 16, 64)
+		endAddr, err2 := strconv.ParseUint(end, 16, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		offset := int64(startAddr / pageSize * 8)
+		remaining := int64((endAddr - startAddr) / pageSize * 8)
+		for remaining > 0 {
+			chunk := buf[:min(remaining, int64(len(buf)))]
+			n, err := pagemap.ReadAt(chunk, offset)
+			for i := 0; i+8 <= n; i += 8 {
+				if binary.LittleEndian.Uint64(chunk[i:])&softDirtyBit != 0 {
+					dirty++
+				}
+			}
+			if err != nil && err != io.EOF {
+				return dirty, err
+			}
+			if n == 0 {
+				break
+			}
+			offset += int64(n)
+			remaining -= int64(n)
+		}
+	}
+	return dirty, nil
+}
+
+// probeSharedStorage writes and reads back a file on the checkpoint share to
+// measure its throughput in bytes per second. The page cache is dropped
+// before reading so the read goes to storage.
+func (s *CheckpointServer) probeSharedStorage(ctx context.Context, size int64) (int64, int64, error) {
+	probePath := filepath.Join("/mnt/checkpoints", ".lpm-probe-"+s.nodeName)
+	f, err := os.Create(probePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer os.Remove(probePath)
+	defer f.Close()
+
+	chunk := make([]byte, 1024*1024)
+	start := time.Now()
+	for written := int64(0); written < size; written += int64(len(chunk)) {
+		if _, err := f.Write(chunk[:min(int64(len(chunk)), size-written)]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return 0, 0, err
+	}
+	writeRate := int64(float64(size) / time.Since(start).Seconds())
+
+	if err := unix.Fadvise(int(f.Fd()), 0, size, unix.FADV_DONTNEED); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to drop page cache of storage probe")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, 0, err
+	}
+	start = time.Now()
+	n, err := io.CopyBuffer(io.Discard, f, chunk)
+	if err != nil {
+		return 0, 0, err
+	}
+	readRate := int64(float64(n) / time.Since(start).Seconds())
+
+	return writeRate, readRate, nil
+}
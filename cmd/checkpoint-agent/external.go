@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// unix_diag constants from linux/unix_diag.h.
+const (
+	udiagShowName   = 0x1
+	udiagShowPeer   = 0x4
+	unixDiagName    = 0
+	unixDiagPeer    = 2
+	unixDiagMsgSize = 16
+	unixDiagReqSize = 24
+)
+
+// unixSocket is a unix socket of a network namespace.
+type unixSocket struct {
+	name string
+	peer uint64
+}
+
+// externalUnixSockets describes the connected unix sockets of pids whose peer
+// is not held by one of pids, e.g. a host daemon's socket mounted through a
+// hostPath volume or a socket of another container of the pod. CRIU cannot
+// dump the peer, so the connection does not survive the migration.
+func externalUnixSockets(pids []int) ([]string, error) {
+	if len(pids) == 0 {
+		return nil, nil
+	}
+	owned := socketInodes(pids)
+
+	// Containers of a pod share its network namespace
+	sockets, err := unixSocketsOf(pids[0])
+	if err != nil {
+		return nil, err
+	}
+	var hostNames map[uint64]string
+	var external []string
+	for inode := range owned {
+		socket, ok := sockets[inode]
+		if !ok || socket.peer == 0 || owned[socket.peer] {
+			continue
+		}
+		if peer, ok := sockets[socket.peer]; ok {
+			external = append(external, fmt.Sprintf("unix socket connected to %s of another process in the pod", socketName(peer.name)))
+			continue
+		}
+		if hostNames == nil {
+			hostNames = procNetUnixNames(1)
+		}
+		if name, ok := hostNames[socket.peer]; ok {
+			external = append(external, fmt.Sprintf("unix socket connected to host socket %s", socketName(name)))
+		} else {
+			external = append(external, "unix socket connected to a socket outside the pod")
+		}
+	}
+	return external, nil
+}
+
+// socketInodes returns the inodes of the sockets pids have open.
+func socketInodes(pids []int) map[uint64]bool {
+	owned := map[uint64]bool{}
+	for _, pid := range pids {
+		for _, file := range openFiles(pid) {
+			if inode, ok := strings.CutPrefix(file, "socket:["); ok {
+				if n, err := strconv.ParseUint(strings.TrimSuffix(inode, "]"), 10, 64); err == nil {
+					owned[n] = true
+				}
+			}
+		}
+	}
+	return owned
+}
+
+func socketName(name string) string {
+	switch {
+	case name == "":
+		return "an unnamed socket"
+	case strings.HasPrefix(name, "@"):
+		return "abstract socket " + name
+	default:
+		return name
+	}
+}
+
+// unixSocketsOf lists the unix sockets of pid's network namespace by inode,
+// using sock_diag from a thread that joined the namespace.
+func unixSocketsOf(pid int) (map[uint64]unixSocket, error) {
+	type result struct {
+		sockets map[uint64]unixSocket
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// The thread is never unlocked, so the runtime discards it together
+		// with the namespace it joined.
+		runtime.LockOSThread()
+		ns, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		defer ns.Close()
+		if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWNET); err != nil {
+			done <- result{err: fmt.Errorf("failed to join network namespace of pid %d: %w", pid, err)}
+			return
+		}
+		sockets, err := dumpUnixSockets()
+		done <- result{sockets, err}
+	}()
+	r := <-done
+	return r.sockets, r.err
+}
+
+// dumpUnixSockets dumps the unix sockets of the calling thread's network
+// namespace with their names and peers.
+func dumpUnixSockets() (map[uint64]unixSocket, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_SOCK_DIAG)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sock_diag socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	req := make([]byte, unix.SizeofNlMsghdr+unixDiagReqSize)
+	binary.NativeEndian.PutUint32(req[0:4], uint32(len(req)))
+	binary.NativeEndian.PutUint16(req[4:6], unix.SOCK_DIAG_BY_FAMILY)
+	binary.NativeEndian.PutUint16(req[6:8], unix.NLM_F_REQUEST|unix.NLM_F_DUMP)
+	body := req[unix.SizeofNlMsghdr:]
+	body[0] = unix.AF_UNIX
+	binary.NativeEndian.PutUint32(body[4:8], 0xffffffff) // all states
+	binary.NativeEndian.PutUint32(body[12:16], udiagShowName|udiagShowPeer)
+	if err := unix.Sendto(fd, req, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, fmt.Errorf("failed to send sock_diag request: %w", err)
+	}
+
+	sockets := map[uint64]unixSocket{}
+	buf := make([]byte, 32*1024)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sock_diag response: %w", err)
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			switch msg.Header.Type {
+			case unix.NLMSG_DONE:
+				return sockets, nil
+			case unix.NLMSG_ERROR:
+				return nil, fmt.Errorf("sock_diag request failed")
+			}
+			if len(msg.Data) < unixDiagMsgSize {
+				continue
+			}
+			inode := uint64(binary.NativeEndian.Uint32(msg.Data[4:8]))
+			var socket unixSocket
+			attrs := msg.Data[unixDiagMsgSize:]
+			for len(attrs) >= unix.SizeofRtAttr {
+				length := int(binary.NativeEndian.Uint16(attrs[0:2]))
+				if length < unix.SizeofRtAttr || length > len(attrs) {
+					break
+				}
+				value := attrs[unix.SizeofRtAttr:length]
+				switch binary.NativeEndian.Uint16(attrs[2:4]) {
+				case unixDiagName:
+					if len(value) > 0 && value[0] == 0 {
+						socket.name = "@" + string(value[1:])
+					} else {
+						socket.name = strings.TrimRight(string(value), "\x00")
+					}
+				case unixDiagPeer:
+					if len(value) >= 4 {
+						socket.peer = uint64(binary.NativeEndian.Uint32(value))
+					}
+				}
+				attrs = attrs[min(len(attrs), (length+unix.NLA_ALIGNTO-1)&^(unix.NLA_ALIGNTO-1)):]
+			}
+			sockets[inode] = socket
+		}
+	}
+}
+
+// procNetUnixNames maps the unix socket inodes of pid's network namespace to
+// their paths, as listed in /proc/<pid>/net/unix.
+func procNetUnixNames(pid int) map[uint64]string {
+	names := map[uint64]string{}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/net/unix", pid))
+	if err != nil {
+		return names
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	for _, line := range lines[1:] {
+		// Num RefCount Protocol Flags Type St Inode [Path]
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+		if inode, err := strconv.ParseUint(fields[6], 10, 64); err == nil {
+			names[inode] = fields[7]
+		}
+	}
+	return names
+}
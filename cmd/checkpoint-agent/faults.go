@@ -0,0 +1,138 @@
+//go:build faultinject
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fault injection points.
+const (
+	faultPointCheckpoint = "checkpoint"
+	faultPointConvert    = "convert"
+	faultPointStorage    = "storage"
+)
+
+var faultSpec = flag.String("faults", os.Getenv("AGENT_FAULTS"),
+	"Faults to inject, e.g. \"checkpoint:delay=5s;convert:fail-nth=2;storage:partial-write=0.5\". "+
+		"Defaults to $AGENT_FAULTS.")
+
+// fault is the configuration and call counter of one injection point.
+type fault struct {
+	delay        time.Duration
+	failNth      int
+	partialWrite float64
+
+	mu    sync.Mutex
+	calls int
+}
+
+var faults = map[string]*fault{}
+
+// setupFaults parses --faults. It must run after flag.Parse.
+func setupFaults() {
+	if *faultSpec == "" {
+		agentLog.Info("Fault injection compiled in but no faults configured")
+		return
+	}
+	for _, pointSpec := range strings.Split(*faultSpec, ";") {
+		point, options, ok := strings.Cut(strings.TrimSpace(pointSpec), ":")
+		if !ok {
+			agentLog.Error(nil, "Invalid fault spec, expected <point>:<option>=<value>,...", "spec", pointSpec)
+			os.Exit(1)
+		}
+		switch point {
+		case faultPointCheckpoint, faultPointConvert, faultPointStorage:
+		default:
+			agentLog.Error(nil, "Unknown fault injection point", "point", point)
+			os.Exit(1)
+		}
+
+		f := &fault{}
+		for _, option := range strings.Split(options, ",") {
+			key, value, _ := strings.Cut(option, "=")
+			var err error
+			switch key {
+			case "delay":
+				f.delay, err = time.ParseDuration(value)
+			case "fail-nth":
+				f.failNth, err = strconv.Atoi(value)
+			case "partial-write":
+				f.partialWrite, err = strconv.ParseFloat(value, 64)
+				if err == nil && (f.partialWrite <= 0 || f.partialWrite >= 1) {
+					err = fmt.Errorf("must be between 0 and 1")
+				}
+			default:
+				err = fmt.Errorf("unknown option")
+			}
+			if err != nil {
+				agentLog.Error(err, "Invalid fault option", "option", option, "point", point)
+				os.Exit(1)
+			}
+		}
+		faults[point] = f
+		agentLog.Info("Fault injection enabled", "point", point, "delay", f.delay.String(), "failNth", f.failNth, "partialWrite", f.partialWrite)
+	}
+}
+
+// injectFault delays and/or fails the current call at point as configured.
+// fail-nth fails only the nth call, so retries after it succeed.
+func injectFault(point string) error {
+	f, ok := faults[point]
+	if !ok {
+		return nil
+	}
+
+	f.mu.Lock()
+	f.calls++
+	call := f.calls
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		agentLog.Info("Injecting delay", "point", point, "delay", f.delay.String(), "call", call)
+		time.Sleep(f.delay)
+	}
+	if f.failNth > 0 && call == f.failNth {
+		agentLog.Info("Injecting failure", "point", point, "call", call)
+		return fmt.Errorf("injected fault at %s (call %d)", point, call)
+	}
+	return nil
+}
+
+// faultWriter wraps w so that, with partial-write configured at point, only
+// that fraction of size bytes is written before failing with a short write.
+func faultWriter(point string, w io.Writer, size int64) io.Writer {
+	f, ok := faults[point]
+	if !ok || f.partialWrite == 0 {
+		return w
+	}
+	return &partialWriter{w: w, remaining: int64(float64(size) * f.partialWrite), point: point}
+}
+
+type partialWriter struct {
+	w         io.Writer
+	remaining int64
+	point     string
+}
+
+func (p *partialWriter) Write(b []byte) (int, error) {
+	if int64(len(b)) <= p.remaining {
+		n, err := p.w.Write(b)
+		p.remaining -= int64(n)
+		return n, err
+	}
+	n, err := p.w.Write(b[:p.remaining])
+	p.remaining -= int64(n)
+	if err == nil {
+		agentLog.Info("Injecting partial write", "point", p.point)
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
@@ -0,0 +1,19 @@
+//go:build !faultinject
+
+package main
+
+import "io"
+
+// Fault injection points. Faults are only injected in agents built with the
+// faultinject build tag; see faults.go.
+const (
+	faultPointCheckpoint = "checkpoint"
+	faultPointConvert    = "convert"
+	faultPointStorage    = "storage"
+)
+
+func setupFaults() {}
+
+func injectFault(string) error { return nil }
+
+func faultWriter(_ string, w io.Writer, _ int64) io.Writer { return w }
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// freezeSettleTimeout bounds how long a freeze or thaw may take to reach
+// every process of the pod.
+const freezeSettleTimeout = 10 * time.Second
+
+// thawTimers thaw pods whose freeze outlived its timeout, by pod UID.
+var thawTimers = struct {
+	sync.Mutex
+	m map[string]*time.Timer
+}{m: map[string]*time.Timer{}}
+
+// FreezePod freezes every container of a pod, the sandbox included, in one
+// write to the pod's cgroup, so the containers dumped while it is frozen are
+// captured at the same instant. CRIU dumps a frozen container as it does a
+// running one and leaves it frozen.
+func (s *CheckpointServer) FreezePod(ctx context.Context, req *pb.FreezePodRequest) (*pb.FreezePodResponse, error) {
+	logger := log.FromContext(ctx).WithValues("podUID", req.PodUid)
+	logger.Info("Freeze pod request", "container", req.ContainerId, "thaw", req.Thaw)
+
+	dir, v1, err := podCgroupDir(req.PodUid, trimContainerID(req.ContainerId))
+	if err != nil {
+		return &pb.FreezePodResponse{Error: err.Error()}, nil
+	}
+
+	thawTimers.Lock()
+	defer thawTimers.Unlock()
+	if timer, ok := thawTimers.m[req.PodUid]; ok {
+		timer.Stop()
+		delete(thawTimers.m, req.PodUid)
+	}
+	if err := setCgroupFrozen(dir, v1, !req.Thaw); err != nil {
+		return &pb.FreezePodResponse{Error: err.Error()}, nil
+	}
+	if !req.Thaw && req.TimeoutSeconds > 0 {
+		podUID := req.PodUid
+		thawTimers.m[podUID] = time.AfterFunc(time.Duration(req.TimeoutSeconds)*time.Second, func() {
+			logger.Info("Freeze of pod timed out, thawing")
+			if err := setCgroupFrozen(dir, v1, false); err != nil {
+				logger.Error(err, "Failed to thaw pod")
+			}
+			thawTimers.Lock()
+			delete(thawTimers.m, podUID)
+			thawTimers.Unlock()
+		})
+	}
+	return &pb.FreezePodResponse{Success: true}, nil
+}
+
+// podCgroupDir finds the cgroup of the pod podUID from the cgroup of one of
+// its containers: the unified hierarchy on cgroup v2, the freezer hierarchy
+// on v1. Both the systemd and cgroupfs drivers name it after the pod UID.
+func podCgroupDir(podUID, containerID string) (string, bool, error) {
+	state, err := readContainerState(containerID)
+	if err != nil {
+		return "", false, err
+	}
+	if state.Pid == 0 {
+		return "", false, fmt.Errorf("container %s is not running", containerID)
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", state.Pid))
+	if err != nil {
+		return "", false, err
+	}
+
+	names := []string{"pod" + podUID, "pod" + strings.ReplaceAll(podUID, "-", "_")}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		var root string
+		v1 := false
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			root = "/sys/fs/cgroup"
+		case strings.Contains(","+parts[1]+",", ",freezer,"):
+			root, v1 = "/sys/fs/cgroup/freezer", true
+		default:
+			continue
+		}
+		for path := parts[2]; path != "/" && path != "."; path = filepath.Dir(path) {
+			base := filepath.Base(path)
+			for _, name := range names {
+				if strings.HasSuffix(strings.TrimSuffix(base, ".slice"), name) {
+					return filepath.Join(root, path), v1, nil
+				}
+			}
+		}
+	}
+	return "", false, fmt.Errorf("no cgroup of pod %s found for container %s", podUID, containerID)
+}
+
+// setCgroupFrozen freezes or thaws a cgroup and waits until every process in
+// it reached that state.
+func setCgroupFrozen(dir string, v1, frozen bool) error {
+	file, value, want := "cgroup.freeze", "0", "frozen 0"
+	if frozen {
+		value, want = "1", "frozen 1"
+	}
+	stateFile := "cgroup.events"
+	if v1 {
+		file, value, want = "freezer.state", "THAWED", "THAWED"
+		if frozen {
+			value, want = "FROZEN", "FROZEN"
+		}
+		stateFile = "freezer.state"
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	deadline := time.Now().Add(freezeSettleTimeout)
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, stateFile))
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.TrimSpace(line) == want {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("cgroup %s did not reach %q within %s", dir, want, freezeSettleTimeout)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
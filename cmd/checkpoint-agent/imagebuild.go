@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// checkpointAnnotation names the container a checkpoint image restores; CRI-O
+// recognizes checkpoint images by it.
+const checkpointAnnotation = "io.kubernetes.cri-o.annotations.checkpoint.name"
+
+// checkpointImage describes the single-layer image a checkpoint archive is
+// converted to: the archive's contents at the root of a scratch image.
+type checkpointImage struct {
+	// archive is the checkpoint archive, added to the image's root
+	archive string
+	// container is the name of the checkpointed container
+	container string
+	// name is the reference the image is stored under
+	name string
+	// overrides replace files of the archive, keyed by path in the image
+	overrides map[string][]byte
+}
+
+// imageBuildError is a failed step of building a checkpoint image.
+type imageBuildError struct {
+	step   string
+	err    error
+	output string
+}
+
+func (e *imageBuildError) Error() string {
+	if e.output == "" {
+		return fmt.Sprintf("failed to %s: %v", e.step, e.err)
+	}
+	return fmt.Sprintf("failed to %s: %v, output: %s", e.step, e.err, e.output)
+}
+
+func (e *imageBuildError) Unwrap() error {
+	return e.err
+}
+
+// imageBuilder builds checkpoint images into the node's container storage.
+type imageBuilder interface {
+	build(image checkpointImage) error
+}
+
+var imageBuilderName = flag.String("image-builder", envOr("AGENT_IMAGE_BUILDER", "native"),
+	"How checkpoint images are built: native to write them without a buildah working container, or buildah. "+
+		"Both need the buildah binary, which copies the image into the node's container storage.")
+
+// checkpointImageBuilder is the builder convertCheckpointToOCI uses.
+var checkpointImageBuilder imageBuilder = nativeBuilder{}
+
+// newImageBuilder returns the builder called name.
+func newImageBuilder(name string) (imageBuilder, error) {
+	switch name {
+	case "buildah":
+		return buildahBuilder{}, nil
+	case "native":
+		return nativeBuilder{}, nil
+	}
+	return nil, fmt.Errorf("unknown image builder %q, want buildah or native", name)
+}
+
+// buildahBuilder builds checkpoint images with the buildah binary.
+type buildahBuilder struct{}
+
+func (buildahBuilder) build(image checkpointImage) error {
+	// Create a working container from scratch
+	output, err := runBuildah("from", "scratch")
+	if err != nil {
+		return &imageBuildError{step: "create working container", err: err, output: string(output)}
+	}
+	containerID := strings.TrimSpace(string(output))
+	agentLog.Info("Created working container", "container", containerID)
+
+	// Clean up working container on exit
+	defer func() {
+		if _, err := runBuildah("rm", containerID); err != nil {
+			agentLog.Error(err, "Failed to remove working container", "container", containerID)
+		}
+	}()
+
+	// buildah only unpacks the compressions of image layers
+	archive, removeArchive, err := decompressedArchive(image.archive)
+	if err != nil {
+		return &imageBuildError{step: "decompress checkpoint", err: err}
+	}
+	defer removeArchive()
+	if output, err := runBuildah("add", containerID, archive, "/"); err != nil {
+		return &imageBuildError{step: "add checkpoint to container", err: err, output: string(output)}
+	}
+	for name, content := range image.overrides {
+		if err := buildahAddFile(containerID, name, content); err != nil {
+			return &imageBuildError{step: "add " + name, err: err}
+		}
+	}
+
+	if output, err := runBuildah("config",
+		fmt.Sprintf("--annotation=%s=%s", checkpointAnnotation, image.container),
+		containerID); err != nil {
+		return &imageBuildError{step: "add checkpoint annotation", err: err, output: string(output)}
+	}
+
+	if output, err := runBuildah("commit", containerID, image.name); err != nil {
+		return &imageBuildError{step: "commit container as image", err: err, output: string(output)}
+	}
+	return nil
+}
+
+// buildahAddFile writes content to name in the working container.
+func buildahAddFile(containerID, name string, content []byte) error {
+	tmp, err := os.CreateTemp("", filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if output, err := runBuildah("add", containerID, tmp.Name(), name); err != nil {
+		return fmt.Errorf("%w, output: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,474 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	// CRIU image magics; images carrying one of these have a second,
+	// image-specific magic before the first entry.
+	criuImgCommonMagic  = 0x54564319
+	criuImgServiceMagic = 0x55105940
+
+	// maxInspectedImageSize guards against reading huge members into memory.
+	maxInspectedImageSize = 64 * 1024 * 1024
+)
+
+// criuFdTypes names CRIU's fd_types enum values.
+var criuFdTypes = map[uint64]string{
+	1: "REG", 2: "PIPE", 3: "FIFO", 4: "INETSK", 5: "UNIXSK", 6: "EVENTFD",
+	7: "EVENTPOLL", 8: "INOTIFY", 9: "SIGNALFD", 10: "PACKETSK", 11: "TTY",
+	12: "FANOTIFY", 13: "NETLINKSK", 14: "NS", 15: "TUNF", 16: "EXT",
+	17: "TIMERFD", 18: "MEMFD", 19: "BPFMAP",
+}
+
+// containerConfigDump is the subset of the runtime's config.dump we report.
+type containerConfigDump struct {
+	Name             string    `json:"name"`
+	Runtime          string    `json:"runtime"`
+	RootfsImageName  string    `json:"rootfsImageName"`
+	RootfsImageRef   string    `json:"rootfsImageRef"`
+	CheckpointedTime time.Time `json:"checkpointedTime"`
+}
+
+// InspectCheckpoint summarizes a checkpoint archive without restoring it,
+// from the summary stored next to it if there is one.
+func (s *CheckpointServer) InspectCheckpoint(ctx context.Context, req *pb.InspectCheckpointRequest) (*pb.InspectCheckpointResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Inspect request", "checkpointPath", req.CheckpointPath)
+
+	if req.CheckpointPath == "" {
+		return &pb.InspectCheckpointResponse{Error: "checkpoint path is required"}, nil
+	}
+
+	archivePath, err := artifactPath(req.CheckpointPath)
+	if err != nil {
+		return &pb.InspectCheckpointResponse{Error: err.Error()}, nil
+	}
+	if summary := readSummary(archivePath); summary != nil {
+		summary.Success = true
+		summary.FromSummary = true
+		return summary, nil
+	}
+	resp, err := inspectArchive(archivePath)
+	if err != nil {
+		logger.Error(err, "Failed to inspect checkpoint")
+		return &pb.InspectCheckpointResponse{Error: fmt.Sprintf("inspect failed: %v", err)}, nil
+	}
+	resp.Success = true
+	return resp, nil
+}
+
+// checkpointArchive holds the metadata and small CRIU images of a checkpoint
+// archive, read in a single pass.
+type checkpointArchive struct {
+	size           int64
+	config         containerConfigDump
+	rootfsDiffSize int64
+	images         map[string][]byte
+}
+
+func inspectArchive(archivePath string) (*pb.InspectCheckpointResponse, error) {
+	archive, err := loadCheckpointArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.InspectCheckpointResponse{
+		ContainerName:   archive.config.Name,
+		Runtime:         archive.config.Runtime,
+		RootfsImageName: archive.config.RootfsImageName,
+		RootfsImageRef:  archive.config.RootfsImageRef,
+		RootfsDiffSize:  archive.rootfsDiffSize,
+		ArchiveSize:     archive.size,
+		OpenFiles:       map[string]uint32{},
+	}
+	if !archive.config.CheckpointedTime.IsZero() {
+		resp.CheckpointedAt = archive.config.CheckpointedTime.Format(time.RFC3339)
+	}
+
+	processes, err := decodeProcessTree(archive.images)
+	if err != nil {
+		return nil, err
+	}
+	resp.Processes = processes
+
+	if files, ok := archive.images["files.img"]; ok {
+		err := forEachCriuEntry(files, func(entry []byte) error {
+			return decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+				if num != 1 { // file_entry.type
+					return
+				}
+				resp.OpenFiles[criuFdType(v)]++
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode files.img: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// loadCheckpointArchive reads config.dump and the process tree, core, ids,
+// file, fd and pagemap images of an archive. Memory pages are left on disk
+// and, unless the archive is compressed, not even read.
+func loadCheckpointArchive(archivePath string) (*checkpointArchive, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	archive := &checkpointArchive{
+		size:   info.Size(),
+		images: map[string][]byte{},
+	}
+
+	err = walkArchive(archivePath, func(name string, hdr *tar.Header, r io.Reader) error {
+		switch {
+		case name == "config.dump":
+			if err := json.NewDecoder(r).Decode(&archive.config); err != nil {
+				return fmt.Errorf("failed to parse config.dump: %w", err)
+			}
+		case name == "rootfs-diff.tar":
+			archive.rootfsDiffSize = hdr.Size
+		case strings.HasPrefix(name, "checkpoint/"):
+			base := path.Base(name)
+			if base != "pstree.img" && base != "files.img" && !strings.HasPrefix(base, "core-") &&
+				!strings.HasPrefix(base, "ids-") && !strings.HasPrefix(base, "fdinfo-") && !strings.HasPrefix(base, "pagemap-") {
+				return nil
+			}
+			if hdr.Size > maxInspectedImageSize {
+				return fmt.Errorf("%s is too large to inspect (%d bytes)", name, hdr.Size)
+			}
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			archive.images[base] = data
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := archive.images["pstree.img"]; !ok {
+		return nil, fmt.Errorf("archive has no CRIU process tree (checkpoint/pstree.img)")
+	}
+	return archive, nil
+}
+
+// walkArchive calls fn for every member of a (possibly gzip, LZ4 or Zstd
+// compressed) checkpoint tar with its cleaned name. The members of an
+// uncompressed tar fn doesn't read are seeked past.
+func walkArchive(archivePath string, fn func(name string, hdr *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var archive io.Reader = r
+	if magic, err := r.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		archive = gz
+	}
+	// Tells a truncated or corrupted stream from its end
+	finish := func() error { return nil }
+	if magic, err := r.Peek(4); err == nil {
+		if c := compressorOf(magic); c != nil {
+			stream, err := c.decompress(r)
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+			archive = stream
+			finish = func() error {
+				// The tar's padding may follow its end
+				if _, err := io.Copy(io.Discard, stream); err != nil {
+					return err
+				}
+				return stream.Close()
+			}
+		}
+	}
+
+	if archive == io.Reader(r) {
+		// tar.Reader seeks past unread members of an io.Seeker
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		archive = f
+	}
+
+	tr := tar.NewReader(archive)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return finish()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if err := fn(strings.TrimPrefix(path.Clean(hdr.Name), "./"), hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeProcessTree builds the process list from pstree.img and enriches it
+// with the command name from core-<pid>.img, the dumped memory regions from
+// pagemap-<pid>.img and the file descriptors from the fdinfo image of the
+// process's file table.
+func decodeProcessTree(images map[string][]byte) ([]*pb.CheckpointProcess, error) {
+	paths, err := decodeFilePaths(images["files.img"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode files.img: %w", err)
+	}
+
+	var processes []*pb.CheckpointProcess
+	err = forEachCriuEntry(images["pstree.img"], func(entry []byte) error {
+		process := &pb.CheckpointProcess{}
+		err := decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+			switch num {
+			case 1: // pstree_entry.pid
+				process.Pid = int32(v)
+			case 2: // pstree_entry.ppid
+				process.Ppid = int32(v)
+			}
+		})
+		processes = append(processes, process)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pstree.img: %w", err)
+	}
+
+	pageSize := int64(os.Getpagesize())
+	for _, process := range processes {
+		pid := strconv.Itoa(int(process.Pid))
+
+		filesID, hasFiles := uint64(0), false
+		if core, ok := images["core-"+pid+".img"]; ok {
+			err := forEachCriuEntry(core, func(entry []byte) error {
+				return decodeFields(entry, func(num protowire.Number, _ uint64, b []byte) {
+					switch num {
+					case 3: // core_entry.tc
+						_ = decodeFields(b, func(num protowire.Number, _ uint64, b []byte) {
+							if num == 6 { // task_core_entry.comm
+								process.Command = string(b)
+							}
+						})
+					case 4: // core_entry.ids
+						filesID, hasFiles = decodeFilesID(b)
+					}
+				})
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode core-%s.img: %w", pid, err)
+			}
+		}
+		// Older CRIU versions keep the ids in an image of their own
+		if ids, ok := images["ids-"+pid+".img"]; ok && !hasFiles {
+			err := forEachCriuEntry(ids, func(entry []byte) error {
+				filesID, hasFiles = decodeFilesID(entry)
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode ids-%s.img: %w", pid, err)
+			}
+		}
+		if fdinfo, ok := images["fdinfo-"+strconv.FormatUint(filesID, 10)+".img"]; ok && hasFiles {
+			if process.OpenFiles, err = decodeFdTable(fdinfo, paths); err != nil {
+				return nil, fmt.Errorf("failed to decode fdinfo-%d.img: %w", filesID, err)
+			}
+		}
+
+		if pagemap, ok := images["pagemap-"+pid+".img"]; ok {
+			first := true
+			err := forEachCriuEntry(pagemap, func(entry []byte) error {
+				// The first entry is the pagemap_head.
+				if first {
+					first = false
+					return nil
+				}
+				process.MemorySegments++
+				return decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+					if num == 2 { // pagemap_entry.nr_pages
+						process.MemoryBytes += int64(v) * pageSize
+					}
+				})
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode pagemap-%s.img: %w", pid, err)
+			}
+		}
+	}
+
+	sort.Slice(processes, func(i, j int) bool { return processes[i].Pid < processes[j].Pid })
+	return processes, nil
+}
+
+// decodeFilesID returns the file table ID of a task_kobj_ids_entry.
+func decodeFilesID(entry []byte) (uint64, bool) {
+	var filesID uint64
+	found := false
+	_ = decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+		if num == 2 { // task_kobj_ids_entry.files_id
+			filesID, found = v, true
+		}
+	})
+	return filesID, found
+}
+
+// decodeFilePaths maps the IDs of the regular files and directories in
+// files.img to their paths.
+func decodeFilePaths(files []byte) (map[uint64]string, error) {
+	paths := map[uint64]string{}
+	if files == nil {
+		return paths, nil
+	}
+	err := forEachCriuEntry(files, func(entry []byte) error {
+		var id uint64
+		var name string
+		err := decodeFields(entry, func(num protowire.Number, v uint64, b []byte) {
+			switch num {
+			case 2: // file_entry.id
+				id = v
+			case 3: // file_entry.reg
+				_ = decodeFields(b, func(num protowire.Number, _ uint64, b []byte) {
+					if num == 6 { // reg_file_entry.name
+						name = string(b)
+					}
+				})
+			}
+		})
+		if name != "" {
+			paths[id] = name
+		}
+		return err
+	})
+	return paths, err
+}
+
+// decodeFdTable lists the file descriptors of an fdinfo image, with the
+// paths of the files in paths.
+func decodeFdTable(fdinfo []byte, paths map[uint64]string) ([]*pb.CheckpointFile, error) {
+	var files []*pb.CheckpointFile
+	err := forEachCriuEntry(fdinfo, func(entry []byte) error {
+		var id uint64
+		file := &pb.CheckpointFile{}
+		err := decodeFields(entry, func(num protowire.Number, v uint64, _ []byte) {
+			switch num {
+			case 1: // fdinfo_entry.id
+				id = v
+			case 3: // fdinfo_entry.type
+				file.Type = criuFdType(v)
+			case 4: // fdinfo_entry.fd
+				file.Fd = uint32(v)
+			}
+		})
+		file.Path = paths[id]
+		files = append(files, file)
+		return err
+	})
+	sort.Slice(files, func(i, j int) bool { return files[i].Fd < files[j].Fd })
+	return files, err
+}
+
+// criuFdType names a value of CRIU's fd_types enum.
+func criuFdType(v uint64) string {
+	if name, ok := criuFdTypes[v]; ok {
+		return name
+	}
+	return "TYPE_" + strconv.FormatUint(v, 10)
+}
+
+// forEachCriuEntry walks the length-prefixed protobuf entries of a CRIU image.
+func forEachCriuEntry(image []byte, fn func(entry []byte) error) error {
+	if len(image) < 4 {
+		return fmt.Errorf("image too short")
+	}
+	switch binary.LittleEndian.Uint32(image) {
+	case criuImgCommonMagic, criuImgServiceMagic:
+		image = image[8:]
+	default:
+		image = image[4:]
+	}
+
+	for len(image) > 0 {
+		if len(image) < 4 {
+			return fmt.Errorf("truncated entry header")
+		}
+		size := int(binary.LittleEndian.Uint32(image))
+		image = image[4:]
+		if size > len(image) {
+			return fmt.Errorf("truncated entry")
+		}
+		if err := fn(image[:size]); err != nil {
+			return err
+		}
+		image = image[size:]
+	}
+	return nil
+}
+
+// decodeFields calls visit for every varint and length-delimited field of a
+// protobuf message; other wire types are skipped.
+func decodeFields(msg []byte, visit func(num protowire.Number, v uint64, b []byte)) error {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		msg = msg[n:]
+
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			visit(num, v, nil)
+			msg = msg[n:]
+		case protowire.BytesType:
+			b, n := protowire.ConsumeBytes(msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			visit(num, 0, b)
+			msg = msg[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, msg)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			msg = msg[n:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport"
+)
+
+// How the agent authenticates to its kubelet.
+const (
+	// kubeletAuthCertificate uses the first client certificate found at
+	// kubeletCredentialPaths.
+	kubeletAuthCertificate = "certificate"
+	// kubeletAuthToken uses the agent's ServiceAccount token, which the
+	// kubelet reviews with the API server; the ServiceAccount needs create on
+	// nodes/checkpoint.
+	kubeletAuthToken = "token"
+	// kubeletAuthKubeconfig uses the credentials of --kubelet-kubeconfig.
+	kubeletAuthKubeconfig = "kubeconfig"
+
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var (
+	kubeletAuth = flag.String("kubelet-auth", envOr("AGENT_KUBELET_AUTH", kubeletAuthCertificate),
+		"How the agent authenticates to the kubelet: certificate (the node's kubelet client certificate), token (the agent's ServiceAccount token) or kubeconfig.")
+	kubeletKubeconfig = flag.String("kubelet-kubeconfig", envOr("AGENT_KUBELET_KUBECONFIG", ""),
+		"Kubeconfig whose credentials and CA the agent uses for the kubelet with --kubelet-auth=kubeconfig. Its server is ignored.")
+	kubeletCAFile = flag.String("kubelet-ca-file", envOr("AGENT_KUBELET_CA_FILE", checkpointCAFile),
+		"CA the kubelet's serving certificate is verified against, in addition to the cluster CA. Defaults to the kubelet's self-signed certificate.")
+)
+
+// newKubeletClient creates an HTTP client authenticating to the kubelet as
+// --kubelet-auth says.
+func newKubeletClient() (*http.Client, error) {
+	switch *kubeletAuth {
+	case kubeletAuthCertificate:
+		cert, err := loadKubeletCredentials()
+		if err != nil {
+			return nil, err
+		}
+		rt, err := newKubeletTransport([]tls.Certificate{cert})
+		if err != nil {
+			return nil, err
+		}
+		return &http.Client{Timeout: checkpointTimeout, Transport: rt}, nil
+	case kubeletAuthToken:
+		rt, err := newKubeletTransport(nil)
+		if err != nil {
+			return nil, err
+		}
+		// The token file is re-read as it rotates
+		withToken, err := transport.NewBearerAuthWithRefreshRoundTripper("", serviceAccountTokenFile, rt)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Client{Timeout: checkpointTimeout, Transport: withToken}, nil
+	case kubeletAuthKubeconfig:
+		if *kubeletKubeconfig == "" {
+			return nil, fmt.Errorf("--kubelet-kubeconfig is required with --kubelet-auth=kubeconfig")
+		}
+		config, err := clientcmd.BuildConfigFromFlags("", *kubeletKubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubelet kubeconfig: %w", err)
+		}
+		if *kubeletInsecureSkipTLSVerify {
+			config.Insecure = true
+			config.CAFile, config.CAData = "", nil
+		}
+		config.Timeout = checkpointTimeout
+		return rest.HTTPClientFor(config)
+	}
+	return nil, fmt.Errorf("unknown kubelet auth %q, want certificate, token or kubeconfig", *kubeletAuth)
+}
+
+// checkKubeletAuth checks the agent has the credentials --kubelet-auth needs.
+func checkKubeletAuth() error {
+	switch *kubeletAuth {
+	case kubeletAuthCertificate:
+		if _, err := loadKubeletCredentials(); err != nil {
+			return err
+		}
+	case kubeletAuthToken:
+		if _, err := os.Stat(serviceAccountTokenFile); err != nil {
+			return err
+		}
+	}
+	_, err := newKubeletClient()
+	return err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// probeNamespace is not a valid namespace name, so probing the checkpoint
+// endpoint with it never checkpoints anything.
+const probeNamespace = "lpm.probe"
+
+// kubeletURL returns the URL of path on the kubelet of nodeName.
+func kubeletURL(nodeName, path string) string {
+	return fmt.Sprintf("https://%s%s", net.JoinHostPort(nodeName, "10250"), path)
+}
+
+// probeKubeletCheckpoint asks the kubelet of nodeName to checkpoint a
+// container of a pod that cannot exist, and returns the
+// NodeKubeletCheckpointLabel value its answer implies, with the answer:
+// a kubelet with the ContainerCheckpoint feature gate off doesn't serve the
+// endpoint at all, while one with it on reports the pod missing.
+func probeKubeletCheckpoint(ctx context.Context, nodeName string) (string, string) {
+	httpClient, err := newKubeletClient()
+	if err != nil {
+		return lpmv1.NodeCapabilityUnknown, err.Error()
+	}
+	url := kubeletURL(nodeName, fmt.Sprintf("/checkpoint/%s/probe/probe", probeNamespace))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return lpmv1.NodeCapabilityUnknown, err.Error()
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return lpmv1.NodeCapabilityUnknown, err.Error()
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	answer := fmt.Sprintf("kubelet responded %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return lpmv1.NodeKubeletCheckpointForbidden, answer
+	case resp.StatusCode == http.StatusNotFound && !strings.Contains(string(data), "pod does not exist"):
+		return lpmv1.NodeKubeletCheckpointDisabled, answer
+	case resp.StatusCode == http.StatusMethodNotAllowed:
+		return lpmv1.NodeCapabilityUnknown, answer
+	}
+	return lpmv1.NodeKubeletCheckpointEnabled, answer
+}
+
+// kubeletCheckpointError returns why checkpoints on nodeName cannot work, or
+// nil if the kubelet's checkpoint API is usable or its state is unknown.
+// The checkpoint path calls it when the kubelet answers 403 or 404, so a
+// disabled feature gate or missing authorization fails the checkpoint at
+// once instead of being retried.
+func kubeletCheckpointError(ctx context.Context, nodeName string) error {
+	state, answer := probeKubeletCheckpoint(ctx, nodeName)
+	switch state {
+	case lpmv1.NodeKubeletCheckpointDisabled:
+		return fmt.Errorf("the kubelet on %s does not serve the checkpoint API; enable its ContainerCheckpoint feature gate (%s)", nodeName, answer)
+	case lpmv1.NodeKubeletCheckpointForbidden:
+		return fmt.Errorf("the kubelet on %s refuses the agent's checkpoint requests; authorize its client certificate for nodes/checkpoint (%s)", nodeName, answer)
+	}
+	log.FromContext(ctx).Info("Kubelet checkpoint API probed", "node", nodeName, "state", state, "answer", answer)
+	return nil
+}
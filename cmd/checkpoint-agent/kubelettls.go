@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var kubeletInsecureSkipTLSVerify = flag.Bool("kubelet-insecure-skip-tls-verify", os.Getenv("AGENT_KUBELET_INSECURE_SKIP_TLS_VERIFY") == "true",
+	"Do not verify the kubelet's serving certificate. Only for clusters whose kubelet certificates name neither the node nor its IP.")
+
+// kubeletRootFiles hold the CAs a kubelet serving certificate may be signed
+// by: the cluster CA, which signs serving certificates requested through
+// CSRs (serverTLSBootstrap), and the kubelet's own self-signed certificate.
+var kubeletRootFiles = []string{
+	"/etc/kubernetes/pki/ca.crt",
+	"/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+}
+
+// kubeletRoots returns the pool of CAs the kubelet's serving certificate is
+// verified against: the kubeletRootFiles and --kubelet-ca-file that exist.
+func kubeletRoots() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	found := false
+	for _, file := range append(kubeletRootFiles, *kubeletCAFile) {
+		data, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			agentLog.Info("No certificates in kubelet CA file", "file", file)
+			continue
+		}
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no CA to verify the kubelet's serving certificate with in %s or --kubelet-ca-file %s",
+			strings.Join(kubeletRootFiles, ", "), *kubeletCAFile)
+	}
+	return pool, nil
+}
+
+// newKubeletTransport returns a transport presenting certificates to the
+// kubelet and verifying its serving certificate against kubeletRoots. The
+// certificate must name the host the agent dialed, the node name, or be
+// issued for the IP address the connection actually reached.
+func newKubeletTransport(certificates []tls.Certificate) (*http.Transport, error) {
+	if *kubeletInsecureSkipTLSVerify {
+		agentLog.Info("Not verifying the kubelet's serving certificate")
+		return &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates:       certificates,
+				InsecureSkipVerify: true,
+			},
+		}, nil
+	}
+
+	roots, err := kubeletRoots()
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	return &http.Transport{
+		DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			var remoteIP net.IP
+			if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+				remoteIP = tcpAddr.IP
+			}
+			tlsConn := tls.Client(conn, &tls.Config{
+				Certificates: certificates,
+				ServerName:   host,
+				// Verified by VerifyConnection, which also accepts the IP
+				// the connection reached
+				InsecureSkipVerify: true,
+				VerifyConnection: func(state tls.ConnectionState) error {
+					return verifyKubeletCertificate(state.PeerCertificates, roots, host, remoteIP)
+				},
+			})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		},
+	}, nil
+}
+
+// verifyKubeletCertificate verifies the chain the kubelet presented against
+// roots and that its leaf is valid for host or remoteIP.
+func verifyKubeletCertificate(chain []*x509.Certificate, roots *x509.CertPool, host string, remoteIP net.IP) error {
+	if len(chain) == 0 {
+		return fmt.Errorf("kubelet presented no certificate")
+	}
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		return fmt.Errorf("kubelet serving certificate is not trusted: %w", err)
+	}
+
+	if leaf.VerifyHostname(host) == nil {
+		return nil
+	}
+	if remoteIP != nil && leaf.VerifyHostname(remoteIP.String()) == nil {
+		return nil
+	}
+	var names []string
+	names = append(names, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return fmt.Errorf("kubelet serving certificate is valid for %s, not %s or %s; fix its SANs or set --kubelet-insecure-skip-tls-verify",
+		strings.Join(names, ", "), host, remoteIP)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	// sharedCheckpointDir is where the shared storage is mounted.
+	sharedCheckpointDir = "/mnt/checkpoints"
+
+	// defaultArtifactLayout names archives in shared storage as they always
+	// were, all in its root directory.
+	defaultArtifactLayout = "{{.PodUID}}-{{.Container}}-{{.Timestamp}}"
+
+	// artifactIndexName is the index of the archives in a directory of the
+	// shared storage; artifactIndexLock, in its root, is the lock writers of
+	// any index take.
+	artifactIndexName = "index.jsonl"
+	artifactIndexLock = ".index.lock"
+)
+
+var artifactLayout = flag.String("artifact-layout", envOr("AGENT_ARTIFACT_LAYOUT", defaultArtifactLayout),
+	"Go template of the path, relative to the shared storage and without extension, archives are copied to. "+
+		"It can use .Namespace, .Pod, .PodUID, .Container, .Generation (the PodCheckpoint generation, 0 for none) and "+
+		".Timestamp, which it must use; directories it names are created, e.g. "+
+		"{{.Namespace}}/{{.Pod}}/{{.Container}}/{{.Generation}}-{{.Timestamp}}.")
+
+// artifactLayoutTemplate is the parsed --artifact-layout.
+var artifactLayoutTemplate *template.Template
+
+// artifactNameData holds the fields an artifact layout can use.
+type artifactNameData struct {
+	Namespace  string
+	Pod        string
+	PodUID     string
+	Container  string
+	Generation int64
+	// Timestamp is when the archive was written, formatted as
+	// 20060102-150405.
+	Timestamp string
+}
+
+// parseArtifactLayout parses an artifact layout and checks it renders a
+// path inside the shared storage. A layout must use the timestamp, so that
+// the archives of one container never share a name.
+func parseArtifactLayout(text string) (*template.Template, error) {
+	if !strings.Contains(text, ".Timestamp") {
+		return nil, errors.New("artifact layout must use {{.Timestamp}}")
+	}
+	tmpl, err := template.New("layout").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := renderArtifactName(tmpl, artifactNameData{
+		Namespace: "default",
+		Pod:       "pod",
+		PodUID:    "00000000-0000-0000-0000-000000000000",
+		Container: "container",
+		Timestamp: "20060102-150405",
+	}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func renderArtifactName(tmpl *template.Template, data artifactNameData) (string, error) {
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", err
+	}
+	path := name.String()
+	if !filepath.IsLocal(path) || filepath.Clean(path) != path {
+		return "", fmt.Errorf("artifact layout renders %q, which is not a clean relative path", path)
+	}
+	for _, element := range strings.Split(path, string(filepath.Separator)) {
+		// Hidden names are the agent's partial copies and locks
+		if strings.HasPrefix(element, ".") {
+			return "", fmt.Errorf("artifact layout renders %q, which has a hidden path element", path)
+		}
+	}
+	return path, nil
+}
+
+// layoutNamespaceDir returns the index, among the path elements the
+// artifact layout renders, of the directory named after the namespace alone,
+// or -1 if the layout has none.
+func layoutNamespaceDir() int {
+	tmpl := artifactLayoutTemplate
+	if tmpl == nil {
+		var err error
+		if tmpl, err = parseArtifactLayout(defaultArtifactLayout); err != nil {
+			return -1
+		}
+	}
+	// Unlike any other field, so that only the namespace renders it
+	const namespace = "lpm-layout-namespace"
+	path, err := renderArtifactName(tmpl, artifactNameData{
+		Namespace: namespace,
+		Pod:       "pod",
+		PodUID:    "00000000-0000-0000-0000-000000000000",
+		Container: "container",
+		Timestamp: "20060102-150405",
+	})
+	if err != nil {
+		return -1
+	}
+	elements := strings.Split(path, string(filepath.Separator))
+	// The last element names the archive, not a directory
+	return slices.Index(elements[:len(elements)-1], namespace)
+}
+
+// artifactName returns the path, relative to the shared storage and
+// without extension, of the archive of req written at modTime.
+func artifactName(req *pb.CheckpointRequest, modTime time.Time) (string, error) {
+	tmpl := artifactLayoutTemplate
+	if tmpl == nil {
+		var err error
+		if tmpl, err = parseArtifactLayout(defaultArtifactLayout); err != nil {
+			return "", err
+		}
+	}
+	return renderArtifactName(tmpl, artifactNameData{
+		Namespace:  req.PodNamespace,
+		Pod:        req.PodName,
+		PodUID:     req.PodUid,
+		Container:  req.ContainerName,
+		Generation: req.Generation,
+		Timestamp:  modTime.Format("20060102-150405"),
+	})
+}
+
+// artifactIndexEntry describes an archive in the index of its directory.
+type artifactIndexEntry struct {
+	URI         string    `json:"uri"`
+	Namespace   string    `json:"namespace"`
+	Pod         string    `json:"pod"`
+	PodUID      string    `json:"podUID"`
+	Container   string    `json:"container"`
+	Generation  int64     `json:"generation,omitempty"`
+	Node        string    `json:"node"`
+	Created     time.Time `json:"created"`
+	Size        int64     `json:"size"`
+	Digest      string    `json:"digest,omitempty"`
+	Compression string    `json:"compression,omitempty"`
+	VolumesURI  string    `json:"volumesURI,omitempty"`
+}
+
+// lockArtifactIndexes locks the indexes against the agents of every node
+// sharing the storage, and returns the function unlocking them. One lock
+// for all of them lets emptied directories be removed.
+func lockArtifactIndexes() (func(), error) {
+	lock, err := os.OpenFile(filepath.Join(sharedCheckpointDir, artifactIndexLock), os.O_RDWR|os.O_CREATE, artifactFileMode)
+	if err != nil {
+		return nil, err
+	}
+	// NFS clients take flock as a byte-range lock on the server
+	if err := unix.Flock(int(lock.Fd()), unix.LOCK_EX); err != nil {
+		lock.Close()
+		return nil, err
+	}
+	return func() { lock.Close() }, nil
+}
+
+// indexArtifact appends entry to the index of the directory its archive is
+// in, one JSON object per line, so the archives can be found by pod
+// without listing the shared storage or the API.
+func indexArtifact(entry artifactIndexEntry, access artifactAccess) error {
+	dir := filepath.Dir(resolveCheckpointPath(entry.URI))
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	unlock, err := lockArtifactIndexes()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	indexPath := filepath.Join(dir, artifactIndexName)
+	index, err := os.OpenFile(indexPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, artifactFileMode)
+	if err != nil {
+		return err
+	}
+	if _, err := index.Write(append(line, '\n')); err != nil {
+		index.Close()
+		return err
+	}
+	if err := index.Close(); err != nil {
+		return err
+	}
+	// An index listing other namespaces' archives stays the agents'
+	relativeDir, err := filepath.Rel(sharedCheckpointDir, dir)
+	if err != nil {
+		return err
+	}
+	if access.shares(relativeDir) {
+		return os.Chmod(indexPath, artifactFileMode)
+	}
+	return access.apply(indexPath)
+}
+
+// unindexArtifact removes the entry of the archive at path from the index
+// of its directory. An emptied index is removed, along with the directories
+// of the layout left empty, so the layout does not accumulate directories
+// of gone pods.
+func unindexArtifact(path string) error {
+	dir := filepath.Dir(path)
+	indexPath := filepath.Join(dir, artifactIndexName)
+	unlock, err := lockArtifactIndexes()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	info, err := os.Stat(indexPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return err
+	}
+	var kept bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var entry artifactIndexEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && resolveCheckpointPath(entry.URI) == path {
+			continue
+		}
+		kept.Write(scanner.Bytes())
+		kept.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if kept.Len() > 0 {
+		tmp := filepath.Join(dir, "."+artifactIndexName+".tmp")
+		if err := os.WriteFile(tmp, kept.Bytes(), artifactFileMode); err != nil {
+			return err
+		}
+		// Readable by whoever could read the index it replaces
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			if err := os.Chown(tmp, -1, int(stat.Gid)); err != nil {
+				return err
+			}
+		}
+		if err := os.Chmod(tmp, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return os.Rename(tmp, indexPath)
+	}
+
+	if err := os.Remove(indexPath); err != nil {
+		return err
+	}
+	for dir != sharedCheckpointDir && strings.HasPrefix(dir, sharedCheckpointDir+"/") {
+		// Fails once a directory holds anything else
+		if os.Remove(dir) != nil {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+// indexedArtifact returns the entry of the archive at path, or of the
+// archive whose volumes it holds, in the index of its directory.
+func indexedArtifact(path string) (artifactIndexEntry, bool, error) {
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(path), artifactIndexName))
+	if errors.Is(err, os.ErrNotExist) {
+		return artifactIndexEntry{}, false, nil
+	}
+	if err != nil {
+		return artifactIndexEntry{}, false, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var entry artifactIndexEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		if resolveCheckpointPath(entry.URI) == path || (entry.VolumesURI != "" && resolveCheckpointPath(entry.VolumesURI) == path) {
+			return entry, true, nil
+		}
+	}
+	return artifactIndexEntry{}, false, scanner.Err()
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+var ledgerPath = flag.String("ledger-path", envOr("AGENT_LEDGER_PATH", filepath.Join(checkpointDir, "agent-ledger.json")),
+	"File the agent records its checkpoint requests in, so it can answer for them after a restart. Empty disables the ledger.")
+
+// ledgerRetention is how long finished checkpoint requests stay in the
+// ledger, well past any retry of the controller.
+const ledgerRetention = 7 * 24 * time.Hour
+
+// ledgerState is the state of a checkpoint request in the ledger.
+type ledgerState string
+
+const (
+	ledgerInProgress ledgerState = "InProgress"
+	ledgerCompleted  ledgerState = "Completed"
+	ledgerFailed     ledgerState = "Failed"
+	// ledgerInterrupted marks a checkpoint the agent was restarted during
+	ledgerInterrupted ledgerState = "Interrupted"
+)
+
+// ledgerEntry records a checkpoint request and its result.
+type ledgerEntry struct {
+	Pod            string      `json:"pod"`
+	Container      string      `json:"container"`
+	State          ledgerState `json:"state"`
+	ArtifactURI    string      `json:"artifactURI,omitempty"`
+	VolumesURI     string      `json:"volumesURI,omitempty"`
+	ArtifactDigest string      `json:"artifactDigest,omitempty"`
+	ArtifactSize   int64       `json:"artifactSize,omitempty"`
+	Compression    string      `json:"compression,omitempty"`
+	Redactions     []redaction `json:"redactions,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	Started        time.Time   `json:"started"`
+	Finished       *time.Time  `json:"finished,omitempty"`
+}
+
+// redaction records a redaction hook that ran on the artifact.
+type redaction struct {
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// redactions returns the redaction hooks that ran on the entry's artifact.
+func (e *ledgerEntry) redactions() []*pb.Redaction {
+	var redactions []*pb.Redaction
+	for _, r := range e.Redactions {
+		redactions = append(redactions, &pb.Redaction{Name: r.Name, Detail: r.Detail})
+	}
+	return redactions
+}
+
+// ledger maps checkpoint request IDs to their artifacts, persisted as a JSON
+// file so a controller retrying a request after an agent restart gets the
+// checkpoint taken before, rather than a second one.
+type ledger struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]*ledgerEntry
+}
+
+// openLedger loads the ledger at path, marking checkpoints in progress when
+// the agent stopped as interrupted.
+func openLedger(path string) (*ledger, error) {
+	l := &ledger{path: path, entries: map[string]*ledgerEntry{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.entries); err != nil {
+		// Only lose the ledger, not the agent, to a corrupted file
+		agentLog.Error(err, "Discarding unreadable ledger", "path", path)
+		l.entries = map[string]*ledgerEntry{}
+	}
+
+	interrupted := 0
+	for _, entry := range l.entries {
+		if entry.State == ledgerInProgress {
+			entry.State = ledgerInterrupted
+			entry.Error = "agent restarted during the checkpoint"
+			interrupted++
+		}
+	}
+	agentLog.Info("Loaded ledger", "path", path, "requests", len(l.entries), "interrupted", interrupted)
+	return l, l.save()
+}
+
+// begin records that the checkpoint of request id started. It returns the
+// entry of a request already completed, whose artifact is still there, or
+// an error if the request is still in progress.
+func (l *ledger) begin(id string, req *pb.CheckpointRequest) (*ledgerEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, ok := l.entries[id]; ok {
+		switch {
+		case entry.State == ledgerInProgress:
+			return nil, fmt.Errorf("checkpoint request %s is already in progress", id)
+		case entry.State == ledgerCompleted && artifactComplete(entry):
+			recorded := *entry
+			return &recorded, nil
+		}
+	}
+	l.entries[id] = &ledgerEntry{
+		Pod:       req.PodNamespace + "/" + req.PodName,
+		Container: req.ContainerName,
+		State:     ledgerInProgress,
+		Started:   time.Now(),
+	}
+	return nil, l.save()
+}
+
+// finish records the result of the checkpoint of request id.
+func (l *ledger) finish(id string, resp *pb.CheckpointResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[id]
+	if !ok {
+		return
+	}
+	now := time.Now()
+	entry.Finished = &now
+	if resp.Success {
+		entry.State = ledgerCompleted
+		entry.ArtifactURI = resp.ArtifactUri
+		entry.VolumesURI = resp.VolumesUri
+		entry.ArtifactDigest = resp.ArtifactDigest
+		entry.ArtifactSize = resp.ArtifactSize
+		entry.Compression = resp.Compression
+		entry.Redactions = nil
+		for _, r := range resp.Redactions {
+			entry.Redactions = append(entry.Redactions, redaction{Name: r.Name, Detail: r.Detail})
+		}
+	} else {
+		entry.State = ledgerFailed
+		entry.Error = resp.Error
+	}
+	if err := l.save(); err != nil {
+		agentLog.Error(err, "Failed to record checkpoint request in the ledger", "requestID", id)
+	}
+}
+
+// get returns a copy of the entry of request id.
+func (l *ledger) get(id string) (ledgerEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[id]
+	if !ok {
+		return ledgerEntry{}, false
+	}
+	return *entry, true
+}
+
+// save drops requests finished longer than ledgerRetention ago and writes
+// the ledger, replacing the file at once so a crash leaves either version.
+// l.mu must be held.
+func (l *ledger) save() error {
+	for id, entry := range l.entries {
+		if entry.Finished != nil && time.Since(*entry.Finished) > ledgerRetention {
+			delete(l.entries, id)
+		}
+	}
+	data, err := json.MarshalIndent(l.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), l.path)
+}
+
+// artifactComplete reports whether the archives of a completed checkpoint
+// are still on this node as recorded. Archives are only published once
+// complete, and a checkpoint only recorded as completed after that, so this
+// only fails for archives removed or replaced since.
+func artifactComplete(entry *ledgerEntry) bool {
+	info, err := os.Stat(resolveCheckpointPath(entry.ArtifactURI))
+	if err != nil || !info.Mode().IsRegular() || (entry.ArtifactSize > 0 && info.Size() != entry.ArtifactSize) {
+		return false
+	}
+	if entry.VolumesURI != "" {
+		if _, err := os.Stat(resolveCheckpointPath(entry.VolumesURI)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// GetCheckpointRecord returns the ledger entry of a checkpoint request.
+func (s *CheckpointServer) GetCheckpointRecord(_ context.Context, req *pb.GetCheckpointRecordRequest) (*pb.GetCheckpointRecordResponse, error) {
+	if s.ledger == nil {
+		return &pb.GetCheckpointRecordResponse{Error: "the agent runs without a ledger"}, nil
+	}
+	entry, ok := s.ledger.get(req.RequestId)
+	if !ok {
+		return &pb.GetCheckpointRecordResponse{Success: true}, nil
+	}
+	return &pb.GetCheckpointRecordResponse{
+		Success:         true,
+		Found:           true,
+		State:           string(entry.State),
+		ArtifactUri:     entry.ArtifactURI,
+		VolumesUri:      entry.VolumesURI,
+		ArtifactDigest:  entry.ArtifactDigest,
+		ArtifactSize:    entry.ArtifactSize,
+		ArtifactPresent: entry.State == ledgerCompleted && artifactComplete(&entry),
+		CheckpointError: entry.Error,
+	}, nil
+}
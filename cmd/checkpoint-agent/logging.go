@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"google.golang.org/grpc"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"my.domain/guestbook/internal/agent"
+)
+
+// agentLog logs what the agent does outside of a call. The logger of a call
+// is taken from its context, see callContext.
+var agentLog = log.Log.WithName("checkpoint-agent")
+
+// logOptions are the zap flags the controller takes as well (--zap-devel,
+// --zap-encoder, --zap-log-level, ...). The agent logs JSON by default.
+var logOptions = zap.Options{}
+
+func init() {
+	logOptions.BindFlags(flag.CommandLine)
+}
+
+// setupLogging installs the logger configured by the flags. It must run
+// after flag.Parse.
+func setupLogging() {
+	log.SetLogger(zap.New(zap.UseFlagOptions(&logOptions)))
+}
+
+// loggingServerOptions returns the interceptors serving each call with a
+// logger naming its method and, if the caller sent one, the migration ID it
+// is made for.
+func loggingServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			return handler(callContext(ctx, info.FullMethod), req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &loggingStream{ServerStream: ss, ctx: callContext(ss.Context(), info.FullMethod)})
+		}),
+	}
+}
+
+// callContext returns ctx carrying the logger of the call to method.
+func callContext(ctx context.Context, method string) context.Context {
+	logger := agentLog.WithValues("method", method)
+	if id := agent.MigrationIDFromIncomingContext(ctx); id != "" {
+		logger = logger.WithValues("migrationID", id)
+	}
+	return log.IntoContext(ctx, logger)
+}
+
+// loggingStream is a server stream whose context carries the call's logger.
+type loggingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingStream) Context() context.Context {
+	return s.ctx
+}
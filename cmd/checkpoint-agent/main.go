@@ -2,16 +2,14 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strings"
@@ -23,73 +21,164 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	pb "my.domain/guestbook/api/proto"
 )
 
 const (
-	port                     = ":50051"
 	checkpointDir            = "/var/lib/kubelet/checkpoints"
 	maxMessageSize           = 100 * 1024 * 1024 // 100MB
 	checkpointTimeout        = 30 * time.Second
 	checkpointBackoffSteps   = 5
 	checkpointBackoffInitial = 2 * time.Second
 	checkpointBackoffFactor  = 2.0
-	
+
+	// containerStorageRoot is the host's containers/storage root (mounted into the agent)
+	containerStorageRoot = "/var/lib/containers/storage"
+
 	// Kubelet certificate paths
 	checkpointCertFile = "/etc/kubernetes/pki/apiserver-kubelet-client.crt"
 	checkpointKeyFile  = "/etc/kubernetes/pki/apiserver-kubelet-client.key"
 	checkpointCAFile   = "/var/lib/kubelet/pki/kubelet.crt"
 )
 
+var listenAddress = flag.String("listen-address", envOr("AGENT_LISTEN_ADDRESS", ":50051"),
+	"Address the gRPC server listens on, e.g. :50051, 0.0.0.0:50051 or [::]:50051.")
+
+// envOr returns the environment variable key, or def if it is unset.
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
 // CheckpointServer implements the CheckpointService
 type CheckpointServer struct {
 	pb.UnimplementedCheckpointServiceServer
 	nodeName string
+	// ledger records checkpoint requests; nil if disabled
+	ledger *ledger
+	// conversions caches the images built from checkpoints; nil if disabled
+	conversions *conversionCache
 }
 
 // NewCheckpointServer creates a new checkpoint server
-func NewCheckpointServer() *CheckpointServer {
+func NewCheckpointServer(ledger *ledger, conversions *conversionCache) *CheckpointServer {
 	nodeName := os.Getenv("NODE_NAME")
 	if nodeName == "" {
 		nodeName = "unknown"
 	}
-	
+
 	return &CheckpointServer{
-		nodeName: nodeName,
+		nodeName:    nodeName,
+		ledger:      ledger,
+		conversions: conversions,
 	}
 }
 
-// Checkpoint implements the checkpoint operation
+// Checkpoint implements the checkpoint operation. A request with an ID the
+// ledger recorded as completed returns the recorded checkpoint.
 func (s *CheckpointServer) Checkpoint(ctx context.Context, req *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
-	log.Printf("Checkpoint request: namespace=%s, pod=%s, container=%s, uid=%s", 
-		req.PodNamespace, req.PodName, req.ContainerName, req.PodUid)
+	if s.ledger == nil || req.RequestId == "" {
+		return s.checkpoint(ctx, req)
+	}
+
+	recorded, err := s.ledger.begin(req.RequestId, req)
+	if err != nil {
+		return &pb.CheckpointResponse{
+			Success: false,
+			Error:   fmt.Sprintf("checkpoint failed: %v", err),
+		}, nil
+	}
+	if recorded != nil {
+		log.FromContext(ctx).Info("Checkpoint request already completed", "requestID", req.RequestId, "artifactURI", recorded.ArtifactURI)
+		return &pb.CheckpointResponse{
+			Success:        true,
+			ArtifactUri:    recorded.ArtifactURI,
+			VolumesUri:     recorded.VolumesURI,
+			ArtifactDigest: recorded.ArtifactDigest,
+			ArtifactSize:   recorded.ArtifactSize,
+			Compression:    recorded.Compression,
+			Redactions:     recorded.redactions(),
+			Message:        "checkpoint already completed",
+		}, nil
+	}
+
+	resp, err := s.checkpoint(ctx, req)
+	if err == nil {
+		s.ledger.finish(req.RequestId, resp)
+	}
+	return resp, err
+}
+
+// checkpoint checkpoints the container through the kubelet
+func (s *CheckpointServer) checkpoint(ctx context.Context, req *pb.CheckpointRequest) (*pb.CheckpointResponse, error) {
+	logger := log.FromContext(ctx).WithValues("namespace", req.PodNamespace, "pod", req.PodName, "container", req.ContainerName)
+	ctx = log.IntoContext(ctx, logger)
+	logger.Info("Checkpoint request", "requestID", req.RequestId, "podUID", req.PodUid,
+		"memoryVolumes", req.MemoryVolumes, "criuOptions", req.CriuOptions)
 
 	// Ensure checkpoint directory exists
 	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
-		log.Printf("Failed to create checkpoint directory: %v", err)
+		logger.Error(err, "Failed to create checkpoint directory")
 		return &pb.CheckpointResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to create checkpoint directory: %v", err),
 		}, nil
 	}
 
+	if err := injectFault(faultPointCheckpoint); err != nil {
+		return &pb.CheckpointResponse{
+			Success: false,
+			Error:   fmt.Sprintf("checkpoint failed: %v", err),
+		}, nil
+	}
+
+	// CRIU dumps the container's init and its descendants only
+	if req.ContainerId != "" {
+		if err := handleExecSessions(ctx, trimContainerID(req.ContainerId), req.TerminateExecSessions); err != nil {
+			return &pb.CheckpointResponse{
+				Success: false,
+				Error:   fmt.Sprintf("checkpoint failed: %v", err),
+			}, nil
+		}
+	}
+
+	// The kubelet API takes no CRIU options; runc reads them from its config
+	if err := ensureCriuOptions(ctx, req.CriuOptions); err != nil {
+		return &pb.CheckpointResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to configure CRIU: %v", err),
+		}, nil
+	}
+
 	// Create checkpoint using kubelet API
-	url := fmt.Sprintf("https://%s:10250/checkpoint/%s/%s/%s",
-		s.nodeName, req.PodNamespace, req.PodName, req.ContainerName)
+	url := kubeletURL(s.nodeName, fmt.Sprintf("/checkpoint/%s/%s/%s", req.PodNamespace, req.PodName, req.ContainerName))
 
-	httpClient, err := s.makeTLSClient()
+	httpClient, err := newKubeletClient()
 	if err != nil {
-		log.Printf("Failed to create TLS client: %v", err)
+		logger.Error(err, "Failed to create TLS client")
 		return &pb.CheckpointResponse{
 			Success: false,
 			Error:   fmt.Sprintf("failed to create TLS client: %v", err),
 		}, nil
 	}
 
+	started := time.Now()
 	checkpointFiles, err := s.doCheckpointWithBackoff(ctx, httpClient, url)
+	if ctx.Err() != nil {
+		// Nobody waits for this checkpoint anymore; don't leave it filling the disk
+		removeCancelledCheckpoint(ctx, req, started, checkpointFiles)
+		logger.Info("Checkpoint cancelled", "cause", context.Cause(ctx))
+		return &pb.CheckpointResponse{
+			Success: false,
+			Error:   fmt.Sprintf("checkpoint cancelled: %v", ctx.Err()),
+		}, nil
+	}
 	if err != nil {
-		log.Printf("Failed to create checkpoint: %v", err)
+		logger.Error(err, "Failed to create checkpoint")
 		return &pb.CheckpointResponse{
 			Success: false,
 			Error:   fmt.Sprintf("checkpoint failed: %v", err),
@@ -103,35 +192,132 @@ func (s *CheckpointServer) Checkpoint(ctx context.Context, req *pb.CheckpointReq
 		}, nil
 	}
 
+	// Who may read what is written to shared storage for the pod
+	access := artifactAccess{namespace: req.PodNamespace, gid: -1}
+	if *sharedStorage || len(req.MemoryVolumes) > 0 {
+		access = artifactAccessFor(ctx, req.PodNamespace)
+	}
+
+	// Archived right away, as the container keeps running and writing them
+	var volumesURI string
+	if len(req.MemoryVolumes) > 0 {
+		if volumesURI, err = archiveVolumes(ctx, req, access); err != nil {
+			logger.Error(err, "Failed to archive memory volumes")
+			return &pb.CheckpointResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to archive memory volumes: %v", err),
+			}, nil
+		}
+	}
+
+	// Before the archive is compressed, copied or pushed off the node
+	redactions, err := redactArchive(ctx, checkpointFiles[0])
+	if err != nil {
+		logger.Error(err, "Failed to redact checkpoint archive, deleting it")
+		// What the hooks were to remove must not be kept
+		if err := os.Remove(checkpointFiles[0]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Error(err, "Failed to delete unredacted checkpoint archive", "path", checkpointFiles[0])
+		}
+		return &pb.CheckpointResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to redact checkpoint: %v", err),
+		}, nil
+	}
+
+	var artifactSize int64
+	if info, err := os.Stat(checkpointFiles[0]); err == nil {
+		artifactSize = info.Size()
+	}
+	// Inspections read the summary stored next to the archive the response
+	// names, wherever it is kept, rather than the whole archive
+	summary, err := inspectArchive(checkpointFiles[0])
+	if err != nil {
+		logger.Error(err, "Failed to summarize checkpoint archive")
+	}
+
+	if !*sharedStorage || req.KeepOnNode {
+		// Converted and pushed to the checkpoint registry, or transferred to
+		// the target node, from this node
+		artifactURI := fmt.Sprintf("file://%s", checkpointFiles[0])
+		digest, err := fileDigest(checkpointFiles[0])
+		if err != nil {
+			logger.Error(err, "Failed to digest checkpoint")
+		}
+		storeSummary(ctx, summary, checkpointFiles[0], artifactSize)
+		logger.Info("Checkpoint created", "artifactURI", artifactURI, "size", artifactSize)
+		return &pb.CheckpointResponse{
+			Success:        true,
+			ArtifactUri:    artifactURI,
+			VolumesUri:     volumesURI,
+			ArtifactDigest: digest,
+			ArtifactSize:   artifactSize,
+			Redactions:     redactions,
+			Message:        "checkpoint created successfully",
+		}, nil
+	}
+
 	// Copy checkpoint to shared storage
-	sharedPath, err := s.copyToSharedStorage(req.PodUid, req.ContainerName, checkpointFiles[0])
+	sharedPath, digest, compression, err := s.copyToSharedStorage(ctx, req, access, checkpointFiles[0])
 	if err != nil {
-		log.Printf("Failed to copy to shared storage: %v", err)
+		logger.Error(err, "Failed to copy checkpoint to shared storage")
 		// Return local path as fallback
 		artifactURI := fmt.Sprintf("file://%s", checkpointFiles[0])
-		log.Printf("Checkpoint created successfully: %s", artifactURI)
+		storeSummary(ctx, summary, checkpointFiles[0], artifactSize)
+		logger.Info("Checkpoint created", "artifactURI", artifactURI, "size", artifactSize)
 		return &pb.CheckpointResponse{
-			Success:     true,
-			ArtifactUri: artifactURI,
-			Message:     "checkpoint created successfully",
+			Success:      true,
+			ArtifactUri:  artifactURI,
+			VolumesUri:   volumesURI,
+			ArtifactSize: artifactSize,
+			Redactions:   redactions,
+			Message:      "checkpoint created successfully",
 		}, nil
 	}
 
 	// Return shared path
 	artifactURI := fmt.Sprintf("shared://%s", sharedPath)
-	log.Printf("Checkpoint created successfully: %s", artifactURI)
+	if compression != "" {
+		// The size of the compressed archive the URI names
+		if info, err := os.Stat(resolveCheckpointPath(artifactURI)); err == nil {
+			artifactSize = info.Size()
+		}
+	}
+	storeSummary(ctx, summary, resolveCheckpointPath(artifactURI), artifactSize)
+	if err := indexArtifact(artifactIndexEntry{
+		URI:         artifactURI,
+		Namespace:   req.PodNamespace,
+		Pod:         req.PodName,
+		PodUID:      req.PodUid,
+		Container:   req.ContainerName,
+		Generation:  req.Generation,
+		Node:        s.nodeName,
+		Created:     time.Now().UTC(),
+		Size:        artifactSize,
+		Digest:      digest,
+		Compression: compression,
+		VolumesURI:  volumesURI,
+	}, access); err != nil {
+		// The index only helps people find archives
+		logger.Error(err, "Failed to index checkpoint archive", "artifactURI", artifactURI)
+	}
+	logger.Info("Checkpoint created", "artifactURI", artifactURI, "size", artifactSize)
 	return &pb.CheckpointResponse{
-		Success:     true,
-		ArtifactUri: artifactURI,
-		Message:     "checkpoint created successfully",
+		Success:        true,
+		ArtifactUri:    artifactURI,
+		VolumesUri:     volumesURI,
+		ArtifactDigest: digest,
+		ArtifactSize:   artifactSize,
+		Compression:    compression,
+		Redactions:     redactions,
+		Message:        "checkpoint created successfully",
 	}, nil
 }
 
-
 // ConvertCheckpointToImage converts a checkpoint tar file to OCI image format
 func (s *CheckpointServer) ConvertCheckpointToImage(ctx context.Context, req *pb.ConvertRequest) (*pb.ConvertResponse, error) {
-	log.Printf("Convert request: checkpoint_path=%s, container_name=%s, image_name=%s", 
-		req.CheckpointPath, req.ContainerName, req.ImageName)
+	logger := log.FromContext(ctx)
+	logger.Info("Convert request", "checkpointPath", req.CheckpointPath, "container", req.ContainerName, "image", req.ImageName,
+		"push", req.Push, "tlsVerify", req.TlsVerify, "artifactDigest", req.ArtifactDigest, "baseImage", req.BaseImage)
 
 	// Validate input
 	if req.CheckpointPath == "" {
@@ -149,10 +335,12 @@ func (s *CheckpointServer) ConvertCheckpointToImage(ctx context.Context, req *pb
 	}
 
 	// Convert shared:// URI to local path
-	checkpointPath := req.CheckpointPath
-	if strings.HasPrefix(checkpointPath, "shared://") {
-		filename := strings.TrimPrefix(checkpointPath, "shared://")
-		checkpointPath = filepath.Join("/mnt/checkpoints", filename)
+	checkpointPath, err := artifactPath(req.CheckpointPath)
+	if err != nil {
+		return &pb.ConvertResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
 	}
 
 	// Verify checkpoint file exists
@@ -163,17 +351,59 @@ func (s *CheckpointServer) ConvertCheckpointToImage(ctx context.Context, req *pb
 		}, nil
 	}
 
-	// Convert checkpoint to OCI image using buildah
-	imageRef, err := s.convertCheckpointToOCI(checkpointPath, req.ContainerName, req.ImageName)
-	if err != nil {
-		log.Printf("Failed to convert checkpoint to OCI: %v", err)
-		return &pb.ConvertResponse{
-			Success: false,
-			Error:   fmt.Sprintf("conversion failed: %v", err),
-		}, nil
+	// An archive corrupted in transit or at rest must not become an image.
+	// The digest also keys the conversion cache.
+	var digest string
+	if req.ArtifactDigest != "" || s.conversions != nil {
+		if digest, err = fileDigest(checkpointPath); err != nil {
+			return &pb.ConvertResponse{
+				Success: false,
+				Error:   fmt.Sprintf("failed to digest checkpoint: %v", err),
+			}, nil
+		}
+		if req.ArtifactDigest != "" && digest != req.ArtifactDigest {
+			return &pb.ConvertResponse{
+				Success: false,
+				Error:   fmt.Sprintf("checkpoint %s has digest %s, expected %s", checkpointPath, digest, req.ArtifactDigest),
+			}, nil
+		}
+	}
+
+	key := conversionKey{digest: digest, container: req.ContainerName, baseImageID: req.BaseImageId}
+	imageRef, cached := s.conversions.lookup(key, req.ImageName)
+	if cached {
+		logger.Info("Reusing cached OCI image of checkpoint", "checkpointPath", checkpointPath, "image", imageRef)
+	} else {
+		// Convert checkpoint to OCI image using buildah
+		imageRef, err = s.convertCheckpointToOCI(ctx, checkpointPath, req.ContainerName, req.ImageName, req.BaseImage, req.BaseImageId)
+		if err == nil {
+			err = injectFault(faultPointConvert)
+		}
+		if err != nil {
+			logger.Error(err, "Failed to convert checkpoint to OCI image", "checkpointPath", checkpointPath)
+			return &pb.ConvertResponse{
+				Success: false,
+				Error:   fmt.Sprintf("conversion failed: %v", err),
+			}, nil
+		}
+
+		logger.Info("Converted checkpoint to OCI image", "checkpointPath", checkpointPath, "image", imageRef)
+		if info, err := os.Stat(checkpointPath); err == nil {
+			s.conversions.add(key, imageRef, info.Size())
+		}
+	}
+
+	if req.Push {
+		if err := pushImage(imageRef, req.TlsVerify, req.RegistryAuth); err != nil {
+			logger.Error(err, "Failed to push checkpoint image", "image", imageRef)
+			return &pb.ConvertResponse{
+				Success: false,
+				Error:   fmt.Sprintf("push failed: %v", err),
+			}, nil
+		}
+		logger.Info("Pushed checkpoint image", "image", imageRef)
 	}
 
-	log.Printf("Successfully converted checkpoint to OCI image: %s", imageRef)
 	return &pb.ConvertResponse{
 		Success:        true,
 		ImageReference: imageRef,
@@ -189,103 +419,10 @@ func (s *CheckpointServer) Health(_ context.Context, _ *pb.HealthRequest) (*pb.H
 	}, nil
 }
 
-// makeTLSClient creates an HTTP client with TLS configuration for kubelet
-func (s *CheckpointServer) makeTLSClient() (*http.Client, error) {
-	// Try different certificate path combinations
-	certPaths := []struct {
-		cert string
-		key  string
-		ca   string
-		desc string
-	}{
-		// Worker node paths (kubelet auto-generated)
-		{
-			cert: "/var/lib/kubelet/pki/kubelet-client-current.pem",
-			key:  "/var/lib/kubelet/pki/kubelet-client-current.pem",
-			ca:   "/etc/kubernetes/pki/ca.crt",
-			desc: "worker node (kubelet auto-generated)",
-		},
-		// Master node paths (kubeadm generated)
-		{
-			cert: "/etc/kubernetes/pki/apiserver-kubelet-client.crt",
-			key:  "/etc/kubernetes/pki/apiserver-kubelet-client.key",
-			ca:   "/etc/kubernetes/pki/ca.crt",
-			desc: "master node (kubeadm generated)",
-		},
-		// Alternative master node paths
-		{
-			cert: "/etc/kubernetes/pki/apiserver-kubelet-client.crt",
-			key:  "/etc/kubernetes/pki/apiserver-kubelet-client.key",
-			ca:   "/var/lib/kubelet/pki/kubelet.crt",
-			desc: "master node (alternative CA)",
-		},
-	}
-	
-	var cert tls.Certificate
-	var caBytes []byte
-	var err error
-	var workingPaths string
-	
-	// Try each certificate path combination
-	for _, paths := range certPaths {
-		// Check if all required files exist
-		if _, err := os.Stat(paths.cert); os.IsNotExist(err) {
-			log.Printf("Certificate file not found: %s", paths.cert)
-			continue
-		}
-		if _, err := os.Stat(paths.key); os.IsNotExist(err) {
-			log.Printf("Key file not found: %s", paths.key)
-			continue
-		}
-		if _, err := os.Stat(paths.ca); os.IsNotExist(err) {
-			log.Printf("CA file not found: %s", paths.ca)
-			continue
-		}
-		
-		// Try to load the certificate
-		cert, err = tls.LoadX509KeyPair(paths.cert, paths.key)
-		if err != nil {
-			log.Printf("Failed to load certificates from %s/%s (%s): %v", paths.cert, paths.key, paths.desc, err)
-			continue
-		}
-		
-		// Try to load the CA
-		caBytes, err = os.ReadFile(paths.ca)
-		if err != nil {
-			log.Printf("Failed to load CA from %s (%s): %v", paths.ca, paths.desc, err)
-			continue
-		}
-		
-		workingPaths = fmt.Sprintf("%s (cert=%s, key=%s, ca=%s)", paths.desc, paths.cert, paths.key, paths.ca)
-		log.Printf("Successfully loaded certificates: %s", workingPaths)
-		break
-	}
-	
-	if err != nil {
-		return nil, fmt.Errorf("failed to load client certificate from any known location: %w", err)
-	}
-
-	pool := x509.NewCertPool()
-	if !pool.AppendCertsFromPEM(caBytes) {
-		return nil, fmt.Errorf("failed to parse CA certificate from %s", workingPaths)
-	}
-
-	return &http.Client{
-		Timeout: checkpointTimeout,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				Certificates:       []tls.Certificate{cert},
-				RootCAs:            pool,
-				InsecureSkipVerify: true, // Skip verification due to IP SAN issues
-			},
-		},
-	}, nil
-}
-
 // doCheckpointWithBackoff calls kubelet checkpoint API with exponential backoff
 func (s *CheckpointServer) doCheckpointWithBackoff(ctx context.Context, httpClient *http.Client, url string) ([]string, error) {
 	var checkpointFiles []string
-	var lastErr error
+	var lastErr, unavailable error
 
 	bo := wait.Backoff{
 		Steps:    checkpointBackoffSteps,
@@ -293,7 +430,8 @@ func (s *CheckpointServer) doCheckpointWithBackoff(ctx context.Context, httpClie
 		Factor:   checkpointBackoffFactor,
 	}
 
-	err := wait.ExponentialBackoff(bo, func() (bool, error) {
+	// A cancelled request stops waiting for the kubelet and retrying it
+	err := wait.ExponentialBackoffWithContext(ctx, bo, func(ctx context.Context) (bool, error) {
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to create request: %w", err)
@@ -303,19 +441,25 @@ func (s *CheckpointServer) doCheckpointWithBackoff(ctx context.Context, httpClie
 		resp, err := httpClient.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("kubelet request failed: %w", err)
-			log.Printf("Kubelet request failed, retrying: %v", err)
+			log.FromContext(ctx).Error(err, "Kubelet request failed, retrying")
 			return false, nil
 		}
 		defer func() {
 			if err := resp.Body.Close(); err != nil {
-				log.Printf("Failed to close response body: %v", err)
+				log.FromContext(ctx).Error(err, "Failed to close response body")
 			}
 		}()
 
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			data, _ := io.ReadAll(resp.Body)
 			lastErr = fmt.Errorf("kubelet responded %d: %s", resp.StatusCode, string(data))
-			log.Printf("Non-2xx from kubelet, retrying: %s", lastErr)
+			// Retrying cannot help a kubelet without the checkpoint API
+			if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+				if unavailable = kubeletCheckpointError(ctx, s.nodeName); unavailable != nil {
+					return false, unavailable
+				}
+			}
+			log.FromContext(ctx).Info("Kubelet refused the checkpoint, retrying", "status", resp.StatusCode, "response", string(data))
 			return false, nil
 		}
 
@@ -339,10 +483,13 @@ func (s *CheckpointServer) doCheckpointWithBackoff(ctx context.Context, httpClie
 		}
 
 		checkpointFiles = parsed.Items
-		log.Printf("Checkpoint created successfully, files: %v", checkpointFiles)
+		log.FromContext(ctx).Info("Kubelet created checkpoint", "files", checkpointFiles)
 		return true, nil
 	})
 
+	if unavailable != nil {
+		return nil, unavailable
+	}
 	if err != nil {
 		return nil, fmt.Errorf("checkpoint failed after retries: %w", lastErr)
 	}
@@ -350,31 +497,94 @@ func (s *CheckpointServer) doCheckpointWithBackoff(ctx context.Context, httpClie
 	return checkpointFiles, nil
 }
 
-
 func main() {
-	log.Printf("Starting checkpoint agent on node %s", os.Getenv("NODE_NAME"))
+	flag.Parse()
+	setupLogging()
+	agentLog.Info("Starting checkpoint agent", "node", os.Getenv("NODE_NAME"))
+	if err := applyRuntimeLimits(*memoryLimitRatio); err != nil {
+		agentLog.Error(err, "Failed to size the Go runtime to the container's limits")
+		os.Exit(1)
+	}
+	setupFaults()
+	validatePrivileges()
+	builder, err := newImageBuilder(*imageBuilderName)
+	if err != nil {
+		agentLog.Error(err, "Invalid --image-builder")
+		os.Exit(1)
+	}
+	checkpointImageBuilder = builder
+	if artifactLayoutTemplate, err = parseArtifactLayout(*artifactLayout); err != nil {
+		agentLog.Error(err, "Invalid --artifact-layout")
+		os.Exit(1)
+	}
+	if err := setupRedaction(); err != nil {
+		agentLog.Error(err, "Invalid redaction configuration")
+		os.Exit(1)
+	}
+	if len(redactionHooks) > 0 {
+		agentLog.Info("Redacting checkpoint archives", "paths", *redactPaths, "env", *redactEnv, "hook", *redactionHookPath)
+	}
+	go runCapabilityLabeler(os.Getenv("NODE_NAME"))
 
 	// Ensure checkpoint directory exists
 	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
-		log.Fatalf("Failed to create checkpoint directory: %v", err)
+		agentLog.Error(err, "Failed to create checkpoint directory")
+		os.Exit(1)
 	}
 
 	// Create gRPC server
-	lis, err := net.Listen("tcp", port)
+	// An empty host (the default) listens on every IPv4 and IPv6 address.
+	lis, err := net.Listen("tcp", *listenAddress)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		agentLog.Error(err, "Failed to listen", "address", *listenAddress)
+		os.Exit(1)
 	}
 
 	// Configure gRPC server with larger message size
-	s := grpc.NewServer(
+	serverOpts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(maxMessageSize),
 		grpc.MaxSendMsgSize(maxMessageSize),
-	)
+		// Other agents stream transfers over TLS to the same port
+		grpc.Creds(newTransferCredentials()),
+	}
+	serverOpts = append(serverOpts, loggingServerOptions()...)
+	if clientSources, err = parseClientSources(*expectedClientCIDRs); err != nil {
+		agentLog.Error(err, "Invalid --expected-client-cidrs")
+		os.Exit(1)
+	}
+	authorizer, err := newClientAuthorizerFor(os.Getenv("NODE_NAME"))
+	if err != nil {
+		agentLog.Error(err, "Failed to set up client authorization")
+		os.Exit(1)
+	}
+	if authorizer != nil {
+		agentLog.Info("Authorizing clients by their bearer tokens")
+		serverOpts = append(serverOpts, authorizer.serverOptions()...)
+	}
+	s := grpc.NewServer(serverOpts...)
+
+	var checkpointLedger *ledger
+	if *ledgerPath != "" {
+		if checkpointLedger, err = openLedger(*ledgerPath); err != nil {
+			agentLog.Error(err, "Failed to open ledger", "path", *ledgerPath)
+			os.Exit(1)
+		}
+	}
+
+	var conversions *conversionCache
+	if *conversionCacheEntries > 0 {
+		conversions = newConversionCache(*conversionCacheEntries, *conversionCacheBytes)
+	}
+	go serveMetrics(*metricsAddress)
+	if *sharedStorage {
+		go runPartialCopySweeper()
+	}
+	go servePprof(*pprofAddress)
 
 	// Register services
-	checkpointServer := NewCheckpointServer()
+	checkpointServer := NewCheckpointServer(checkpointLedger, conversions)
 	pb.RegisterCheckpointServiceServer(s, checkpointServer)
-	
+
 	// Register health service
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(s, healthServer)
@@ -388,91 +598,124 @@ func main() {
 		sigChan := make(chan os.Signal, 1)
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
-		
-		log.Println("Shutting down checkpoint agent...")
+
+		agentLog.Info("Shutting down checkpoint agent")
 		s.GracefulStop()
 	}()
 
-	log.Printf("Checkpoint agent listening on %s", port)
+	agentLog.Info("Checkpoint agent listening", "address", lis.Addr().String())
 	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+		agentLog.Error(err, "Failed to serve")
+		os.Exit(1)
 	}
 }
 
-// convertCheckpointToOCI converts a checkpoint tar file to OCI image format using buildah
-func (s *CheckpointServer) convertCheckpointToOCI(checkpointPath, containerName, imageName string) (string, error) {
-	log.Printf("Converting checkpoint %s to OCI image %s", checkpointPath, imageName)
-
-	// Common buildah flags to use the mounted container storage
-	buildahFlags := []string{"--root", "/var/lib/containers/storage"}
-
-	// Create a working container from scratch
-	cmd := exec.Command("buildah", append(buildahFlags, "from", "scratch")...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("failed to create working container: %v, output: %s", err, output)
-	}
-	
-	containerID := strings.TrimSpace(string(output))
-	log.Printf("Created working container: %s", containerID)
-
-	// Clean up working container on exit
-	defer func() {
-		cmd := exec.Command("buildah", append(buildahFlags, "rm", containerID)...)
-		if err := cmd.Run(); err != nil {
-			log.Printf("Warning: failed to remove working container %s: %v", containerID, err)
+// convertCheckpointToOCI converts a checkpoint tar file to OCI image format.
+// A non-empty baseImage replaces the image the container is restored on top of.
+func (s *CheckpointServer) convertCheckpointToOCI(ctx context.Context, checkpointPath, containerName, imageName, baseImage, baseImageID string) (string, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Converting checkpoint to OCI image", "checkpointPath", checkpointPath, "image", imageName)
+
+	image := checkpointImage{archive: checkpointPath, container: containerName, name: imageName}
+	// The runtime restores on top of the image config.dump names
+	if baseImage != "" {
+		if baseImageID == "" {
+			return "", fmt.Errorf("failed to rebase checkpoint onto %s: base image ID is required", baseImage)
 		}
-	}()
-
-	// Add checkpoint file to container
-	cmd = exec.Command("buildah", append(buildahFlags, "add", containerID, checkpointPath, "/")...)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to add checkpoint to container: %v", err)
-	}
-
-	// Add checkpoint annotation
-	cmd = exec.Command("buildah", append(buildahFlags, "config", 
-		fmt.Sprintf("--annotation=io.kubernetes.cri-o.annotations.checkpoint.name=%s", containerName), 
-		containerID)...)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to add checkpoint annotation: %v", err)
+		config, err := rebasedConfigDump(checkpointPath, baseImage, baseImageID)
+		if err != nil {
+			return "", fmt.Errorf("failed to rebase checkpoint onto %s: %w", baseImage, err)
+		}
+		image.overrides = map[string][]byte{"/config.dump": config}
 	}
 
-	// Commit the container as an image
-	cmd = exec.Command("buildah", append(buildahFlags, "commit", containerID, imageName)...)
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to commit container as image: %v", err)
+	if err := checkpointImageBuilder.build(image); err != nil {
+		return "", err
 	}
 
-	log.Printf("Successfully created OCI image: %s", imageName)
+	logger.Info("Created OCI image", "image", imageName)
 	return imageName, nil
 }
 
-// copyToSharedStorage copies checkpoint to shared NFS mount
-func (s *CheckpointServer) copyToSharedStorage(podUID, containerName, localPath string) (string, error) {
-	// Simple path: /mnt/checkpoints/<podUID>-<container>-<timestamp>.tar
-	timestamp := time.Now().Format("20060102-150405")
-	filename := fmt.Sprintf("%s-%s-%s.tar", podUID, containerName, timestamp)
-	sharedPath := filepath.Join("/mnt/checkpoints", filename)
-	
-	// Copy file
-	sourceFile, err := os.Open(localPath)
+// resolveCheckpointPath maps an artifact URI to a path on this node.
+func resolveCheckpointPath(uri string) string {
+	if filename, ok := strings.CutPrefix(uri, "shared://"); ok {
+		return filepath.Join(sharedCheckpointDir, filename)
+	}
+	return strings.TrimPrefix(uri, "file://")
+}
+
+// copyToSharedStorage copies the checkpoint of req to shared NFS mount,
+// compressed with req.Compression and readable as access allows, and
+// returns the copy's sha256 digest and compression along with its path. A
+// failed copy is retried with backoff, each attempt resuming where the last
+// one stopped.
+func (s *CheckpointServer) copyToSharedStorage(ctx context.Context, req *pb.CheckpointRequest, access artifactAccess, localPath string) (string, string, string, error) {
+	info, err := os.Stat(localPath)
 	if err != nil {
-		return "", err
+		return "", "", "", err
 	}
-	defer sourceFile.Close()
-	
-	destFile, err := os.Create(sharedPath)
+	// Named by --artifact-layout after the archive's time, and the partial
+	// copy after the archive itself, so copying it again, even after the
+	// agent restarted, resumes the copy
+	name, err := artifactName(req, info.ModTime())
 	if err != nil {
-		return "", err
+		return "", "", "", err
 	}
-	defer destFile.Close()
-	
-	_, err = io.Copy(destFile, sourceFile)
+	filename := name + ".tar"
+	compression := req.Compression
+
+	c, err := compressorFor(compression)
 	if err != nil {
-		return "", err
+		return "", "", "", err
+	}
+	compressed := ""
+	if c != nil {
+		// Compressed on the node first, so a copy can resume
+		started := time.Now()
+		if compressed, err = c.compressArchive(ctx, localPath); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to compress checkpoint archive, copying it uncompressed", "compression", compression)
+		} else {
+			defer os.Remove(compressed)
+			log.FromContext(ctx).Info("Compressed checkpoint archive", "compression", compression, "duration", time.Since(started).String())
+			localPath = compressed
+			filename += c.extension
+		}
+	}
+	if compressed == "" {
+		compression = ""
+	}
+	sharedPath := filepath.Join(sharedCheckpointDir, filename)
+
+	var digest string
+	var lastErr error
+	bo := wait.Backoff{
+		Steps:    copyBackoffSteps,
+		Duration: copyBackoffInitial,
+		Factor:   copyBackoffFactor,
 	}
-	
+	err = wait.ExponentialBackoffWithContext(ctx, bo, func(ctx context.Context) (bool, error) {
+		if lastErr = injectFault(faultPointStorage); lastErr == nil {
+			// The layout's directories are removed once emptied
+			if lastErr = access.mkdirs(filepath.Dir(filename)); lastErr == nil {
+				if digest, lastErr = resumableCopy(ctx, localPath, sharedPath, archiveIdentity(info)); lastErr == nil {
+					lastErr = access.apply(sharedPath)
+				}
+			}
+		}
+		if lastErr != nil {
+			log.FromContext(ctx).Error(lastErr, "Copy to shared storage failed, retrying", "path", sharedPath)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		return "", "", "", err
+	}
+
 	// Return relative path for shared:// URI
-	return filename, destFile.Sync()
+	return filename, digest, compression, nil
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var metricsAddress = flag.String("metrics-address", envOr("AGENT_METRICS_ADDRESS", ":50052"),
+	"Address the agent serves Prometheus metrics on at /metrics. \"0\" disables it.")
+
+// serveMetrics serves the agent's metrics on address until the agent exits.
+func serveMetrics(address string) {
+	if address == "0" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	agentLog.Info("Serving metrics", "address", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		agentLog.Error(err, "Metrics server stopped")
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OCI image-spec media types of the images nativeBuilder writes.
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	ociLayerMediaType    = "application/vnd.oci.image.layer.v1.tar"
+	// ociRefNameAnnotation names an image in an OCI layout's index
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// ociDescriptor is an OCI content descriptor.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest.
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// ociIndex is the index.json of an OCI layout.
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+// ociImageConfig is the part of an OCI image config a checkpoint image has.
+type ociImageConfig struct {
+	Created      time.Time `json:"created"`
+	Architecture string    `json:"architecture"`
+	OS           string    `json:"os"`
+	RootFS       struct {
+		Type    string   `json:"type"`
+		DiffIDs []string `json:"diff_ids"`
+	} `json:"rootfs"`
+}
+
+// nativeBuilder writes checkpoint images itself, as an OCI layout holding
+// the archive as the image's single uncompressed layer, a config and a
+// manifest carrying the checkpoint annotation. Buildah only copies the
+// finished image into the node's container storage, where the runtime
+// restores it from.
+type nativeBuilder struct{}
+
+func (nativeBuilder) build(image checkpointImage) error {
+	dir, err := os.MkdirTemp("", "checkpoint-image-*")
+	if err != nil {
+		return &imageBuildError{step: "create image layout", err: err}
+	}
+	defer os.RemoveAll(dir)
+
+	if err := writeOCILayout(dir, image); err != nil {
+		return &imageBuildError{step: "write image layout", err: err}
+	}
+	// The reference in the layout's index names the image in storage
+	if output, err := runBuildah("pull", "--quiet", "oci:"+dir+":"+image.name); err != nil {
+		return &imageBuildError{step: "copy image to container storage", err: err, output: string(output)}
+	}
+	agentLog.Info("Built checkpoint image natively", "image", image.name)
+	return nil
+}
+
+// writeOCILayout writes image as an OCI image layout in dir.
+func writeOCILayout(dir string, image checkpointImage) error {
+	blobs := filepath.Join(dir, "blobs", "sha256")
+	if err := os.MkdirAll(blobs, 0o755); err != nil {
+		return err
+	}
+
+	layer, err := writeLayerBlob(blobs, image)
+	if err != nil {
+		return fmt.Errorf("failed to write layer: %w", err)
+	}
+
+	config := ociImageConfig{Created: time.Now().UTC(), Architecture: runtime.GOARCH, OS: "linux"}
+	config.RootFS.Type = "layers"
+	// The layer is uncompressed, so its digest is also its diff ID
+	config.RootFS.DiffIDs = []string{layer.Digest}
+	configDesc, err := writeJSONBlob(blobs, ociConfigMediaType, config)
+	if err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	manifestDesc, err := writeJSONBlob(blobs, ociManifestMediaType, ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		Config:        configDesc,
+		Layers:        []ociDescriptor{layer},
+		Annotations:   map[string]string{checkpointAnnotation: image.container},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	manifestDesc.Annotations = map[string]string{ociRefNameAnnotation: image.name}
+
+	index, err := json.Marshal(ociIndex{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.index.v1+json",
+		Manifests:     []ociDescriptor{manifestDesc},
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.json"), index, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644)
+}
+
+// writeLayerBlob writes the contents of the checkpoint archive, with the
+// files of image.overrides replaced, as an uncompressed tar blob in blobs.
+// Compressed archives are decompressed, as buildah add does.
+func writeLayerBlob(blobs string, image checkpointImage) (ociDescriptor, error) {
+	overrides := make(map[string][]byte, len(image.overrides))
+	for name, content := range image.overrides {
+		overrides[strings.TrimPrefix(filepath.Clean(name), "/")] = content
+	}
+
+	return writeBlob(blobs, ociLayerMediaType, func(w io.Writer) error {
+		tw := tar.NewWriter(w)
+		err := walkArchive(image.archive, func(name string, hdr *tar.Header, r io.Reader) error {
+			if _, ok := overrides[name]; ok || name == "." {
+				return nil
+			}
+			hdr.Name = name
+			if hdr.Typeflag == tar.TypeDir {
+				hdr.Name += "/"
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err := io.Copy(tw, r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		for _, name := range slices.Sorted(maps.Keys(overrides)) {
+			content := overrides[name]
+			hdr := &tar.Header{
+				Name:    name,
+				Mode:    0o644,
+				Size:    int64(len(content)),
+				ModTime: time.Now(),
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := tw.Write(content); err != nil {
+				return err
+			}
+		}
+		return tw.Close()
+	})
+}
+
+// writeJSONBlob writes v as a JSON blob in blobs.
+func writeJSONBlob(blobs, mediaType string, v any) (ociDescriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	return writeBlob(blobs, mediaType, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeBlob writes the content write produces as a blob in blobs, named by
+// its digest, and returns its descriptor.
+func writeBlob(blobs, mediaType string, write func(io.Writer) error) (ociDescriptor, error) {
+	tmp, err := os.CreateTemp(blobs, ".blob-*")
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	digester := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(tmp, digester)}
+	if err := write(counter); err != nil {
+		tmp.Close()
+		return ociDescriptor{}, err
+	}
+	if err := tmp.Close(); err != nil {
+		return ociDescriptor{}, err
+	}
+
+	desc := ociDescriptor{MediaType: mediaType, Digest: "sha256:" + hex.EncodeToString(digester.Sum(nil)), Size: counter.n}
+	if err := os.Rename(tmp.Name(), filepath.Join(blobs, strings.TrimPrefix(desc.Digest, "sha256:"))); err != nil {
+		return ociDescriptor{}, err
+	}
+	return desc, nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
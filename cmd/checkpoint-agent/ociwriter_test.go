@@ -0,0 +1,204 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// testArchiveFiles are the files of the checkpoint archives the tests write.
+var testArchiveFiles = map[string]string{
+	"config.dump":                `{"rootfsImageName":"busybox"}`,
+	"spec.dump":                  `{"process":{"env":["A=1"]}}`,
+	"checkpoint/pages-1.img":     "memory pages",
+	"checkpoint/inventory.img":   "inventory",
+	"rootfs-diff.tar":            "rootfs diff",
+	"checkpoint/core-1.img":      "core",
+	"checkpoint/descriptors.img": "descriptors",
+}
+
+// writeTestArchive writes testArchiveFiles as a tar archive to path,
+// gzip-compressed if compress is set.
+func writeTestArchive(path string, compress bool) {
+	f, err := os.Create(path)
+	Expect(err).NotTo(HaveOccurred())
+	defer f.Close()
+
+	var w io.Writer = f
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(f)
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	Expect(tw.WriteHeader(&tar.Header{Name: "checkpoint/", Typeflag: tar.TypeDir, Mode: 0o755})).To(Succeed())
+	for _, name := range slices.Sorted(maps.Keys(testArchiveFiles)) {
+		content := testArchiveFiles[name]
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content))})).To(Succeed())
+		_, err := tw.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	if gz != nil {
+		Expect(gz.Close()).To(Succeed())
+	}
+}
+
+// readBlob reads the blob desc names from blobs and checks it against the
+// descriptor's digest and size.
+func readBlob(blobs string, desc ociDescriptor) []byte {
+	data, err := os.ReadFile(filepath.Join(blobs, strings.TrimPrefix(desc.Digest, "sha256:")))
+	Expect(err).NotTo(HaveOccurred())
+	sum := sha256.Sum256(data)
+	Expect("sha256:" + hex.EncodeToString(sum[:])).To(Equal(desc.Digest))
+	Expect(int64(len(data))).To(Equal(desc.Size))
+	return data
+}
+
+// layerFiles returns the regular files of a layer by name, and the names of
+// its directories.
+func layerFiles(layer []byte) (map[string]string, []string) {
+	files := map[string]string{}
+	var dirs []string
+	tr := tar.NewReader(bytes.NewReader(layer))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, dirs
+		}
+		Expect(err).NotTo(HaveOccurred())
+		if hdr.Typeflag == tar.TypeDir {
+			dirs = append(dirs, hdr.Name)
+			continue
+		}
+		Expect(files).NotTo(HaveKey(hdr.Name), "duplicate layer entry %s", hdr.Name)
+		content, err := io.ReadAll(tr)
+		Expect(err).NotTo(HaveOccurred())
+		files[hdr.Name] = string(content)
+	}
+}
+
+var _ = Describe("writeLayerBlob", func() {
+	var dir, blobs string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		blobs = filepath.Join(dir, "blobs")
+		Expect(os.Mkdir(blobs, 0o755)).To(Succeed())
+	})
+
+	It("writes the archive's files as a blob named by its digest", func() {
+		archive := filepath.Join(dir, "checkpoint.tar")
+		writeTestArchive(archive, false)
+
+		desc, err := writeLayerBlob(blobs, checkpointImage{archive: archive})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desc.MediaType).To(Equal(ociLayerMediaType))
+
+		files, dirs := layerFiles(readBlob(blobs, desc))
+		Expect(files).To(Equal(testArchiveFiles))
+		Expect(dirs).To(ConsistOf("checkpoint/"))
+	})
+
+	It("replaces the archive's files with the overrides", func() {
+		archive := filepath.Join(dir, "checkpoint.tar")
+		writeTestArchive(archive, false)
+
+		desc, err := writeLayerBlob(blobs, checkpointImage{archive: archive, overrides: map[string][]byte{
+			"/config.dump":         []byte(`{"rootfsImageName":"busybox:rebased"}`),
+			"checkpoint/extra.img": []byte("extra"),
+		}})
+		Expect(err).NotTo(HaveOccurred())
+
+		files, _ := layerFiles(readBlob(blobs, desc))
+		Expect(files).To(HaveKeyWithValue("config.dump", `{"rootfsImageName":"busybox:rebased"}`))
+		Expect(files).To(HaveKeyWithValue("checkpoint/extra.img", "extra"))
+		Expect(files).To(HaveKeyWithValue("spec.dump", testArchiveFiles["spec.dump"]))
+		Expect(files).To(HaveLen(len(testArchiveFiles) + 1))
+	})
+
+	It("writes the same files for a compressed archive", func() {
+		archive := filepath.Join(dir, "checkpoint.tar.gz")
+		writeTestArchive(archive, true)
+
+		desc, err := writeLayerBlob(blobs, checkpointImage{archive: archive})
+		Expect(err).NotTo(HaveOccurred())
+
+		files, _ := layerFiles(readBlob(blobs, desc))
+		Expect(files).To(Equal(testArchiveFiles))
+	})
+
+	It("fails on an archive truncated within a file", func() {
+		archive := filepath.Join(dir, "checkpoint.tar")
+		writeTestArchive(archive, false)
+		// Past the directory's header and the first file's header, inside
+		// its content
+		Expect(os.Truncate(archive, 2*512+2)).To(Succeed())
+
+		_, err := writeLayerBlob(blobs, checkpointImage{archive: archive})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("writeOCILayout", func() {
+	It("writes an image whose manifest, config and layer reference each other", func() {
+		dir := GinkgoT().TempDir()
+		archive := filepath.Join(dir, "checkpoint.tar")
+		writeTestArchive(archive, false)
+		layout := filepath.Join(dir, "layout")
+
+		Expect(writeOCILayout(layout, checkpointImage{
+			archive:   archive,
+			container: "app",
+			name:      "localhost/checkpoint/default/web:app",
+			overrides: map[string][]byte{"config.dump": []byte("{}")},
+		})).To(Succeed())
+		blobs := filepath.Join(layout, "blobs", "sha256")
+
+		data, err := os.ReadFile(filepath.Join(layout, "oci-layout"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"imageLayoutVersion":"1.0.0"}`))
+
+		var index ociIndex
+		data, err = os.ReadFile(filepath.Join(layout, "index.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(data, &index)).To(Succeed())
+		Expect(index.Manifests).To(HaveLen(1))
+		Expect(index.Manifests[0].MediaType).To(Equal(ociManifestMediaType))
+		Expect(index.Manifests[0].Annotations).To(HaveKeyWithValue(ociRefNameAnnotation, "localhost/checkpoint/default/web:app"))
+
+		var manifest ociManifest
+		Expect(json.Unmarshal(readBlob(blobs, index.Manifests[0]), &manifest)).To(Succeed())
+		Expect(manifest.SchemaVersion).To(Equal(2))
+		Expect(manifest.Annotations).To(HaveKeyWithValue(checkpointAnnotation, "app"))
+		Expect(manifest.Config.MediaType).To(Equal(ociConfigMediaType))
+		Expect(manifest.Layers).To(HaveLen(1))
+
+		var config ociImageConfig
+		Expect(json.Unmarshal(readBlob(blobs, manifest.Config), &config)).To(Succeed())
+		Expect(config.OS).To(Equal("linux"))
+		Expect(config.RootFS.Type).To(Equal("layers"))
+		// The layer is uncompressed: its diff ID is its digest
+		Expect(config.RootFS.DiffIDs).To(Equal([]string{manifest.Layers[0].Digest}))
+
+		files, _ := layerFiles(readBlob(blobs, manifest.Layers[0]))
+		Expect(files).To(HaveKeyWithValue("config.dump", "{}"))
+		Expect(files).To(HaveKeyWithValue("checkpoint/pages-1.img", "memory pages"))
+
+		entries, err := os.ReadDir(blobs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3), "only the manifest, config and layer blobs are left")
+	})
+})
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// PreflightContainers reports the user namespace setup of running containers
+// and what CRIU cannot handle in them: unsupported user namespace setups,
+// open device files, connections to sockets outside the container, exec
+// sessions, and sockets bound to addresses the restored pod will not get
+// back. For host network pods it lists the sockets each container holds
+// instead.
+func (s *CheckpointServer) PreflightContainers(ctx context.Context, req *pb.PreflightContainersRequest) (*pb.PreflightContainersResponse, error) {
+	log.FromContext(ctx).Info("Preflight request", "containers", req.ContainerIds, "unstableAddresses", req.UnstableAddresses, "hostNetwork", req.HostNetwork)
+
+	resp := &pb.PreflightContainersResponse{}
+	netnsPid := 0
+	for _, id := range req.ContainerIds {
+		containerID := trimContainerID(id)
+		preflight, err := preflightContainer(containerID, req.HostNetwork)
+		if err != nil {
+			return &pb.PreflightContainersResponse{Error: fmt.Sprintf("container %s: %v", containerID, err)}, nil
+		}
+		resp.Containers = append(resp.Containers, preflight)
+		// Containers of a pod share its network namespace
+		if state, err := readContainerState(containerID); err == nil && netnsPid == 0 {
+			netnsPid = state.Pid
+		}
+	}
+
+	// The node's network namespace holds the sockets of every host process
+	if len(req.UnstableAddresses) > 0 && netnsPid != 0 && !req.HostNetwork {
+		bound, err := socketsBoundTo(netnsPid, req.UnstableAddresses)
+		if err != nil {
+			return &pb.PreflightContainersResponse{Error: err.Error()}, nil
+		}
+		resp.Unsupported = append(resp.Unsupported, bound...)
+	}
+	resp.Success = true
+	return resp, nil
+}
+
+func preflightContainer(containerID string, hostNetwork bool) (*pb.ContainerPreflight, error) {
+	state, err := readContainerState(containerID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Pid == 0 {
+		return nil, fmt.Errorf("container is not running")
+	}
+
+	preflight := &pb.ContainerPreflight{ContainerId: containerID}
+	if preflight.UidMappings, err = readIDMappings(state.Pid, "uid_map"); err != nil {
+		return nil, err
+	}
+	if preflight.GidMappings, err = readIDMappings(state.Pid, "gid_map"); err != nil {
+		return nil, err
+	}
+	preflight.UserNamespace = !isIdentityMapping(preflight.UidMappings)
+	preflight.TimeNamespace = inOwnTimeNamespace(state.Pid)
+	preflight.SelinuxLabel, preflight.ApparmorProfile = processLSMLabels(state.Pid)
+
+	// The container's init is a child of conmon; a conmon outside the initial
+	// user namespace means CRI-O itself runs rootless.
+	if ppid, err := parentPid(state.Pid); err == nil && ppid > 1 {
+		if runtimeMappings, err := readIDMappings(ppid, "uid_map"); err == nil {
+			preflight.RootlessRuntime = !isIdentityMapping(runtimeMappings)
+		}
+	}
+
+	if preflight.UserNamespace && preflight.RootlessRuntime {
+		preflight.Unsupported = append(preflight.Unsupported,
+			"user namespace under a rootless runtime: CRIU can only restore user namespaces as root in the initial user namespace")
+	}
+
+	// CRIU restores a single user namespace per container; processes that
+	// unshared another one cannot be restored.
+	initNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/user", state.Pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read user namespace of pid %d: %w", state.Pid, err)
+	}
+	hugepages := map[int64]int64{}
+	if cgroupDir, err := containerCgroupDir(state.Pid); err == nil {
+		pids, err := cgroupPids(cgroupDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, pid := range pids {
+			ns, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/user", pid))
+			if err != nil {
+				continue // exited
+			}
+			if ns != initNS {
+				preflight.Unsupported = append(preflight.Unsupported,
+					fmt.Sprintf("process %d runs in a nested user namespace", pid))
+			}
+			for _, device := range openDevices(pid) {
+				preflight.Unsupported = append(preflight.Unsupported,
+					fmt.Sprintf("process %d has device %s open; device state cannot be checkpointed", pid, device))
+			}
+			hugetlb, unsupported, err := sharedMemoryUsage(pid)
+			if err != nil {
+				continue // exited
+			}
+			for pageSize, bytes := range hugetlb {
+				hugepages[pageSize] += bytes
+			}
+			preflight.Unsupported = append(preflight.Unsupported, unsupported...)
+		}
+		if preflight.ExternalResources, err = externalUnixSockets(pids); err != nil {
+			return nil, err
+		}
+		preflight.ExecSessions = execSessions(state.Pid, pids)
+		if hostNetwork {
+			if preflight.HostSockets, err = hostSockets(pids); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for pageSize, bytes := range hugepages {
+		preflight.Hugepages = append(preflight.Hugepages, &pb.HugePageUsage{PageSizeBytes: pageSize, Bytes: bytes})
+	}
+
+	return preflight, nil
+}
+
+// containerDevices are the device files every container gets, which CRIU
+// reopens on restore. Anything else under /dev was injected by a device
+// plugin or a privileged spec.
+var containerDevices = []string{
+	"/dev/null", "/dev/zero", "/dev/full", "/dev/random", "/dev/urandom",
+	"/dev/tty", "/dev/console", "/dev/ptmx", "/dev/net/tun", "/dev/fuse",
+	"/dev/pts/", "/dev/shm/", "/dev/mqueue/",
+}
+
+// openDevices lists the non-standard device files pid holds open.
+func openDevices(pid int) []string {
+	var devices []string
+	for _, target := range openFiles(pid) {
+		if !strings.HasPrefix(target, "/dev/") {
+			continue
+		}
+		standard := slices.ContainsFunc(containerDevices, func(device string) bool {
+			return target == device || (strings.HasSuffix(device, "/") && strings.HasPrefix(target, device))
+		})
+		if !standard && !slices.Contains(devices, target) {
+			devices = append(devices, target)
+		}
+	}
+	return devices
+}
+
+// openFiles returns the paths of the files pid holds open.
+func openFiles(pid int) []string {
+	fdDir := fmt.Sprintf("/proc/%d/fd", pid)
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil // exited
+	}
+	var files []string
+	for _, entry := range entries {
+		if target, err := os.Readlink(filepath.Join(fdDir, entry.Name())); err == nil {
+			files = append(files, target)
+		}
+	}
+	return files
+}
+
+// sharedMemoryUsage returns the hugetlb memory pid maps, per page size, and
+// the shared memory it uses that the checkpoint would not contain: SysV
+// segments live in the pod's IPC namespace and POSIX segments on the pod's
+// /dev/shm, both owned by the pod sandbox rather than the container. CRIU
+// dumps hugetlb mappings like other memory, but the target node must reserve
+// the pages.
+func sharedMemoryUsage(pid int) (map[int64]int64, []string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/smaps", pid))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	hugetlb := map[int64]int64{}
+	var unsupported []string
+	seen := map[string]bool{}
+	report := func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		if strings.HasPrefix(path, "/SYSV") {
+			unsupported = append(unsupported, fmt.Sprintf(
+				"process %d maps SysV shared memory segment %s; the pod's IPC namespace is not part of the checkpoint",
+				pid, strings.TrimSuffix(path, " (deleted)")))
+		} else {
+			unsupported = append(unsupported, fmt.Sprintf(
+				"process %d uses POSIX shared memory %s; /dev/shm is not part of the checkpoint", pid, path))
+		}
+	}
+
+	var path string
+	var pageSize, hugetlbBytes int64
+	flush := func() {
+		if hugetlbBytes > 0 && pageSize > 0 {
+			hugetlb[pageSize] += hugetlbBytes
+		}
+		hugetlbBytes = 0
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if !strings.HasSuffix(fields[0], ":") {
+			// Mapping header: address perms offset dev inode [path]
+			flush()
+			path = ""
+			if len(fields) >= 6 {
+				path = strings.Join(fields[5:], " ")
+			}
+			if strings.HasPrefix(path, "/SYSV") || strings.HasPrefix(path, "/dev/shm/") {
+				report(path)
+			}
+			continue
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "KernelPageSize:":
+			pageSize = value * 1024
+		case "Shared_Hugetlb:", "Private_Hugetlb:":
+			hugetlbBytes += value * 1024
+		}
+	}
+	flush()
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for _, file := range openFiles(pid) {
+		if strings.HasPrefix(file, "/dev/shm/") {
+			report(file)
+		}
+	}
+	return hugetlb, unsupported, nil
+}
+
+// readIDMappings parses /proc/<pid>/uid_map or gid_map.
+func readIDMappings(pid int, file string) ([]*pb.IDMapping, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/%s", pid, file))
+	if err != nil {
+		return nil, err
+	}
+	var mappings []*pb.IDMapping
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		var values [3]uint64
+		for i, field := range fields {
+			if values[i], err = strconv.ParseUint(field, 10, 32); err != nil {
+				return nil, fmt.Errorf("invalid %s line %q", file, line)
+			}
+		}
+		mappings = append(mappings, &pb.IDMapping{
+			ContainerId: uint32(values[0]),
+			HostId:      uint32(values[1]),
+			Size:        uint32(values[2]),
+		})
+	}
+	return mappings, nil
+}
+
+// isIdentityMapping reports whether mappings are those of the initial user
+// namespace.
+func isIdentityMapping(mappings []*pb.IDMapping) bool {
+	return len(mappings) == 1 && mappings[0].ContainerId == 0 && mappings[0].HostId == 0 &&
+		mappings[0].Size == 4294967295
+}
+
+// socketsBoundTo lists the sockets in pid's network namespace whose local
+// address is one of addresses. The restored pod's interfaces are recreated by
+// CNI, so CRIU can only rebind such sockets if the address comes back.
+func socketsBoundTo(pid int, addresses []string) ([]string, error) {
+	wanted := make(map[string]bool, len(addresses))
+	for _, addr := range addresses {
+		if ip := net.ParseIP(addr); ip != nil {
+			wanted[ip.String()] = true
+		}
+	}
+
+	var bound []string
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/net/%s", pid, proto))
+		if os.IsNotExist(err) {
+			continue // protocol not enabled
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sockets of pid %d: %w", pid, err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		for _, line := range lines[1:] {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			host, port, ok := strings.Cut(fields[1], ":")
+			if !ok {
+				continue
+			}
+			ip := parseProcNetIP(host)
+			if ip == nil || !wanted[ip.String()] {
+				continue
+			}
+			portNum, _ := strconv.ParseUint(port, 16, 16)
+			bound = append(bound, fmt.Sprintf("%s socket bound to %s, which the restored pod will not get back",
+				strings.TrimSuffix(proto, "6"), net.JoinHostPort(ip.String(), strconv.FormatUint(portNum, 10))))
+		}
+	}
+	return bound, nil
+}
+
+// TCP states from include/net/tcp_states.h; connected UDP sockets report
+// ESTABLISHED too.
+const (
+	tcpEstablished = "01"
+	tcpListen      = "0A"
+)
+
+// hostSockets lists the TCP and UDP sockets pids hold in the node's network
+// namespace, skipping those of the other host processes.
+func hostSockets(pids []int) ([]*pb.HostSocket, error) {
+	if len(pids) == 0 {
+		return nil, nil
+	}
+	owned := socketInodes(pids)
+	var sockets []*pb.HostSocket
+	for _, proto := range []string{"tcp", "tcp6", "udp", "udp6"} {
+		data, err := os.ReadFile(fmt.Sprintf("/proc/%d/net/%s", pids[0], proto))
+		if os.IsNotExist(err) {
+			continue // protocol not enabled
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sockets of pid %d: %w", pids[0], err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		for _, line := range lines[1:] {
+			// sl local_address rem_address st tx:rx tr:when retrnsmt uid timeout inode
+			fields := strings.Fields(line)
+			if len(fields) < 10 {
+				continue
+			}
+			inode, err := strconv.ParseUint(fields[9], 10, 64)
+			if err != nil || !owned[inode] {
+				continue
+			}
+			socket := &pb.HostSocket{
+				Protocol:     strings.TrimSuffix(proto, "6"),
+				LocalAddress: procNetAddress(fields[1]),
+				Listening:    fields[3] == tcpListen || (strings.HasPrefix(proto, "udp") && fields[3] != tcpEstablished),
+			}
+			if fields[3] == tcpEstablished {
+				socket.RemoteAddress = procNetAddress(fields[2])
+			}
+			sockets = append(sockets, socket)
+		}
+	}
+	return sockets, nil
+}
+
+// procNetAddress decodes an ip:port pair from /proc/net/{tcp,udp}[6].
+func procNetAddress(s string) string {
+	host, port, ok := strings.Cut(s, ":")
+	if !ok {
+		return s
+	}
+	ip := parseProcNetIP(host)
+	if ip == nil {
+		return s
+	}
+	portNum, _ := strconv.ParseUint(port, 16, 16)
+	return net.JoinHostPort(ip.String(), strconv.FormatUint(portNum, 10))
+}
+
+// parseProcNetIP decodes an address from /proc/net/{tcp,udp}[6]: the hex
+// encoding of the address as 32-bit words in host byte order.
+func parseProcNetIP(s string) net.IP {
+	raw, err := hex.DecodeString(s)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		word := binary.NativeEndian.Uint32(raw[i : i+4])
+		binary.BigEndian.PutUint32(ip[i:i+4], word)
+	}
+	return ip
+}
+
+func parentPid(pid int) (int, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "PPid:"); ok {
+			return strconv.Atoi(strings.TrimSpace(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("no PPid in /proc/%d/status", pid)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// The agent's privileged operations are confined to this file: reading the
+// kubelet client credentials and running buildah against the node's
+// container storage. Everything else only touches the checkpoint directories.
+
+var leastPrivilege = flag.Bool("least-privilege", os.Getenv("AGENT_LEAST_PRIVILEGE") == "true",
+	"Refuse to start unless every privilege the agent needs is granted, instead of only warning. "+
+		"Use with a non-privileged container and a read-only root filesystem.")
+
+// Linux capability numbers checked at startup.
+const (
+	capSysPtrace = 19
+	capSysAdmin  = 21
+)
+
+// kubeletCredentialPaths are tried in order for the kubelet client
+// certificate.
+var kubeletCredentialPaths = []struct {
+	cert string
+	key  string
+	desc string
+}{
+	// Worker node paths (kubelet auto-generated)
+	{
+		cert: "/var/lib/kubelet/pki/kubelet-client-current.pem",
+		key:  "/var/lib/kubelet/pki/kubelet-client-current.pem",
+		desc: "worker node (kubelet auto-generated)",
+	},
+	// Master node paths (kubeadm generated)
+	{
+		cert: "/etc/kubernetes/pki/apiserver-kubelet-client.crt",
+		key:  "/etc/kubernetes/pki/apiserver-kubelet-client.key",
+		desc: "master node (kubeadm generated)",
+	},
+}
+
+// loadKubeletCredentials loads the first usable kubelet client certificate.
+func loadKubeletCredentials() (tls.Certificate, error) {
+	var errs []error
+	for _, paths := range kubeletCredentialPaths {
+		cert, err := tls.LoadX509KeyPair(paths.cert, paths.key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", paths.desc, err))
+			continue
+		}
+		agentLog.Info("Loaded kubelet client certificate", "source", paths.desc, "cert", paths.cert, "key", paths.key)
+		return cert, nil
+	}
+	return tls.Certificate{}, fmt.Errorf("failed to load client certificate from any known location: %w", errors.Join(errs...))
+}
+
+// buildahSubcommands are the only buildah operations the agent performs.
+var buildahSubcommands = map[string]bool{
+	"from": true, "add": true, "config": true, "commit": true, "rm": true, "push": true, "pull": true,
+	"inspect": true, "mount": true, "tag": true, "rmi": true,
+}
+
+// runBuildah runs one buildah subcommand against the node's container
+// storage and returns its combined output.
+func runBuildah(subcommand string, args ...string) ([]byte, error) {
+	if !buildahSubcommands[subcommand] {
+		return nil, fmt.Errorf("buildah %s is not permitted", subcommand)
+	}
+	cmd := exec.Command("buildah", append([]string{"--root", containerStorageRoot, subcommand}, args...)...)
+	return cmd.CombinedOutput()
+}
+
+// privilegeCheck is one thing the agent needs from its pod spec.
+type privilegeCheck struct {
+	name string
+	// neededFor names the RPCs that fail without it.
+	neededFor string
+	// optional checks only degrade a feature and never stop the agent.
+	optional bool
+	// hint tells the operator how to grant it.
+	hint  string
+	check func() error
+}
+
+var privilegeChecks = []privilegeCheck{
+	{
+		name:      "kubelet credentials",
+		neededFor: "Checkpoint",
+		hint:      "mount /var/lib/kubelet/pki and /etc/kubernetes/pki read-only, or set --kubelet-auth to token or kubeconfig",
+		check:     checkKubeletAuth,
+	},
+	{
+		name:      "kubelet checkpoint directory " + checkpointDir,
+		neededFor: "Checkpoint",
+		hint:      "mount the host's " + checkpointDir,
+		check:     func() error { return checkReadableDir(checkpointDir) },
+	},
+	{
+		name:      "shared checkpoint storage /mnt/checkpoints",
+		neededFor: "Checkpoint, EstimateMigration",
+		hint:      "mount the checkpoint-repo PVC read-write at /mnt/checkpoints",
+		check: func() error {
+			if !*sharedStorage {
+				return nil
+			}
+			return checkWritableDir("/mnt/checkpoints")
+		},
+	},
+	{
+		name:      "container storage " + containerStorageRoot,
+		neededFor: "ConvertCheckpointToImage, PullImage, VerifyRestore",
+		hint:      "mount the host's " + containerStorageRoot + " read-write",
+		check:     func() error { return checkWritableDir(containerStorageRoot) },
+	},
+	{
+		name:      "buildah binary",
+		neededFor: "ConvertCheckpointToImage, PullImage",
+		hint:      "use the agent image, which ships buildah",
+		check: func() error {
+			_, err := exec.LookPath("buildah")
+			return err
+		},
+	},
+	{
+		name:      "writable temporary directory",
+		neededFor: "ConvertCheckpointToImage",
+		hint:      "with a read-only root filesystem, mount an emptyDir and point TMPDIR at it",
+		check:     func() error { return checkWritableDir(os.TempDir()) },
+	},
+	{
+		name:      "CAP_SYS_ADMIN",
+		neededFor: "ConvertCheckpointToImage",
+		hint:      "add SYS_ADMIN to the container's capabilities (buildah mounts overlay layers)",
+		check:     func() error { return checkCapability(capSysAdmin) },
+	},
+	{
+		name:      "CAP_SYS_PTRACE",
+		neededFor: "EstimateMigration",
+		optional:  true,
+		hint:      "add SYS_PTRACE and run with hostPID to sample workload memory",
+		check:     func() error { return checkCapability(capSysPtrace) },
+	},
+}
+
+// validatePrivileges checks every privilege the agent needs and reports each
+// missing one explicitly. In least-privilege mode a missing required
+// privilege stops the agent; otherwise it is logged and the affected RPCs
+// fail when called.
+func validatePrivileges() {
+	var missing []string
+	for _, p := range privilegeChecks {
+		err := p.check()
+		if err == nil {
+			continue
+		}
+		if p.optional || !*leastPrivilege {
+			agentLog.Error(err, "Missing privilege", "privilege", p.name, "neededFor", p.neededFor, "hint", p.hint)
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("missing %s (needed for %s): %v; %s", p.name, p.neededFor, err, p.hint))
+	}
+	if len(missing) > 0 {
+		agentLog.Error(nil, "Agent is missing required privileges", "missing", missing)
+		os.Exit(1)
+	}
+}
+
+func checkReadableDir(dir string) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Readdirnames(1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func checkWritableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".lpm-write-check-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// checkCapability reports whether the agent's effective capability set
+// contains capability.
+func checkCapability(capability uint) error {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "CapEff:")
+		if !ok {
+			continue
+		}
+		caps, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse CapEff: %w", err)
+		}
+		if caps&(1<<capability) == 0 {
+			return fmt.Errorf("capability not in effective set")
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("CapEff not found in /proc/self/status")
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	defaultProbeTimeout = 3 * time.Second
+	maxProbeTimeout     = 30 * time.Second
+)
+
+// ProbeConnectivity opens a TCP connection to each address from this node and
+// closes it again.
+func (s *CheckpointServer) ProbeConnectivity(ctx context.Context, req *pb.ProbeConnectivityRequest) (*pb.ProbeConnectivityResponse, error) {
+	log.FromContext(ctx).Info("Probe request", "addresses", req.Addresses)
+
+	timeout := min(time.Duration(req.TimeoutMs)*time.Millisecond, maxProbeTimeout)
+	if timeout == 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	resp := &pb.ProbeConnectivityResponse{Success: true}
+	dialer := net.Dialer{Timeout: timeout}
+	for _, address := range req.Addresses {
+		result := &pb.ProbeResult{Address: address}
+		start := time.Now()
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Reachable = true
+			result.LatencyMs = time.Since(start).Milliseconds()
+			conn.Close()
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+
+	"my.domain/guestbook/internal/runtimelimits"
+)
+
+var (
+	pprofAddress = flag.String("pprof-address", envOr("AGENT_PPROF_ADDRESS", "0"),
+		"Address the agent serves the pprof endpoints on at /debug/pprof/, e.g. 127.0.0.1:50053. \"0\" disables it. "+
+			"Profiles reveal the agent's memory, including checkpoint contents: reach them by port-forwarding, never expose them.")
+	memoryLimitRatio = flag.Float64("memory-limit-ratio", envFloat("AGENT_MEMORY_LIMIT_RATIO", 0.9),
+		"Fraction of the container's memory limit the Go runtime's soft memory limit is set to, unless GOMEMLIMIT is set. "+
+			"0 leaves it unset. GOMAXPROCS is likewise set to the container's CPU limit unless set.")
+)
+
+// envFloat returns the environment variable key as a float, or def if it
+// is unset or invalid.
+func envFloat(key string, def float64) float64 {
+	f, err := strconv.ParseFloat(envOr(key, strconv.FormatFloat(def, 'g', -1, 64)), 64)
+	if err != nil {
+		agentLog.Error(err, "Ignoring invalid environment variable", "name", key)
+		return def
+	}
+	return f
+}
+
+// applyRuntimeLimits sizes the Go runtime to the agent container's CPU and
+// memory limits, so a large copy or conversion is collected before the
+// container is OOM-killed.
+func applyRuntimeLimits(ratio float64) error {
+	limits, err := runtimelimits.Apply(ratio)
+	if err != nil {
+		return err
+	}
+	if limits.GOMAXPROCS != 0 || limits.MemoryLimit != 0 {
+		agentLog.Info("Sized the Go runtime to the container's limits",
+			"GOMAXPROCS", limits.GOMAXPROCS, "memoryLimit", limits.MemoryLimit)
+	}
+	return nil
+}
+
+// servePprof serves the runtime profiles on address until the agent exits.
+func servePprof(address string) {
+	if address == "0" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	agentLog.Info("Serving pprof", "address", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		agentLog.Error(err, "pprof server stopped")
+	}
+}
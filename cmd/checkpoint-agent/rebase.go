@@ -0,0 +1,265 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// maxSymlinkHops bounds symlink resolution inside an image's root, as the
+// kernel does.
+const maxSymlinkHops = 40
+
+// CheckRebase compares the files the checkpointed processes have open or
+// mapped from the container's root filesystem with req.BaseImage. CRIU
+// refuses to restore a process whose file changed size; files the container
+// wrote itself are restored from the checkpoint and not compared.
+func (s *CheckpointServer) CheckRebase(ctx context.Context, req *pb.CheckRebaseRequest) (*pb.CheckRebaseResponse, error) {
+	logger := log.FromContext(ctx).WithValues("checkpointPath", req.CheckpointPath, "baseImage", req.BaseImage)
+	logger.Info("Check rebase request")
+
+	if req.CheckpointPath == "" || req.BaseImage == "" {
+		return &pb.CheckRebaseResponse{Error: "checkpoint path and base image are required"}, nil
+	}
+	archivePath, err := artifactPath(req.CheckpointPath)
+	if err != nil {
+		return &pb.CheckRebaseResponse{Error: err.Error()}, nil
+	}
+
+	config, files, err := rootfsFiles(archivePath)
+	if err != nil {
+		return &pb.CheckRebaseResponse{Error: fmt.Sprintf("failed to read checkpoint: %v", err)}, nil
+	}
+
+	resp := &pb.CheckRebaseResponse{OriginalImage: config.RootfsImageName, CheckedFiles: int32(len(files))}
+	err = withImageRoot(req.BaseImage, func(root, imageID string) error {
+		resp.BaseImageId = imageID
+		for _, name := range slices.Sorted(maps.Keys(files)) {
+			info, err := statInRoot(root, name)
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				resp.IncompatibleFiles = append(resp.IncompatibleFiles, name+": missing")
+			case err != nil:
+				return fmt.Errorf("failed to stat %s: %w", name, err)
+			case !info.Mode().IsRegular():
+				resp.IncompatibleFiles = append(resp.IncompatibleFiles, name+": not a regular file")
+			case info.Size() != files[name]:
+				resp.IncompatibleFiles = append(resp.IncompatibleFiles,
+					fmt.Sprintf("%s: %d bytes when checkpointed, %d bytes in the base image", name, files[name], info.Size()))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "Failed to check rebase")
+		return &pb.CheckRebaseResponse{Error: fmt.Sprintf("failed to check base image: %v", err)}, nil
+	}
+
+	logger.Info("Checked rebase", "baseImageID", resp.BaseImageId, "checkedFiles", resp.CheckedFiles, "incompatibleFiles", len(resp.IncompatibleFiles))
+	resp.Success = true
+	return resp, nil
+}
+
+// rootfsFiles returns the config.dump of a checkpoint archive and the sizes
+// of the regular files its processes use from the root mount, leaving out
+// those in rootfs-diff.tar.
+func rootfsFiles(archivePath string) (containerConfigDump, map[string]int64, error) {
+	var config containerConfigDump
+	var filesImage []byte
+	var mountImages [][]byte
+	written := map[string]bool{}
+
+	err := walkArchive(archivePath, func(name string, hdr *tar.Header, r io.Reader) error {
+		switch {
+		case name == "config.dump":
+			if err := json.NewDecoder(r).Decode(&config); err != nil {
+				return fmt.Errorf("failed to parse config.dump: %w", err)
+			}
+		case name == "rootfs-diff.tar":
+			tr := tar.NewReader(r)
+			for {
+				hdr, err := tr.Next()
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("failed to read rootfs-diff.tar: %w", err)
+				}
+				written[path.Clean("/"+hdr.Name)] = true
+			}
+		case name == "checkpoint/files.img" || strings.HasPrefix(name, "checkpoint/mountpoints-"):
+			if hdr.Size > maxInspectedImageSize {
+				return fmt.Errorf("%s is too large to inspect (%d bytes)", name, hdr.Size)
+			}
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			if name == "checkpoint/files.img" {
+				filesImage = data
+			} else {
+				mountImages = append(mountImages, data)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return config, nil, err
+	}
+	if filesImage == nil || len(mountImages) == 0 {
+		return config, nil, fmt.Errorf("archive has no CRIU file or mount images")
+	}
+
+	rootMounts := map[uint64]bool{}
+	for _, image := range mountImages {
+		err := forEachCriuEntry(image, func(entry []byte) error {
+			var id uint64
+			var mountpoint string
+			err := decodeFields(entry, func(num protowire.Number, v uint64, b []byte) {
+				switch num {
+				case 2: // mnt_entry.mnt_id
+					id = v
+				case 7: // mnt_entry.mountpoint
+					mountpoint = string(b)
+				}
+			})
+			if mountpoint == "/" {
+				rootMounts[id] = true
+			}
+			return err
+		})
+		if err != nil {
+			return config, nil, fmt.Errorf("failed to decode mount image: %w", err)
+		}
+	}
+
+	files := map[string]int64{}
+	err = forEachCriuEntry(filesImage, func(entry []byte) error {
+		return decodeFields(entry, func(num protowire.Number, _ uint64, b []byte) {
+			if num != 3 { // file_entry.reg
+				return
+			}
+			var name string
+			var mountID uint64
+			var size int64 = -1
+			_ = decodeFields(b, func(num protowire.Number, v uint64, b []byte) {
+				switch num {
+				case 6: // reg_file_entry.name
+					name = string(b)
+				case 7: // reg_file_entry.mnt_id
+					mountID = v
+				case 8: // reg_file_entry.size
+					size = int64(v)
+				}
+			})
+			name = path.Clean("/" + name)
+			if rootMounts[mountID] && size >= 0 && !written[name] {
+				files[name] = size
+			}
+		})
+	})
+	if err != nil {
+		return config, nil, fmt.Errorf("failed to decode files.img: %w", err)
+	}
+	return config, files, nil
+}
+
+// withImageRoot pulls image if the node lacks it, mounts it and calls fn
+// with its root directory and image ID.
+func withImageRoot(image string, fn func(root, imageID string) error) error {
+	output, err := runBuildah("from", "--pull=missing", image)
+	if err != nil {
+		return fmt.Errorf("failed to create working container: %v, output: %s", err, output)
+	}
+	containerID := strings.TrimSpace(string(output))
+	defer func() {
+		if _, err := runBuildah("rm", containerID); err != nil {
+			agentLog.Error(err, "Failed to remove working container", "container", containerID)
+		}
+	}()
+
+	output, err = runBuildah("inspect", "--format", "{{.FromImageID}}", containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %v, output: %s", image, err, output)
+	}
+	imageID := strings.TrimSpace(string(output))
+
+	output, err = runBuildah("mount", containerID)
+	if err != nil {
+		return fmt.Errorf("failed to mount %s: %v, output: %s", image, err, output)
+	}
+	return fn(strings.TrimSpace(string(output)), imageID)
+}
+
+// statInRoot stats name as seen from inside root, resolving symlinks against
+// root rather than the agent's filesystem.
+func statInRoot(root, name string) (os.FileInfo, error) {
+	resolved := "/"
+	rest := strings.Split(strings.TrimPrefix(path.Clean("/"+name), "/"), "/")
+	for hops := 0; len(rest) > 0; {
+		next := path.Join(resolved, rest[0])
+		rest = rest[1:]
+		info, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if hops++; hops > maxSymlinkHops {
+			return nil, fmt.Errorf("too many symlinks resolving %s", name)
+		}
+		target, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return nil, err
+		}
+		if !path.IsAbs(target) {
+			target = path.Join(resolved, target)
+		}
+		rest = append(strings.Split(strings.TrimPrefix(path.Clean(target), "/"), "/"), rest...)
+		resolved = "/"
+	}
+	return os.Stat(filepath.Join(root, resolved))
+}
+
+// rebasedConfigDump returns the config.dump of a checkpoint archive naming
+// image, with ID imageID, as the image to restore on top of. Fields the
+// agent doesn't know are kept.
+func rebasedConfigDump(archivePath, image, imageID string) ([]byte, error) {
+	var config map[string]json.RawMessage
+	err := walkArchive(archivePath, func(name string, _ *tar.Header, r io.Reader) error {
+		if name != "config.dump" {
+			return nil
+		}
+		return json.NewDecoder(r).Decode(&config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("archive has no config.dump")
+	}
+	for key, value := range map[string]string{"rootfsImage": image, "rootfsImageName": image, "rootfsImageRef": imageID} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		config[key] = encoded
+	}
+	return json.Marshal(config)
+}
@@ -0,0 +1,354 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+var (
+	redactPaths = flag.String("redact-paths", envOr("AGENT_REDACT_PATHS", ""),
+		"Comma-separated glob patterns of paths in the container's filesystem removed from the filesystem changes of "+
+			"checkpoint archives before they leave the node, e.g. /run/secrets/*,/etc/app/credentials.json. "+
+			"A pattern matching a directory removes everything under it.")
+	redactEnv = flag.String("redact-env", envOr("AGENT_REDACT_ENV", ""),
+		"Comma-separated glob patterns of environment variable names whose values are blanked in the container "+
+			"spec (spec.dump) of checkpoint archives before they leave the node, e.g. *_PASSWORD,API_TOKEN. "+
+			"The values remain in the CRIU images of the processes' memory pages and environment, which are not rewritten; "+
+			"use --redaction-hook or keep such secrets out of the environment.")
+	redactionHookPath = flag.String("redaction-hook", envOr("AGENT_REDACTION_HOOK", ""),
+		"Executable run on every checkpoint archive after the redactions above as <hook> <archive> <output>. It writes the "+
+			"redacted archive to <output> and may print what it removed; the checkpoint fails if it exits non-zero.")
+)
+
+// redactionHook removes content from a checkpoint archive after the dump,
+// before the archive is compressed, copied or pushed off the node.
+type redactionHook interface {
+	// name identifies the hook in the checkpoint's content status.
+	name() string
+	// redact writes the archive at src, redacted, to dst and says what it
+	// removed.
+	redact(ctx context.Context, src, dst string) (string, error)
+}
+
+// redactionHooks run on every archive, in order. Set up from the flags at
+// startup.
+var redactionHooks []redactionHook
+
+// setupRedaction builds the redaction hooks the flags configure.
+func setupRedaction() error {
+	redactionHooks = nil
+	if patterns, err := parsePatterns(*redactPaths); err != nil {
+		return fmt.Errorf("invalid --redact-paths: %w", err)
+	} else if len(patterns) > 0 {
+		redactionHooks = append(redactionHooks, pathsRedaction{patterns: patterns})
+	}
+	if patterns, err := parsePatterns(*redactEnv); err != nil {
+		return fmt.Errorf("invalid --redact-env: %w", err)
+	} else if len(patterns) > 0 {
+		redactionHooks = append(redactionHooks, envRedaction{patterns: patterns})
+	}
+	if *redactionHookPath != "" {
+		executable, err := exec.LookPath(*redactionHookPath)
+		if err != nil {
+			return fmt.Errorf("invalid --redaction-hook: %w", err)
+		}
+		redactionHooks = append(redactionHooks, execRedaction{executable: executable})
+	}
+	return nil
+}
+
+// parsePatterns splits a comma-separated list of glob patterns.
+func parsePatterns(list string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// matchesAny reports whether name matches one of patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArchive runs the redaction hooks on the archive at archivePath,
+// replacing it with each hook's output, and returns what each removed. The
+// archive keeps its modification time, which names its copies.
+func redactArchive(ctx context.Context, archivePath string) ([]*pb.Redaction, error) {
+	if len(redactionHooks) == 0 {
+		return nil, nil
+	}
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	var redactions []*pb.Redaction
+	for _, hook := range redactionHooks {
+		started := time.Now()
+		redacted := filepath.Join(filepath.Dir(archivePath), "."+filepath.Base(archivePath)+".redacted")
+		// Left by an agent stopped while redacting
+		os.Remove(redacted)
+		detail, err := hook.redact(ctx, archivePath, redacted)
+		if err == nil {
+			err = os.Chtimes(redacted, info.ModTime(), info.ModTime())
+		}
+		if err == nil {
+			err = os.Rename(redacted, archivePath)
+		}
+		if err != nil {
+			os.Remove(redacted)
+			return nil, fmt.Errorf("redaction %s failed: %w", hook.name(), err)
+		}
+		log.FromContext(ctx).Info("Redacted checkpoint archive", "redaction", hook.name(), "detail", detail,
+			"duration", time.Since(started).String())
+		redactions = append(redactions, &pb.Redaction{Name: hook.name(), Detail: detail})
+	}
+	return redactions, nil
+}
+
+// rewriteArchive copies the checkpoint tar at src to dst, writing the member
+// named member through rewrite. Only uncompressed tars, as the kubelet
+// writes them, can be rewritten.
+func rewriteArchive(src, dst, member string, rewrite func(r io.Reader, w io.Writer) error) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	r := bufio.NewReader(in)
+	if magic, err := r.Peek(4); err == nil && (compressorOf(magic) != nil || bytes.HasPrefix(magic, []byte{0x1f, 0x8b})) {
+		return errors.New("archive is compressed")
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, artifactFileMode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(out)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || strings.TrimPrefix(path.Clean(hdr.Name), "./") != member {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+		// The member's new size goes in its header, before its content
+		if err := rewriteMember(tw, hdr, tr, filepath.Dir(dst), rewrite); err != nil {
+			return fmt.Errorf("failed to redact %s: %w", member, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// rewriteMember writes the member of hdr, read from r and rewritten through
+// a temporary file in dir, to tw.
+func rewriteMember(tw *tar.Writer, hdr *tar.Header, r io.Reader, dir string, rewrite func(r io.Reader, w io.Writer) error) error {
+	tmp, err := os.CreateTemp(dir, ".redacting-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if err := rewrite(r, tmp); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	rewritten := *hdr
+	rewritten.Size = size
+	if err := tw.WriteHeader(&rewritten); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+// pathsRedaction removes files from the container filesystem changes the
+// archive carries in rootfs-diff.tar.
+type pathsRedaction struct {
+	patterns []string
+}
+
+func (h pathsRedaction) name() string {
+	return "Paths"
+}
+
+func (h pathsRedaction) redact(_ context.Context, src, dst string) (string, error) {
+	removed := 0
+	err := rewriteArchive(src, dst, "rootfs-diff.tar", func(r io.Reader, w io.Writer) error {
+		tr := tar.NewReader(r)
+		tw := tar.NewWriter(w)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if h.matches(hdr.Name) {
+				removed++
+				continue
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+		return tw.Close()
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("removed %d files", removed), nil
+}
+
+// matches reports whether the file at name, relative to the container's
+// root, or a directory it is in matches a pattern.
+func (h pathsRedaction) matches(name string) bool {
+	for p := path.Join("/", name); p != "/"; p = path.Dir(p) {
+		if matchesAny(h.patterns, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// envRedaction blanks the values of environment variables in the OCI spec
+// of the container the archive carries in spec.dump. The checkpointed
+// processes keep them in their memory, which no redaction can rewrite.
+type envRedaction struct {
+	patterns []string
+}
+
+func (h envRedaction) name() string {
+	return "Env"
+}
+
+func (h envRedaction) redact(_ context.Context, src, dst string) (string, error) {
+	blanked := 0
+	err := rewriteArchive(src, dst, "spec.dump", func(r io.Reader, w io.Writer) error {
+		// Fields the agent doesn't know are kept
+		var spec map[string]json.RawMessage
+		if err := json.NewDecoder(r).Decode(&spec); err != nil {
+			return err
+		}
+		var process map[string]json.RawMessage
+		if err := json.Unmarshal(spec["process"], &process); err != nil {
+			return fmt.Errorf("no process in spec: %w", err)
+		}
+		var env []string
+		if raw, ok := process["env"]; ok {
+			if err := json.Unmarshal(raw, &env); err != nil {
+				return fmt.Errorf("invalid environment in spec: %w", err)
+			}
+		}
+		for i, variable := range env {
+			name, _, _ := strings.Cut(variable, "=")
+			if matchesAny(h.patterns, name) {
+				env[i] = name + "="
+				blanked++
+			}
+		}
+		if blanked == 0 {
+			return json.NewEncoder(w).Encode(spec)
+		}
+		var err error
+		if process["env"], err = json.Marshal(env); err != nil {
+			return err
+		}
+		if spec["process"], err = json.Marshal(process); err != nil {
+			return err
+		}
+		return json.NewEncoder(w).Encode(spec)
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("blanked %d environment variables", blanked), nil
+}
+
+// maxRedactionDetail is how much of an external hook's output is recorded.
+const maxRedactionDetail = 256
+
+// execRedaction runs an external executable on the archive.
+type execRedaction struct {
+	executable string
+}
+
+func (h execRedaction) name() string {
+	return "Exec:" + filepath.Base(h.executable)
+}
+
+func (h execRedaction) redact(ctx context.Context, src, dst string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.executable, src, dst)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w, output: %s", err, stderr.String())
+	}
+	if info, err := os.Stat(dst); err != nil {
+		return "", fmt.Errorf("hook wrote no archive: %w", err)
+	} else if !info.Mode().IsRegular() {
+		return "", errors.New("hook output is not a regular file")
+	}
+	detail := strings.TrimSpace(stdout.String())
+	if len(detail) > maxRedactionDetail {
+		detail = detail[:maxRedactionDetail]
+	}
+	return detail, nil
+}
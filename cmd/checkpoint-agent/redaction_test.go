@@ -0,0 +1,120 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// tarOf returns members as an uncompressed tar, as the kubelet writes
+// checkpoint archives.
+func tarOf(members map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range slices.Sorted(maps.Keys(members)) {
+		Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(members[name]))})).To(Succeed())
+		_, err := tw.Write([]byte(members[name]))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+// membersOf returns the regular files of the tar data by name.
+func membersOf(data []byte) map[string]string {
+	members := map[string]string{}
+	tr := tar.NewReader(bytes.NewReader(data))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return members
+		}
+		Expect(err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(tr)
+		Expect(err).NotTo(HaveOccurred())
+		members[hdr.Name] = string(content)
+	}
+}
+
+var _ = Describe("Redaction", func() {
+	var src, dst string
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		src, dst = filepath.Join(dir, "checkpoint.tar"), filepath.Join(dir, "redacted.tar")
+	})
+
+	// redacted returns the members of the redacted archive.
+	redacted := func() map[string]string {
+		data, err := os.ReadFile(dst)
+		Expect(err).NotTo(HaveOccurred())
+		return membersOf(data)
+	}
+
+	Describe("envRedaction", func() {
+		It("blanks matching variables in the container spec, not in the dumped memory", func() {
+			pages := "heap DB_PASSWORD=hunter2 API_TOKEN=abc heap"
+			members := map[string]string{
+				"spec.dump":              `{"process":{"env":["PATH=/bin","DB_PASSWORD=hunter2","API_TOKEN=abc"],"cwd":"/"},"hostname":"web"}`,
+				"config.dump":            `{"rootfsImageName":"busybox"}`,
+				"checkpoint/pages-1.img": pages,
+			}
+			Expect(os.WriteFile(src, tarOf(members), 0o600)).To(Succeed())
+
+			detail, err := envRedaction{patterns: []string{"*_PASSWORD", "API_TOKEN"}}.redact(context.Background(), src, dst)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detail).To(Equal("blanked 2 environment variables"))
+
+			result := redacted()
+			var spec struct {
+				Process struct {
+					Env []string `json:"env"`
+					Cwd string   `json:"cwd"`
+				} `json:"process"`
+				Hostname string `json:"hostname"`
+			}
+			Expect(json.Unmarshal([]byte(result["spec.dump"]), &spec)).To(Succeed())
+			Expect(spec.Process.Env).To(Equal([]string{"PATH=/bin", "DB_PASSWORD=", "API_TOKEN="}))
+			// Fields the redaction doesn't know are kept
+			Expect(spec.Process.Cwd).To(Equal("/"))
+			Expect(spec.Hostname).To(Equal("web"))
+			// The dumped memory keeps the values: no redaction rewrites it
+			Expect(result["checkpoint/pages-1.img"]).To(Equal(pages))
+			Expect(result["config.dump"]).To(Equal(members["config.dump"]))
+		})
+
+		It("refuses compressed archives", func() {
+			Expect(os.WriteFile(src, []byte{0x1f, 0x8b, 0x08, 0x00}, 0o600)).To(Succeed())
+			_, err := envRedaction{patterns: []string{"*"}}.redact(context.Background(), src, dst)
+			Expect(err).To(MatchError(ContainSubstring("compressed")))
+		})
+	})
+
+	Describe("pathsRedaction", func() {
+		It("removes matching files, and those under matching directories, from the filesystem changes", func() {
+			rootfsDiff := tarOf(map[string]string{
+				"run/secrets/token":  "secret",
+				"etc/app/creds.json": "secret",
+				"etc/app/app.conf":   "config",
+			})
+			Expect(os.WriteFile(src, tarOf(map[string]string{"rootfs-diff.tar": string(rootfsDiff), "spec.dump": "{}"}), 0o600)).To(Succeed())
+
+			detail, err := pathsRedaction{patterns: []string{"/run/secrets", "/etc/app/*.json"}}.redact(context.Background(), src, dst)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(detail).To(Equal("removed 2 files"))
+
+			result := redacted()
+			Expect(membersOf([]byte(result["rootfs-diff.tar"]))).To(Equal(map[string]string{"etc/app/app.conf": "config"}))
+			Expect(result["spec.dump"]).To(Equal("{}"))
+		})
+	})
+})
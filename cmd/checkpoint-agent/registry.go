@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+var sharedStorage = flag.Bool("shared-storage", os.Getenv("AGENT_SHARED_STORAGE") != "false",
+	"Copy checkpoints to the shared storage mounted at /mnt/checkpoints. Disable when checkpoint images "+
+		"are moved between nodes through the controller's checkpoint registry instead.")
+
+// The in-cluster checkpoint registry runs behind a ClusterIP Service without
+// TLS; only the agents and the controller use it. An external one is served
+// with TLS and may need the credentials the controller passes along.
+
+// pushImage pushes imageName from the node's container storage to the
+// registry its name starts with, with the credentials of the auth file auth.
+func pushImage(imageName string, tlsVerify bool, auth []byte) error {
+	args, cleanup, err := authFileArgs(auth)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	args = append([]string{fmt.Sprintf("--tls-verify=%t", tlsVerify)}, args...)
+	output, err := runBuildah("push", append(args, imageName, "docker://"+imageName)...)
+	if err != nil {
+		return fmt.Errorf("buildah push failed: %w, output: %s", err, string(output))
+	}
+	return nil
+}
+
+// authFileArgs writes the auth file auth, if any, where only the agent can
+// read it, and returns the buildah arguments using it and a func removing
+// it.
+func authFileArgs(auth []byte) ([]string, func(), error) {
+	if len(auth) == 0 {
+		return nil, func() {}, nil
+	}
+	f, err := os.CreateTemp("", "registry-auth-*.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(auth); err != nil {
+		f.Close()
+		cleanup()
+		return nil, nil, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return []string{"--authfile", f.Name()}, cleanup, nil
+}
+
+// PullImage pulls a checkpoint image the source node's agent pushed, or the
+// base image of a rebased checkpoint, so the restored pod finds it in the
+// node's container storage.
+func (s *CheckpointServer) PullImage(ctx context.Context, req *pb.PullImageRequest) (*pb.PullImageResponse, error) {
+	logger := log.FromContext(ctx).WithValues("image", req.ImageReference)
+	logger.Info("Pull image request", "tlsVerify", req.TlsVerify, "credentials", len(req.RegistryAuth) > 0)
+
+	if req.ImageReference == "" {
+		return &pb.PullImageResponse{Error: "image reference is required"}, nil
+	}
+	args, cleanup, err := authFileArgs(req.RegistryAuth)
+	if err != nil {
+		return &pb.PullImageResponse{Error: fmt.Sprintf("failed to write registry credentials: %v", err)}, nil
+	}
+	defer cleanup()
+	args = append([]string{fmt.Sprintf("--tls-verify=%t", req.TlsVerify)}, args...)
+	output, err := runBuildah("pull", append(args, "docker://"+req.ImageReference)...)
+	if err != nil {
+		logger.Error(err, "Failed to pull image", "output", string(output))
+		return &pb.PullImageResponse{Error: fmt.Sprintf("buildah pull failed: %v, output: %s", err, string(output))}, nil
+	}
+
+	logger.Info("Pulled image")
+	return &pb.PullImageResponse{Success: true}, nil
+}
+
+// fileDigest returns the sha256 digest of the file at path, in
+// algorithm:hex form.
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// crioContainerState is the subset of CRI-O's per-container state.json we
+// use: the container's init PID and whether it was restored from a checkpoint.
+type crioContainerState struct {
+	Pid            int       `json:"pid,omitempty"`
+	Restored       bool      `json:"restored,omitempty"`
+	CheckpointedAt time.Time `json:"checkpointedAt,omitempty"`
+}
+
+// readContainerState reads CRI-O's state.json for a container on this node.
+func readContainerState(containerID string) (*crioContainerState, error) {
+	statePath := filepath.Join(containerStorageRoot, "overlay-containers", containerID, "userdata", "state.json")
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read container state %s: %v", statePath, err)
+	}
+
+	var state crioContainerState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse container state %s: %v", statePath, err)
+	}
+	return &state, nil
+}
+
+// trimContainerID strips the runtime scheme (e.g. "cri-o://") from a
+// container ID reported in the pod status.
+func trimContainerID(containerID string) string {
+	if i := strings.Index(containerID, "://"); i >= 0 {
+		return containerID[i+3:]
+	}
+	return containerID
+}
+
+// VerifyRestore reads the runtime's restore marker for a container so the
+// controller can tell a CRIU restore apart from a fresh boot of the image.
+func (s *CheckpointServer) VerifyRestore(ctx context.Context, req *pb.VerifyRestoreRequest) (*pb.VerifyRestoreResponse, error) {
+	containerID := trimContainerID(req.ContainerId)
+	if containerID == "" {
+		return &pb.VerifyRestoreResponse{Error: "container id is required"}, nil
+	}
+
+	log.FromContext(ctx).Info("VerifyRestore request", "container", containerID)
+
+	state, err := readContainerState(containerID)
+	if err != nil {
+		return &pb.VerifyRestoreResponse{Error: err.Error()}, nil
+	}
+
+	if !state.Restored {
+		return &pb.VerifyRestoreResponse{
+			Restored: false,
+			Message:  "runtime state has no restore marker; container was started from its image",
+		}, nil
+	}
+
+	resp := &pb.VerifyRestoreResponse{
+		Restored: true,
+		Message:  "container restored from checkpoint",
+	}
+	if !state.CheckpointedAt.IsZero() {
+		resp.CheckpointedAt = state.CheckpointedAt.Format(time.RFC3339)
+	}
+	return resp, nil
+}
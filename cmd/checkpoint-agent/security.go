@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	// kubeletSeccompDir is where the kubelet looks up Localhost seccomp
+	// profiles (mounted into the agent read-only).
+	kubeletSeccompDir = "/var/lib/kubelet/seccomp"
+
+	// appArmorProfilesFile lists the AppArmor profiles loaded in the kernel.
+	appArmorProfilesFile = "/sys/kernel/security/apparmor/profiles"
+
+	// selinuxEnforceFile exists when SELinux is enabled.
+	selinuxEnforceFile = "/sys/fs/selinux/enforce"
+)
+
+func selinuxEnabled() bool {
+	_, err := os.Stat(selinuxEnforceFile)
+	return err == nil
+}
+
+func appArmorEnabled() bool {
+	data, err := os.ReadFile("/sys/module/apparmor/parameters/enabled")
+	return err == nil && strings.TrimSpace(string(data)) == "Y"
+}
+
+// processLSMLabels returns the SELinux label and AppArmor profile pid runs
+// under. Both are empty when the module is not enabled, and the AppArmor
+// profile is empty for an unconfined process.
+func processLSMLabels(pid int) (string, string) {
+	var selinuxLabel, appArmorProfile string
+	if selinuxEnabled() {
+		selinuxLabel = readProcAttr(fmt.Sprintf("/proc/%d/attr/current", pid))
+	}
+	if appArmorEnabled() {
+		// Kernels before 5.8 only have the shared attr/current
+		path := fmt.Sprintf("/proc/%d/attr/apparmor/current", pid)
+		if _, err := os.Stat(path); err != nil {
+			path = fmt.Sprintf("/proc/%d/attr/current", pid)
+		}
+		// "name (mode)"
+		profile, _, _ := strings.Cut(readProcAttr(path), " (")
+		if profile != "unconfined" {
+			appArmorProfile = profile
+		}
+	}
+	return selinuxLabel, appArmorProfile
+}
+
+func readProcAttr(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00"))
+}
+
+// CheckSecurityProfiles reports which of the requested profiles the node
+// lacks and which security modules it runs. The runtime derives CRIU's
+// --lsm-profile and --lsm-mount-context from the restored container's
+// labels, so a restore fails unless they exist on the node.
+func (s *CheckpointServer) CheckSecurityProfiles(ctx context.Context, req *pb.CheckSecurityProfilesRequest) (*pb.CheckSecurityProfilesResponse, error) {
+	log.FromContext(ctx).Info("Check security profiles request", "seccomp", req.SeccompProfiles, "apparmor", req.ApparmorProfiles)
+
+	resp := &pb.CheckSecurityProfilesResponse{
+		SelinuxEnabled:  selinuxEnabled(),
+		ApparmorEnabled: appArmorEnabled(),
+	}
+	for _, profile := range req.SeccompProfiles {
+		if !filepath.IsLocal(profile) {
+			return &pb.CheckSecurityProfilesResponse{Error: fmt.Sprintf("invalid seccomp profile path %q", profile)}, nil
+		}
+		if _, err := os.Stat(filepath.Join(kubeletSeccompDir, profile)); err != nil {
+			resp.MissingSeccompProfiles = append(resp.MissingSeccompProfiles, profile)
+		}
+	}
+
+	if len(req.ApparmorProfiles) > 0 {
+		loaded := map[string]bool{}
+		if resp.ApparmorEnabled {
+			data, err := os.ReadFile(appArmorProfilesFile)
+			if err != nil {
+				return &pb.CheckSecurityProfilesResponse{Error: fmt.Sprintf("failed to list AppArmor profiles: %v", err)}, nil
+			}
+			for _, line := range strings.Split(string(data), "\n") {
+				if name, _, ok := strings.Cut(line, " ("); ok {
+					loaded[name] = true
+				}
+			}
+		}
+		for _, profile := range req.ApparmorProfiles {
+			if !loaded[profile] {
+				resp.MissingApparmorProfiles = append(resp.MissingApparmorProfiles, profile)
+			}
+		}
+	}
+
+	resp.Success = true
+	return resp, nil
+}
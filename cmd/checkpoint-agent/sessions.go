@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+const (
+	// criuConfigFile is the CRIU configuration runc reads for containers
+	// without an org.criu.config annotation (mounted from the host).
+	criuConfigFile = "/etc/criu/runc.conf"
+
+	// sessionExitTimeout bounds how long killed exec sessions may take to
+	// leave the container's cgroup.
+	sessionExitTimeout = 5 * time.Second
+)
+
+// allowedCriuOptions are the options ConfigureCriu may add. They only make
+// CRIU accept more; none changes how other containers are dumped.
+var allowedCriuOptions = []string{"shell-job", "tcp-established", "tcp-close", "file-locks"}
+
+// criuConfigMu serializes updates of criuConfigFile.
+var criuConfigMu sync.Mutex
+
+// execSessions returns the first process of each exec session among pids,
+// the processes of a container whose init is initPid. An exec'd process is
+// started by the runtime next to init, so its parent is outside the
+// container.
+func execSessions(initPid int, pids []int) []*pb.ExecSession {
+	var sessions []*pb.ExecSession
+	for _, pid := range pids {
+		if pid == initPid {
+			continue
+		}
+		ppid, tty, err := processStat(pid)
+		if err != nil || slices.Contains(pids, ppid) {
+			continue // exited, or forked inside the container
+		}
+		sessions = append(sessions, &pb.ExecSession{
+			Pid:     int32(pid),
+			Command: processCommand(pid),
+			Tty:     tty,
+		})
+	}
+	return sessions
+}
+
+// processStat reads the parent and whether a process has a controlling
+// terminal from /proc/<pid>/stat.
+func processStat(pid int) (int, bool, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, false, err
+	}
+	// The command may contain spaces and parentheses; fields follow the last ")"
+	i := strings.LastIndexByte(string(data), ')')
+	if i < 0 {
+		return 0, false, fmt.Errorf("invalid /proc/%d/stat", pid)
+	}
+	// state ppid pgrp session tty_nr ...
+	fields := strings.Fields(string(data[i+1:]))
+	if len(fields) < 5 {
+		return 0, false, fmt.Errorf("invalid /proc/%d/stat", pid)
+	}
+	ppid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, false, err
+	}
+	return ppid, fields[4] != "0", nil
+}
+
+// processCommand returns the command line of a process.
+func processCommand(pid int) string {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(data), "\x00", " "))
+}
+
+// handleExecSessions fails when the container has exec sessions, which CRIU
+// cannot dump along with the container's process tree, or with terminate
+// kills them and waits for them to exit.
+func handleExecSessions(ctx context.Context, containerID string, terminate bool) error {
+	state, err := readContainerState(containerID)
+	if err != nil {
+		return err
+	}
+	cgroupDir, err := containerCgroupDir(state.Pid)
+	if err != nil {
+		return err
+	}
+	pids, err := cgroupPids(cgroupDir)
+	if err != nil {
+		return err
+	}
+	sessions := execSessions(state.Pid, pids)
+	if len(sessions) == 0 {
+		return nil
+	}
+	if !terminate {
+		var descriptions []string
+		for _, session := range sessions {
+			descriptions = append(descriptions, fmt.Sprintf("pid %d (%s)", session.Pid, session.Command))
+		}
+		return fmt.Errorf("container has exec sessions: %s", strings.Join(descriptions, ", "))
+	}
+
+	killed := sessionProcesses(sessions, pids)
+	for _, pid := range killed {
+		log.FromContext(ctx).Info("Terminating exec session process", "pid", pid, "command", processCommand(pid), "container", containerID)
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return fmt.Errorf("failed to kill exec session process %d: %w", pid, err)
+		}
+	}
+	// Exited processes leave the cgroup even before they are reaped
+	deadline := time.Now().Add(sessionExitTimeout)
+	for {
+		remaining, err := cgroupPids(cgroupDir)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(killed, func(pid int) bool { return slices.Contains(remaining, pid) }) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("exec sessions did not exit within %s", sessionExitTimeout)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// sessionProcesses returns the processes among pids that belong to sessions:
+// their first processes and everything these started.
+func sessionProcesses(sessions []*pb.ExecSession, pids []int) []int {
+	roots := map[int]bool{}
+	for _, session := range sessions {
+		roots[int(session.Pid)] = true
+	}
+	parents := map[int]int{}
+	for _, pid := range pids {
+		if ppid, _, err := processStat(pid); err == nil {
+			parents[pid] = ppid
+		}
+	}
+	inSession := func(pid int) bool {
+		// Bounded by the number of processes in case of a pid reused meanwhile
+		for range len(parents) + 1 {
+			if roots[pid] {
+				return true
+			}
+			ppid, ok := parents[pid]
+			if !ok {
+				return false
+			}
+			pid = ppid
+		}
+		return false
+	}
+	var members []int
+	for _, pid := range pids {
+		if inSession(pid) {
+			members = append(members, pid)
+		}
+	}
+	return members
+}
+
+// ConfigureCriu adds options to the node's CRIU configuration for runc. The
+// kubelet checkpoint API passes no CRIU options, and the restore happens when
+// the runtime creates the restored container, so both go through this file.
+// Options already set are kept; none is ever removed.
+func (s *CheckpointServer) ConfigureCriu(ctx context.Context, req *pb.ConfigureCriuRequest) (*pb.ConfigureCriuResponse, error) {
+	log.FromContext(ctx).Info("Configure CRIU request", "options", req.Options)
+
+	if err := ensureCriuOptions(ctx, req.Options); err != nil {
+		return &pb.ConfigureCriuResponse{Error: err.Error()}, nil
+	}
+	return &pb.ConfigureCriuResponse{Success: true}, nil
+}
+
+// ensureCriuOptions adds the options missing from criuConfigFile.
+func ensureCriuOptions(ctx context.Context, options []string) error {
+	for _, option := range options {
+		if !slices.Contains(allowedCriuOptions, option) {
+			return fmt.Errorf("CRIU option %q is not supported", option)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	criuConfigMu.Lock()
+	defer criuConfigMu.Unlock()
+
+	data, err := os.ReadFile(criuConfigFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var set []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			set = append(set, strings.TrimPrefix(fields[0], "--"))
+		}
+	}
+	config := string(data)
+	changed := false
+	for _, option := range options {
+		if slices.Contains(set, option) {
+			continue
+		}
+		if config != "" && !strings.HasSuffix(config, "\n") {
+			config += "\n"
+		}
+		config += option + "\n"
+		set = append(set, option)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(criuConfigFile), 0o755); err != nil {
+		return err
+	}
+	// Written aside and renamed, as runc may read it at any time
+	tmp := criuConfigFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(config), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, criuConfigFile); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("Updated CRIU configuration", "file", criuConfigFile, "options", options)
+	return nil
+}
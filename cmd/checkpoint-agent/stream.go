@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// checkpointChunkBytes is the size of each message of a streamed archive.
+const checkpointChunkBytes = 1024 * 1024
+
+var streamedCheckpointBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lpm_agent_streamed_checkpoint_bytes_total",
+	Help: "Bytes of checkpoint archives streamed by PullCheckpoint and TransferCheckpoint (sent) and kept from PushCheckpoint (received).",
+}, []string{"direction"})
+
+func init() {
+	prometheus.MustRegister(streamedCheckpointBytes)
+}
+
+// PullCheckpoint streams the archive at req.ArtifactUri, on the node or in
+// shared storage, in chunks. The first one names it and gives its size.
+func (s *CheckpointServer) PullCheckpoint(req *pb.PullCheckpointRequest, stream pb.CheckpointService_PullCheckpointServer) error {
+	f, err := openArchive(req.ArtifactUri)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	log.FromContext(stream.Context()).Info("Streaming checkpoint archive", "artifactURI", req.ArtifactUri)
+	return sendArchive(f, req.ArtifactUri, "", stream.Send)
+}
+
+// openArchive opens the archive at artifactURI, on the node or in shared
+// storage, to be streamed.
+func openArchive(artifactURI string) (*os.File, error) {
+	path, err := artifactPath(artifactURI)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, status.Errorf(codes.NotFound, "checkpoint archive %s not found", artifactURI)
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a regular file", artifactURI)
+	}
+	return f, nil
+}
+
+// sendArchive sends the archive f, opened from artifactURI, in chunks
+// through send, the first naming it with its size and digest.
+func sendArchive(f *os.File, artifactURI, digest string, send func(*pb.CheckpointChunk) error) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// Lets an agent authorizing its callers authorize the push
+	namespace, _ := archiveNamespace(f.Name())
+	chunk := &pb.CheckpointChunk{Header: &pb.CheckpointChunkHeader{Name: filepath.Base(f.Name()), Size: info.Size(), Digest: digest, Namespace: namespace}}
+	buf := make([]byte, checkpointChunkBytes)
+	var sent int64
+	for {
+		n, readErr := io.ReadFull(f, buf)
+		// The header is sent even for an empty archive
+		if n > 0 || chunk.Header != nil {
+			chunk.Data = buf[:n]
+			if err := send(chunk); err != nil {
+				return err
+			}
+			sent += int64(n)
+			streamedCheckpointBytes.WithLabelValues("sent").Add(float64(n))
+			chunk = &pb.CheckpointChunk{}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	if sent != info.Size() {
+		return status.Errorf(codes.Aborted, "%s changed size from %d to %d bytes while being streamed", artifactURI, info.Size(), sent)
+	}
+	return nil
+}
+
+// PushCheckpoint receives an archive streamed as PullCheckpoint sends it and
+// keeps it in the node's checkpoint directory under its name. Like a copy to
+// shared storage, it is written to a hidden file first and only appears
+// under its name once complete and, if the header has a digest, verified.
+func (s *CheckpointServer) PushCheckpoint(stream pb.CheckpointService_PushCheckpointServer) error {
+	logger := log.FromContext(stream.Context())
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	header := first.Header
+	if header == nil {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: "the first chunk has no header"})
+	}
+	if header.Name == "" || header.Name != filepath.Base(header.Name) || strings.HasPrefix(header.Name, ".") {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("invalid archive name %q", header.Name)})
+	}
+	dst := filepath.Join(checkpointDir, header.Name)
+
+	// Named uniquely, so concurrent pushes of an archive don't mix
+	tmp, err := os.CreateTemp(checkpointDir, "."+header.Name+".*"+partialSuffix)
+	if err != nil {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("failed to create archive: %v", err)})
+	}
+	published := false
+	defer func() {
+		tmp.Close()
+		if !published {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	w := io.MultiWriter(tmp, hash)
+	var received int64
+	for chunk := first; ; {
+		if received += int64(len(chunk.Data)); received > header.Size {
+			return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("received more than the %d bytes announced", header.Size)})
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("failed to write archive: %v", err)})
+		}
+		if chunk, err = stream.Recv(); errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	streamedCheckpointBytes.WithLabelValues("received").Add(float64(received))
+	if received != header.Size {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("received %d of the %d bytes announced", received, header.Size)})
+	}
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	if header.Digest != "" && header.Digest != digest {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("received archive has digest %s, expected %s", digest, header.Digest)})
+	}
+	if err := tmp.Sync(); err != nil {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("failed to sync archive: %v", err)})
+	}
+	if err := tmp.Close(); err != nil {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("failed to write archive: %v", err)})
+	}
+	// The push was authorized for the namespace the header names
+	if header.Namespace != "" {
+		if err := checkPushedNamespace(tmp.Name(), dst, header.Namespace); err != nil {
+			return stream.SendAndClose(&pb.PushCheckpointResponse{Error: err.Error()})
+		}
+	}
+	if err := publishArtifact(tmp.Name(), dst, digest); err != nil {
+		return stream.SendAndClose(&pb.PushCheckpointResponse{Error: fmt.Sprintf("failed to keep archive: %v", err)})
+	}
+	published = true
+
+	logger.Info("Received checkpoint archive", "path", dst, "size", received, "digest", digest)
+	return stream.SendAndClose(&pb.PushCheckpointResponse{
+		Success:     true,
+		ArtifactUri: "file://" + dst,
+		Size:        received,
+		Digest:      digest,
+	})
+}
+
+// checkPushedNamespace checks the archive received at path is of namespace,
+// if it names one, and that it replaces no other namespace's archive at dst.
+func checkPushedNamespace(path, dst, namespace string) error {
+	if got, err := archiveNamespace(path); err == nil && got != "" && got != namespace {
+		return fmt.Errorf("received archive is of namespace %s, not %s", got, namespace)
+	}
+	if got, err := archiveNamespace(dst); err == nil && got != namespace {
+		return fmt.Errorf("%s is an archive of another namespace", filepath.Base(dst))
+	}
+	return nil
+}
+
+// TransferCheckpoint pushes the archive at req.ArtifactUri straight to the
+// agent at req.TargetEndpoint, encrypted with the key of the transfer that
+// agent opened, and returns where it kept it. The caller's bearer token is
+// passed on, so an agent authorizing its callers accepts the push as a call
+// from the controller.
+func (s *CheckpointServer) TransferCheckpoint(ctx context.Context, req *pb.TransferCheckpointRequest) (*pb.TransferCheckpointResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Checkpoint transfer request", "artifactURI", req.ArtifactUri, "target", req.TargetEndpoint)
+	if req.Transfer == nil {
+		return &pb.TransferCheckpointResponse{Error: "transfer key is required"}, nil
+	}
+	f, err := openArchive(req.ArtifactUri)
+	if err != nil {
+		return &pb.TransferCheckpointResponse{Error: status.Convert(err).Message()}, nil
+	}
+	defer f.Close()
+
+	var encryption *pb.TransferEncryption
+	creds, err := transferClientCredentials(req.Transfer, func(state tls.ConnectionState) {
+		encryption = transferEncryption(state)
+	})
+	if err != nil {
+		return &pb.TransferCheckpointResponse{Error: err.Error()}, nil
+	}
+	conn, err := grpc.NewClient(req.TargetEndpoint,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(maxMessageSize)),
+	)
+	if err != nil {
+		return &pb.TransferCheckpointResponse{Error: fmt.Sprintf("failed to dial %s: %v", req.TargetEndpoint, err)}, nil
+	}
+	defer conn.Close()
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, value := range md.Get("authorization") {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", value)
+		}
+	}
+	start := time.Now()
+	stream, err := pb.NewCheckpointServiceClient(conn).PushCheckpoint(ctx)
+	if err != nil {
+		return &pb.TransferCheckpointResponse{Error: fmt.Sprintf("failed to open transfer stream: %v", err)}, nil
+	}
+	err = sendArchive(f, req.ArtifactUri, req.Digest, stream.Send)
+	// The actual error is returned by CloseAndRecv
+	if err != nil && !errors.Is(err, io.EOF) {
+		return &pb.TransferCheckpointResponse{Error: fmt.Sprintf("failed to send archive: %v", err)}, nil
+	}
+	result, err := stream.CloseAndRecv()
+	if err != nil {
+		return &pb.TransferCheckpointResponse{Error: fmt.Sprintf("transfer failed: %v", err)}, nil
+	}
+	if !result.Success {
+		return &pb.TransferCheckpointResponse{Error: fmt.Sprintf("target failed to keep archive: %s", result.Error)}, nil
+	}
+
+	elapsed := time.Since(start)
+	logger.Info("Transferred checkpoint archive", "target", req.TargetEndpoint, "artifactURI", result.ArtifactUri,
+		"size", result.Size, "duration", elapsed.String())
+	return &pb.TransferCheckpointResponse{
+		Success:     true,
+		ArtifactUri: result.ArtifactUri,
+		Size:        result.Size,
+		Digest:      result.Digest,
+		DurationMs:  elapsed.Milliseconds(),
+		Encryption:  encryption,
+	}, nil
+}
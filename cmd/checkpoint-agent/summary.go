@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// summarySuffix names the summary stored next to an archive: its process
+// tree and file descriptor tables, as InspectCheckpoint reports them, in a
+// few kilobytes however large the archive's memory pages are.
+const summarySuffix = ".summary.json"
+
+// summaryPath is where the summary of the archive at archivePath is stored.
+func summaryPath(archivePath string) string {
+	return archivePath + summarySuffix
+}
+
+// writeSummary stores summary next to the archive at archivePath, readable
+// by whoever can read the archive. Like an archive, it is written to a
+// hidden file first, so readers never see a partial one.
+func writeSummary(archivePath string, summary *pb.InspectCheckpointResponse) error {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return err
+	}
+	data, err := protojson.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	dst := summaryPath(archivePath)
+	tmp := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+partialSuffix)
+	if err := os.WriteFile(tmp, data, artifactFileMode); err != nil {
+		return err
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		err = os.Chown(tmp, -1, int(stat.Gid))
+	}
+	if err == nil {
+		err = os.Chmod(tmp, info.Mode().Perm())
+	}
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
+
+// readSummary returns the summary stored next to the archive at archivePath,
+// or nil if there is none or it describes an archive of another size, such
+// as one rewritten since.
+func readSummary(archivePath string) *pb.InspectCheckpointResponse {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(summaryPath(archivePath))
+	if err != nil {
+		return nil
+	}
+	summary := &pb.InspectCheckpointResponse{}
+	if err := protojson.Unmarshal(data, summary); err != nil || summary.ArchiveSize != info.Size() {
+		return nil
+	}
+	return summary
+}
+
+// summarizeArchive reads the archive at archivePath and stores its summary
+// next to it, returning the summary.
+func summarizeArchive(archivePath string) (*pb.InspectCheckpointResponse, error) {
+	summary, err := inspectArchive(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeSummary(archivePath, summary); err != nil {
+		return nil, fmt.Errorf("failed to store summary: %w", err)
+	}
+	return summary, nil
+}
+
+// storeSummary stores summary, if any, next to the archive of size bytes at
+// archivePath, the node-local archive or its copy in shared storage. Only
+// inspections need it, so failing to store it is logged.
+func storeSummary(ctx context.Context, summary *pb.InspectCheckpointResponse, archivePath string, size int64) {
+	if summary == nil {
+		return
+	}
+	summary.ArchiveSize = size
+	if err := writeSummary(archivePath, summary); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to store summary of checkpoint archive", "path", archivePath)
+	}
+}
+
+// removeSummary removes the summary of the archive at archivePath, if any.
+func removeSummary(archivePath string) error {
+	if err := os.Remove(summaryPath(archivePath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SummarizeCheckpoint stores the summary of an archive, for archives the
+// agent didn't summarize when it dumped them.
+func (s *CheckpointServer) SummarizeCheckpoint(ctx context.Context, req *pb.SummarizeCheckpointRequest) (*pb.SummarizeCheckpointResponse, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Summarize request", "artifactURI", req.ArtifactUri)
+
+	path, err := artifactPath(req.ArtifactUri)
+	if err != nil {
+		return &pb.SummarizeCheckpointResponse{Error: err.Error()}, nil
+	}
+	if _, err := summarizeArchive(path); err != nil {
+		logger.Error(err, "Failed to summarize checkpoint")
+		return &pb.SummarizeCheckpointResponse{Error: fmt.Sprintf("summarize failed: %v", err)}, nil
+	}
+	info, err := os.Stat(summaryPath(path))
+	if err != nil {
+		return &pb.SummarizeCheckpointResponse{Error: err.Error()}, nil
+	}
+	logger.Info("Summarized checkpoint", "path", summaryPath(path), "size", info.Size())
+	return &pb.SummarizeCheckpointResponse{
+		Success:     true,
+		SummaryUri:  req.ArtifactUri + summarySuffix,
+		SummarySize: info.Size(),
+	}, nil
+}
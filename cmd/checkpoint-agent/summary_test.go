@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+var _ = Describe("storeSummary", func() {
+	var archive string
+
+	BeforeEach(func() {
+		archive = filepath.Join(GinkgoT().TempDir(), "checkpoint.tar")
+		Expect(os.WriteFile(archive, []byte("archive"), 0o600)).To(Succeed())
+	})
+
+	It("stores the summary next to the archive for inspections to read", func() {
+		storeSummary(context.Background(), &pb.InspectCheckpointResponse{ContainerName: "app"}, archive, int64(len("archive")))
+
+		summary := readSummary(archive)
+		Expect(summary).NotTo(BeNil())
+		Expect(summary.ContainerName).To(Equal("app"))
+		Expect(summary.ArchiveSize).To(Equal(int64(len("archive"))))
+	})
+
+	It("ignores a summary of an archive rewritten since", func() {
+		storeSummary(context.Background(), &pb.InspectCheckpointResponse{ContainerName: "app"}, archive, int64(len("archive")))
+		Expect(os.WriteFile(archive, []byte("compressed archive"), 0o600)).To(Succeed())
+
+		Expect(readSummary(archive)).To(BeNil())
+	})
+
+	It("stores nothing without a summary", func() {
+		storeSummary(context.Background(), nil, archive, 0)
+		Expect(summaryPath(archive)).NotTo(BeAnExistingFile())
+	})
+})
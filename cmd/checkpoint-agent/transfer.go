@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// copySyncInterval is how much of a copy is written between syncs, and so
+	// the most an interrupted copy writes again when it resumes.
+	copySyncInterval = 64 << 20
+
+	copyBackoffSteps   = 5
+	copyBackoffInitial = time.Second
+	copyBackoffFactor  = 2.0
+
+	// partialSuffix marks a copy in progress; offsetSuffix its offset file.
+	partialSuffix = ".partial"
+	offsetSuffix  = ".offset"
+)
+
+var partialCopyTTL = flag.Duration("partial-copy-ttl", time.Hour,
+	"How long an interrupted copy to shared storage is kept for resuming after it was last written; 0 keeps them.")
+
+var storageCopyBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "lpm_agent_storage_copy_bytes_total",
+	Help: "Bytes of checkpoint archives copied to shared storage (written) and not copied again because an interrupted copy resumed (resumed).",
+}, []string{"kind"})
+
+func init() {
+	prometheus.MustRegister(storageCopyBytes)
+}
+
+// partialPath is where a copy to dst is written until it is complete:
+// hidden, so nothing mistakes it for an archive.
+func partialPath(dst string) string {
+	return filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+partialSuffix)
+}
+
+// archiveIdentity identifies the node-local archive info describes by its
+// device, inode, size and modification time, which stay the same across
+// agent restarts but not across archives, even those taken within the same
+// second and so named alike by --artifact-layout.
+func archiveIdentity(info os.FileInfo) string {
+	id := fmt.Sprintf("%d-%d", info.Size(), info.ModTime().UnixNano())
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		id = fmt.Sprintf("%d-%d-%s", stat.Dev, stat.Ino, id)
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:8])
+}
+
+// resumableCopy copies src to dst through partialPath(dst) suffixed with
+// identity, that of the archive src is or was compressed from, recording in
+// an offset file how much of it is synced. A copy interrupted by an error, or
+// by the agent restarting, continues from there the next time the archive is
+// copied to dst, once the synced part is checked to match src; dst only
+// appears once complete and verified. It returns the sha256 digest of src,
+// read in full, so a resumed copy has the same digest as a fresh one.
+func resumableCopy(ctx context.Context, src, dst, identity string) (string, error) {
+	partial := partialPath(dst + "." + identity)
+	offsetFile := partial + offsetSuffix
+
+	source, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+	info, err := source.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	dest, err := os.OpenFile(partial, os.O_RDWR|os.O_CREATE, artifactFileMode)
+	if err != nil {
+		return "", err
+	}
+	defer dest.Close()
+	// Partial copies written before by a laxer agent are tightened too
+	if err := dest.Chmod(artifactFileMode); err != nil {
+		return "", err
+	}
+
+	offset := syncedOffset(offsetFile, info.Size())
+	hash := sha256.New()
+	if offset > 0 {
+		matches, err := copiedPrefixMatches(source, dest, offset, hash)
+		if err != nil {
+			return "", fmt.Errorf("failed to verify the copied part of %s: %w", src, err)
+		}
+		if !matches {
+			log.FromContext(ctx).Info("Partial copy does not match the archive, copying it again", "path", dst, "offset", offset)
+			offset = 0
+			hash.Reset()
+			if _, err := source.Seek(0, io.SeekStart); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := dest.Truncate(offset); err != nil {
+		return "", err
+	}
+	if _, err := dest.Seek(offset, io.SeekStart); err != nil {
+		return "", err
+	}
+	if offset > 0 {
+		log.FromContext(ctx).Info("Resuming copy to shared storage", "path", dst, "offset", offset, "size", info.Size())
+		storageCopyBytes.WithLabelValues("resumed").Add(float64(offset))
+	}
+
+	w := io.MultiWriter(faultWriter(faultPointStorage, dest, info.Size()), hash)
+	for offset < info.Size() {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		n, copyErr := io.CopyN(w, source, copySyncInterval)
+		if copyErr == io.EOF {
+			copyErr = nil
+		}
+		storageCopyBytes.WithLabelValues("written").Add(float64(n))
+		// What was written before an error is kept for the next attempt
+		if err := dest.Sync(); err != nil {
+			return "", err
+		}
+		offset += n
+		if err := os.WriteFile(offsetFile, []byte(strconv.FormatInt(offset, 10)), artifactFileMode); err != nil {
+			return "", err
+		}
+		if copyErr != nil {
+			return "", copyErr
+		}
+		if n == 0 {
+			return "", fmt.Errorf("%s shrank to %d bytes while being copied", src, offset)
+		}
+	}
+
+	if err := dest.Close(); err != nil {
+		return "", err
+	}
+	digest := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	if err := publishArtifact(partial, dst, digest); err != nil {
+		// Resuming a copy that doesn't match the archive can't fix it
+		removePartial(log.FromContext(ctx), partial)
+		return "", err
+	}
+	if err := os.Remove(offsetFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.FromContext(ctx).Error(err, "Failed to remove offset file of completed copy", "path", offsetFile)
+	}
+	return digest, nil
+}
+
+// copiedPrefixMatches reads the first n bytes of src, writing them to hash,
+// and of the partial copy, and reports whether they are the same.
+func copiedPrefixMatches(src, partial io.Reader, n int64, hash io.Writer) (bool, error) {
+	want := make([]byte, 1<<20)
+	got := make([]byte, len(want))
+	for n > 0 {
+		size := min(n, int64(len(want)))
+		if _, err := io.ReadFull(src, want[:size]); err != nil {
+			return false, err
+		}
+		hash.Write(want[:size])
+		if _, err := io.ReadFull(partial, got[:size]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return false, nil
+			}
+			return false, err
+		}
+		if !bytes.Equal(want[:size], got[:size]) {
+			return false, nil
+		}
+		n -= size
+	}
+	return true, nil
+}
+
+// publishArtifact renames the synced archive at partial to dst once its
+// content has digest, then syncs their directory so the rename survives a
+// crash. Readers of dst thus never see a truncated or corrupted archive.
+func publishArtifact(partial, dst, digest string) error {
+	written, err := fileDigest(partial)
+	if err != nil {
+		return fmt.Errorf("failed to verify %s: %w", partial, err)
+	}
+	if written != digest {
+		return fmt.Errorf("%s has digest %s after writing, expected %s", partial, written, digest)
+	}
+	if err := os.Rename(partial, dst); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(dst))
+}
+
+// syncDir syncs the directory at path, persisting the entries renamed into it.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// removePartial removes the partial copy at partial and its offset file.
+func removePartial(logger logr.Logger, partial string) {
+	for _, path := range []string{partial, partial + offsetSuffix} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Error(err, "Failed to remove partial copy", "path", path)
+		}
+	}
+}
+
+// syncedOffset returns how much of a copy of size bytes its offset file
+// records as synced, or 0 without a valid one.
+func syncedOffset(offsetFile string, size int64) int64 {
+	data, err := os.ReadFile(offsetFile)
+	if err != nil {
+		return 0
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || offset < 0 || offset > size {
+		return 0
+	}
+	return offset
+}
+
+// runPartialCopySweeper removes interrupted copies to shared storage not
+// written for --partial-copy-ttl, which no retry is going to resume.
+func runPartialCopySweeper() {
+	if *partialCopyTTL <= 0 {
+		return
+	}
+	for {
+		sweepPartialCopies(time.Now().Add(-*partialCopyTTL))
+		time.Sleep(*partialCopyTTL / 2)
+	}
+}
+
+// sweepPartialCopies removes the partial copies in the shared storage, in
+// any directory of its layout, last written before cutoff, along with their
+// offset files. The storage is shared, so this also removes those of agents
+// on other nodes.
+func sweepPartialCopies(cutoff time.Time) {
+	var matches []string
+	err := filepath.WalkDir(sharedCheckpointDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), ".") && strings.HasSuffix(entry.Name(), partialSuffix) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		agentLog.Error(err, "Failed to list partial copies")
+		return
+	}
+	for _, partial := range matches {
+		info, err := os.Stat(partial)
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		removePartial(agentLog, partial)
+		agentLog.Info("Removed stale partial copy", "path", partial, "size", info.Size(), "lastWritten", info.ModTime())
+	}
+}
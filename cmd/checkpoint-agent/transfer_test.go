@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("resumableCopy", func() {
+	var dir, src, dst, digest string
+	content := []byte("checkpoint archive content, long enough to be copied in parts")
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		src = filepath.Join(dir, "checkpoint.tar")
+		dst = filepath.Join(dir, "shared.tar")
+		Expect(os.WriteFile(src, content, 0o600)).To(Succeed())
+		sum := sha256.Sum256(content)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	})
+
+	// interrupted leaves a partial copy of the archive identified by id
+	// holding partial, recorded as synced.
+	interrupted := func(id string, partial []byte) string {
+		path := partialPath(dst + "." + id)
+		Expect(os.WriteFile(path, partial, 0o600)).To(Succeed())
+		Expect(os.WriteFile(path+offsetSuffix, []byte(strconv.Itoa(len(partial))), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("resumes a partial copy of the same archive", func() {
+		partial := interrupted("id", content[:10])
+
+		Expect(resumableCopy(context.Background(), src, dst, "id")).To(Equal(digest))
+		Expect(os.ReadFile(dst)).To(Equal(content))
+		Expect(partial).NotTo(BeAnExistingFile())
+		Expect(partial + offsetSuffix).NotTo(BeAnExistingFile())
+	})
+
+	It("copies again over a partial copy that does not match the archive", func() {
+		interrupted("id", []byte("some other archive"))
+
+		Expect(resumableCopy(context.Background(), src, dst, "id")).To(Equal(digest))
+		Expect(os.ReadFile(dst)).To(Equal(content))
+	})
+
+	It("copies again over a partial copy shorter than it records", func() {
+		partial := interrupted("id", content[:10])
+		Expect(os.WriteFile(partial+offsetSuffix, []byte("20"), 0o600)).To(Succeed())
+
+		Expect(resumableCopy(context.Background(), src, dst, "id")).To(Equal(digest))
+		Expect(os.ReadFile(dst)).To(Equal(content))
+	})
+
+	It("leaves partial copies of other archives alone", func() {
+		other := interrupted("other", []byte("some other archive"))
+
+		Expect(resumableCopy(context.Background(), src, dst, "id")).To(Equal(digest))
+		Expect(os.ReadFile(dst)).To(Equal(content))
+		Expect(other).To(BeAnExistingFile())
+	})
+})
+
+var _ = Describe("archiveIdentity", func() {
+	It("tells apart archives taken within the same second", func() {
+		dir := GinkgoT().TempDir()
+		first, second := filepath.Join(dir, "first.tar"), filepath.Join(dir, "second.tar")
+		modTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		for _, path := range []string{first, second} {
+			Expect(os.WriteFile(path, []byte("archive"), 0o600)).To(Succeed())
+			Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+		}
+		firstInfo, err := os.Stat(first)
+		Expect(err).NotTo(HaveOccurred())
+		secondInfo, err := os.Stat(second)
+		Expect(err).NotTo(HaveOccurred())
+		again, err := os.Stat(first)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(archiveIdentity(firstInfo)).NotTo(Equal(archiveIdentity(secondInfo)))
+		Expect(archiveIdentity(firstInfo)).To(Equal(archiveIdentity(again)))
+	})
+})
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// transferKeyTTL is how long the key of a transfer is kept, well past the
+// longest transfer.
+const transferKeyTTL = time.Hour
+
+// transferServerName is the TLS server name of the key of transfer id.
+func transferServerName(id string) string {
+	return id + ".transfer.lpm.my.domain"
+}
+
+// transferKeys are the keys the agent receives transfers with, generated
+// for each transfer so that a leaked key exposes only its streams. TLS 1.3
+// gives each connection its own session keys on top.
+type transferKeys struct {
+	mu   sync.Mutex
+	keys map[string]transferKey
+}
+
+type transferKey struct {
+	certificate *tls.Certificate
+	expires     time.Time
+}
+
+var receivedTransfers = &transferKeys{keys: map[string]transferKey{}}
+
+// open generates the key of transfer id, replacing any earlier one, and
+// returns its self-signed certificate.
+func (k *transferKeys) open(id string) (*pb.TransferKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	serverName := transferServerName(id)
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: serverName},
+		DNSNames:     []string{serverName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(transferKeyTTL),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for name, key := range k.keys {
+		if now.After(key.expires) {
+			delete(k.keys, name)
+		}
+	}
+	k.keys[serverName] = transferKey{
+		certificate: &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		expires:     template.NotAfter,
+	}
+	return &pb.TransferKey{
+		Certificate: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		ServerName:  serverName,
+	}, nil
+}
+
+// certificate returns the key of the transfer a TLS client names.
+func (k *transferKeys) certificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.keys[hello.ServerName]
+	if !ok || time.Now().After(key.expires) {
+		return nil, fmt.Errorf("no open transfer %q", hello.ServerName)
+	}
+	return key.certificate, nil
+}
+
+// OpenTransfer generates the key the agent receives a transfer with.
+func (s *CheckpointServer) OpenTransfer(ctx context.Context, req *pb.OpenTransferRequest) (*pb.OpenTransferResponse, error) {
+	if req.TransferId == "" {
+		return &pb.OpenTransferResponse{Error: "transfer ID is required"}, nil
+	}
+	key, err := receivedTransfers.open(req.TransferId)
+	if err != nil {
+		return &pb.OpenTransferResponse{Error: fmt.Sprintf("failed to generate transfer key: %v", err)}, nil
+	}
+	log.FromContext(ctx).Info("Opened transfer", "serverName", key.ServerName)
+	return &pb.OpenTransferResponse{Success: true, Key: key}, nil
+}
+
+// transferCredentials serve the agent's plaintext clients as before, and
+// connections opening with a TLS handshake with the key of the transfer
+// they name, so other agents stream transfers encrypted on the same port.
+type transferCredentials struct {
+	config *tls.Config
+}
+
+func newTransferCredentials() credentials.TransportCredentials {
+	return &transferCredentials{config: &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		NextProtos:     []string{"h2"},
+		GetCertificate: receivedTransfers.certificate,
+	}}
+}
+
+// recordTypeHandshake starts every TLS connection.
+const recordTypeHandshake = 0x16
+
+func (c *transferCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	recordConnection(rawConn.RemoteAddr())
+	conn := &peekedConn{Conn: rawConn, r: bufio.NewReader(rawConn)}
+	first, err := conn.r.Peek(1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if first[0] != recordTypeHandshake {
+		return conn, plaintextInfo{credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}, nil
+	}
+	tlsConn := tls.Server(conn, c.config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, nil, err
+	}
+	return tlsConn, credentials.TLSInfo{
+		State:          tlsConn.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+	}, nil
+}
+
+func (c *transferCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("transfer credentials only serve connections")
+}
+
+func (c *transferCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c *transferCredentials) Clone() credentials.TransportCredentials {
+	return &transferCredentials{config: c.config.Clone()}
+}
+
+func (c *transferCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// peekedConn reads a connection through the reader its first byte was
+// peeked with.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// plaintextInfo is the AuthInfo of a plaintext connection.
+type plaintextInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (plaintextInfo) AuthType() string {
+	return "insecure"
+}
+
+// transferClientCredentials return the credentials of a stream to the key
+// of a transfer: TLS 1.3 to its server name, trusting only its certificate.
+// The state of the connection is passed to established.
+func transferClientCredentials(key *pb.TransferKey, established func(tls.ConnectionState)) (credentials.TransportCredentials, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(key.Certificate) {
+		return nil, errors.New("invalid transfer certificate")
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS13,
+		RootCAs:    roots,
+		ServerName: key.ServerName,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			established(state)
+			return nil
+		},
+	}), nil
+}
+
+// transferEncryption describes the TLS of an established stream.
+func transferEncryption(state tls.ConnectionState) *pb.TransferEncryption {
+	return &pb.TransferEncryption{
+		Protocol:    tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+)
+
+// kubeletPodsDir is where the kubelet mounts pod volumes (mounted into the
+// agent with host-to-container propagation).
+const kubeletPodsDir = "/var/lib/kubelet/pods"
+
+// volumesRestoredFile is created in the marker volume once a restored pod's
+// volumes are filled; the pod's init container waits for it.
+const volumesRestoredFile = "restored"
+
+// emptyDirPath returns the host directory of an emptyDir volume of a pod.
+func emptyDirPath(podUID, volume string) (string, error) {
+	if volume == "" || strings.ContainsAny(volume, "/\\") || volume == "." || volume == ".." {
+		return "", fmt.Errorf("invalid volume name %q", volume)
+	}
+	return filepath.Join(kubeletPodsDir, podUID, "volumes", "kubernetes.io~empty-dir", volume), nil
+}
+
+// mountedTmpfs checks that dir is the tmpfs the kubelet mounts for a
+// memory-backed emptyDir, rather than the directory under it.
+func mountedTmpfs(dir string) error {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(dir, &stat); err != nil {
+		return fmt.Errorf("volume %s is not set up yet: %w", filepath.Base(dir), err)
+	}
+	if stat.Type != unix.TMPFS_MAGIC {
+		return fmt.Errorf("volume %s is not mounted yet", filepath.Base(dir))
+	}
+	return nil
+}
+
+// archiveVolumes writes the content of a pod's memory-backed emptyDir
+// volumes to a tar in shared storage, each under a directory named after the
+// volume, and returns its shared:// URI. The tar is published under its name
+// only once complete, like a checkpoint copy.
+func archiveVolumes(ctx context.Context, req *pb.CheckpointRequest, access artifactAccess) (string, error) {
+	name, err := artifactName(req, time.Now())
+	if err != nil {
+		return "", err
+	}
+	filename := name + "-volumes.tar"
+	dst := filepath.Join(sharedCheckpointDir, filename)
+	if err := access.mkdirs(filepath.Dir(filename)); err != nil {
+		return "", err
+	}
+	partial := partialPath(dst)
+	f, err := os.OpenFile(partial, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, artifactFileMode)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	published := false
+	defer func() {
+		if !published {
+			removePartial(log.FromContext(ctx), partial)
+		}
+	}()
+
+	hash := sha256.New()
+	tw := tar.NewWriter(io.MultiWriter(f, hash))
+	for _, volume := range req.MemoryVolumes {
+		dir, err := emptyDirPath(req.PodUid, volume)
+		if err != nil {
+			return "", err
+		}
+		if err := archiveDir(tw, dir, volume); err != nil {
+			return "", fmt.Errorf("failed to archive volume %s: %w", volume, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := publishArtifact(partial, dst, "sha256:"+hex.EncodeToString(hash.Sum(nil))); err != nil {
+		return "", err
+	}
+	published = true
+	if err := access.apply(dst); err != nil {
+		return "", err
+	}
+	return "shared://" + filename, nil
+}
+
+// archiveDir adds the tree under dir to tw, keeping modes, owners and
+// modification times. Sockets and device files are skipped.
+func archiveDir(tw *tar.Writer, dir, prefix string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		case !info.Mode().IsRegular() && !info.IsDir():
+			agentLog.Info("Skipping volume entry that is not a regular file, directory or symlink", "path", path, "volume", prefix)
+			return nil
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	})
+}
+
+// RestoreVolumes unpacks volume archives into the memory-backed emptyDir
+// volumes of a restored pod, then creates the marker file the pod's init
+// container waits for, so the restored processes find the files they had.
+func (s *CheckpointServer) RestoreVolumes(ctx context.Context, req *pb.RestoreVolumesRequest) (*pb.RestoreVolumesResponse, error) {
+	log.FromContext(ctx).Info("Restore volumes request", "podUID", req.PodUid, "archives", req.ArchiveUris)
+
+	markerDir, err := emptyDirPath(req.PodUid, req.MarkerVolume)
+	if err != nil {
+		return &pb.RestoreVolumesResponse{Error: err.Error()}, nil
+	}
+	if err := mountedTmpfs(markerDir); err != nil {
+		return &pb.RestoreVolumesResponse{Error: err.Error()}, nil
+	}
+	for _, uri := range req.ArchiveUris {
+		path, err := artifactPath(uri)
+		if err != nil {
+			return &pb.RestoreVolumesResponse{Error: err.Error()}, nil
+		}
+		if err := extractVolumes(path, req.PodUid); err != nil {
+			return &pb.RestoreVolumesResponse{Error: fmt.Sprintf("failed to restore %s: %v", uri, err)}, nil
+		}
+	}
+	if err := os.WriteFile(filepath.Join(markerDir, volumesRestoredFile), nil, 0o644); err != nil {
+		return &pb.RestoreVolumesResponse{Error: fmt.Sprintf("failed to mark volumes restored: %v", err)}, nil
+	}
+	return &pb.RestoreVolumesResponse{Success: true}, nil
+}
+
+// extractVolumes unpacks an archive written by archiveVolumes into the
+// volumes of the pod podUID, which must already be mounted.
+func extractVolumes(path, podUID string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	mounted := map[string]bool{}
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		volume, rel, _ := strings.Cut(strings.TrimSuffix(header.Name, "/"), "/")
+		dir, err := emptyDirPath(podUID, volume)
+		if err != nil {
+			return err
+		}
+		if !mounted[volume] {
+			if err := mountedTmpfs(dir); err != nil {
+				return err
+			}
+			mounted[volume] = true
+		}
+		if rel == "" {
+			rel = "."
+		}
+		if !filepath.IsLocal(rel) || throughSymlink(dir, rel) {
+			return fmt.Errorf("archive entry %s escapes its volume", header.Name)
+		}
+		if err := extractEntry(tr, header, filepath.Join(dir, rel)); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+// throughSymlink reports whether a parent directory of rel under dir is a
+// symlink, which writing the entry would follow out of the volume.
+func throughSymlink(dir, rel string) bool {
+	parent := dir
+	for _, name := range strings.Split(filepath.Dir(rel), string(filepath.Separator)) {
+		if name == "." {
+			continue
+		}
+		parent = filepath.Join(parent, name)
+		if info, err := os.Lstat(parent); err == nil && info.Mode()&os.ModeSymlink != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func extractEntry(r io.Reader, header *tar.Header, target string) error {
+	mode := header.FileInfo().Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+	// Never write through a symlink already in the volume
+	if info, err := os.Lstat(target); err == nil && (info.Mode()&os.ModeSymlink != 0 || header.Typeflag == tar.TypeSymlink) {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	switch header.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+	case tar.TypeReg:
+		dst, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|unix.O_NOFOLLOW, 0o600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(dst, r)
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		if err := os.Symlink(header.Linkname, target); err != nil {
+			return err
+		}
+		return os.Lchown(target, header.Uid, header.Gid)
+	default:
+		return nil
+	}
+	if err := os.Lchown(target, header.Uid, header.Gid); err != nil {
+		return err
+	}
+	// After chown, which clears setuid and setgid bits
+	if err := os.Chmod(target, mode); err != nil {
+		return err
+	}
+	return os.Chtimes(target, header.AccessTime, header.ModTime)
+}
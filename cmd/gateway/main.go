@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"my.domain/guestbook/internal/gateway"
+	"my.domain/guestbook/pkg/client/clientset/versioned"
+)
+
+var setupLog = ctrl.Log.WithName("setup")
+
+func main() {
+	var bindAddr, tokenFile, tlsCertFile, tlsKeyFile string
+	var insecure bool
+	flag.StringVar(&bindAddr, "bind-address", ":8090", "The address the gateway HTTPS server binds to.")
+	flag.StringVar(&tokenFile, "token-file", "/etc/lpm-gateway/tokens",
+		"File with one accepted bearer token per line.")
+	flag.StringVar(&tlsCertFile, "tls-cert-file", "", "Serving certificate. Required unless --insecure is set.")
+	flag.StringVar(&tlsKeyFile, "tls-key-file", "", "Serving key matching --tls-cert-file.")
+	flag.BoolVar(&insecure, "insecure", false,
+		"Serve plain HTTP without --tls-cert-file, sending the bearer tokens in the clear. Only for testing "+
+			"or behind a TLS-terminating proxy.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if (tlsCertFile == "") != (tlsKeyFile == "") {
+		setupLog.Error(errors.New("--tls-cert-file and --tls-key-file must be set together"), "Invalid TLS flags")
+		os.Exit(1)
+	}
+	if tlsCertFile == "" && !insecure {
+		setupLog.Error(errors.New("--tls-cert-file is required; set --insecure to serve plain HTTP"), "Refusing to serve bearer tokens in the clear")
+		os.Exit(1)
+	}
+
+	tokens, err := readTokens(tokenFile)
+	if err != nil {
+		setupLog.Error(err, "Failed to read API tokens")
+		os.Exit(1)
+	}
+
+	clientset, err := versioned.NewForConfig(ctrl.GetConfigOrDie())
+	if err != nil {
+		setupLog.Error(err, "Failed to create clientset")
+		os.Exit(1)
+	}
+
+	server, err := gateway.NewServer(clientset, tokens)
+	if err != nil {
+		setupLog.Error(err, "Failed to create gateway")
+		os.Exit(1)
+	}
+
+	httpServer := &http.Server{
+		Addr:              bindAddr,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+		<-sigChan
+
+		setupLog.Info("Shutting down gateway")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			setupLog.Error(err, "Graceful shutdown failed")
+		}
+	}()
+
+	setupLog.Info("Migration gateway listening", "address", bindAddr, "tls", tlsCertFile != "")
+	if tlsCertFile != "" {
+		err = httpServer.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		setupLog.Error(err, "Failed to serve")
+		os.Exit(1)
+	}
+}
+
+// readTokens loads the accepted bearer tokens, skipping blank lines and comments.
+func readTokens(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens found in %s", path)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// newApprovalCommand returns the approve or reject command, which sets the
+// Approved condition of a PodMigration to approved.
+func newApprovalCommand(approved bool) *cobra.Command {
+	var namespace, message string
+
+	use, status, reason, done := "approve", metav1.ConditionTrue, "Approved", "approved"
+	if !approved {
+		use, status, reason, done = "reject", metav1.ConditionFalse, "Rejected", "rejected"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <podmigration>",
+		Short: fmt.Sprintf("Set the Approved condition of a migration waiting for approval to %s", status),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			key := client.ObjectKey{Namespace: namespace, Name: args[0]}
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				var podMigration lpmv1.PodMigration
+				if err := c.Get(ctx, key, &podMigration); err != nil {
+					return err
+				}
+				meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+					Type:    lpmv1.MigrationConditionApproved,
+					Status:  status,
+					Reason:  reason,
+					Message: message,
+				})
+				return c.Status().Update(ctx, &podMigration)
+			})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "podmigration/%s %s\n", key.Name, done)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the PodMigration.")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Reason for the decision, e.g. a change request ID.")
+	return cmd
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+)
+
+// checkpointEntry is a PodCheckpointContent with what it is made of and how
+// it is used.
+type checkpointEntry struct {
+	Namespace  string    `json:"namespace"`
+	Content    string    `json:"content"`
+	Pod        string    `json:"pod"`
+	Checkpoint string    `json:"checkpoint"`
+	Created    time.Time `json:"created"`
+	SizeBytes  int64     `json:"sizeBytes"`
+	// Storage is where the archives are: "shared", or "node/<name>" for
+	// archives only on the node they were taken on.
+	Storage    []string `json:"storage"`
+	Containers []string `json:"containers"`
+	// InUse explains why the checkpoint must not be pruned, if it must not.
+	InUse string `json:"inUse,omitempty"`
+
+	contents []*lpmv1.ContainerCheckpointContent
+}
+
+func newCheckpointsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "checkpoints",
+		Short: "List and prune checkpoints",
+	}
+	cmd.AddCommand(newCheckpointsListCommand())
+	cmd.AddCommand(newCheckpointsPruneCommand())
+	return cmd
+}
+
+func newCheckpointsListCommand() *cobra.Command {
+	var namespace, output string
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List pod checkpoints with their size, age and storage",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format %q", output)
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				namespace = ""
+			}
+			entries, err := listCheckpoints(cmd.Context(), c, namespace)
+			if err != nil {
+				return err
+			}
+			if output == "json" {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return err
+			}
+			return printCheckpoints(cmd.OutOrStdout(), entries, time.Now())
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the checkpoints.")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List checkpoints in all namespaces.")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json.")
+	return cmd
+}
+
+func newCheckpointsPruneCommand() *cobra.Command {
+	var namespace string
+	var allNamespaces, dryRun bool
+	var olderThan time.Duration
+	var keep int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old checkpoints and their archives",
+		Long: `Prune deletes the checkpoints older than --older-than, or beyond the newest
+--keep of each pod, along with their archives on the nodes or shared storage.
+The latest checkpoint of a PodCheckpoint and the checkpoints of PodCheckpoints
+an unfinished migration restores are kept.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if olderThan <= 0 && keep < 0 {
+				return fmt.Errorf("set --older-than, --keep, or both")
+			}
+			ctx := cmd.Context()
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			if allNamespaces {
+				namespace = ""
+			}
+			entries, err := listCheckpoints(ctx, c, namespace)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			agentClient := agent.NewClient(c)
+			now := time.Now()
+			var pruned, failed int
+			var freed int64
+			for _, entry := range selectPrunable(entries, olderThan, keep, now) {
+				name := entry.Namespace + "/" + entry.Content
+				if entry.InUse != "" {
+					fmt.Fprintf(out, "keeping %s: %s\n", name, entry.InUse)
+					continue
+				}
+				if dryRun {
+					fmt.Fprintf(out, "would delete %s (%s, %s old)\n", name, formatBytes(entry.SizeBytes), formatAge(now.Sub(entry.Created)))
+					continue
+				}
+				bytes, err := pruneCheckpoint(ctx, c, agentClient, entry)
+				if err != nil {
+					fmt.Fprintf(out, "failed to delete %s: %v\n", name, err)
+					failed++
+					continue
+				}
+				fmt.Fprintf(out, "deleted %s (%s freed)\n", name, formatBytes(bytes))
+				pruned++
+				freed += bytes
+			}
+			if !dryRun {
+				fmt.Fprintf(out, "%d checkpoints deleted, %s freed\n", pruned, formatBytes(freed))
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d checkpoints could not be deleted", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the checkpoints.")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Prune checkpoints in all namespaces.")
+	cmd.Flags().DurationVar(&olderThan, "older-than", 0, "Delete checkpoints older than this, e.g. 72h.")
+	cmd.Flags().IntVar(&keep, "keep", -1, "Delete all but the newest this many checkpoints of each pod.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only print what would be deleted.")
+	return cmd
+}
+
+// listCheckpoints gathers the PodCheckpointContents in namespace, or all
+// namespaces if empty, newest first.
+func listCheckpoints(ctx context.Context, c client.Client, namespace string) ([]*checkpointEntry, error) {
+	var podCheckpointContents lpmv1.PodCheckpointContentList
+	if err := c.List(ctx, &podCheckpointContents, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var podCheckpoints lpmv1.PodCheckpointList
+	if err := c.List(ctx, &podCheckpoints, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	latest := map[string]string{}
+	for _, podCheckpoint := range podCheckpoints.Items {
+		if podCheckpoint.Status.BoundContentName != "" {
+			latest[podCheckpoint.Namespace+"/"+podCheckpoint.Status.BoundContentName] = podCheckpoint.Name
+		}
+	}
+	var podMigrations lpmv1.PodMigrationList
+	if err := c.List(ctx, &podMigrations, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	restoring := map[string]string{}
+	for _, podMigration := range podMigrations.Items {
+		phase := podMigration.Status.Phase
+		if ref := podMigration.Status.PodCheckpointRef; ref != nil && phase != lpmv1.MigrationPhaseSucceeded && phase != lpmv1.MigrationPhaseFailed {
+			restoring[podMigration.Namespace+"/"+ref.Name] = podMigration.Name
+		}
+	}
+
+	var entries []*checkpointEntry
+	for _, podCheckpointContent := range podCheckpointContents.Items {
+		entry := &checkpointEntry{
+			Namespace:  podCheckpointContent.Namespace,
+			Content:    podCheckpointContent.Name,
+			Pod:        podCheckpointContent.Spec.PodName,
+			Checkpoint: podCheckpointContent.Spec.PodCheckpointRef.Name,
+			Created:    podCheckpointContent.CreationTimestamp.Time,
+		}
+		if created := podCheckpointContent.Status.CreationTime; created != nil {
+			entry.Created = created.Time
+		}
+		storage := sets.New[string]()
+		for _, ref := range podCheckpointContent.Spec.ContainerContents {
+			var content lpmv1.ContainerCheckpointContent
+			if err := c.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					return nil, err
+				}
+				continue
+			}
+			entry.contents = append(entry.contents, &content)
+			entry.Containers = append(entry.Containers, content.Spec.ContainerName)
+			entry.SizeBytes += content.Spec.ArtifactSizeBytes
+			storage.Insert(artifactStorage(&content))
+		}
+		entry.Storage = sets.List(storage)
+
+		checkpointKey := entry.Namespace + "/" + entry.Checkpoint
+		switch {
+		case restoring[checkpointKey] != "":
+			entry.InUse = "restored by podmigration/" + restoring[checkpointKey]
+		case latest[entry.Namespace+"/"+entry.Content] != "":
+			entry.InUse = "latest checkpoint of podcheckpoint/" + entry.Checkpoint
+		}
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Created.After(entries[j].Created) })
+	return entries, nil
+}
+
+// artifactStorage describes where the archive of content is.
+func artifactStorage(content *lpmv1.ContainerCheckpointContent) string {
+	if strings.HasPrefix(content.Spec.ArtifactURI, "shared://") {
+		return "shared"
+	}
+	return "node/" + content.Spec.NodeName
+}
+
+// selectPrunable returns the entries older than olderThan, if set, and
+// those beyond the newest keep of each pod, if keep is not negative.
+// entries must be sorted newest first.
+func selectPrunable(entries []*checkpointEntry, olderThan time.Duration, keep int, now time.Time) []*checkpointEntry {
+	var selected []*checkpointEntry
+	seen := map[string]int{}
+	for _, entry := range entries {
+		pod := entry.Namespace + "/" + entry.Pod
+		seen[pod]++
+		if (olderThan > 0 && now.Sub(entry.Created) > olderThan) || (keep >= 0 && seen[pod] > keep) {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
+
+// pruneCheckpoint removes the archives of entry through the agents, then
+// its objects, and drops it from its PodCheckpoint's history. Objects are
+// only deleted once their archives are, so a failed prune can be retried.
+func pruneCheckpoint(ctx context.Context, c client.Client, agentClient *agent.Client, entry *checkpointEntry) (int64, error) {
+	var freed int64
+	for _, content := range entry.contents {
+		nodeName := content.Spec.NodeName
+		if nodeName == "" {
+			var err error
+			if nodeName, err = pickAgentNode(ctx, c, content); err != nil {
+				return freed, err
+			}
+		}
+		bytes, err := agentClient.DeleteCheckpoint(ctx, nodeName, []string{content.Spec.ArtifactURI, content.Spec.VolumesArtifactURI})
+		if err != nil {
+			return freed, fmt.Errorf("container %s: %w", content.Spec.ContainerName, err)
+		}
+		freed += bytes
+
+		if err := c.Delete(ctx, content); client.IgnoreNotFound(err) != nil {
+			return freed, err
+		}
+		ref := content.Spec.ContainerCheckpointRef
+		containerCheckpoint := &lpmv1.ContainerCheckpoint{}
+		containerCheckpoint.Namespace, containerCheckpoint.Name = ref.Namespace, ref.Name
+		if err := c.Delete(ctx, containerCheckpoint); client.IgnoreNotFound(err) != nil {
+			return freed, err
+		}
+	}
+
+	podCheckpointContent := &lpmv1.PodCheckpointContent{}
+	podCheckpointContent.Namespace, podCheckpointContent.Name = entry.Namespace, entry.Content
+	if err := c.Delete(ctx, podCheckpointContent); client.IgnoreNotFound(err) != nil {
+		return freed, err
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var podCheckpoint lpmv1.PodCheckpoint
+		if err := c.Get(ctx, client.ObjectKey{Namespace: entry.Namespace, Name: entry.Checkpoint}, &podCheckpoint); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		history := slices.DeleteFunc(slices.Clone(podCheckpoint.Status.History), func(generation lpmv1.PodCheckpointGeneration) bool {
+			return generation.ContentName == entry.Content
+		})
+		if len(history) == len(podCheckpoint.Status.History) {
+			return nil
+		}
+		podCheckpoint.Status.History = history
+		return c.Status().Update(ctx, &podCheckpoint)
+	})
+	return freed, err
+}
+
+func printCheckpoints(out io.Writer, entries []*checkpointEntry, now time.Time) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tCONTENT\tPOD\tCHECKPOINT\tCONTAINERS\tSIZE\tAGE\tSTORAGE\tIN USE")
+	var total int64
+	for _, entry := range entries {
+		inUse := entry.InUse
+		if inUse == "" {
+			inUse = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n", entry.Namespace, entry.Content, entry.Pod, entry.Checkpoint,
+			len(entry.Containers), formatBytes(entry.SizeBytes), formatAge(now.Sub(entry.Created)), strings.Join(entry.Storage, ","), inUse)
+		total += entry.SizeBytes
+	}
+	fmt.Fprintf(w, "%d checkpoints, %s\n", len(entries), formatBytes(total))
+	return w.Flush()
+}
+
+// formatAge formats d like kubectl's AGE column.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+)
+
+func newDiffCommand() *cobra.Command {
+	var nodeName, output string
+
+	cmd := &cobra.Command{
+		Use:   "diff <base-containercheckpointcontent> <containercheckpointcontent>",
+		Short: "Compare two checkpoints of the same container",
+		Long: `Diff reports how much memory changed between two checkpoints of a container,
+which files of its writable layer were added, modified, or removed, and which
+processes started or exited. A small memory delta relative to the total means
+the workload converges quickly under iterative pre-copy.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format %q", output)
+			}
+
+			ctx := cmd.Context()
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			var base, content lpmv1.ContainerCheckpointContent
+			if err := c.Get(ctx, client.ObjectKey{Name: args[0]}, &base); err != nil {
+				return err
+			}
+			if err := c.Get(ctx, client.ObjectKey{Name: args[1]}, &content); err != nil {
+				return err
+			}
+			if base.Spec.PodNamespace != content.Spec.PodNamespace || base.Spec.PodName != content.Spec.PodName ||
+				base.Spec.ContainerName != content.Spec.ContainerName {
+				return fmt.Errorf("%s and %s are checkpoints of different containers", base.Name, content.Name)
+			}
+
+			if nodeName == "" {
+				if nodeName, err = pickAgentNode(ctx, c, &content); err != nil {
+					return err
+				}
+			}
+
+			resp, err := agent.NewClient(c).DiffCheckpoints(ctx, nodeName, base.Spec.ArtifactURI, content.Spec.ArtifactURI)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return err
+			}
+			printDiff(cmd.OutOrStdout(), resp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&nodeName, "node", "",
+		"Node whose agent reads the archives. Defaults to the checkpointed pod's node, or any ready node for shared artifacts.")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json.")
+	return cmd
+}
+
+func printDiff(out io.Writer, resp *pb.DiffCheckpointsResponse) {
+	percent := 0.0
+	if resp.TotalMemoryBytes > 0 {
+		percent = 100 * float64(resp.ChangedMemoryBytes) / float64(resp.TotalMemoryBytes)
+	}
+	fmt.Fprintf(out, "Memory: %s of %s changed (%.1f%%)\n",
+		formatBytes(resp.ChangedMemoryBytes), formatBytes(resp.TotalMemoryBytes), percent)
+
+	fmt.Fprintf(out, "Files: %d added, %d modified, %d removed\n",
+		len(resp.AddedFiles), len(resp.ModifiedFiles), len(resp.RemovedFiles))
+	for _, group := range []struct {
+		prefix string
+		paths  []string
+	}{{"+", resp.AddedFiles}, {"~", resp.ModifiedFiles}, {"-", resp.RemovedFiles}} {
+		for _, p := range group.paths {
+			fmt.Fprintf(out, "  %s %s\n", group.prefix, p)
+		}
+	}
+
+	fmt.Fprintf(out, "Processes: %d new, %d exited\n", len(resp.NewProcesses), len(resp.ExitedProcesses))
+	for _, process := range resp.NewProcesses {
+		fmt.Fprintf(out, "  + %d %s\n", process.Pid, strings.TrimSpace(process.Command))
+	}
+	for _, process := range resp.ExitedProcesses {
+		fmt.Fprintf(out, "  - %d %s\n", process.Pid, strings.TrimSpace(process.Command))
+	}
+}
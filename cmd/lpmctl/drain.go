@@ -0,0 +1,386 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// drainPollInterval is how often drain checks on its migrations.
+const drainPollInterval = 2 * time.Second
+
+// drainItem is a pod drain moves and the migration moving it.
+type drainItem struct {
+	pod       *corev1.Pod
+	migration *lpmv1.PodMigration
+	started   time.Time
+	ended     time.Time
+}
+
+func (item *drainItem) phase() lpmv1.PodMigrationPhase {
+	switch {
+	case item.migration == nil:
+		return "Waiting"
+	case item.migration.Status.Phase == "":
+		return lpmv1.MigrationPhasePending
+	}
+	return item.migration.Status.Phase
+}
+
+func (item *drainItem) done() bool {
+	phase := item.phase()
+	return phase == lpmv1.MigrationPhaseSucceeded || phase == lpmv1.MigrationPhaseFailed
+}
+
+func newDrainCommand() *cobra.Command {
+	var selector, targetNode string
+	var parallel int
+	var timeout time.Duration
+	var keepCordoned bool
+
+	cmd := &cobra.Command{
+		Use:   "drain <node>",
+		Short: "Cordon a node and live-migrate its pods off it",
+		Long: `Drain cordons the node, then creates a PodMigration for each pod on it that
+can be migrated, at most --parallel at a time, and shows their progress until
+they end. Pods of DaemonSets, static pods, system critical pods and pods
+already being migrated are skipped.
+
+When every migration succeeds the node is left cordoned, like kubectl drain.
+When one fails the node is uncordoned again, unless it was cordoned before or
+--keep-cordoned is set, and the failures are reported.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if parallel < 1 {
+				return fmt.Errorf("--parallel must be at least 1")
+			}
+			podSelector, err := labels.Parse(selector)
+			if err != nil {
+				return fmt.Errorf("invalid selector: %w", err)
+			}
+
+			ctx := cmd.Context()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			nodeName := args[0]
+
+			var node corev1.Node
+			if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+				return err
+			}
+			wasCordoned := node.Spec.Unschedulable
+			if !wasCordoned {
+				if err := setUnschedulable(ctx, c, &node, true); err != nil {
+					return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+				}
+				fmt.Fprintf(out, "node/%s cordoned\n", nodeName)
+			}
+
+			items, err := drainItems(ctx, c, out, nodeName, podSelector)
+			if err != nil {
+				return err
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "node/%s drained: no pods to migrate\n", nodeName)
+				return nil
+			}
+
+			runErr := runDrain(ctx, c, out, nodeName, targetNode, parallel, items)
+
+			var failed []*drainItem
+			for _, item := range items {
+				if item.phase() != lpmv1.MigrationPhaseSucceeded {
+					failed = append(failed, item)
+				}
+			}
+			if runErr == nil && len(failed) == 0 {
+				fmt.Fprintf(out, "node/%s drained: %d pods migrated\n", nodeName, len(items))
+				return nil
+			}
+
+			fmt.Fprintln(out)
+			for _, item := range failed {
+				message := "not migrated"
+				if item.migration != nil {
+					message = fmt.Sprintf("podmigration/%s %s", item.migration.Name, item.phase())
+					if item.migration.Status.Message != "" {
+						message += ": " + item.migration.Status.Message
+					}
+				}
+				fmt.Fprintf(out, "%s/%s: %s\n", item.pod.Namespace, item.pod.Name, message)
+			}
+			if !wasCordoned && !keepCordoned {
+				// The interrupted context can no longer be used to undo the cordon
+				uncordonCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				if err := c.Get(uncordonCtx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+					return fmt.Errorf("failed to uncordon node %s: %w", nodeName, err)
+				}
+				if err := setUnschedulable(uncordonCtx, c, &node, false); err != nil {
+					return fmt.Errorf("failed to uncordon node %s: %w", nodeName, err)
+				}
+				fmt.Fprintf(out, "node/%s uncordoned\n", nodeName)
+			}
+			if runErr != nil {
+				return fmt.Errorf("drain of node %s interrupted with %d of %d pods not migrated: %w", nodeName, len(failed), len(items), runErr)
+			}
+			return fmt.Errorf("%d of %d pods on node %s were not migrated", len(failed), len(items), nodeName)
+		},
+	}
+
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Only migrate the pods matching this label selector.")
+	cmd.Flags().StringVar(&targetNode, "target-node", "", "Node to migrate the pods to. Unset, the controller picks a node for each pod.")
+	cmd.Flags().IntVar(&parallel, "parallel", 4, "Number of migrations run at the same time.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up after this long; migrations already created keep running. Zero waits forever.")
+	cmd.Flags().BoolVar(&keepCordoned, "keep-cordoned", false, "Leave the node cordoned when a migration fails.")
+	return cmd
+}
+
+func setUnschedulable(ctx context.Context, c client.Client, node *corev1.Node, unschedulable bool) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+	return c.Patch(ctx, node, patch)
+}
+
+// drainItems lists the pods on nodeName that drain migrates, printing those
+// it skips and why.
+func drainItems(ctx context.Context, c client.Client, out io.Writer, nodeName string, selector labels.Selector) ([]*drainItem, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.MatchingFields{"spec.nodeName": nodeName}, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	var podMigrations lpmv1.PodMigrationList
+	if err := c.List(ctx, &podMigrations); err != nil {
+		return nil, err
+	}
+	migrating := map[string]string{}
+	for _, podMigration := range podMigrations.Items {
+		if phase := podMigration.Status.Phase; phase != lpmv1.MigrationPhaseSucceeded && phase != lpmv1.MigrationPhaseFailed {
+			migrating[podMigration.Namespace+"/"+podMigration.Spec.PodName] = podMigration.Name
+		}
+	}
+
+	var items []*drainItem
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		reason := drainSkipReason(pod, migrating)
+		if reason == "" && policy.IsCritical(pod) {
+			policies, err := policy.Matching(ctx, c, pod)
+			if err != nil {
+				return nil, err
+			}
+			reason = policy.CriticalPodDenied(policies, pod)
+		}
+		if reason != "" {
+			fmt.Fprintf(out, "skipping %s/%s: %s\n", pod.Namespace, pod.Name, reason)
+			continue
+		}
+		items = append(items, &drainItem{pod: pod})
+	}
+	return items, nil
+}
+
+// drainSkipReason returns why drain leaves pod alone, or "" if it migrates
+// it. The controller would reject the pods skipped in preflight.
+func drainSkipReason(pod *corev1.Pod, migrating map[string]string) string {
+	owner := metav1.GetControllerOf(pod)
+	switch {
+	case pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+		return "finished"
+	case pod.DeletionTimestamp != nil:
+		return "being deleted"
+	case owner != nil && owner.Kind == "DaemonSet":
+		return "DaemonSet pod"
+	case owner != nil && owner.Kind == "Node":
+		return "static pod"
+	case migrating[pod.Namespace+"/"+pod.Name] != "":
+		return "already being migrated by podmigration/" + migrating[pod.Namespace+"/"+pod.Name]
+	}
+	if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+		return "static pod"
+	}
+	return ""
+}
+
+// runDrain creates the migrations of items, keeping at most parallel of them
+// running, and shows their progress until all have ended or ctx is done.
+func runDrain(ctx context.Context, c client.Client, out io.Writer, nodeName, targetNode string, parallel int, items []*drainItem) error {
+	progress := newDrainProgress(out)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		running, remaining := 0, 0
+		for _, item := range items {
+			if item.migration == nil || item.done() {
+				continue
+			}
+			var podMigration lpmv1.PodMigration
+			if err := c.Get(ctx, client.ObjectKeyFromObject(item.migration), &podMigration); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				progress.note(fmt.Sprintf("failed to get podmigration/%s: %v", item.migration.Name, err))
+				continue
+			}
+			item.migration = &podMigration
+			if item.done() {
+				item.ended = time.Now()
+			}
+		}
+		for _, item := range items {
+			switch {
+			case item.migration == nil:
+				remaining++
+			case !item.done():
+				running++
+				remaining++
+			}
+		}
+		for _, item := range items {
+			if running >= parallel {
+				break
+			}
+			if item.migration != nil {
+				continue
+			}
+			podMigration := &lpmv1.PodMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: item.pod.Name + "-drain-",
+					Namespace:    item.pod.Namespace,
+					Labels:       map[string]string{lpmv1.DrainNodeLabel: nodeName},
+				},
+				Spec: lpmv1.PodMigrationSpec{PodName: item.pod.Name, TargetNode: targetNode},
+			}
+			if err := c.Create(ctx, podMigration); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return fmt.Errorf("failed to create migration of pod %s/%s: %w", item.pod.Namespace, item.pod.Name, err)
+			}
+			item.migration = podMigration
+			item.started = time.Now()
+			running++
+		}
+
+		progress.render(items)
+		if remaining == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainProgress shows the state of a drain's migrations. On a terminal it
+// redraws a table in place; otherwise it prints a line whenever a
+// migration's phase changes.
+type drainProgress struct {
+	view   *liveView
+	notes  []string
+	phases map[*drainItem]lpmv1.PodMigrationPhase
+}
+
+func newDrainProgress(out io.Writer) *drainProgress {
+	return &drainProgress{view: newLiveView(out), phases: map[*drainItem]lpmv1.PodMigrationPhase{}}
+}
+
+// note shows message below the table on the next render.
+func (p *drainProgress) note(message string) {
+	if !p.view.terminal {
+		fmt.Fprintln(p.view.out, message)
+		return
+	}
+	p.notes = append(p.notes, message)
+}
+
+func (p *drainProgress) render(items []*drainItem) {
+	if !p.view.terminal {
+		for _, item := range items {
+			phase := item.phase()
+			if item.migration == nil || p.phases[item] == phase {
+				continue
+			}
+			p.phases[item] = phase
+			line := fmt.Sprintf("%s/%s: podmigration/%s %s", item.pod.Namespace, item.pod.Name, item.migration.Name, phase)
+			if target := item.migration.Spec.TargetNode; target != "" {
+				line += " -> " + target
+			}
+			if message := item.migration.Status.Message; message != "" && item.done() {
+				line += ": " + message
+			}
+			fmt.Fprintln(p.view.out, line)
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tPOD\tMIGRATION\tPHASE\tTARGET\tELAPSED\tMESSAGE")
+	done := 0
+	for _, item := range items {
+		name, target, elapsed, message := "", "", "", ""
+		if item.migration != nil {
+			name = item.migration.Name
+			target = item.migration.Spec.TargetNode
+			message = item.migration.Status.Message
+			end := item.ended
+			if end.IsZero() {
+				end = time.Now()
+			}
+			elapsed = end.Sub(item.started).Round(time.Second).String()
+		}
+		if item.done() {
+			done++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", item.pod.Namespace, item.pod.Name, name, item.phase(), target, elapsed,
+			truncate(message, statusMessageWidth))
+	}
+	w.Flush()
+	fmt.Fprintf(&buf, "%d/%d migrations ended\n", done, len(items))
+	for _, note := range p.notes {
+		fmt.Fprintln(&buf, note)
+	}
+	p.notes = nil
+
+	p.view.show(buf.String())
+}
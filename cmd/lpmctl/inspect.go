@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+)
+
+func newInspectCommand() *cobra.Command {
+	var nodeName, output string
+	var summarize, fds bool
+
+	cmd := &cobra.Command{
+		Use:   "inspect <containercheckpointcontent>",
+		Short: "Show what a container checkpoint contains",
+		Long: `Inspect opens the checkpoint archive of a ContainerCheckpointContent on a
+node agent and prints its process tree, open files, dumped memory, and the
+image the container was started from.
+
+Agents store a small summary of the process tree and file descriptor tables
+next to every archive they dump, so inspecting reads a few kilobytes rather
+than the archive. Archives dumped by older agents are read in full unless
+--summarize stores a summary for them first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format %q", output)
+			}
+
+			ctx := cmd.Context()
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			var content lpmv1.ContainerCheckpointContent
+			if err := c.Get(ctx, client.ObjectKey{Name: args[0]}, &content); err != nil {
+				return err
+			}
+
+			if nodeName == "" {
+				if nodeName, err = pickAgentNode(ctx, c, &content); err != nil {
+					return err
+				}
+			}
+
+			agentClient := agent.NewClient(c)
+			if summarize {
+				if _, err := agentClient.SummarizeCheckpoint(ctx, nodeName, content.Spec.ArtifactURI); err != nil {
+					return err
+				}
+			}
+			resp, err := agentClient.InspectCheckpoint(ctx, nodeName, content.Spec.ArtifactURI)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := protojson.MarshalOptions{Multiline: true}.Marshal(resp)
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+				return err
+			}
+			return printInspection(cmd.OutOrStdout(), &content, resp, fds)
+		},
+	}
+
+	cmd.Flags().StringVar(&nodeName, "node", "",
+		"Node whose agent reads the archive. Defaults to the checkpointed pod's node, or any ready node for shared artifacts.")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table or json.")
+	cmd.Flags().BoolVar(&summarize, "summarize", false,
+		"Store a summary next to the archive before inspecting it, for archives dumped without one.")
+	cmd.Flags().BoolVar(&fds, "fds", false, "List the file descriptors of each process.")
+	return cmd
+}
+
+// pickAgentNode chooses a node whose agent can read the artifact. Local
+// (file://) artifacts only exist on the source pod's node.
+func pickAgentNode(ctx context.Context, c client.Client, content *lpmv1.ContainerCheckpointContent) (string, error) {
+	var pod corev1.Pod
+	err := c.Get(ctx, client.ObjectKey{Namespace: content.Spec.PodNamespace, Name: content.Spec.PodName}, &pod)
+	if err == nil && pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName, nil
+	}
+	if client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(content.Spec.ArtifactURI, "shared://") {
+		return "", fmt.Errorf("source pod %s/%s is gone and %s is node-local; pass --node",
+			content.Spec.PodNamespace, content.Spec.PodName, content.Spec.ArtifactURI)
+	}
+
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes); err != nil {
+		return "", err
+	}
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				return node.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no ready node found; pass --node")
+}
+
+func printInspection(out io.Writer, content *lpmv1.ContainerCheckpointContent, resp *pb.InspectCheckpointResponse, fds bool) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Content:\t%s\n", content.Name)
+	fmt.Fprintf(w, "Pod:\t%s/%s\n", content.Spec.PodNamespace, content.Spec.PodName)
+	fmt.Fprintf(w, "Container:\t%s\n", content.Spec.ContainerName)
+	fmt.Fprintf(w, "Artifact:\t%s (%s)\n", content.Spec.ArtifactURI, formatBytes(resp.ArchiveSize))
+	fmt.Fprintf(w, "Runtime:\t%s\n", resp.Runtime)
+	fmt.Fprintf(w, "Checkpointed:\t%s\n", resp.CheckpointedAt)
+	fmt.Fprintf(w, "Image:\t%s\n", resp.RootfsImageName)
+	fmt.Fprintf(w, "Image ID:\t%s\n", resp.RootfsImageRef)
+	fmt.Fprintf(w, "Rootfs diff:\t%s\n", formatBytes(resp.RootfsDiffSize))
+	if resp.FromSummary {
+		fmt.Fprintf(w, "Read from:\tsummary\n")
+	} else {
+		fmt.Fprintf(w, "Read from:\tarchive\n")
+	}
+
+	fmt.Fprintln(w, "\nPROCESS\tPPID\tCOMMAND\tSEGMENTS\tMEMORY")
+	var totalMemory int64
+	for _, process := range resp.Processes {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%s\n",
+			process.Pid, process.Ppid, process.Command, process.MemorySegments, formatBytes(process.MemoryBytes))
+		totalMemory += process.MemoryBytes
+	}
+	fmt.Fprintf(w, "\t\t\t\t%s total\n", formatBytes(totalMemory))
+
+	fmt.Fprintln(w, "\nFILE TYPE\tCOUNT")
+	types := make([]string, 0, len(resp.OpenFiles))
+	for fileType := range resp.OpenFiles {
+		types = append(types, fileType)
+	}
+	sort.Strings(types)
+	for _, fileType := range types {
+		fmt.Fprintf(w, "%s\t%d\n", fileType, resp.OpenFiles[fileType])
+	}
+
+	if fds {
+		fmt.Fprintln(w, "\nPROCESS\tFD\tTYPE\tPATH")
+		for _, process := range resp.Processes {
+			for _, file := range process.OpenFiles {
+				fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", process.Pid, file.Fd, file.Type, file.Path)
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// liveView shows text that changes over time. On a terminal it redraws the
+// text in place; otherwise it prints the text again whenever it changes.
+type liveView struct {
+	out      io.Writer
+	terminal bool
+	lines    int
+	last     string
+}
+
+func newLiveView(out io.Writer) *liveView {
+	view := &liveView{out: out}
+	if file, ok := out.(*os.File); ok {
+		if info, err := file.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			view.terminal = true
+		}
+	}
+	return view
+}
+
+func (v *liveView) show(text string) {
+	if text == v.last {
+		return
+	}
+	v.last = text
+	if !v.terminal {
+		fmt.Fprint(v.out, text)
+		return
+	}
+	if v.lines > 0 {
+		// Move back up to the text drawn last and clear it
+		fmt.Fprintf(v.out, "\x1b[%dA\x1b[J", v.lines)
+	}
+	v.lines = strings.Count(text, "\n")
+	fmt.Fprint(v.out, text)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// lpmctl is the command-line client for live pod migration. Installed on the
+// PATH as kubectl-lpm it also works as a kubectl plugin ("kubectl lpm ...").
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(lpmv1.AddToScheme(scheme))
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "lpmctl",
+		Short:        "Inspect and drive live pod migrations",
+		SilenceUsage: true,
+	}
+	// --kubeconfig is registered by controller-runtime on the Go flag set.
+	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+
+	cmd.AddCommand(newInspectCommand())
+	cmd.AddCommand(newDiffCommand())
+	cmd.AddCommand(newApprovalCommand(true))
+	cmd.AddCommand(newApprovalCommand(false))
+	cmd.AddCommand(newDrainCommand())
+	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newCheckpointsCommand())
+	cmd.AddCommand(newRestoreCommand())
+	return cmd
+}
+
+// newClient returns a client for the cluster selected by --kubeconfig,
+// $KUBECONFIG, or the in-cluster config.
+func newClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
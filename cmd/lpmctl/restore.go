@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+func newRestoreCommand() *cobra.Command {
+	var namespace, checkpoint, nodeName, podName, quarantine string
+	var generation int64
+	var timeout time.Duration
+	var wait, force bool
+
+	cmd := &cobra.Command{
+		Use:   "restore --from-checkpoint <podcheckpoint> --node <node>",
+		Short: "Start a pod from a checkpoint",
+		Long: `Restore creates a PodRestore starting a new pod on --node from a
+PodCheckpoint, by default from its latest generation, and waits until the pod
+runs from the checkpoint. The pod is named after the checkpointed pod unless
+--as names it; the checkpointed pod, if it still exists, is left alone.
+A checkpoint older than the pod's MigrationPolicies allow is only restored
+with --force.
+
+--quarantine restores a copy to debug in a namespace, lpm-quarantine unless
+named, where a NetworkPolicy denies all traffic. The copy loses its labels,
+annotations, service account, Secrets, ConfigMaps and volumes, so it
+neither serves traffic nor reaches the pod's dependencies:
+
+  lpmctl restore --from-checkpoint web-checkpoint --node worker-3 --quarantine`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+
+			podRestore := &lpmv1.PodRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: checkpoint + "-restore-",
+					Namespace:    namespace,
+				},
+				Spec: lpmv1.PodRestoreSpec{
+					CheckpointRef: lpmv1.CheckpointReference{Name: checkpoint, AllowStale: force},
+					NodeName:      nodeName,
+					PodName:       podName,
+				},
+			}
+			if cmd.Flags().Changed("generation") {
+				podRestore.Spec.CheckpointRef.Generation = &generation
+			}
+			if cmd.Flags().Changed("quarantine") {
+				podRestore.Spec.Quarantine = &lpmv1.RestoreQuarantine{Namespace: quarantine}
+			}
+			if err := c.Create(ctx, podRestore); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "podrestore/%s created\n", podRestore.Name)
+			if !wait {
+				return nil
+			}
+
+			ticker := time.NewTicker(statusPollInterval)
+			defer ticker.Stop()
+			var lastPhase lpmv1.PodRestorePhase
+			lastMessage := ""
+			for {
+				if err := c.Get(ctx, client.ObjectKeyFromObject(podRestore), podRestore); err != nil {
+					return err
+				}
+				status := podRestore.Status
+				if status.Phase != lastPhase || status.Message != lastMessage {
+					lastPhase, lastMessage = status.Phase, status.Message
+					if status.Phase != "" {
+						fmt.Fprintf(out, "%s: %s\n", status.Phase, status.Message)
+					}
+				}
+				switch status.Phase {
+				case lpmv1.RestorePhaseSucceeded:
+					if podRestore.Spec.Quarantine != nil {
+						fmt.Fprintf(out, "pod/%s restored in quarantine namespace %s on node %s\n",
+							status.RestoredPodName, podRestore.Spec.Quarantine.Namespace, podRestore.Spec.NodeName)
+						return nil
+					}
+					fmt.Fprintf(out, "pod/%s restored on node %s\n", status.RestoredPodName, podRestore.Spec.NodeName)
+					return nil
+				case lpmv1.RestorePhaseFailed:
+					return fmt.Errorf("podrestore/%s failed: %s", podRestore.Name, status.Message)
+				}
+
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("gave up waiting for podrestore/%s, which keeps running: %w", podRestore.Name, ctx.Err())
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the PodCheckpoint and, unless quarantined, the restored pod.")
+	cmd.Flags().StringVar(&checkpoint, "from-checkpoint", "", "PodCheckpoint to restore.")
+	cmd.Flags().Int64Var(&generation, "generation", 0, "Checkpoint generation to restore. Defaults to the latest.")
+	cmd.Flags().StringVar(&nodeName, "node", "", "Node to restore the pod on.")
+	cmd.Flags().StringVar(&podName, "as", "", "Name of the restored pod. Defaults to the checkpointed pod's name.")
+	cmd.Flags().StringVar(&quarantine, "quarantine", "", "Restore a network-isolated copy, stripped of its labels and credentials, in this namespace.")
+	cmd.Flags().Lookup("quarantine").NoOptDefVal = lpmv1.DefaultQuarantineNamespace
+	cmd.Flags().BoolVar(&force, "force", false, "Restore the checkpoint even if it is older than the pod's MigrationPolicies allow.")
+	cmd.Flags().BoolVar(&wait, "wait", true, "Wait until the restore succeeds or fails.")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Give up waiting after this long; the restore keeps running. Zero waits forever.")
+	_ = cmd.MarkFlagRequired("from-checkpoint")
+	_ = cmd.MarkFlagRequired("node")
+	return cmd
+}
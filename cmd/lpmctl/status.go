@@ -0,0 +1,296 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// The labels the controller sets on the ContainerCheckpoints of a
+// PodCheckpoint generation.
+const (
+	podCheckpointLabel        = "podcheckpoint"
+	checkpointGenerationLabel = "podcheckpoint-generation"
+)
+
+// statusPollInterval is how often status --watch reads the migration.
+const statusPollInterval = 2 * time.Second
+
+// statusMessageWidth is where messages in status tables are cut off.
+const statusMessageWidth = 60
+
+// containerProgress is the checkpoint of one container of a migration.
+type containerProgress struct {
+	checkpoint lpmv1.ContainerCheckpoint
+	content    *lpmv1.ContainerCheckpointContent
+}
+
+func newStatusCommand() *cobra.Command {
+	var namespace string
+	var watch bool
+
+	cmd := &cobra.Command{
+		Use:   "status <podmigration>",
+		Short: "Show the timeline and progress of a migration",
+		Long: `Status shows a migration's phase timeline, conditions, the checkpoint of
+each container with its size, and the downtime: predicted before the
+checkpoint, so far while restoring, and measured once the migration
+succeeded. With --watch it follows the migration until it ends.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			key := client.ObjectKey{Namespace: namespace, Name: args[0]}
+			view := newLiveView(cmd.OutOrStdout())
+
+			ticker := time.NewTicker(statusPollInterval)
+			defer ticker.Stop()
+			lastVersion := ""
+			for {
+				var podMigration lpmv1.PodMigration
+				if err := c.Get(ctx, key, &podMigration); err != nil {
+					return err
+				}
+				containers, err := checkpointProgress(ctx, c, &podMigration)
+				if err != nil {
+					return err
+				}
+				// Off a terminal, only print again when something changed
+				// rather than every time the elapsed times tick
+				version := podMigration.ResourceVersion
+				for _, container := range containers {
+					version += "/" + container.checkpoint.ResourceVersion
+				}
+				if view.terminal || version != lastVersion {
+					lastVersion = version
+					var buf bytes.Buffer
+					printStatus(&buf, &podMigration, containers, time.Now())
+					view.show(buf.String())
+				}
+
+				phase := podMigration.Status.Phase
+				if !watch || phase == lpmv1.MigrationPhaseSucceeded || phase == lpmv1.MigrationPhaseFailed {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace of the PodMigration.")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Follow the migration until it succeeds or fails.")
+	return cmd
+}
+
+// checkpointProgress returns the container checkpoints of the generation
+// of the PodCheckpoint the migration restores, with their contents once
+// bound.
+func checkpointProgress(ctx context.Context, c client.Client, podMigration *lpmv1.PodMigration) ([]containerProgress, error) {
+	ref := podMigration.Status.PodCheckpointRef
+	if ref == nil {
+		return nil, nil
+	}
+	generation := podMigration.Status.CheckpointGeneration
+	if generation == 0 {
+		var podCheckpoint lpmv1.PodCheckpoint
+		if err := c.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: ref.Name}, &podCheckpoint); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		generation = podCheckpoint.Status.Generation
+	}
+
+	var checkpoints lpmv1.ContainerCheckpointList
+	err := c.List(ctx, &checkpoints, client.InNamespace(podMigration.Namespace), client.MatchingLabels{
+		podCheckpointLabel:        ref.Name,
+		checkpointGenerationLabel: strconv.FormatInt(generation, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(checkpoints.Items, func(i, j int) bool {
+		return checkpoints.Items[i].Spec.ContainerName < checkpoints.Items[j].Spec.ContainerName
+	})
+
+	var containers []containerProgress
+	for _, checkpoint := range checkpoints.Items {
+		progress := containerProgress{checkpoint: checkpoint}
+		if name := checkpoint.Status.BoundContentName; name != "" {
+			var content lpmv1.ContainerCheckpointContent
+			if err := c.Get(ctx, client.ObjectKey{Name: name}, &content); err == nil {
+				progress.content = &content
+			} else if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+		}
+		containers = append(containers, progress)
+	}
+	return containers, nil
+}
+
+func printStatus(out io.Writer, podMigration *lpmv1.PodMigration, containers []containerProgress, now time.Time) {
+	status := &podMigration.Status
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	phase := status.Phase
+	if phase == "" {
+		phase = lpmv1.MigrationPhasePending
+	}
+	target := podMigration.Spec.TargetNode
+	if target == "" {
+		target = "(not picked yet)"
+	}
+	source := status.SourceNode
+	if source == "" {
+		source = "?"
+	}
+	fmt.Fprintf(w, "Migration:\t%s/%s\n", podMigration.Namespace, podMigration.Name)
+	fmt.Fprintf(w, "Pod:\t%s (%s -> %s)\n", podMigration.Spec.PodName, source, target)
+	if status.Reason != "" {
+		fmt.Fprintf(w, "Phase:\t%s (%s)\n", phase, status.Reason)
+	} else {
+		fmt.Fprintf(w, "Phase:\t%s\n", phase)
+	}
+	if status.Message != "" {
+		fmt.Fprintf(w, "Message:\t%s\n", status.Message)
+	}
+	if status.RestoredPodName != "" {
+		fmt.Fprintf(w, "Restored pod:\t%s\n", status.RestoredPodName)
+	}
+
+	end := now
+	ended := phase == lpmv1.MigrationPhaseSucceeded || phase == lpmv1.MigrationPhaseFailed
+	if n := len(status.PhaseTransitions); ended && n > 0 {
+		end = status.PhaseTransitions[n-1].Time.Time
+	}
+	fmt.Fprintf(w, "Elapsed:\t%s\n", formatDuration(end.Sub(podMigration.CreationTimestamp.Time)))
+
+	fmt.Fprintln(w, "\nPHASE\tSTARTED\tDURATION")
+	for i, transition := range status.PhaseTransitions {
+		duration := "-"
+		switch {
+		case i+1 < len(status.PhaseTransitions):
+			duration = formatDuration(status.PhaseTransitions[i+1].Time.Sub(transition.Time.Time))
+		case !ended:
+			duration = formatDuration(now.Sub(transition.Time.Time)) + "..."
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", transition.Phase, transition.Time.Format(time.TimeOnly), duration)
+	}
+
+	if len(status.Conditions) > 0 {
+		fmt.Fprintln(w, "\nCONDITION\tSTATUS\tREASON\tSINCE\tMESSAGE")
+		for _, condition := range status.Conditions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason,
+				condition.LastTransitionTime.Format(time.TimeOnly), truncate(condition.Message, statusMessageWidth))
+		}
+	}
+
+	if len(containers) > 0 {
+		fmt.Fprintf(w, "\nCheckpoint:\t%s (generation %d)\n", status.PodCheckpointRef.Name, status.CheckpointGeneration)
+		fmt.Fprintln(w, "CONTAINER\tPHASE\tSIZE\tIMAGE\tMESSAGE")
+		var total int64
+		for _, container := range containers {
+			checkpointPhase := string(container.checkpoint.Status.Phase)
+			if checkpointPhase == "" {
+				checkpointPhase = string(lpmv1.ContainerCheckpointPhasePending)
+			}
+			size := "-"
+			if container.content != nil && container.content.Spec.ArtifactSizeBytes > 0 {
+				size = formatBytes(container.content.Spec.ArtifactSizeBytes)
+				total += container.content.Spec.ArtifactSizeBytes
+			}
+			image := status.CheckpointImages[container.checkpoint.Spec.ContainerName]
+			if image == "" {
+				image = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", container.checkpoint.Spec.ContainerName, checkpointPhase, size, image,
+				truncate(container.checkpoint.Status.Message, statusMessageWidth))
+		}
+		fmt.Fprintf(w, "Transferred:\t%s\n", formatBytes(total))
+	}
+
+	if len(status.TopologySpread) > 0 {
+		fmt.Fprintln(w, "\nSPREAD\tKEY\tMOVE\tSKEW BEFORE\tSKEW AFTER\tMAX SKEW")
+		for _, report := range status.TopologySpread {
+			maxSkew := "-"
+			if report.MaxSkew != nil {
+				maxSkew = strconv.Itoa(int(*report.MaxSkew))
+			}
+			source, target := report.SourceDomain, report.TargetDomain
+			if source == "" {
+				source = "-"
+			}
+			if target == "" {
+				target = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s -> %s\t%d\t%d\t%s\n", report.Subject, report.TopologyKey,
+				source, target, report.SkewBefore, report.SkewAfter, maxSkew)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if status.PredictedDowntime != nil {
+		fmt.Fprintf(w, "Predicted downtime:\t%s\n", formatDuration(status.PredictedDowntime.Duration))
+	}
+	if status.CheckpointStartTime != nil {
+		downtime := formatDuration(end.Sub(status.CheckpointStartTime.Time))
+		switch phase {
+		case lpmv1.MigrationPhaseSucceeded:
+			fmt.Fprintf(w, "Downtime:\t%s\n", downtime)
+		case lpmv1.MigrationPhaseFailed:
+			// The source pod was kept, so nothing was lost
+		default:
+			fmt.Fprintf(w, "Downtime:\t%s so far\n", downtime)
+		}
+	}
+	for _, warning := range status.Warnings {
+		fmt.Fprintf(w, "Warning:\t%s\n", warning)
+	}
+	w.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
@@ -21,15 +21,21 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -40,6 +46,11 @@ import (
 	lpmv1 "my.domain/guestbook/api/v1"
 	"my.domain/guestbook/internal/agent"
 	"my.domain/guestbook/internal/controller"
+	"my.domain/guestbook/internal/podexec"
+	"my.domain/guestbook/internal/registry"
+	"my.domain/guestbook/internal/runtimelimits"
+	webhooklpmv1 "my.domain/guestbook/internal/webhook/v1"
+	"my.domain/guestbook/pkg/scoring"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -48,6 +59,18 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// Controller groups selected with --controllers. Each needs only the
+// permissions of its own ClusterRole in config/split.
+const (
+	// controllersMigration runs the PodMigration, PodRestore, AutoRestore,
+	// RestoredPod and NodeMigrationStatus controllers, the only ones creating
+	// and deleting pods.
+	controllersMigration = "migration"
+	// controllersCheckpoint runs the PodCheckpoint and ContainerCheckpoint
+	// controllers, which only read pods.
+	controllersCheckpoint = "checkpoint"
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
@@ -65,9 +88,29 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
+	var maxCheckpointsPerNode, checkpointWorkers int
+	var agentIPFamily, agentDiscovery, agentNamespace, agentService string
+	var imageNameTemplate string
+	var enableRegistry bool
+	var registryImage, registryStorage, registryStorageClass string
+	var registryURL, registrySecret string
+	var manageAgent, agentLeastPrivilege bool
+	var agentImage, agentImagePullPolicy, agentNodeSelector, agentStorageClaim string
+	var enableWebhooks bool
+	var targetScorerURL string
+	var targetScorerTimeout time.Duration
+	var autoRestoreGracePeriod, autoRestoreMaxCheckpointAge time.Duration
+	var watchNamespaces, agentTokenFile, controllers string
+	var pprofAddr string
+	var memoryLimitRatio float64
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "0", "The address the pprof endpoints (/debug/pprof/) bind to, "+
+		"e.g. 127.0.0.1:8082. Use \"0\" to disable them. Profiles reveal the controller's internals: do not expose them.")
+	flag.Float64Var(&memoryLimitRatio, "memory-limit-ratio", 0.9,
+		"Fraction of the container's memory limit the Go runtime's soft memory limit is set to, unless GOMEMLIMIT is set. "+
+			"0 leaves it unset. GOMAXPROCS is likewise set to the container's CPU limit unless set.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -82,6 +125,69 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&maxCheckpointsPerNode, "max-concurrent-checkpoints-per-node", 2,
+		"Maximum number of container checkpoints dispatched to a single node at once; 0 disables the limit.")
+	flag.IntVar(&checkpointWorkers, "checkpoint-workers", 8,
+		"Number of container checkpoints worked on in parallel across all nodes.")
+	flag.StringVar(&agentIPFamily, "agent-ip-family", "",
+		"Address family used to reach the checkpoint agents on dual-stack nodes (IPv4 or IPv6). "+
+			"Defaults to the first address the node reports.")
+	flag.StringVar(&agentDiscovery, "agent-discovery", string(agent.DiscoveryNodeAddress),
+		"How the checkpoint agent of a node is found: NodeAddress (agent uses hostNetwork), "+
+			"PodIP or ServiceDNS (agent pod on the pod network).")
+	flag.StringVar(&agentNamespace, "agent-namespace", agent.DefaultAgentNamespace,
+		"Namespace of the checkpoint agent DaemonSet, used by pod-based agent discovery and for the checkpoint registry.")
+	flag.StringVar(&agentService, "agent-service", agent.DefaultAgentService,
+		"Headless Service of the checkpoint agent pods, used by ServiceDNS agent discovery.")
+	flag.StringVar(&imageNameTemplate, "checkpoint-image-template", controller.DefaultImageNameTemplate,
+		"Go template naming the images checkpoints are converted to for a restore. "+
+			"Fields: .Namespace, .Pod, .Container, .Timestamp and .Digest (sha256 of the checkpoint archive).")
+	flag.BoolVar(&manageAgent, "manage-agent", false,
+		"Install the checkpoint agent DaemonSet and keep it matching the --agent-* flags, instead of deploying config/agent.")
+	flag.StringVar(&agentImage, "agent-image", "",
+		"Image of the managed agent DaemonSet, pinned by tag or digest. Required with --manage-agent.")
+	flag.StringVar(&agentImagePullPolicy, "agent-image-pull-policy", string(corev1.PullIfNotPresent),
+		"Pull policy of the managed agent image.")
+	flag.StringVar(&agentNodeSelector, "agent-node-selector", "",
+		"Labels of the nodes the managed agents run on, e.g. lpm.my.domain/checkpoint=true; every node if empty.")
+	flag.BoolVar(&agentLeastPrivilege, "agent-least-privilege", false,
+		"Run the managed agents unprivileged with a read-only root filesystem.")
+	flag.StringVar(&agentStorageClaim, "agent-shared-storage-claim", agent.DefaultSharedStorageClaim,
+		"Claim of the shared checkpoint storage the managed agents mount; not mounted with --checkpoint-registry.")
+	flag.BoolVar(&enableRegistry, "checkpoint-registry", false,
+		"Deploy an in-cluster registry in the agent namespace and move checkpoints that are not in shared "+
+			"storage to the target node through it.")
+	flag.StringVar(&registryImage, "checkpoint-registry-image", registry.DefaultImage,
+		"Image of the checkpoint registry server.")
+	flag.StringVar(&registryStorage, "checkpoint-registry-storage", registry.DefaultStorageSize,
+		"Size of the checkpoint registry's volume.")
+	flag.StringVar(&registryStorageClass, "checkpoint-registry-storage-class", "",
+		"Storage class of the checkpoint registry's volume; the cluster default if empty.")
+	flag.StringVar(&registryURL, "checkpoint-registry-url", "",
+		"External registry, as host[:port][/path], to move checkpoints that are not in shared storage through "+
+			"instead of deploying one; implies --checkpoint-registry. It must be served with a trusted certificate.")
+	flag.StringVar(&registrySecret, "checkpoint-registry-secret", "",
+		"kubernetes.io/dockerconfigjson Secret in the agent namespace with the credentials of --checkpoint-registry-url.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Serve the PodMigration validating webhook, which rejects target nodes forbidden by MigrationPolicies. "+
+			"Requires the webhook certificates of config/webhook.")
+	flag.StringVar(&targetScorerURL, "target-scorer-url", "",
+		"URL of an external service ranking the candidate target nodes of migrations without one; see pkg/scoring.")
+	flag.DurationVar(&targetScorerTimeout, "target-scorer-timeout", 5*time.Second,
+		"How long to wait for the external target scorer before ranking without it.")
+	flag.DurationVar(&autoRestoreGracePeriod, "auto-restore-grace-period", controller.DefaultAutoRestoreGracePeriod,
+		"How long a node must be not ready before its pods annotated lpm.my.domain/auto-restore=true are restored elsewhere.")
+	flag.DurationVar(&autoRestoreMaxCheckpointAge, "auto-restore-max-checkpoint-age", controller.DefaultAutoRestoreMaxCheckpointAge,
+		"Age of the oldest checkpoint a pod of a failed node is restored from.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma-separated namespaces the controller migrates, checkpoints and restores pods in. Empty means all namespaces. "+
+			"Lets each team run its own controller with RBAC limited to its namespaces.")
+	flag.StringVar(&agentTokenFile, "agent-token-file", "",
+		"File holding the bearer token the controller authenticates to the agents with, e.g. its ServiceAccount token. "+
+			"Re-read on every call so rotated tokens are picked up. Required by agents run with --client-authorization=token.")
+	flag.StringVar(&controllers, "controllers", controllersMigration+","+controllersCheckpoint,
+		"Comma-separated controller groups to run: migration (PodMigration, PodRestore, AutoRestore, RestoredPod, NodeMigrationStatus, NodeMaintenance) "+
+			"and checkpoint (PodCheckpoint, ContainerCheckpoint). Lets each group run as its own Deployment with its own RBAC.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -90,6 +196,16 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	limits, err := runtimelimits.Apply(memoryLimitRatio)
+	if err != nil {
+		setupLog.Error(err, "unable to size the Go runtime to the container's limits")
+		os.Exit(1)
+	}
+	if limits.GOMAXPROCS != 0 || limits.MemoryLimit != 0 {
+		setupLog.Info("Sized the Go runtime to the container's limits",
+			"GOMAXPROCS", limits.GOMAXPROCS, "memoryLimit", limits.MemoryLimit)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -179,13 +295,65 @@ func main() {
 		})
 	}
 
+	// Each group of controllers run separately elects its own leader
+	runControllers := map[string]bool{}
+	for _, group := range strings.Split(controllers, ",") {
+		group = strings.TrimSpace(group)
+		if group != controllersMigration && group != controllersCheckpoint {
+			setupLog.Error(nil, "unknown controller group in --controllers, want migration or checkpoint", "group", group)
+			os.Exit(1)
+		}
+		runControllers[group] = true
+	}
+	if registryURL != "" {
+		if err := registry.ValidateURL(registryURL); err != nil {
+			setupLog.Error(err, "invalid --checkpoint-registry-url")
+			os.Exit(1)
+		}
+		enableRegistry = true
+	} else if registrySecret != "" {
+		setupLog.Error(nil, "--checkpoint-registry-secret needs --checkpoint-registry-url")
+		os.Exit(1)
+	}
+	if (manageAgent || enableRegistry) && !runControllers[controllersMigration] {
+		setupLog.Error(nil, "--manage-agent and --checkpoint-registry need the migration controllers")
+		os.Exit(1)
+	}
+	leaderElectionID := "ecaf1259.my.domain"
+	if len(runControllers) == 1 {
+		for group := range runControllers {
+			leaderElectionID = group + "." + leaderElectionID
+		}
+	}
+
+	// A namespaced controller only caches its namespaces, plus the agent
+	// namespace in which the agent pods are discovered
+	var cacheOptions cache.Options
+	var namespaces []string
+	if watchNamespaces != "" {
+		if manageAgent || enableRegistry {
+			setupLog.Error(nil, "--manage-agent and --checkpoint-registry are shared by all namespaces and cannot be used with --watch-namespaces")
+			os.Exit(1)
+		}
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{agentNamespace: {}}
+		for _, namespace := range strings.Split(watchNamespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace != "" {
+				namespaces = append(namespaces, namespace)
+				cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+			}
+		}
+		setupLog.Info("Watching namespaces", "namespaces", namespaces)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "ecaf1259.my.domain",
+		LeaderElectionID:       leaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -203,29 +371,197 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.PodMigrationReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		AgentClient: agent.NewClient(mgr.GetClient()),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "PodMigration")
+	ipFamily, err := agent.ParseIPFamily(agentIPFamily)
+	if err != nil {
+		setupLog.Error(err, "invalid --agent-ip-family")
 		os.Exit(1)
 	}
-	if err = (&controller.PodCheckpointReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "PodCheckpoint")
+	discovery, err := agent.ParseDiscovery(agentDiscovery)
+	if err != nil {
+		setupLog.Error(err, "invalid --agent-discovery")
 		os.Exit(1)
 	}
-	if err = (&controller.ContainerCheckpointReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Agent:  *agent.NewClient(mgr.GetClient()),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ContainerCheckpoint")
+	agentOpts := agent.Options{
+		Discovery:         discovery,
+		AgentNamespace:    agentNamespace,
+		AgentService:      agentService,
+		PreferredIPFamily: ipFamily,
+		TokenFile:         agentTokenFile,
+	}
+
+	imageTemplate, err := controller.ParseImageNameTemplate(imageNameTemplate)
+	if err != nil {
+		setupLog.Error(err, "invalid --checkpoint-image-template")
 		os.Exit(1)
 	}
+
+	var checkpointRegistry *registry.Registry
+	if enableRegistry {
+		storageSize, err := resource.ParseQuantity(registryStorage)
+		if err != nil {
+			setupLog.Error(err, "invalid --checkpoint-registry-storage")
+			os.Exit(1)
+		}
+		checkpointRegistry = registry.New(mgr.GetClient(), mgr.GetAPIReader(), registry.Options{
+			Namespace:         agentNamespace,
+			Image:             registryImage,
+			StorageSize:       storageSize,
+			StorageClassName:  registryStorageClass,
+			URL:               registryURL,
+			CredentialsSecret: registrySecret,
+		})
+		if err := mgr.Add(checkpointRegistry); err != nil {
+			setupLog.Error(err, "unable to add checkpoint registry to manager")
+			os.Exit(1)
+		}
+	}
+
+	if manageAgent {
+		if agentImage == "" {
+			setupLog.Error(nil, "--agent-image is required with --manage-agent")
+			os.Exit(1)
+		}
+		if _, tag, _ := strings.Cut(agentImage[strings.LastIndex(agentImage, "/")+1:], ":"); !strings.Contains(agentImage, "@") && (tag == "" || tag == "latest") {
+			setupLog.Info("--agent-image is not pinned to a version; agents may not match the controller", "image", agentImage)
+		}
+		nodeSelector, err := labels.ConvertSelectorToLabelsMap(agentNodeSelector)
+		if err != nil {
+			setupLog.Error(err, "invalid --agent-node-selector")
+			os.Exit(1)
+		}
+		if enableRegistry {
+			agentStorageClaim = ""
+		}
+		if err = (&controller.AgentDaemonSetReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Config: agent.DaemonSetConfig{
+				Namespace:          agentNamespace,
+				Name:               agent.DefaultAgentDaemonSet,
+				ServiceAccountName: agent.DefaultAgentServiceAccount,
+				Image:              agentImage,
+				ImagePullPolicy:    corev1.PullPolicy(agentImagePullPolicy),
+				NodeSelector:       nodeSelector,
+				Discovery:          discovery,
+				Service:            agentService,
+				SharedStorageClaim: agentStorageClaim,
+				LeastPrivilege:     agentLeastPrivilege,
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AgentDaemonSet")
+			os.Exit(1)
+		}
+	}
+
+	var scorers []scoring.Weighted
+	if targetScorerURL != "" {
+		scorers = append(scorers, scoring.Weighted{Scorer: scoring.NewHTTPScorer(targetScorerURL, targetScorerTimeout), Weight: 1})
+	}
+
+	podExec, err := podexec.NewExecutor(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create pod executor")
+		os.Exit(1)
+	}
+	// The agent client and target fit checks list pods by node
+	if err := controller.SetupPodIndexes(mgr); err != nil {
+		setupLog.Error(err, "unable to index pods")
+		os.Exit(1)
+	}
+	if runControllers[controllersMigration] {
+		podMigrationReconciler := &controller.PodMigrationReconciler{
+			Client:            mgr.GetClient(),
+			Scheme:            mgr.GetScheme(),
+			AgentClient:       agent.NewClientWithOptions(mgr.GetClient(), agentOpts),
+			Exec:              podExec,
+			ImageNameTemplate: imageTemplate,
+			Registry:          checkpointRegistry,
+			Scorers:           scorers,
+			APIReader:         mgr.GetAPIReader(),
+		}
+		if err = podMigrationReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PodMigration")
+			os.Exit(1)
+		}
+		if err = (&controller.PodRestoreReconciler{
+			Client:     mgr.GetClient(),
+			Scheme:     mgr.GetScheme(),
+			Migrations: podMigrationReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PodRestore")
+			os.Exit(1)
+		}
+		if err = (&controller.AutoRestoreReconciler{
+			Client:           mgr.GetClient(),
+			Scheme:           mgr.GetScheme(),
+			GracePeriod:      autoRestoreGracePeriod,
+			MaxCheckpointAge: autoRestoreMaxCheckpointAge,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AutoRestore")
+			os.Exit(1)
+		}
+		// NodeMigrationStatus sums up the migrations of every namespace, and
+		// NodeMaintenance and MigrationPolicy migrate pods of every namespace
+		if len(namespaces) == 0 {
+			if err = (&controller.NodeMigrationStatusReconciler{
+				Client:      mgr.GetClient(),
+				Scheme:      mgr.GetScheme(),
+				AgentClient: agent.NewClientWithOptions(mgr.GetClient(), agentOpts),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "NodeMigrationStatus")
+				os.Exit(1)
+			}
+			if err = (&controller.NodeMaintenanceReconciler{
+				Client: mgr.GetClient(),
+				Scheme: mgr.GetScheme(),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "NodeMaintenance")
+				os.Exit(1)
+			}
+			if err = (&controller.MigrationPolicyReconciler{
+				Client: mgr.GetClient(),
+				Scheme: mgr.GetScheme(),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "MigrationPolicy")
+				os.Exit(1)
+			}
+		}
+		if err = (&controller.RestoredPodReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "RestoredPod")
+			os.Exit(1)
+		}
+	}
+	if runControllers[controllersCheckpoint] {
+		if err = (&controller.PodCheckpointReconciler{
+			Client:      mgr.GetClient(),
+			Scheme:      mgr.GetScheme(),
+			Exec:        podExec,
+			AgentClient: agent.NewClientWithOptions(mgr.GetClient(), agentOpts),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PodCheckpoint")
+			os.Exit(1)
+		}
+		if err = (&controller.ContainerCheckpointReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Agent:  *agent.NewClientWithOptions(mgr.GetClient(), agentOpts),
+
+			MaxCheckpointsPerNode:   maxCheckpointsPerNode,
+			MaxConcurrentReconciles: checkpointWorkers,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ContainerCheckpoint")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err = webhooklpmv1.SetupPodMigrationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PodMigration")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
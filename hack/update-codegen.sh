@@ -0,0 +1,61 @@
+#!/usr/bin/env bash
+
+# Copyright 2025.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates the typed clientset, listers, and informers for the lpm.my.domain
+# API group into pkg/client. Invoked by `make generate-client`, which installs
+# the code-generator binaries into ./bin first.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+LOCALBIN=${LOCALBIN:-${SCRIPT_ROOT}/bin}
+CLIENT_GEN=${CLIENT_GEN:-${LOCALBIN}/client-gen}
+LISTER_GEN=${LISTER_GEN:-${LOCALBIN}/lister-gen}
+INFORMER_GEN=${INFORMER_GEN:-${LOCALBIN}/informer-gen}
+
+MODULE=my.domain/guestbook
+APIS_PKG=${MODULE}/api/v1
+OUTPUT_DIR=${SCRIPT_ROOT}/pkg/client
+OUTPUT_PKG=${MODULE}/pkg/client
+BOILERPLATE=${SCRIPT_ROOT}/hack/boilerplate.go.txt
+
+cd "${SCRIPT_ROOT}"
+
+rm -rf "${OUTPUT_DIR}/clientset" "${OUTPUT_DIR}/listers" "${OUTPUT_DIR}/informers"
+
+"${CLIENT_GEN}" \
+  --clientset-name versioned \
+  --input-base "" \
+  --input "${APIS_PKG}" \
+  --output-dir "${OUTPUT_DIR}/clientset" \
+  --output-pkg "${OUTPUT_PKG}/clientset" \
+  --go-header-file "${BOILERPLATE}"
+
+"${LISTER_GEN}" \
+  --output-dir "${OUTPUT_DIR}/listers" \
+  --output-pkg "${OUTPUT_PKG}/listers" \
+  --go-header-file "${BOILERPLATE}" \
+  "${APIS_PKG}"
+
+"${INFORMER_GEN}" \
+  --versioned-clientset-package "${OUTPUT_PKG}/clientset/versioned" \
+  --listers-package "${OUTPUT_PKG}/listers" \
+  --output-dir "${OUTPUT_DIR}/informers" \
+  --output-pkg "${OUTPUT_PKG}/informers" \
+  --go-header-file "${BOILERPLATE}" \
+  "${APIS_PKG}"
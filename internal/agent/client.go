@@ -2,7 +2,12 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -17,24 +22,106 @@ const (
 	maxMessageSize = 100 * 1024 * 1024 // 100MB
 )
 
+// IPFamily selects which address of a dual-stack node the agent is dialed on.
+type IPFamily string
+
+const (
+	// IPFamilyAny uses the first address the node reports.
+	IPFamilyAny  IPFamily = ""
+	IPFamilyIPv4 IPFamily = "IPv4"
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
+// nodeAddressPreference is the order in which node address types are tried.
+var nodeAddressPreference = []corev1.NodeAddressType{
+	corev1.NodeInternalIP,
+	corev1.NodeExternalIP,
+	corev1.NodeInternalDNS,
+	corev1.NodeExternalDNS,
+	corev1.NodeHostName,
+}
+
+// Options configures how a Client reaches the agents.
+type Options struct {
+	// Discovery selects how the agent of a node is found. Defaults to
+	// DiscoveryNodeAddress.
+	Discovery Discovery
+	// AgentNamespace is the namespace of the agent DaemonSet, used by the
+	// pod-based discovery modes. Defaults to DefaultAgentNamespace.
+	AgentNamespace string
+	// AgentService is the headless Service governing the agent pods, used
+	// by DiscoveryServiceDNS. Defaults to DefaultAgentService.
+	AgentService string
+	// PreferredIPFamily picks the address family on dual-stack nodes. An
+	// address of the other family is still used if it is the only one.
+	PreferredIPFamily IPFamily
+	// TokenFile holds a bearer token sent to the agents with every call,
+	// for agents that authorize their clients. It is read on every call, so
+	// a rotated ServiceAccount token is picked up.
+	TokenFile string
+}
+
 // Client provides methods to communicate with checkpoint agents on nodes
 type Client struct {
 	k8sClient client.Client
+	opts      Options
 }
 
 // NewClient creates a new agent client
 func NewClient(k8sClient client.Client) *Client {
+	return NewClientWithOptions(k8sClient, Options{})
+}
+
+// NewClientWithOptions creates a new agent client with the given options
+func NewClientWithOptions(k8sClient client.Client, opts Options) *Client {
 	return &Client{
 		k8sClient: k8sClient,
+		opts:      opts,
 	}
 }
 
-// CheckpointContainer performs a checkpoint operation on a container
-func (c *Client) CheckpointContainer(ctx context.Context, nodeName, podNamespace, podName, containerName, podUID string) (string, error) {
+// ParseIPFamily parses an IP family name as accepted on the command line.
+func ParseIPFamily(s string) (IPFamily, error) {
+	switch IPFamily(s) {
+	case IPFamilyAny, IPFamilyIPv4, IPFamilyIPv6:
+		return IPFamily(s), nil
+	}
+	return "", fmt.Errorf("unknown IP family %q (want %s or %s)", s, IPFamilyIPv4, IPFamilyIPv6)
+}
+
+// CheckpointOptions tunes a container checkpoint.
+type CheckpointOptions struct {
+	// MemoryVolumes are memory-backed emptyDir volumes whose content is
+	// archived along with the checkpoint.
+	MemoryVolumes []string
+	// ContainerID identifies the running container, whose exec sessions
+	// fail the checkpoint unless TerminateExecSessions is set.
+	ContainerID           string
+	TerminateExecSessions bool
+	// CriuOptions are set in the node's CRIU configuration before the dump.
+	CriuOptions []string
+	// RequestID identifies the checkpoint across retries; the agent returns
+	// the checkpoint it already took for the same ID instead of a new one.
+	RequestID string
+	// Compression of the archive the agent copies to shared storage, e.g.
+	// LZ4; empty copies it uncompressed.
+	Compression string
+	// Generation is the PodCheckpoint generation the checkpoint belongs to,
+	// 0 if none.
+	Generation int64
+	// KeepOnNode keeps the archive on the node, for TransferCheckpoint to
+	// push to the target node, even where shared storage is mounted.
+	KeepOnNode bool
+}
+
+// CheckpointContainer performs a checkpoint operation on a container. The
+// response holds the URI and digest of the checkpoint and the URI of the
+// archive of opts.MemoryVolumes.
+func (c *Client) CheckpointContainer(ctx context.Context, nodeName, podNamespace, podName, containerName, podUID string, opts CheckpointOptions) (*pb.CheckpointResponse, error) {
 	// Create gRPC connection to agent
 	conn, err := c.dialAgent(ctx, nodeName)
 	if err != nil {
-		return "", fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
 	}
 	defer func() {
 		if err := conn.Close(); err != nil {
@@ -47,26 +134,239 @@ func (c *Client) CheckpointContainer(ctx context.Context, nodeName, podNamespace
 
 	// Perform checkpoint
 	req := &pb.CheckpointRequest{
-		PodNamespace:  podNamespace,
-		PodName:       podName,
-		ContainerName: containerName,
-		PodUid:        podUID,
+		PodNamespace:          podNamespace,
+		PodName:               podName,
+		ContainerName:         containerName,
+		PodUid:                podUID,
+		MemoryVolumes:         opts.MemoryVolumes,
+		ContainerId:           opts.ContainerID,
+		TerminateExecSessions: opts.TerminateExecSessions,
+		CriuOptions:           opts.CriuOptions,
+		RequestId:             opts.RequestID,
+		Compression:           opts.Compression,
+		Generation:            opts.Generation,
+		KeepOnNode:            opts.KeepOnNode,
 	}
 
 	resp, err := checkpointClient.Checkpoint(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("checkpoint RPC failed: %w", err)
+		return nil, fmt.Errorf("checkpoint RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("checkpoint failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// ContainerCheckpoint is one container checkpointed by CheckpointPod.
+type ContainerCheckpoint struct {
+	ContainerName string
+	Options       CheckpointOptions
+}
+
+// ContainerCheckpointResult is the outcome of checkpointing one container
+// with CheckpointPod: its response, or why it failed.
+type ContainerCheckpointResult struct {
+	Response *pb.CheckpointResponse
+	Err      error
+}
+
+// CheckpointPod checkpoints the containers of a pod in one call to the agent
+// on nodeName, which dumps them in parallel. It returns the result of each
+// container in order; an error means none was checkpointed. Agents that
+// predate the call return codes.Unimplemented.
+func (c *Client) CheckpointPod(ctx context.Context, nodeName, podNamespace, podName, podUID string, containers []ContainerCheckpoint) ([]ContainerCheckpointResult, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	req := &pb.CheckpointPodRequest{}
+	for _, container := range containers {
+		req.Containers = append(req.Containers, &pb.CheckpointRequest{
+			PodNamespace:          podNamespace,
+			PodName:               podName,
+			ContainerName:         container.ContainerName,
+			PodUid:                podUID,
+			MemoryVolumes:         container.Options.MemoryVolumes,
+			ContainerId:           container.Options.ContainerID,
+			TerminateExecSessions: container.Options.TerminateExecSessions,
+			CriuOptions:           container.Options.CriuOptions,
+			RequestId:             container.Options.RequestID,
+			Compression:           container.Options.Compression,
+			Generation:            container.Options.Generation,
+			KeepOnNode:            container.Options.KeepOnNode,
+		})
+	}
+
+	resp, err := checkpointClient.CheckpointPod(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint pod RPC failed: %w", err)
+	}
+	if len(resp.Containers) != len(containers) {
+		return nil, fmt.Errorf("checkpoint pod returned %d results for %d containers", len(resp.Containers), len(containers))
+	}
+
+	results := make([]ContainerCheckpointResult, len(containers))
+	for i, container := range resp.Containers {
+		if !container.Success {
+			results[i].Err = fmt.Errorf("checkpoint failed: %s", container.Error)
+			continue
+		}
+		results[i].Response = container
+	}
+	return results, nil
+}
+
+// FreezePod asks the agent on nodeName to freeze, or with thaw to thaw, the
+// pod podUID, found through its container containerID. A freeze is undone by
+// the agent after timeout unless thawed before.
+func (c *Client) FreezePod(ctx context.Context, nodeName, podUID, containerID string, thaw bool, timeout time.Duration) error {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.FreezePod(ctx, &pb.FreezePodRequest{
+		PodUid:         podUID,
+		ContainerId:    containerID,
+		Thaw:           thaw,
+		TimeoutSeconds: int64(timeout.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("freeze pod RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("freeze pod failed: %s", resp.Error)
+	}
+
+	return nil
+}
+
+// RestoreVolumes asks the agent on nodeName to unpack volume archives into
+// the memory-backed emptyDir volumes of the restored pod podUID and to mark
+// them done in markerVolume.
+func (c *Client) RestoreVolumes(ctx context.Context, nodeName, podUID string, archiveURIs []string, markerVolume string) error {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.RestoreVolumes(ctx, &pb.RestoreVolumesRequest{
+		PodUid:       podUID,
+		ArchiveUris:  archiveURIs,
+		MarkerVolume: markerVolume,
+	})
+	if err != nil {
+		return fmt.Errorf("restore volumes RPC failed: %w", err)
 	}
 
 	if !resp.Success {
-		return "", fmt.Errorf("checkpoint failed: %s", resp.Error)
+		return fmt.Errorf("restore volumes failed: %s", resp.Error)
 	}
 
-	return resp.ArtifactUri, nil
+	return nil
 }
 
-// ConvertCheckpointToImage converts a checkpoint file to OCI image format
-func (c *Client) ConvertCheckpointToImage(ctx context.Context, nodeName, checkpointPath, containerName, imageName string) (string, error) {
+// ConfigureCriu asks the agent on nodeName to add options to the node's
+// CRIU configuration.
+func (c *Client) ConfigureCriu(ctx context.Context, nodeName string, options []string) error {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.ConfigureCriu(ctx, &pb.ConfigureCriuRequest{Options: options})
+	if err != nil {
+		return fmt.Errorf("configure CRIU RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("configure CRIU failed: %s", resp.Error)
+	}
+
+	return nil
+}
+
+// CheckSecurityProfiles asks the agent on nodeName which of the given
+// seccomp and AppArmor profiles the node lacks.
+func (c *Client) CheckSecurityProfiles(ctx context.Context, nodeName string, seccompProfiles, appArmorProfiles []string) (*pb.CheckSecurityProfilesResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.CheckSecurityProfiles(ctx, &pb.CheckSecurityProfilesRequest{
+		SeccompProfiles:  seccompProfiles,
+		ApparmorProfiles: appArmorProfiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("check security profiles RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("check security profiles failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// ConvertOptions tunes the conversion of a checkpoint to an image.
+type ConvertOptions struct {
+	// Push pushes the image to the registry the image name names, verifying
+	// its certificate if TLSVerify and with the credentials of the auth file
+	// RegistryAuth, if any.
+	Push         bool
+	TLSVerify    bool
+	RegistryAuth []byte
+	// ArtifactDigest, if set, is checked against the checkpoint first.
+	ArtifactDigest string
+	// BaseImage, with its image ID BaseImageID, replaces the image the
+	// container is restored on top of; see CheckRebase.
+	BaseImage   string
+	BaseImageID string
+}
+
+// ConvertCheckpointToImage converts a checkpoint file to OCI image format.
+func (c *Client) ConvertCheckpointToImage(ctx context.Context, nodeName, checkpointPath, containerName, imageName string, opts ConvertOptions) (string, error) {
 	// Create gRPC connection to agent
 	conn, err := c.dialAgent(ctx, nodeName)
 	if err != nil {
@@ -86,6 +386,12 @@ func (c *Client) ConvertCheckpointToImage(ctx context.Context, nodeName, checkpo
 		CheckpointPath: checkpointPath,
 		ContainerName:  containerName,
 		ImageName:      imageName,
+		Push:           opts.Push,
+		TlsVerify:      opts.TLSVerify,
+		RegistryAuth:   opts.RegistryAuth,
+		ArtifactDigest: opts.ArtifactDigest,
+		BaseImage:      opts.BaseImage,
+		BaseImageId:    opts.BaseImageID,
 	}
 
 	resp, err := checkpointClient.ConvertCheckpointToImage(ctx, req)
@@ -100,37 +406,630 @@ func (c *Client) ConvertCheckpointToImage(ctx context.Context, nodeName, checkpo
 	return resp.ImageReference, nil
 }
 
+// PullImage has the agent on nodeName pull imageRef from the checkpoint
+// registry into the node's container storage, verifying the registry's
+// certificate if tlsVerify and with the credentials of the auth file auth,
+// if any.
+func (c *Client) PullImage(ctx context.Context, nodeName, imageRef string, tlsVerify bool, auth []byte) error {
+	return c.pullImage(ctx, nodeName, &pb.PullImageRequest{ImageReference: imageRef, TlsVerify: tlsVerify, RegistryAuth: auth})
+}
+
+// PullBaseImage has the agent on nodeName pull imageRef, the base image of
+// a rebased checkpoint, into the node's container storage, verifying the
+// registry's certificate.
+func (c *Client) PullBaseImage(ctx context.Context, nodeName, imageRef string) error {
+	return c.pullImage(ctx, nodeName, &pb.PullImageRequest{ImageReference: imageRef, TlsVerify: true})
+}
+
+func (c *Client) pullImage(ctx context.Context, nodeName string, req *pb.PullImageRequest) error {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.PullImage(ctx, req)
+	if err != nil {
+		return fmt.Errorf("pull image RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("image pull failed: %s", resp.Error)
+	}
+
+	return nil
+}
+
+// CheckRebase asks the agent on nodeName whether the checkpoint at
+// checkpointPath can be restored on top of baseImage. The response lists the
+// files the checkpointed processes use that differ in baseImage.
+func (c *Client) CheckRebase(ctx context.Context, nodeName, checkpointPath, baseImage string) (*pb.CheckRebaseResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.CheckRebase(ctx, &pb.CheckRebaseRequest{CheckpointPath: checkpointPath, BaseImage: baseImage})
+	if err != nil {
+		return nil, fmt.Errorf("check rebase RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("check rebase failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// CheckpointRecord asks the agent on nodeName what its ledger recorded for
+// the checkpoint request requestID, e.g. whether a checkpoint cut short by a
+// lost connection completed.
+func (c *Client) CheckpointRecord(ctx context.Context, nodeName, requestID string) (*pb.GetCheckpointRecordResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.GetCheckpointRecord(ctx, &pb.GetCheckpointRecordRequest{RequestId: requestID})
+	if err != nil {
+		return nil, fmt.Errorf("get checkpoint record RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("get checkpoint record failed: %s", resp.Error)
+	}
 
-// getNodeEndpoint gets the agent endpoint using node IP
+	return resp, nil
+}
+
+// DeleteCheckpoint asks the agent on nodeName to remove the checkpoint
+// archives at artifactURIs. Node-local archives must be removed by the agent
+// of the node they were taken on. It returns how many bytes were freed;
+// archives already gone are skipped.
+func (c *Client) DeleteCheckpoint(ctx context.Context, nodeName string, artifactURIs []string) (int64, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.DeleteCheckpoint(ctx, &pb.DeleteCheckpointRequest{ArtifactUris: artifactURIs})
+	if err != nil {
+		return 0, fmt.Errorf("delete checkpoint RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return 0, fmt.Errorf("delete checkpoint failed: %s", resp.Error)
+	}
+
+	return resp.FreedBytes, nil
+}
+
+// StreamCheckpoint streams the archive at artifactURI from the agent on
+// sourceNode to the agent on targetNode, one chunk at a time through the
+// caller, and returns where the target keeps it, a file:// URI. With digest
+// set, the target only keeps an archive that has it.
+func (c *Client) StreamCheckpoint(ctx context.Context, sourceNode, targetNode, artifactURI, digest string) (*pb.PushCheckpointResponse, error) {
+	sourceConn, err := c.dialAgent(ctx, sourceNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", sourceNode, err)
+	}
+	defer func() {
+		if err := sourceConn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+	targetConn, err := c.dialAgent(ctx, targetNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", targetNode, err)
+	}
+	defer func() {
+		if err := targetConn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	// Cancelling either stream makes the target drop what it received
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pull, err := pb.NewCheckpointServiceClient(sourceConn).PullCheckpoint(ctx, &pb.PullCheckpointRequest{ArtifactUri: artifactURI})
+	if err != nil {
+		return nil, fmt.Errorf("pull checkpoint RPC failed: %w", err)
+	}
+	push, err := pb.NewCheckpointServiceClient(targetConn).PushCheckpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("push checkpoint RPC failed: %w", err)
+	}
+	for {
+		chunk, err := pull.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pulling checkpoint from node %s failed: %w", sourceNode, err)
+		}
+		if chunk.Header != nil {
+			chunk.Header.Digest = digest
+		}
+		if err := push.Send(chunk); err != nil {
+			// The target's error is returned by CloseAndRecv
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("pushing checkpoint to node %s failed: %w", targetNode, err)
+		}
+	}
+	resp, err := push.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("push checkpoint RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("push checkpoint failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// TransferCheckpoint has the agent on sourceNode push the archive at
+// artifactURI straight to the agent on targetNode, encrypted with the key
+// of the transfer targetNode opened, and returns where the target keeps it,
+// a file:// URI. With digest set, the target only keeps an archive that has
+// it. Agents that predate the call return codes.Unimplemented.
+func (c *Client) TransferCheckpoint(ctx context.Context, sourceNode, targetNode, artifactURI, digest string, transfer *pb.TransferKey) (*pb.TransferCheckpointResponse, error) {
+	targetEndpoint, err := c.getAgentEndpoint(ctx, targetNode)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.dialAgent(ctx, sourceNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", sourceNode, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.TransferCheckpoint(ctx, &pb.TransferCheckpointRequest{
+		ArtifactUri:    artifactURI,
+		TargetEndpoint: targetEndpoint,
+		Transfer:       transfer,
+		Digest:         digest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer checkpoint RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("transfer checkpoint failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// VerifyRestore asks the agent on nodeName whether the container was restored
+// from a checkpoint. It returns the restore verdict and the agent's
+// explanation; an error, such as the agent failing to read the container's
+// state, means there is no verdict. Agents that predate the call return
+// codes.Unimplemented.
+func (c *Client) VerifyRestore(ctx context.Context, nodeName, containerID string) (bool, string, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.VerifyRestore(ctx, &pb.VerifyRestoreRequest{ContainerId: containerID})
+	if err != nil {
+		return false, "", fmt.Errorf("verify restore RPC failed: %w", err)
+	}
+
+	if resp.Error != "" {
+		return false, "", fmt.Errorf("agent could not verify restore: %s", resp.Error)
+	}
+
+	return resp.Restored, resp.Message, nil
+}
+
+// InspectCheckpoint asks the agent on nodeName to summarize the checkpoint
+// archive at checkpointPath.
+func (c *Client) InspectCheckpoint(ctx context.Context, nodeName, checkpointPath string) (*pb.InspectCheckpointResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.InspectCheckpoint(ctx, &pb.InspectCheckpointRequest{CheckpointPath: checkpointPath})
+	if err != nil {
+		return nil, fmt.Errorf("inspect RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("inspect failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// SummarizeCheckpoint asks the agent on nodeName to store the summary of the
+// archive at artifactURI next to it, so later inspections don't read the
+// archive.
+func (c *Client) SummarizeCheckpoint(ctx context.Context, nodeName, artifactURI string) (*pb.SummarizeCheckpointResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.SummarizeCheckpoint(ctx, &pb.SummarizeCheckpointRequest{ArtifactUri: artifactURI})
+	if err != nil {
+		return nil, fmt.Errorf("summarize RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("summarize failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// DiffCheckpoints asks the agent on nodeName to compare two checkpoint
+// archives of the same container.
+func (c *Client) DiffCheckpoints(ctx context.Context, nodeName, baseCheckpointPath, checkpointPath string) (*pb.DiffCheckpointsResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.DiffCheckpoints(ctx, &pb.DiffCheckpointsRequest{
+		BaseCheckpointPath: baseCheckpointPath,
+		CheckpointPath:     checkpointPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("diff RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("diff failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// EstimateMigration asks the agent on nodeName to measure the memory and
+// memory dirty rate of the given containers, sampled over sample, and the
+// throughput of shared storage.
+func (c *Client) EstimateMigration(ctx context.Context, nodeName string, containerIDs []string, sample time.Duration) (*pb.EstimateMigrationResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.EstimateMigration(ctx, &pb.EstimateMigrationRequest{
+		ContainerIds:  containerIDs,
+		SampleSeconds: uint32(sample.Seconds()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("estimate RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("estimate failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// PreflightContainers asks the agent on nodeName to examine running
+// containers for configurations CRIU cannot handle.
+func (c *Client) PreflightContainers(ctx context.Context, nodeName string, containerIDs, unstableAddresses []string, hostNetwork bool) (*pb.PreflightContainersResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.PreflightContainers(ctx, &pb.PreflightContainersRequest{
+		ContainerIds:      containerIDs,
+		UnstableAddresses: unstableAddresses,
+		HostNetwork:       hostNetwork,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("preflight RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("preflight failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// ProbeConnectivity asks the agent on nodeName to open TCP connections to
+// addresses (host:port).
+func (c *Client) ProbeConnectivity(ctx context.Context, nodeName string, addresses []string, timeout time.Duration) (*pb.ProbeConnectivityResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.ProbeConnectivity(ctx, &pb.ProbeConnectivityRequest{
+		Addresses: addresses,
+		TimeoutMs: uint32(timeout.Milliseconds()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("probe RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("probe failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// ProbeBandwidth asks the agent on sourceNode to stream data to the agent on
+// targetNode for duration and returns the throughput the target measured.
+func (c *Client) ProbeBandwidth(ctx context.Context, sourceNode, targetNode string, duration time.Duration, transfer *pb.TransferKey) (*pb.ProbeBandwidthResponse, error) {
+	targetEndpoint, err := c.getAgentEndpoint(ctx, targetNode)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.dialAgent(ctx, sourceNode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", sourceNode, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.ProbeBandwidth(ctx, &pb.ProbeBandwidthRequest{
+		TargetEndpoint: targetEndpoint,
+		DurationMs:     uint32(duration.Milliseconds()),
+		Transfer:       transfer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bandwidth probe RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("bandwidth probe failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// OpenTransfer has the agent on nodeName generate the key it receives the
+// streams of transferID with, and returns the key's certificate for the
+// sending agent. Agents that predate the call return codes.Unimplemented.
+func (c *Client) OpenTransfer(ctx context.Context, nodeName, transferID string) (*pb.TransferKey, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.OpenTransfer(ctx, &pb.OpenTransferRequest{TransferId: transferID})
+	if err != nil {
+		return nil, fmt.Errorf("open transfer RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("opening transfer failed: %s", resp.Error)
+	}
+
+	return resp.Key, nil
+}
+
+// GetNodeClock reads the clocks of nodeName.
+func (c *Client) GetNodeClock(ctx context.Context, nodeName string) (*pb.GetNodeClockResponse, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.GetNodeClock(ctx, &pb.GetNodeClockRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("clock RPC failed: %w", err)
+	}
+
+	if !resp.Success {
+		return nil, fmt.Errorf("reading clock failed: %s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// Health asks the agent on nodeName whether it is serving and returns its
+// message.
+func (c *Client) Health(ctx context.Context, nodeName string) (string, error) {
+	conn, err := c.dialAgent(ctx, nodeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to agent on node %s: %w", nodeName, err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			// Log error but don't fail the operation
+		}
+	}()
+
+	checkpointClient := pb.NewCheckpointServiceClient(conn)
+
+	resp, err := checkpointClient.Health(ctx, &pb.HealthRequest{})
+	if err != nil {
+		return "", fmt.Errorf("health RPC failed: %w", err)
+	}
+
+	if !resp.Healthy {
+		return "", fmt.Errorf("agent unhealthy: %s", resp.Message)
+	}
+
+	return resp.Message, nil
+}
+
+// getNodeEndpoint gets the agent endpoint using the node's addresses. Address
+// types are tried in nodeAddressPreference order; within a type, addresses of
+// the preferred IP family come first.
 func (c *Client) getNodeEndpoint(ctx context.Context, nodeName string) (string, error) {
 	node := &corev1.Node{}
 	if err := c.k8sClient.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
 		return "", fmt.Errorf("failed to get node %s: %w", nodeName, err)
 	}
 
-	for _, addr := range node.Status.Addresses {
-		if addr.Type == corev1.NodeInternalIP {
-			return fmt.Sprintf("%s:%d", addr.Address, agentPort), nil
+	host := selectNodeAddress(node.Status.Addresses, c.opts.PreferredIPFamily)
+	if host == "" {
+		return "", fmt.Errorf("no usable address found for node %s", nodeName)
+	}
+	return net.JoinHostPort(host, strconv.Itoa(agentPort)), nil
+}
+
+func selectNodeAddress(addresses []corev1.NodeAddress, family IPFamily) string {
+	for _, addrType := range nodeAddressPreference {
+		var fallback string
+		for _, addr := range addresses {
+			if addr.Type != addrType || addr.Address == "" {
+				continue
+			}
+			if family == IPFamilyAny || ipFamilyOf(addr.Address) == family {
+				return addr.Address
+			}
+			if fallback == "" {
+				fallback = addr.Address
+			}
+		}
+		if fallback != "" {
+			return fallback
 		}
 	}
+	return ""
+}
 
-	return "", fmt.Errorf("no internal IP found for node %s", nodeName)
+// ipFamilyOf returns the family of an IP address, or IPFamilyAny for a DNS
+// name.
+func ipFamilyOf(address string) IPFamily {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return IPFamilyAny
+	case ip.To4() != nil:
+		return IPFamilyIPv4
+	default:
+		return IPFamilyIPv6
+	}
 }
 
 // dialAgent creates a gRPC connection to the agent on the specified node
 func (c *Client) dialAgent(ctx context.Context, nodeName string) (*grpc.ClientConn, error) {
-	endpoint, err := c.getNodeEndpoint(ctx, nodeName)
+	endpoint, err := c.getAgentEndpoint(ctx, nodeName)
 	if err != nil {
 		return nil, err
 	}
 
-	conn, err := grpc.NewClient(endpoint,
+	dialOpts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(maxMessageSize),
 			grpc.MaxCallSendMsgSize(maxMessageSize),
 		),
-	)
+	}
+	if c.opts.TokenFile != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenFileCredentials(c.opts.TokenFile)))
+	}
+	conn, err := grpc.NewClient(endpoint, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial %s: %w", endpoint, err)
 	}
@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MigrationIDMetadataKey is the gRPC metadata key carrying the ID of the
+// migration a call to an agent is made for, which the agent logs with the
+// call.
+const MigrationIDMetadataKey = "lpm-migration-id"
+
+// WithMigrationID returns a context whose calls to the agents carry the
+// migration ID id. An empty id, or a context already carrying one, is left
+// as is.
+func WithMigrationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MigrationIDMetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MigrationIDMetadataKey, id)
+}
+
+// MigrationIDFromIncomingContext returns the migration ID of a call an agent
+// serves, or "" if the caller sent none.
+func MigrationIDFromIncomingContext(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(MigrationIDMetadataKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
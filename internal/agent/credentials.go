@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tokenFileCredentials authenticates calls to the agents with the bearer
+// token in a file.
+type tokenFileCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (f tokenFileCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	data, err := os.ReadFile(string(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent token: %w", err)
+	}
+	return map[string]string{"authorization": "Bearer " + strings.TrimSpace(string(data))}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials. The
+// agents are reached over plaintext gRPC on the cluster network.
+func (tokenFileCredentials) RequireTransportSecurity() bool {
+	return false
+}
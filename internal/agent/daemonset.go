@@ -0,0 +1,207 @@
+package agent
+
+import (
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DefaultAgentDaemonSet and DefaultAgentServiceAccount match config/agent.
+	DefaultAgentDaemonSet      = "live-pod-migration-controller-checkpoint-agent"
+	DefaultAgentServiceAccount = "live-pod-migration-controller-checkpoint-agent"
+
+	// DefaultSharedStorageClaim is the claim of the shared checkpoint storage
+	// in the agent namespace.
+	DefaultSharedStorageClaim = "checkpoint-repo"
+)
+
+// DaemonSetConfig is the agent DaemonSet the controller manages.
+type DaemonSetConfig struct {
+	// Namespace and Name of the DaemonSet.
+	Namespace string
+	Name      string
+	// ServiceAccountName the agents run as. Its RBAC is installed with
+	// config/agent.
+	ServiceAccountName string
+	// Image of the agent, pinned by tag or digest so the controller and its
+	// agents are upgraded together.
+	Image string
+	// ImagePullPolicy of the agent image.
+	ImagePullPolicy corev1.PullPolicy
+	// NodeSelector restricts the agents to the nodes able to checkpoint.
+	NodeSelector map[string]string
+	// Discovery is how the controller finds the agents: DiscoveryNodeAddress
+	// runs them on the host network.
+	Discovery Discovery
+	// Service is the headless Service the agent pods are named under for
+	// DiscoveryServiceDNS.
+	Service string
+	// SharedStorageClaim is mounted at /mnt/checkpoints. Empty keeps
+	// checkpoints on their node, for the checkpoint registry.
+	SharedStorageClaim string
+	// LeastPrivilege runs the agents unprivileged with a read-only root
+	// filesystem.
+	LeastPrivilege bool
+}
+
+// DaemonSet returns the agent DaemonSet config describes, the same as
+// config/agent with the patches matching config applied.
+func (c DaemonSetConfig) DaemonSet() *appsv1.DaemonSet {
+	labels := map[string]string{agentPodLabel: agentPodLabelVal}
+	propagation := corev1.MountPropagationHostToContainer
+	privileged, readOnlyRoot := !c.LeastPrivilege, c.LeastPrivilege
+	runAsNonRoot, root := false, int64(0)
+	hostPathVolume := func(name, path string, pathType corev1.HostPathType) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			HostPath: &corev1.HostPathVolumeSource{Path: path, Type: &pathType},
+		}}
+	}
+
+	container := corev1.Container{
+		Name:            "agent",
+		Image:           c.Image,
+		ImagePullPolicy: c.ImagePullPolicy,
+		Env: []corev1.EnvVar{
+			{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"}}},
+			{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			{Name: "POD_NAMESPACE", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}}},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               &privileged,
+			AllowPrivilegeEscalation: &privileged,
+			ReadOnlyRootFilesystem:   &readOnlyRoot,
+			RunAsNonRoot:             &runAsNonRoot,
+			RunAsUser:                &root,
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"SYS_ADMIN", "SYS_PTRACE", "SYS_CHROOT"},
+			},
+		},
+		Ports: []corev1.ContainerPort{{Name: agentPortName, ContainerPort: agentPort, Protocol: corev1.ProtocolTCP}},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler:        corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: agentPort}},
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       30,
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler:        corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: agentPort}},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       10,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "cri-sock", MountPath: "/var/run/crio/crio.sock"},
+			{Name: "checkpoints", MountPath: "/var/lib/kubelet/checkpoints"},
+			{Name: "checkpoint-repo", MountPath: "/mnt/checkpoints"},
+			{Name: "kubelet-pods", MountPath: "/var/lib/kubelet/pods", MountPropagation: &propagation},
+			{Name: "container-storage", MountPath: "/var/lib/containers/storage"},
+			{Name: "sysfs", MountPath: "/sys", ReadOnly: true},
+			// Writable for freezing pods during Pod scope checkpoints
+			{Name: "cgroup", MountPath: "/sys/fs/cgroup"},
+			{Name: "proc", MountPath: "/host/proc", ReadOnly: true},
+			{Name: "run", MountPath: "/run"},
+			{Name: "criu-config", MountPath: "/etc/criu"},
+			{Name: "kubelet-seccomp", MountPath: "/var/lib/kubelet/seccomp", ReadOnly: true},
+			{Name: "k8s-certs", MountPath: "/etc/kubernetes/pki", ReadOnly: true},
+			{Name: "kubelet-certs", MountPath: "/var/lib/kubelet/pki", ReadOnly: true},
+		},
+	}
+	volumes := []corev1.Volume{
+		hostPathVolume("cri-sock", "/var/run/crio/crio.sock", corev1.HostPathSocket),
+		hostPathVolume("checkpoints", "/var/lib/kubelet/checkpoints", corev1.HostPathDirectoryOrCreate),
+		hostPathVolume("kubelet-pods", "/var/lib/kubelet/pods", corev1.HostPathDirectory),
+		hostPathVolume("container-storage", "/var/lib/containers/storage", corev1.HostPathDirectoryOrCreate),
+		hostPathVolume("sysfs", "/sys", corev1.HostPathDirectory),
+		hostPathVolume("cgroup", "/sys/fs/cgroup", corev1.HostPathDirectory),
+		hostPathVolume("proc", "/proc", corev1.HostPathDirectory),
+		hostPathVolume("run", "/run", corev1.HostPathDirectory),
+		hostPathVolume("criu-config", "/etc/criu", corev1.HostPathDirectoryOrCreate),
+		hostPathVolume("kubelet-seccomp", "/var/lib/kubelet/seccomp", corev1.HostPathDirectoryOrCreate),
+		hostPathVolume("k8s-certs", "/etc/kubernetes/pki", corev1.HostPathDirectory),
+		hostPathVolume("kubelet-certs", "/var/lib/kubelet/pki", corev1.HostPathDirectory),
+	}
+
+	if c.SharedStorageClaim != "" {
+		volumes = append(volumes, corev1.Volume{Name: "checkpoint-repo", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: c.SharedStorageClaim},
+		}})
+	} else {
+		volumes = append(volumes, corev1.Volume{Name: "checkpoint-repo", VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}})
+		container.Env = append(container.Env, corev1.EnvVar{Name: "AGENT_SHARED_STORAGE", Value: "false"})
+	}
+
+	if c.LeastPrivilege {
+		container.SecurityContext.Capabilities = &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+			Add:  []corev1.Capability{"SYS_ADMIN", "SYS_PTRACE", "DAC_READ_SEARCH"},
+		}
+		container.Env = append(container.Env,
+			corev1.EnvVar{Name: "AGENT_LEAST_PRIVILEGE", Value: "true"},
+			corev1.EnvVar{Name: "TMPDIR", Value: "/tmp"})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "tmp", MountPath: "/tmp"})
+		volumes = append(volumes, corev1.Volume{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	} else {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "dev", MountPath: "/dev"})
+		volumes = append(volumes, hostPathVolume("dev", "/dev", corev1.HostPathDirectory))
+	}
+
+	podSpec := corev1.PodSpec{
+		ServiceAccountName: c.ServiceAccountName,
+		HostPID:            true,
+		NodeSelector:       maps.Clone(c.NodeSelector),
+		Tolerations: []corev1.Toleration{
+			{Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+			{Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+		},
+		Volumes: volumes,
+	}
+	if c.Discovery == DiscoveryPodIP || c.Discovery == DiscoveryServiceDNS {
+		podSpec.DNSPolicy = corev1.DNSClusterFirst
+		// Must match the headless Service name for ServiceDNS
+		podSpec.Subdomain = c.Service
+	} else {
+		podSpec.HostNetwork = true
+		podSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+		container.Ports[0].HostPort = agentPort
+	}
+	podSpec.Containers = []corev1.Container{container}
+
+	maxUnavailable := intstr.FromInt32(1)
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+			Labels: map[string]string{
+				agentPodLabel:                  agentPodLabelVal,
+				"control-plane":                agentPodLabelVal,
+				"app.kubernetes.io/managed-by": "live-pod-migration-controller",
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: maps.Clone(labels)},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
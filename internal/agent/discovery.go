@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Discovery selects how the agent of a node is located.
+type Discovery string
+
+const (
+	// DiscoveryNodeAddress dials the node's own address. The agent must run
+	// with hostNetwork.
+	DiscoveryNodeAddress Discovery = "NodeAddress"
+	// DiscoveryPodIP dials the IP of the node's agent pod.
+	DiscoveryPodIP Discovery = "PodIP"
+	// DiscoveryServiceDNS dials the DNS name the headless agent Service
+	// gives the node's agent pod.
+	DiscoveryServiceDNS Discovery = "ServiceDNS"
+)
+
+const (
+	// DefaultAgentNamespace and DefaultAgentService match config/agent.
+	DefaultAgentNamespace = "live-pod-migration-controller-system"
+	DefaultAgentService   = "live-pod-migration-controller-checkpoint-agent"
+
+	agentPodLabel    = "app"
+	agentPodLabelVal = "checkpoint-agent"
+	agentPortName    = "grpc"
+)
+
+// ParseDiscovery parses a discovery mode as accepted on the command line.
+func ParseDiscovery(s string) (Discovery, error) {
+	switch Discovery(s) {
+	case "":
+		return DiscoveryNodeAddress, nil
+	case DiscoveryNodeAddress, DiscoveryPodIP, DiscoveryServiceDNS:
+		return Discovery(s), nil
+	}
+	return "", fmt.Errorf("unknown agent discovery mode %q (want %s, %s or %s)",
+		s, DiscoveryNodeAddress, DiscoveryPodIP, DiscoveryServiceDNS)
+}
+
+// getAgentEndpoint returns the host:port the agent of nodeName is dialed on.
+func (c *Client) getAgentEndpoint(ctx context.Context, nodeName string) (string, error) {
+	switch c.opts.Discovery {
+	case DiscoveryPodIP, DiscoveryServiceDNS:
+		return c.getAgentPodEndpoint(ctx, nodeName)
+	default:
+		return c.getNodeEndpoint(ctx, nodeName)
+	}
+}
+
+// getAgentPod finds the agent DaemonSet pod scheduled to nodeName. The field
+// selector needs a spec.nodeName index when k8sClient reads from a cache; the
+// manager registers one.
+func (c *Client) getAgentPod(ctx context.Context, nodeName string) (*corev1.Pod, error) {
+	namespace := c.opts.AgentNamespace
+	if namespace == "" {
+		namespace = DefaultAgentNamespace
+	}
+
+	var pods corev1.PodList
+	if err := c.k8sClient.List(ctx, &pods,
+		client.InNamespace(namespace),
+		client.MatchingLabels{agentPodLabel: agentPodLabelVal},
+		client.MatchingFields{"spec.nodeName": nodeName},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list agent pods on node %s: %w", nodeName, err)
+	}
+
+	pod := readyAgentPod(pods.Items)
+	if pod == nil {
+		return nil, fmt.Errorf("no ready agent pod found on node %s in namespace %s", nodeName, namespace)
+	}
+	return pod, nil
+}
+
+// AgentImage returns the image the agent on nodeName runs, which is present
+// on the node and provides a shell.
+func (c *Client) AgentImage(ctx context.Context, nodeName string) (string, error) {
+	pod, err := c.getAgentPod(ctx, nodeName)
+	if err != nil {
+		return "", err
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == agentPortName {
+				return container.Image, nil
+			}
+		}
+	}
+	return pod.Spec.Containers[0].Image, nil
+}
+
+// getAgentPodEndpoint returns the endpoint of the agent pod on nodeName.
+func (c *Client) getAgentPodEndpoint(ctx context.Context, nodeName string) (string, error) {
+	pod, err := c.getAgentPod(ctx, nodeName)
+	if err != nil {
+		return "", err
+	}
+	port := strconv.Itoa(agentContainerPort(pod))
+
+	if c.opts.Discovery == DiscoveryServiceDNS {
+		service := c.opts.AgentService
+		if service == "" {
+			service = DefaultAgentService
+		}
+		// Pods whose subdomain matches a headless Service get an A/AAAA
+		// record under it; without a hostname the pod name is used.
+		hostname := pod.Spec.Hostname
+		if hostname == "" {
+			hostname = pod.Name
+		}
+		return net.JoinHostPort(fmt.Sprintf("%s.%s.%s.svc", hostname, service, pod.Namespace), port), nil
+	}
+
+	var ips []corev1.NodeAddress
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: podIP.IP})
+	}
+	if len(ips) == 0 && pod.Status.PodIP != "" {
+		ips = append(ips, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: pod.Status.PodIP})
+	}
+	host := selectNodeAddress(ips, c.opts.PreferredIPFamily)
+	if host == "" {
+		return "", fmt.Errorf("agent pod %s/%s has no IP", pod.Namespace, pod.Name)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// readyAgentPod returns a running, ready pod, preferring it over pods that
+// are merely running (e.g. during a rolling update).
+func readyAgentPod(pods []corev1.Pod) *corev1.Pod {
+	var running *corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+				return pod
+			}
+		}
+		if running == nil {
+			running = pod
+		}
+	}
+	return running
+}
+
+// agentContainerPort returns the pod's "grpc" container port, defaulting to
+// the agent's standard port.
+func agentContainerPort(pod *corev1.Pod) int {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == agentPortName {
+				return int(port.ContainerPort)
+			}
+		}
+	}
+	return agentPort
+}
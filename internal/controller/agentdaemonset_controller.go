@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"my.domain/guestbook/internal/agent"
+)
+
+// AgentDaemonSetReconciler installs the checkpoint agent DaemonSet and keeps
+// it matching the controller's configuration, so upgrading the controller
+// upgrades the agents.
+type AgentDaemonSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	Config agent.DaemonSetConfig
+}
+
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch
+
+func (r *AgentDaemonSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	desired := r.Config.DaemonSet()
+	var current appsv1.DaemonSet
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), &current)
+	if apierrors.IsNotFound(err) {
+		logger.Info("Installing checkpoint agent DaemonSet", "image", r.Config.Image)
+		return ctrl.Result{}, r.Create(ctx, desired)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The selector is immutable; keep the one a DaemonSet installed from
+	// config/agent was created with
+	desired.Spec.Selector = current.Spec.Selector
+	maps.Copy(desired.Spec.Template.Labels, current.Spec.Selector.MatchLabels)
+
+	// Fields the API server defaults are left out of the desired spec
+	if equality.Semantic.DeepDerivative(desired.Spec, current.Spec) &&
+		equality.Semantic.DeepDerivative(desired.Labels, current.Labels) {
+		return ctrl.Result{}, nil
+	}
+	logger.Info("Updating checkpoint agent DaemonSet", "image", r.Config.Image)
+	if current.Labels == nil {
+		current.Labels = map[string]string{}
+	}
+	maps.Copy(current.Labels, desired.Labels)
+	current.Spec = desired.Spec
+	return ctrl.Result{}, r.Update(ctx, &current)
+}
+
+// SetupWithManager sets up the controller with the Manager. It reconciles
+// once at startup, so a missing DaemonSet is installed, and whenever the
+// DaemonSet changes.
+func (r *AgentDaemonSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	desired := r.Config.DaemonSet()
+	startup := make(chan event.GenericEvent, 1)
+	startup <- event.GenericEvent{Object: desired}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.DaemonSet{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == desired.Namespace && obj.GetName() == desired.Name
+		}))).
+		WatchesRawSource(source.Channel(startup, &handler.EnqueueRequestForObject{})).
+		Named("agentdaemonset").
+		Complete(r)
+}
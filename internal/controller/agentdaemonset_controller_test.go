@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"my.domain/guestbook/internal/agent"
+)
+
+var _ = Describe("AgentDaemonSet Controller", func() {
+	Context("When reconciling the agent DaemonSet", func() {
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      agent.DefaultAgentDaemonSet,
+			Namespace: "default",
+		}
+		config := agent.DaemonSetConfig{
+			Namespace:          typeNamespacedName.Namespace,
+			Name:               typeNamespacedName.Name,
+			ServiceAccountName: agent.DefaultAgentServiceAccount,
+			Image:              "localhost/checkpoint-agent:v1",
+			Discovery:          agent.DiscoveryNodeAddress,
+			SharedStorageClaim: agent.DefaultSharedStorageClaim,
+		}
+
+		AfterEach(func() {
+			daemonSet := &appsv1.DaemonSet{}
+			if err := k8sClient.Get(ctx, typeNamespacedName, daemonSet); err == nil {
+				By("Cleanup the agent DaemonSet")
+				Expect(k8sClient.Delete(ctx, daemonSet)).To(Succeed())
+			}
+		})
+
+		It("should install the DaemonSet and upgrade its image", func() {
+			controllerReconciler := &AgentDaemonSetReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+				Config: config,
+			}
+
+			By("Reconciling without a DaemonSet")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			daemonSet := &appsv1.DaemonSet{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, daemonSet)).To(Succeed())
+			Expect(daemonSet.Spec.Template.Spec.Containers[0].Image).To(Equal("localhost/checkpoint-agent:v1"))
+			Expect(daemonSet.Spec.Template.Spec.HostNetwork).To(BeTrue())
+
+			By("Reconciling with a new agent image")
+			controllerReconciler.Config.Image = "localhost/checkpoint-agent:v2"
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, daemonSet)).To(Succeed())
+			Expect(daemonSet.Spec.Template.Spec.Containers[0].Image).To(Equal("localhost/checkpoint-agent:v2"))
+		})
+	})
+})
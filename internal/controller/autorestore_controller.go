@@ -0,0 +1,297 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+const (
+	// DefaultAutoRestoreGracePeriod is how long a node must be not ready
+	// before its pods are restored elsewhere.
+	DefaultAutoRestoreGracePeriod = time.Minute
+
+	// DefaultAutoRestoreMaxCheckpointAge is the age of the oldest checkpoint
+	// a pod is restored from when its node fails.
+	DefaultAutoRestoreMaxCheckpointAge = time.Hour
+
+	// autoRestoreRetryInterval is how often pods that could not be restored
+	// yet, e.g. for lack of a node they fit on, are tried again.
+	autoRestoreRetryInterval = 30 * time.Second
+)
+
+// AutoRestoreReconciler restores the pods of failed nodes. Once a node has
+// not been ready for GracePeriod, every pod on it annotated with
+// lpm.my.domain/auto-restore=true that has a checkpoint no older than
+// MaxCheckpointAge still reachable without the node gets a PodRestore onto a
+// ready node it fits on. The pods on the failed node are left alone; the
+// node may only be partitioned.
+type AutoRestoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// GracePeriod defaults to DefaultAutoRestoreGracePeriod.
+	GracePeriod time.Duration
+
+	// MaxCheckpointAge defaults to DefaultAutoRestoreMaxCheckpointAge.
+	MaxCheckpointAge time.Duration
+}
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podrestores,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpoints;podcheckpointcontents;containercheckpointcontents;migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;pods;namespaces,verbs=get;list;watch
+
+func (r *AutoRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var node corev1.Node
+	if err := r.Get(ctx, req.NamespacedName, &node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	ready, since := nodeReadiness(&node)
+	if ready {
+		return ctrl.Result{}, nil
+	}
+	gracePeriod := r.GracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = DefaultAutoRestoreGracePeriod
+	}
+	if wait := gracePeriod - time.Since(since); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeNameField: node.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	pending := false
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Annotations[lpmv1.AutoRestoreAnnotation] != "true" || !pod.DeletionTimestamp.IsZero() ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		message, err := r.restorePod(ctx, pod, node.Name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if message != "" {
+			logger.Info("Cannot restore pod of failed node yet", "pod", pod.Namespace+"/"+pod.Name, "node", node.Name, "reason", message)
+			pending = true
+		}
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: autoRestoreRetryInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// restorePod creates the PodRestore of pod, which ran on the failed node
+// failedNode. It returns why it could not, or "" once the PodRestore exists.
+func (r *AutoRestoreReconciler) restorePod(ctx context.Context, pod *corev1.Pod, failedNode string) (string, error) {
+	name := fmt.Sprintf("%s-auto-%s", pod.Name, string(pod.UID)[:8])
+	var existing lpmv1.PodRestore
+	err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: name}, &existing)
+	if err == nil {
+		return "", nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	ref, err := r.recentCheckpoint(ctx, pod, failedNode)
+	if err != nil || ref == nil {
+		return "no checkpoint recent enough and reachable without the node", err
+	}
+	target, err := r.healthyNode(ctx, pod, failedNode)
+	if err != nil || target == "" {
+		return "no ready node the pod fits on", err
+	}
+
+	podRestore := &lpmv1.PodRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: pod.Namespace,
+			Labels:    map[string]string{lpmv1.AutoRestoreNodeLabel: failedNode},
+		},
+		Spec: lpmv1.PodRestoreSpec{
+			CheckpointRef: *ref,
+			NodeName:      target,
+			// The pod of the failed node keeps its name until it is deleted
+			PodName: pod.Name + "-restored",
+		},
+	}
+	if err := r.Create(ctx, podRestore); err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", err
+	}
+	log.FromContext(ctx).Info("Restoring pod of failed node", "pod", pod.Namespace+"/"+pod.Name,
+		"node", failedNode, "target", target, "checkpoint", ref.Name, "generation", *ref.Generation)
+	return "", nil
+}
+
+// recentCheckpoint returns the newest checkpoint generation of pod taken
+// within MaxCheckpointAge, or the shorter maxCheckpointAge of its
+// MigrationPolicies, whose archives don't live only on failedNode, or nil if
+// there is none.
+func (r *AutoRestoreReconciler) recentCheckpoint(ctx context.Context, pod *corev1.Pod, failedNode string) (*lpmv1.CheckpointReference, error) {
+	maxAge := r.MaxCheckpointAge
+	if maxAge == 0 {
+		maxAge = DefaultAutoRestoreMaxCheckpointAge
+	}
+	policies, err := policy.Matching(ctx, r.Client, pod)
+	if err != nil {
+		return nil, err
+	}
+	if policyMaxAge := policy.MaxCheckpointAge(policies); policyMaxAge > 0 {
+		maxAge = min(maxAge, policyMaxAge)
+	}
+
+	var podCheckpoints lpmv1.PodCheckpointList
+	if err := r.List(ctx, &podCheckpoints, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, err
+	}
+	var best *lpmv1.CheckpointReference
+	var bestTime time.Time
+	for _, podCheckpoint := range podCheckpoints.Items {
+		if podCheckpoint.Spec.PodName == nil || *podCheckpoint.Spec.PodName != pod.Name {
+			continue
+		}
+		for _, entry := range slices.Backward(podCheckpoint.Status.History) {
+			// A checkpoint of an earlier pod of the same name is not this pod's
+			if time.Since(entry.CreationTime.Time) > maxAge || entry.CreationTime.Time.Before(pod.CreationTimestamp.Time) {
+				break
+			}
+			if !entry.CreationTime.Time.After(bestTime) {
+				break
+			}
+			reachable, err := r.reachableWithout(ctx, pod.Namespace, entry.ContentName, failedNode)
+			if err != nil {
+				return nil, err
+			}
+			if reachable {
+				generation := entry.Generation
+				best = &lpmv1.CheckpointReference{Name: podCheckpoint.Name, Generation: &generation}
+				bestTime = entry.CreationTime.Time
+				break
+			}
+		}
+	}
+	return best, nil
+}
+
+// reachableWithout reports whether the archives of the PodCheckpointContent
+// contentName can be read without failedNode: they are in shared storage or
+// were taken on another node.
+func (r *AutoRestoreReconciler) reachableWithout(ctx context.Context, namespace, contentName, failedNode string) (bool, error) {
+	var checkpointContent lpmv1.PodCheckpointContent
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: contentName}, &checkpointContent); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	for _, ref := range checkpointContent.Spec.ContainerContents {
+		var content lpmv1.ContainerCheckpointContent
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		if !strings.HasPrefix(content.Spec.ArtifactURI, "shared://") && content.Spec.NodeName == failedNode {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// healthyNode returns the first node by name, other than failedNode, that
+// the pod's migration policies allow and that it fits on, or "" if there is
+// none.
+func (r *AutoRestoreReconciler) healthyNode(ctx context.Context, pod *corev1.Pod, failedNode string) (string, error) {
+	policies, err := policy.Matching(ctx, r.Client, pod)
+	if err != nil {
+		return "", err
+	}
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return "", err
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+
+	placement, err := newPlacementCheck(ctx, r.Client, pod, pod)
+	if err != nil {
+		return "", err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Name == failedNode {
+			continue
+		}
+		denied, err := policy.TargetDenied(policies, node)
+		if err != nil {
+			return "", err
+		}
+		if denied != "" {
+			continue
+		}
+		violations, _, err := placement.evaluate(node)
+		if err != nil {
+			return "", err
+		}
+		if len(violations) > 0 {
+			continue
+		}
+		message, err := checkTargetFit(ctx, r.Client, pod, node.Name)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			return node.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// nodeReadiness reports whether node is ready and since when it has been in
+// that state.
+func nodeReadiness(node *corev1.Node) (bool, time.Time) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue, condition.LastTransitionTime.Time
+		}
+	}
+	// A node that never reported is not failing
+	return true, time.Time{}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AutoRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		Named("autorestore").
+		Complete(r)
+}
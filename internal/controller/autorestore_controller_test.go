@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var _ = Describe("AutoRestore Controller", func() {
+	Context("When a node with an auto-restore pod fails", func() {
+		ctx := context.Background()
+
+		nodeName := types.NamespacedName{Name: "failed-node"}
+		podName := types.NamespacedName{Name: "auto-restore-pod", Namespace: "default"}
+
+		BeforeEach(func() {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name}}
+			Expect(k8sClient.Create(ctx, node)).To(Succeed())
+			node.Status.Conditions = []corev1.NodeCondition{{
+				Type:               corev1.NodeReady,
+				Status:             corev1.ConditionUnknown,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			}}
+			Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        podName.Name,
+					Namespace:   podName.Namespace,
+					Annotations: map[string]string{lpmv1.AutoRestoreAnnotation: "true"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:   nodeName.Name,
+					Containers: []corev1.Container{{Name: "app", Image: "busybox"}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			pod := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, podName, pod)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0))).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name}})).To(Succeed())
+		})
+
+		It("should wait for a checkpoint rather than restore without one", func() {
+			controllerReconciler := &AutoRestoreReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nodeName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(autoRestoreRetryInterval))
+
+			var podRestores lpmv1.PodRestoreList
+			Expect(k8sClient.List(ctx, &podRestores, client.MatchingLabels{lpmv1.AutoRestoreNodeLabel: nodeName.Name})).To(Succeed())
+			Expect(podRestores.Items).To(BeEmpty())
+		})
+	})
+})
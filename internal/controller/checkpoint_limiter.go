@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"my.domain/guestbook/internal/metrics"
+)
+
+// checkpointQueueEntryTTL drops queued checkpoints that stopped polling for a
+// slot (e.g. because they were deleted) so they don't hold up the queue.
+const checkpointQueueEntryTTL = time.Minute
+
+// nodeLimiter caps the number of checkpoint RPCs dispatched to each node.
+// Callers that don't get a slot are queued per node and served in FIFO order
+// as they poll again; acquiring never blocks a reconcile worker.
+type nodeLimiter struct {
+	max int
+
+	mu       sync.Mutex
+	inFlight map[string]int
+	queues   map[string][]*queuedCheckpoint
+}
+
+type queuedCheckpoint struct {
+	key      types.NamespacedName
+	queuedAt time.Time
+	lastSeen time.Time
+}
+
+// newNodeLimiter returns a limiter allowing max concurrent checkpoints per
+// node; max <= 0 disables the limit.
+func newNodeLimiter(max int) *nodeLimiter {
+	return &nodeLimiter{
+		max:      max,
+		inFlight: map[string]int{},
+		queues:   map[string][]*queuedCheckpoint{},
+	}
+}
+
+// tryAcquire takes a slot on node for key. It returns false, and queues key,
+// if the node is at its limit or earlier checkpoints are still waiting. A
+// successful call must be paired with release.
+func (l *nodeLimiter) tryAcquire(node string, key types.NamespacedName) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	queue := l.queues[node][:0]
+	var entry *queuedCheckpoint
+	for _, q := range l.queues[node] {
+		if q.key == key {
+			q.lastSeen = now
+			entry = q
+		}
+		if now.Sub(q.lastSeen) < checkpointQueueEntryTTL {
+			queue = append(queue, q)
+		}
+	}
+	if entry == nil {
+		entry = &queuedCheckpoint{key: key, queuedAt: now, lastSeen: now}
+		queue = append(queue, entry)
+	}
+
+	if l.max > 0 && (l.inFlight[node] >= l.max || queue[0] != entry) {
+		l.queues[node] = queue
+		return false
+	}
+
+	queue = removeQueued(queue, entry)
+	if len(queue) == 0 {
+		delete(l.queues, node)
+	} else {
+		l.queues[node] = queue
+	}
+	l.inFlight[node]++
+	metrics.CheckpointQueueWait.WithLabelValues(node).Observe(now.Sub(entry.queuedAt).Seconds())
+	metrics.CheckpointsInFlight.WithLabelValues(node).Inc()
+	return true
+}
+
+// release frees a slot taken by tryAcquire.
+func (l *nodeLimiter) release(node string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight[node]--
+	if l.inFlight[node] <= 0 {
+		delete(l.inFlight, node)
+	}
+	metrics.CheckpointsInFlight.WithLabelValues(node).Dec()
+}
+
+// dequeue drops keys from the queue of node, e.g. once their checkpoints
+// were taken along with another one's.
+func (l *nodeLimiter) dequeue(node string, keys ...types.NamespacedName) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	queue := slices.DeleteFunc(l.queues[node], func(q *queuedCheckpoint) bool {
+		return slices.Contains(keys, q.key)
+	})
+	if len(queue) == 0 {
+		delete(l.queues, node)
+	} else {
+		l.queues[node] = queue
+	}
+}
+
+func removeQueued(queue []*queuedCheckpoint, entry *queuedCheckpoint) []*queuedCheckpoint {
+	for i, q := range queue {
+		if q == entry {
+			return append(queue[:i], queue[i+1:]...)
+		}
+	}
+	return queue
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// errCheckpointBatched is returned for a ContainerCheckpoint being dumped
+// along with another one, whose reconcile records its result.
+var errCheckpointBatched = errors.New("container is being checkpointed with the other containers of its pod")
+
+// checkpointBatches tracks the ContainerCheckpoints being dumped by a
+// CheckpointPod call, so their own reconciles don't dump them again. The
+// zero value is ready to use.
+type checkpointBatches struct {
+	mu      sync.Mutex
+	claimed map[types.NamespacedName]bool
+}
+
+// claim marks the keys not claimed yet as being dumped and returns them.
+// Those returned must be released once their dump is recorded.
+func (b *checkpointBatches) claim(keys []types.NamespacedName) []types.NamespacedName {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.claimed == nil {
+		b.claimed = map[types.NamespacedName]bool{}
+	}
+	var claimed []types.NamespacedName
+	for _, key := range keys {
+		if !b.claimed[key] {
+			b.claimed[key] = true
+			claimed = append(claimed, key)
+		}
+	}
+	return claimed
+}
+
+// release undoes claim.
+func (b *checkpointBatches) release(keys []types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, key := range keys {
+		delete(b.claimed, key)
+	}
+}
+
+// containerDump is the outcome of checkpointing one ContainerCheckpoint.
+type containerDump struct {
+	containerCheckpoint *lpmv1.ContainerCheckpoint
+	response            *pb.CheckpointResponse
+	criuOptions         []string
+	err                 error
+}
+
+// checkpointBatch returns containerCheckpoint along with the other
+// ContainerCheckpoints of its PodCheckpoint generation still to be dumped
+// from pod, which are taken together in one CheckpointPod call. A
+// ContainerCheckpoint created on its own is dumped alone.
+func (r *ContainerCheckpointReconciler) checkpointBatch(ctx context.Context, containerCheckpoint *lpmv1.ContainerCheckpoint, pod *corev1.Pod) ([]*lpmv1.ContainerCheckpoint, error) {
+	batch := []*lpmv1.ContainerCheckpoint{containerCheckpoint}
+	podCheckpoint := containerCheckpoint.Labels["podcheckpoint"]
+	generation := containerCheckpoint.Labels[checkpointGenerationLabel]
+	if podCheckpoint == "" || generation == "" {
+		return batch, nil
+	}
+
+	var siblings lpmv1.ContainerCheckpointList
+	if err := r.List(ctx, &siblings, client.InNamespace(containerCheckpoint.Namespace), client.MatchingLabels{
+		"podcheckpoint":           podCheckpoint,
+		checkpointGenerationLabel: generation,
+	}); err != nil {
+		return nil, err
+	}
+	for i := range siblings.Items {
+		sibling := &siblings.Items[i]
+		phase := sibling.Status.Phase
+		if sibling.UID == containerCheckpoint.UID || sibling.Spec.PodName != pod.Name || sibling.Status.BoundContentName != "" ||
+			(phase != "" && phase != lpmv1.ContainerCheckpointPhasePending && phase != lpmv1.ContainerCheckpointPhaseRunning) {
+			continue
+		}
+		// Pending ones skip the checks of their Pending phase; do them here
+		if !slices.ContainsFunc(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == sibling.Spec.ContainerName }) {
+			continue
+		}
+		batch = append(batch, sibling)
+	}
+	return batch, nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// inFlightCheckpoints holds the cancel functions of the agent calls dumping
+// ContainerCheckpoints, so a checkpoint nobody waits for anymore stops on the
+// node instead of dumping the container in the background. Cancelling the
+// call cancels the agent's kubelet request and its retries. The zero value is
+// ready to use.
+type inFlightCheckpoints struct {
+	mu      sync.Mutex
+	entries map[types.UID]inFlightCheckpoint
+}
+
+type inFlightCheckpoint struct {
+	podCheckpoint types.NamespacedName
+	cancel        context.CancelCauseFunc
+}
+
+// add records that cancel stops the dumps of containerCheckpoints.
+func (f *inFlightCheckpoints) add(containerCheckpoints []*lpmv1.ContainerCheckpoint, cancel context.CancelCauseFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.entries == nil {
+		f.entries = map[types.UID]inFlightCheckpoint{}
+	}
+	for _, c := range containerCheckpoints {
+		f.entries[c.UID] = inFlightCheckpoint{
+			podCheckpoint: types.NamespacedName{Namespace: c.Namespace, Name: c.Labels["podcheckpoint"]},
+			cancel:        cancel,
+		}
+	}
+}
+
+// remove undoes add once the dumps returned.
+func (f *inFlightCheckpoints) remove(containerCheckpoints []*lpmv1.ContainerCheckpoint) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, c := range containerCheckpoints {
+		delete(f.entries, c.UID)
+	}
+}
+
+// cancel stops the dump of the ContainerCheckpoint uid, if one is in flight.
+// Containers dumped in the same call are stopped with it.
+func (f *inFlightCheckpoints) cancel(uid types.UID, cause error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if entry, ok := f.entries[uid]; ok {
+		entry.cancel(cause)
+	}
+}
+
+// cancelPodCheckpoint stops the dumps of the ContainerCheckpoints of the
+// PodCheckpoint key.
+func (f *inFlightCheckpoints) cancelPodCheckpoint(key types.NamespacedName, cause error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, entry := range f.entries {
+		if entry.podCheckpoint == key {
+			entry.cancel(cause)
+		}
+	}
+}
+
+// containerCheckpointCancelHandler cancels the dump of a ContainerCheckpoint
+// being deleted. It enqueues nothing; the reconcile of the
+// ContainerCheckpoint is still busy with the dump.
+func (r *ContainerCheckpointReconciler) containerCheckpointCancelHandler() handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			if !e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				r.inFlight.cancel(e.ObjectNew.GetUID(), fmt.Errorf("ContainerCheckpoint %s is being deleted", e.ObjectNew.GetName()))
+			}
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.inFlight.cancel(e.Object.GetUID(), fmt.Errorf("ContainerCheckpoint %s was deleted", e.Object.GetName()))
+		},
+	}
+}
+
+// podMigrationCancelHandler cancels the dumps of the checkpoint a
+// PodMigration took for itself once the migration failed or is deleted.
+func (r *ContainerCheckpointReconciler) podMigrationCancelHandler() handler.EventHandler {
+	cancel := func(obj client.Object, cause error) {
+		podMigration, ok := obj.(*lpmv1.PodMigration)
+		// A referenced checkpoint is not the migration's own
+		if !ok || podMigration.Spec.CheckpointRef != nil {
+			return
+		}
+		name := podMigration.Name
+		if ref := podMigration.Status.PodCheckpointRef; ref != nil && ref.Name != "" {
+			name = ref.Name
+		}
+		r.inFlight.cancelPodCheckpoint(types.NamespacedName{Namespace: podMigration.Namespace, Name: name}, cause)
+	}
+	return handler.Funcs{
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			podMigration, ok := e.ObjectNew.(*lpmv1.PodMigration)
+			if !ok {
+				return
+			}
+			switch {
+			case !podMigration.DeletionTimestamp.IsZero():
+				cancel(podMigration, fmt.Errorf("PodMigration %s is being deleted", podMigration.Name))
+			case podMigration.Status.Phase == lpmv1.MigrationPhaseFailed:
+				cancel(podMigration, fmt.Errorf("PodMigration %s failed", podMigration.Name))
+			}
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			cancel(e.Object, fmt.Errorf("PodMigration %s was deleted", e.Object.GetName()))
+		},
+	}
+}
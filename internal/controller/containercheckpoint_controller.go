@@ -18,17 +18,26 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	pb "my.domain/guestbook/api/proto"
 	lpmv1 "my.domain/guestbook/api/v1"
 	"my.domain/guestbook/internal/agent"
 )
@@ -38,22 +47,43 @@ type ContainerCheckpointReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Agent  agent.Client
+
+	// MaxCheckpointsPerNode caps the checkpoint RPCs dispatched to a single
+	// node at once; further checkpoints for that node are queued. Zero means
+	// no limit.
+	MaxCheckpointsPerNode int
+	// MaxConcurrentReconciles is the number of checkpoints the controller
+	// works on in parallel across all nodes.
+	MaxConcurrentReconciles int
+
+	limiter  *nodeLimiter
+	batches  checkpointBatches
+	inFlight inFlightCheckpoints
 }
 
+// checkpointSlotPollInterval is how often a queued checkpoint retries for a
+// free slot on its node.
+const checkpointSlotPollInterval = 2 * time.Second
+
+// errNodeBusy is returned when a node has no free checkpoint slot.
+var errNodeBusy = errors.New("node is at its concurrent checkpoint limit")
+
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpoints/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpoints/finalizers,verbs=update
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpointcontents,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpointcontents/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
 func (r *ContainerCheckpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
 	var containerCheckpoint lpmv1.ContainerCheckpoint
 	if err := r.Get(ctx, req.NamespacedName, &containerCheckpoint); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx = withMigrationID(ctx, containerCheckpoint.Labels[lpmv1.MigrationIDLabel])
+	logger := log.FromContext(ctx)
 
 	if containerCheckpoint.Status.Phase == "" {
 		containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhasePending
@@ -87,6 +117,9 @@ func (r *ContainerCheckpointReconciler) handlePendingPhase(ctx context.Context,
 		}
 		return ctrl.Result{}, err
 	}
+	if message := podReplacedMessage(containerCheckpoint.Spec.PodUID, srcPod); message != "" {
+		return ctrl.Result{}, r.updatePhase(ctx, containerCheckpoint, lpmv1.ContainerCheckpointPhaseFailed, message)
+	}
 
 	// Verify container exists in pod
 	containerExists := false
@@ -123,67 +156,110 @@ func (r *ContainerCheckpointReconciler) handleCheckpointingPhase(ctx context.Con
 		return ctrl.Result{}, r.Status().Update(ctx, containerCheckpoint)
 	}
 
-	// Perform the container checkpoint operation
-	artifactURI, err := r.performContainerCheckpoint(ctx, containerCheckpoint)
+	// Perform the container checkpoint operation, along with those of the
+	// other containers of its pod waiting for one
+	dumps, nodeName, err := r.performContainerCheckpoint(ctx, containerCheckpoint)
+	if errors.Is(err, errCheckpointBatched) {
+		return ctrl.Result{RequeueAfter: checkpointSlotPollInterval}, nil
+	}
+	if errors.Is(err, errNodeBusy) {
+		const queuedMessage = "waiting for a checkpoint slot on the node"
+		if containerCheckpoint.Status.Message != queuedMessage {
+			containerCheckpoint.Status.Message = queuedMessage
+			if err := r.Status().Update(ctx, containerCheckpoint); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{RequeueAfter: checkpointSlotPollInterval}, nil
+	}
 	if err != nil {
-		now := metav1.Now()
-		containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhaseFailed
-		containerCheckpoint.Status.Message = "checkpointing failed: " + err.Error()
-		containerCheckpoint.Status.Ready = false
-		containerCheckpoint.Status.CompletionTime = &now
-		return ctrl.Result{}, r.Status().Update(ctx, containerCheckpoint)
+		return ctrl.Result{}, r.failCheckpoint(ctx, containerCheckpoint, err)
+	}
+
+	// A result not recorded is taken again on retry; the agent returns the
+	// same checkpoint for the same request ID
+	for _, dump := range dumps {
+		if dump.err != nil {
+			err = r.failCheckpoint(ctx, dump.containerCheckpoint, dump.err)
+		} else {
+			err = r.bindContent(ctx, dump.containerCheckpoint, dump.response, nodeName, dump.criuOptions)
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
+	return ctrl.Result{}, nil
+}
+
+// failCheckpoint marks containerCheckpoint failed with err.
+func (r *ContainerCheckpointReconciler) failCheckpoint(ctx context.Context, containerCheckpoint *lpmv1.ContainerCheckpoint, err error) error {
+	now := metav1.Now()
+	containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhaseFailed
+	containerCheckpoint.Status.Message = "checkpointing failed: " + err.Error()
+	containerCheckpoint.Status.Ready = false
+	containerCheckpoint.Status.CompletionTime = &now
+	// A checkpoint cancelled by its deletion is gone
+	return client.IgnoreNotFound(r.Status().Update(ctx, containerCheckpoint))
+}
 
+// bindContent records the checkpoint taken of containerCheckpoint on
+// nodeName in a ContainerCheckpointContent and marks it complete.
+func (r *ContainerCheckpointReconciler) bindContent(ctx context.Context, containerCheckpoint *lpmv1.ContainerCheckpoint, checkpoint *pb.CheckpointResponse, nodeName string, criuOptions []string) error {
 	// Use deterministic naming for content object
 	contentName := containerCheckpoint.Name
 
-	// Try to get existing content object
-	containerCheckpointContent := &lpmv1.ContainerCheckpointContent{}
-	err = r.Get(ctx, client.ObjectKey{Name: contentName}, containerCheckpointContent)
-
 	// Create content object if it doesn't exist
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			containerCheckpointContent = &lpmv1.ContainerCheckpointContent{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: contentName,
-				},
-				Spec: lpmv1.ContainerCheckpointContentSpec{
-					ContainerCheckpointRef: corev1.ObjectReference{
-						Namespace: containerCheckpoint.Namespace,
-						Name:      containerCheckpoint.Name,
-					},
-					PodNamespace:  containerCheckpoint.Namespace,
-					PodName:       containerCheckpoint.Spec.PodName,
-					ContainerName: containerCheckpoint.Spec.ContainerName,
-					ArtifactURI:   artifactURI,
+	containerCheckpointContent := &lpmv1.ContainerCheckpointContent{}
+	err := r.Get(ctx, client.ObjectKey{Name: contentName}, containerCheckpointContent)
+	if apierrors.IsNotFound(err) {
+		containerCheckpointContent = &lpmv1.ContainerCheckpointContent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: contentName,
+			},
+			Spec: lpmv1.ContainerCheckpointContentSpec{
+				ContainerCheckpointRef: corev1.ObjectReference{
+					Namespace: containerCheckpoint.Namespace,
+					Name:      containerCheckpoint.Name,
 				},
-			}
-
-			if err := r.Create(ctx, containerCheckpointContent); err != nil {
-				return ctrl.Result{}, err
-			}
+				PodNamespace:       containerCheckpoint.Namespace,
+				PodName:            containerCheckpoint.Spec.PodName,
+				ContainerName:      containerCheckpoint.Spec.ContainerName,
+				NodeName:           nodeName,
+				ArtifactURI:        checkpoint.ArtifactUri,
+				ArtifactDigest:     checkpoint.ArtifactDigest,
+				ArtifactSizeBytes:  checkpoint.ArtifactSize,
+				Compression:        lpmv1.CheckpointCompression(checkpoint.Compression),
+				ArtifactTransfer:   containerCheckpoint.Spec.ArtifactTransfer,
+				VolumesArtifactURI: checkpoint.VolumesUri,
+				CriuOptions:        criuOptions,
+			},
+		}
+		if err := r.Create(ctx, containerCheckpointContent); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
 
-			// Bind content and mark checkpoint as ready immediately
-			now := metav1.Now()
-			containerCheckpoint.Status.BoundContentName = containerCheckpointContent.Name
-			containerCheckpoint.Status.Ready = true
-			containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhaseSucceeded
-			containerCheckpoint.Status.Message = "done"
-			containerCheckpoint.Status.CompletionTime = &now
-			return ctrl.Result{}, r.Status().Update(ctx, containerCheckpoint)
+	// Status is only written once the content exists
+	if len(checkpoint.Redactions) > 0 && len(containerCheckpointContent.Status.Redactions) == 0 {
+		for _, redaction := range checkpoint.Redactions {
+			containerCheckpointContent.Status.Redactions = append(containerCheckpointContent.Status.Redactions,
+				lpmv1.CheckpointRedaction{Name: redaction.Name, Detail: redaction.Detail})
+		}
+		if err := r.Status().Update(ctx, containerCheckpointContent); err != nil {
+			return err
 		}
-		return ctrl.Result{}, err
 	}
 
-	// Content already exists, mark checkpoint as complete
+	// Bind content and mark checkpoint as ready immediately
 	now := metav1.Now()
 	containerCheckpoint.Status.BoundContentName = containerCheckpointContent.Name
 	containerCheckpoint.Status.Ready = true
 	containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhaseSucceeded
 	containerCheckpoint.Status.Message = "done"
 	containerCheckpoint.Status.CompletionTime = &now
-	return ctrl.Result{}, r.Status().Update(ctx, containerCheckpoint)
+	return r.Status().Update(ctx, containerCheckpoint)
 }
 
 func (r *ContainerCheckpointReconciler) handleCompletedOrFailedPhase(ctx context.Context, checkpoint *lpmv1.ContainerCheckpoint) (ctrl.Result, error) {
@@ -197,7 +273,10 @@ func (r *ContainerCheckpointReconciler) updatePhase(ctx context.Context, contain
 	return r.Status().Update(ctx, containerCheckpoint)
 }
 
-func (r *ContainerCheckpointReconciler) performContainerCheckpoint(ctx context.Context, containerCheckpoint *lpmv1.ContainerCheckpoint) (string, error) {
+// performContainerCheckpoint checkpoints containerCheckpoint, and the other
+// ContainerCheckpoints of its batch, on the node of their pod. It returns
+// the outcome of each with the node.
+func (r *ContainerCheckpointReconciler) performContainerCheckpoint(ctx context.Context, containerCheckpoint *lpmv1.ContainerCheckpoint) ([]containerDump, string, error) {
 	// Get the pod to extract node name and UID
 	pod := &corev1.Pod{}
 	err := r.Get(ctx, client.ObjectKey{
@@ -205,28 +284,132 @@ func (r *ContainerCheckpointReconciler) performContainerCheckpoint(ctx context.C
 		Name:      containerCheckpoint.Spec.PodName,
 	}, pod)
 	if err != nil {
-		return "", fmt.Errorf("failed to get pod %s/%s: %w", containerCheckpoint.Namespace, containerCheckpoint.Spec.PodName, err)
+		return nil, "", fmt.Errorf("failed to get pod %s/%s: %w", containerCheckpoint.Namespace, containerCheckpoint.Spec.PodName, err)
+	}
+	// The pod may have been recreated while the checkpoint waited for a slot
+	if message := podReplacedMessage(containerCheckpoint.Spec.PodUID, pod); message != "" {
+		return nil, "", errors.New(message)
 	}
 
 	// Ensure pod is scheduled to a node
 	if pod.Spec.NodeName == "" {
-		return "", fmt.Errorf("pod %s/%s is not scheduled to any node", containerCheckpoint.Namespace, containerCheckpoint.Spec.PodName)
+		return nil, "", fmt.Errorf("pod %s/%s is not scheduled to any node", containerCheckpoint.Namespace, containerCheckpoint.Spec.PodName)
+	}
+
+	batch, err := r.checkpointBatch(ctx, containerCheckpoint, pod)
+	if err != nil {
+		return nil, "", err
+	}
+	key := client.ObjectKeyFromObject(containerCheckpoint)
+	keys := make([]types.NamespacedName, 0, len(batch))
+	for _, c := range batch {
+		keys = append(keys, client.ObjectKeyFromObject(c))
+	}
+	claimed := r.batches.claim(keys)
+	defer r.batches.release(claimed)
+	if !slices.Contains(claimed, key) {
+		return nil, "", errCheckpointBatched
+	}
+	batch = slices.DeleteFunc(batch, func(c *lpmv1.ContainerCheckpoint) bool {
+		return !slices.Contains(claimed, client.ObjectKeyFromObject(c))
+	})
+
+	// Don't overload the node's agent and kubelet with concurrent dumps
+	if !r.limiter.tryAcquire(pod.Spec.NodeName, key) {
+		return nil, "", errNodeBusy
+	}
+	defer r.limiter.release(pod.Spec.NodeName)
+	r.limiter.dequeue(pod.Spec.NodeName, claimed...)
+
+	// Deleting the checkpoint or failing its migration stops the dump
+	ctx, cancel := context.WithCancelCause(ctx)
+	r.inFlight.add(batch, cancel)
+	defer func() {
+		r.inFlight.remove(batch)
+		cancel(nil)
+	}()
+
+	dumps := make([]containerDump, len(batch))
+	containers := make([]agent.ContainerCheckpoint, len(batch))
+	for i, c := range batch {
+		var containerID string
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == c.Spec.ContainerName {
+				containerID = containerStatus.ContainerID
+			}
+		}
+		criuOptions := containerCriuOptions(pod, c.Spec.ContainerName)
+		// Unset for a ContainerCheckpoint of no PodCheckpoint
+		generation, _ := strconv.ParseInt(c.Labels[checkpointGenerationLabel], 10, 64)
+		dumps[i] = containerDump{containerCheckpoint: c, criuOptions: criuOptions}
+		containers[i] = agent.ContainerCheckpoint{
+			ContainerName: c.Spec.ContainerName,
+			Options: agent.CheckpointOptions{
+				MemoryVolumes:         memoryVolumes(pod, c.Spec.ContainerName),
+				ContainerID:           containerID,
+				TerminateExecSessions: c.Spec.TerminateExecSessions,
+				CriuOptions:           criuOptions,
+				// Retries of the same checkpoint get the one the agent took
+				RequestID:   string(c.UID),
+				Compression: string(c.Spec.Compression),
+				Generation:  generation,
+				KeepOnNode:  c.Spec.ArtifactTransfer == lpmv1.CheckpointTransferDirect,
+			},
+		}
+	}
+
+	// One call dumps the containers in parallel on the node
+	if len(batch) > 1 {
+		results, err := r.Agent.CheckpointPod(ctx, pod.Spec.NodeName, pod.Namespace, pod.Name, string(pod.UID), containers)
+		if status.Code(err) != codes.Unimplemented {
+			for i := range dumps {
+				if err != nil {
+					dumps[i].err = err
+				} else {
+					dumps[i].response, dumps[i].err = results[i].Response, results[i].Err
+				}
+			}
+			return cancelledDumps(ctx, dumps), pod.Spec.NodeName, nil
+		}
+		log.FromContext(ctx).Info("Agent does not support checkpointing pods, checkpointing containers one by one", "node", pod.Spec.NodeName)
 	}
 
 	// Call the agent to perform the container checkpoint operation
-	return r.Agent.CheckpointContainer(ctx,
-		pod.Spec.NodeName,
-		containerCheckpoint.Namespace,
-		containerCheckpoint.Spec.PodName,
-		containerCheckpoint.Spec.ContainerName,
-		string(pod.UID),
-	)
+	for i, container := range containers {
+		dumps[i].response, dumps[i].err = r.Agent.CheckpointContainer(ctx,
+			pod.Spec.NodeName,
+			pod.Namespace,
+			pod.Name,
+			container.ContainerName,
+			string(pod.UID),
+			container.Options,
+		)
+	}
+	return cancelledDumps(ctx, dumps), pod.Spec.NodeName, nil
+}
+
+// cancelledDumps replaces the errors of the dumps that failed because ctx
+// was cancelled with why it was.
+func cancelledDumps(ctx context.Context, dumps []containerDump) []containerDump {
+	if ctx.Err() == nil {
+		return dumps
+	}
+	for i := range dumps {
+		if dumps[i].err != nil {
+			dumps[i].err = fmt.Errorf("checkpoint cancelled: %w", context.Cause(ctx))
+		}
+	}
+	return dumps
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ContainerCheckpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.limiter = newNodeLimiter(r.MaxCheckpointsPerNode)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&lpmv1.ContainerCheckpoint{}).
+		Watches(&lpmv1.ContainerCheckpoint{}, r.containerCheckpointCancelHandler()).
+		Watches(&lpmv1.PodMigration{}, r.podMigrationCancelHandler()).
 		Named("containercheckpoint").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
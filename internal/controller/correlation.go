@@ -0,0 +1,36 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"my.domain/guestbook/internal/agent"
+)
+
+// withMigrationID returns ctx with its logger and its calls to the agents
+// tagged with the migration ID id, the UID of the PodMigration, so the logs
+// of one migration can be followed across the controller and the agents.
+func withMigrationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	ctx = log.IntoContext(ctx, log.FromContext(ctx).WithValues("migrationID", id))
+	return agent.WithMigrationID(ctx, id)
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// policyMigrationRetryInterval is how long after a triggered migration of a
+// pod failed the trigger migrates the pod again, if it still fires.
+const policyMigrationRetryInterval = 5 * time.Minute
+
+// MigrationPolicyReconciler runs the triggers of MigrationPolicies: when one
+// fires on a node, it creates a PodMigration for each pod on the node the
+// policy selects, at most spec.maxConcurrentMigrations running at a time,
+// until none is left.
+type MigrationPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=migrationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=nodemaintenances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;pods;namespaces,verbs=get;list;watch
+
+func (r *MigrationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var migrationPolicy lpmv1.MigrationPolicy
+	if err := r.Get(ctx, req.NamespacedName, &migrationPolicy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	now := time.Now()
+	status := lpmv1.MigrationPolicyStatus{LastTriggerTime: migrationPolicy.Status.LastTriggerTime}
+
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return ctrl.Result{}, err
+	}
+	maintained, err := r.maintainedNodes(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var requeueAfter time.Duration
+	fired := map[string]lpmv1.MigrationTriggerType{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		// A drain migrates the node's pods as its plan says
+		if maintained[node.Name] {
+			continue
+		}
+		trigger, wait := firedTrigger(migrationPolicy.Spec.Triggers, node, now)
+		if trigger != "" {
+			fired[node.Name] = trigger
+			status.TriggeredNodes = append(status.TriggeredNodes, node.Name)
+		} else if wait > 0 && (requeueAfter == 0 || wait < requeueAfter) {
+			requeueAfter = wait
+		}
+	}
+	slices.Sort(status.TriggeredNodes)
+
+	migrations, err := r.policyMigrations(ctx, &migrationPolicy)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.ActiveMigrations = int32(migrations.active)
+
+	namespaces := map[string]*corev1.Namespace{}
+	for _, nodeName := range status.TriggeredNodes {
+		pods, err := r.triggeredPods(ctx, &migrationPolicy, nodeName, namespaces)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for i := range pods {
+			pod := &pods[i]
+			key := client.ObjectKeyFromObject(pod)
+			if migrations.inFlight[key] {
+				continue
+			}
+			if retryIn := migrations.lastFailed[key].Add(policyMigrationRetryInterval).Sub(now); retryIn > 0 {
+				if requeueAfter == 0 || retryIn < requeueAfter {
+					requeueAfter = retryIn
+				}
+				continue
+			}
+			if migrations.active >= policyConcurrency(&migrationPolicy) {
+				// The running migrations report back as they end
+				status.PendingPods++
+				continue
+			}
+			if err := r.migratePod(ctx, &migrationPolicy, pod, fired[nodeName], migrations.created[key]); err != nil {
+				return ctrl.Result{}, err
+			}
+			migrations.active++
+			status.ActiveMigrations++
+			status.LastTriggerTime = &metav1.Time{Time: now}
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(status, migrationPolicy.Status) {
+		migrationPolicy.Status = status
+		if err := r.Status().Update(ctx, &migrationPolicy); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if status.PendingPods > 0 {
+		logger.Info("Pods waiting for a triggered migration", "pending", status.PendingPods, "active", status.ActiveMigrations)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// migratePod creates the PodMigration of pod for the trigger of the policy
+// that fired on its node. Its name counts the earlier ones, so that a
+// migration created again from a stale cache already exists. The policy does
+// not own it: deleting the policy must not garbage-collect a migration half
+// way through moving a pod, so MigrationPolicyLabel alone tracks it.
+func (r *MigrationPolicyReconciler) migratePod(ctx context.Context, migrationPolicy *lpmv1.MigrationPolicy, pod *corev1.Pod, trigger lpmv1.MigrationTriggerType, earlier int) error {
+	migration := &lpmv1.PodMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-policy-%s-%d", pod.Name, string(pod.UID)[:8], earlier),
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				lpmv1.MigrationPolicyLabel:  migrationPolicy.Name,
+				lpmv1.MigrationTriggerLabel: string(trigger),
+			},
+		},
+		// No target node: the policy's target constraints pick it
+		Spec: lpmv1.PodMigrationSpec{PodName: pod.Name},
+	}
+	if err := r.Create(ctx, migration); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create migration of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	log.FromContext(ctx).Info("Migration policy triggered", "trigger", trigger, "node", pod.Spec.NodeName,
+		"pod", client.ObjectKeyFromObject(pod), "podMigration", migration.Name)
+	return nil
+}
+
+// triggeredPods returns the pods on nodeName the policy migrates, sorted by
+// namespace and name: those it selects that a drain would not leave on the
+// node. namespaces caches the namespaces of the pods across nodes.
+func (r *MigrationPolicyReconciler) triggeredPods(ctx context.Context, migrationPolicy *lpmv1.MigrationPolicy, nodeName string, namespaces map[string]*corev1.Namespace) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeNameField: nodeName}); err != nil {
+		return nil, err
+	}
+	var triggered []corev1.Pod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		namespace := namespaces[pod.Namespace]
+		if namespace == nil {
+			namespace = &corev1.Namespace{}
+			if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, namespace); err != nil {
+				return nil, fmt.Errorf("failed to get namespace %s: %w", pod.Namespace, err)
+			}
+			namespaces[pod.Namespace] = namespace
+		}
+		selected, err := policy.Selects(migrationPolicy, namespace, pod)
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		reason, err := maintenanceSkipReason(ctx, r.Client, pod)
+		if err != nil {
+			return nil, err
+		}
+		if reason == "" {
+			triggered = append(triggered, *pod)
+		}
+	}
+	slices.SortFunc(triggered, func(a, b corev1.Pod) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return triggered, nil
+}
+
+// policyMigrationState is what the migrations of the cluster tell about the
+// pods a policy migrates.
+type policyMigrationState struct {
+	// active counts the policy's migrations that have not ended.
+	active int
+	// inFlight holds the pods any migration that has not ended moves.
+	inFlight map[client.ObjectKey]bool
+	// lastFailed is when the policy's last failed migration of each pod
+	// ended.
+	lastFailed map[client.ObjectKey]time.Time
+	// created counts the policy's migrations of each pod.
+	created map[client.ObjectKey]int
+}
+
+// policyMigrations sums up the migrations of the cluster for the policy.
+func (r *MigrationPolicyReconciler) policyMigrations(ctx context.Context, migrationPolicy *lpmv1.MigrationPolicy) (*policyMigrationState, error) {
+	var podMigrations lpmv1.PodMigrationList
+	if err := r.List(ctx, &podMigrations); err != nil {
+		return nil, err
+	}
+	state := &policyMigrationState{
+		inFlight:   map[client.ObjectKey]bool{},
+		lastFailed: map[client.ObjectKey]time.Time{},
+		created:    map[client.ObjectKey]int{},
+	}
+	for i := range podMigrations.Items {
+		podMigration := &podMigrations.Items[i]
+		if podMigration.Spec.Simulate {
+			continue
+		}
+		key := client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}
+		ended := podMigration.Status.Phase == lpmv1.MigrationPhaseSucceeded || podMigration.Status.Phase == lpmv1.MigrationPhaseFailed
+		if !ended {
+			state.inFlight[key] = true
+		}
+		if podMigration.Labels[lpmv1.MigrationPolicyLabel] != migrationPolicy.Name {
+			continue
+		}
+		state.created[key]++
+		if !ended {
+			state.active++
+		}
+		if podMigration.Status.Phase == lpmv1.MigrationPhaseFailed {
+			endTime := podMigration.CreationTimestamp.Time
+			if n := len(podMigration.Status.PhaseTransitions); n > 0 {
+				endTime = podMigration.Status.PhaseTransitions[n-1].Time.Time
+			}
+			if endTime.After(state.lastFailed[key]) {
+				state.lastFailed[key] = endTime
+			}
+		}
+	}
+	return state, nil
+}
+
+// maintainedNodes returns the nodes a NodeMaintenance that has not ended is
+// for.
+func (r *MigrationPolicyReconciler) maintainedNodes(ctx context.Context) (map[string]bool, error) {
+	var maintenances lpmv1.NodeMaintenanceList
+	if err := r.List(ctx, &maintenances); err != nil {
+		return nil, err
+	}
+	nodes := map[string]bool{}
+	for _, maintenance := range maintenances.Items {
+		switch maintenance.Status.Phase {
+		case lpmv1.NodeMaintenancePhaseSucceeded, lpmv1.NodeMaintenancePhaseFailed:
+		default:
+			nodes[maintenance.Spec.NodeName] = true
+		}
+	}
+	return nodes, nil
+}
+
+// policyConcurrency is the number of migrations the policy's triggers run
+// at the same time.
+func policyConcurrency(migrationPolicy *lpmv1.MigrationPolicy) int {
+	return max(int(migrationPolicy.Spec.MaxConcurrentMigrations), 1)
+}
+
+// firedTrigger returns the first of triggers that fires on node at now, or
+// "" and how long until the first pressure condition the node reports has
+// held for its trigger's For.
+func firedTrigger(triggers []lpmv1.MigrationTrigger, node *corev1.Node, now time.Time) (lpmv1.MigrationTriggerType, time.Duration) {
+	var wait time.Duration
+	for _, trigger := range triggers {
+		if trigger.Type == lpmv1.MigrationTriggerNodeCordoned {
+			if node.Spec.Unschedulable {
+				return trigger.Type, 0
+			}
+			continue
+		}
+		since, ok := nodePressureSince(node, corev1.NodeConditionType(trigger.Type))
+		if !ok {
+			continue
+		}
+		var holdFor time.Duration
+		if trigger.For != nil {
+			holdFor = trigger.For.Duration
+		}
+		remaining := since.Add(holdFor).Sub(now)
+		if remaining <= 0 {
+			return trigger.Type, 0
+		}
+		if wait == 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+	return "", wait
+}
+
+// nodePressureSince reports whether node reports the pressure condition
+// conditionType and since when.
+func nodePressureSince(node *corev1.Node, conditionType corev1.NodeConditionType) (time.Time, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.LastTransitionTime.Time, condition.Status == corev1.ConditionTrue
+		}
+	}
+	return time.Time{}, false
+}
+
+// nodeTriggerState is what of a node the triggers look at.
+func nodeTriggerState(node *corev1.Node) string {
+	state := []string{fmt.Sprint(node.Spec.Unschedulable)}
+	for _, trigger := range []lpmv1.MigrationTriggerType{
+		lpmv1.MigrationTriggerMemoryPressure, lpmv1.MigrationTriggerDiskPressure, lpmv1.MigrationTriggerPIDPressure,
+	} {
+		since, ok := nodePressureSince(node, corev1.NodeConditionType(trigger))
+		state = append(state, fmt.Sprintf("%t@%s", ok, since))
+	}
+	return strings.Join(state, ",")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MigrationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Nodes report their conditions every few seconds; only a change of one
+	// a trigger looks at matters
+	triggerChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return nodeTriggerState(e.ObjectOld.(*corev1.Node)) != nodeTriggerState(e.ObjectNew.(*corev1.Node))
+		},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&lpmv1.MigrationPolicy{}).
+		// Migrations report back to the policy labeled on them as their
+		// phase changes
+		Watches(&lpmv1.PodMigration{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				name := obj.GetLabels()[lpmv1.MigrationPolicyLabel]
+				if name == "" {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name}}}
+			})).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				var policies lpmv1.MigrationPolicyList
+				if err := mgr.GetClient().List(ctx, &policies); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to list migration policies")
+					return nil
+				}
+				var requests []reconcile.Request
+				for _, migrationPolicy := range policies.Items {
+					if len(migrationPolicy.Spec.Triggers) > 0 {
+						requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: migrationPolicy.Name}})
+					}
+				}
+				return requests
+			}), builder.WithPredicates(triggerChanged)).
+		Named("migrationpolicy").
+		Complete(r)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var _ = Describe("MigrationPolicy Controller", func() {
+	Context("When a node with selected pods is cordoned", func() {
+		ctx := context.Background()
+
+		policyName := types.NamespacedName{Name: "migrate-on-cordon"}
+		nodeName := types.NamespacedName{Name: "cordoned-node"}
+		podNames := []types.NamespacedName{
+			{Name: "policy-pod-a", Namespace: "default"},
+			{Name: "policy-pod-b", Namespace: "default"},
+		}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name},
+				Spec:       corev1.NodeSpec{Unschedulable: true},
+			})).To(Succeed())
+			for _, podName := range podNames {
+				Expect(k8sClient.Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName.Name,
+						Namespace: podName.Namespace,
+						Labels:    map[string]string{"app": "policy-test"},
+					},
+					Spec: corev1.PodSpec{
+						NodeName:   nodeName.Name,
+						Containers: []corev1.Container{{Name: "app", Image: "busybox"}},
+					},
+				})).To(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, &lpmv1.MigrationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: policyName.Name},
+				Spec: lpmv1.MigrationPolicySpec{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "policy-test"}},
+					Triggers:    []lpmv1.MigrationTrigger{{Type: lpmv1.MigrationTriggerNodeCordoned}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			var podMigrations lpmv1.PodMigrationList
+			Expect(k8sClient.List(ctx, &podMigrations, client.MatchingLabels{lpmv1.MigrationPolicyLabel: policyName.Name})).To(Succeed())
+			for i := range podMigrations.Items {
+				Expect(k8sClient.Delete(ctx, &podMigrations.Items[i])).To(Succeed())
+			}
+			Expect(k8sClient.Delete(ctx, &lpmv1.MigrationPolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName.Name}})).To(Succeed())
+			for _, podName := range podNames {
+				pod := &corev1.Pod{}
+				Expect(k8sClient.Get(ctx, podName, pod)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0))).To(Succeed())
+			}
+			Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name}})).To(Succeed())
+		})
+
+		It("should migrate one pod at a time", func() {
+			controllerReconciler := &MigrationPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: policyName})
+			Expect(err).NotTo(HaveOccurred())
+
+			var podMigrations lpmv1.PodMigrationList
+			Expect(k8sClient.List(ctx, &podMigrations, client.MatchingLabels{lpmv1.MigrationPolicyLabel: policyName.Name})).To(Succeed())
+			Expect(podMigrations.Items).To(HaveLen(1))
+			Expect(podMigrations.Items[0].Spec.PodName).To(Equal(podNames[0].Name))
+			Expect(podMigrations.Items[0].Spec.TargetNode).To(BeEmpty())
+			Expect(podMigrations.Items[0].Labels).To(HaveKeyWithValue(lpmv1.MigrationTriggerLabel, string(lpmv1.MigrationTriggerNodeCordoned)))
+			// Deleting the policy leaves its migrations running
+			Expect(podMigrations.Items[0].OwnerReferences).To(BeEmpty())
+
+			var migrationPolicy lpmv1.MigrationPolicy
+			Expect(k8sClient.Get(ctx, policyName, &migrationPolicy)).To(Succeed())
+			Expect(migrationPolicy.Status.TriggeredNodes).To(Equal([]string{nodeName.Name}))
+			Expect(migrationPolicy.Status.ActiveMigrations).To(Equal(int32(1)))
+			Expect(migrationPolicy.Status.PendingPods).To(Equal(int32(1)))
+		})
+	})
+})
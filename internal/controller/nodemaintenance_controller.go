@@ -0,0 +1,506 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// evictionRetryInterval is how often the eviction of a pod a
+// PodDisruptionBudget blocks is retried.
+const evictionRetryInterval = 10 * time.Second
+
+// maintenancePhaseEvicted is the phase of a pod evicted by a drain once it
+// is gone.
+const maintenancePhaseEvicted = "Evicted"
+
+// NodeMaintenanceReconciler drains nodes in two steps: it simulates the
+// migration of each pod on the node and records the plan, then, once the
+// plan is approved, cordons the node, migrates the pods the plan migrates
+// and evicts those it evicts.
+type NodeMaintenanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=nodemaintenances,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=nodemaintenances/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+
+func (r *NodeMaintenanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var maintenance lpmv1.NodeMaintenance
+	if err := r.Get(ctx, req.NamespacedName, &maintenance); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch maintenance.Status.Phase {
+	case "", lpmv1.NodeMaintenancePhasePlanning:
+		return r.plan(ctx, &maintenance)
+	case lpmv1.NodeMaintenancePhaseAwaitingApproval:
+		return ctrl.Result{}, r.awaitApproval(ctx, &maintenance)
+	case lpmv1.NodeMaintenancePhaseDraining:
+		return r.drain(ctx, &maintenance)
+	}
+	return ctrl.Result{}, nil
+}
+
+// plan simulates the migration of each pod on the node the drain does not
+// skip, and once every simulation has ended records what to do with each
+// pod: migrate it to the target its simulation picked, or evict it if the
+// simulation failed.
+func (r *NodeMaintenanceReconciler) plan(ctx context.Context, maintenance *lpmv1.NodeMaintenance) (ctrl.Result, error) {
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: maintenance.Spec.NodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseFailed, "node not found")
+		}
+		return ctrl.Result{}, err
+	}
+	pods, err := r.maintenancePods(ctx, maintenance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	simulations, err := r.maintenanceMigrations(ctx, maintenance, true)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var plans []lpmv1.MaintenancePodPlan
+	pending := 0
+	for i := range pods {
+		pod := &pods[i]
+		plan := lpmv1.MaintenancePodPlan{Namespace: pod.Namespace, Pod: pod.Name}
+		reason, err := maintenanceSkipReason(ctx, r.Client, pod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if reason != "" {
+			plan.Action = lpmv1.MaintenanceActionSkip
+			plan.Reason = reason
+			plans = append(plans, plan)
+			continue
+		}
+
+		simulation := simulations[client.ObjectKeyFromObject(pod)]
+		if simulation == nil {
+			simulation = &lpmv1.PodMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: pod.Name + "-plan-",
+					Namespace:    pod.Namespace,
+					Labels:       map[string]string{lpmv1.NodeMaintenanceLabel: maintenance.Name},
+				},
+				Spec: lpmv1.PodMigrationSpec{PodName: pod.Name, TargetNode: maintenance.Spec.TargetNode, Simulate: true},
+			}
+			if err := controllerutil.SetControllerReference(maintenance, simulation, r.Scheme); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.Create(ctx, simulation); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to create simulation of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+		plan.Simulation = simulation.Name
+
+		switch simulation.Status.Phase {
+		case lpmv1.MigrationPhaseSucceeded:
+			plan.Action = lpmv1.MaintenanceActionMigrate
+			plan.TargetNode = simulation.Spec.TargetNode
+			if prediction := simulation.Status.Simulation; prediction != nil {
+				plan.PredictedDuration = &metav1.Duration{Duration: prediction.PredictedDuration.Duration}
+				plan.PredictedDowntime = &metav1.Duration{Duration: prediction.PredictedDowntime.Duration}
+			}
+		case lpmv1.MigrationPhaseFailed:
+			plan.Action = lpmv1.MaintenanceActionEvict
+			plan.Reason = "cannot be migrated: " + simulation.Status.Message
+		default:
+			pending++
+		}
+		plans = append(plans, plan)
+	}
+	maintenance.Status.Pods = plans
+
+	if pending > 0 {
+		maintenance.Status.Phase = lpmv1.NodeMaintenancePhasePlanning
+		maintenance.Status.Message = fmt.Sprintf("simulating the migration of %d pods", pending)
+		// The simulations report back as they end
+		return ctrl.Result{}, r.Status().Update(ctx, maintenance)
+	}
+
+	maintenance.Status.MigratePods, maintenance.Status.EvictPods, maintenance.Status.SkipPods = 0, 0, 0
+	var durations []time.Duration
+	for _, plan := range plans {
+		switch plan.Action {
+		case lpmv1.MaintenanceActionMigrate:
+			maintenance.Status.MigratePods++
+			if plan.PredictedDuration != nil {
+				durations = append(durations, plan.PredictedDuration.Duration)
+			}
+		case lpmv1.MaintenanceActionEvict:
+			maintenance.Status.EvictPods++
+		case lpmv1.MaintenanceActionSkip:
+			maintenance.Status.SkipPods++
+		}
+	}
+	maintenance.Status.PredictedDuration = &metav1.Duration{Duration: drainDuration(durations, maintenanceParallel(maintenance))}
+	maintenance.Status.Phase = lpmv1.NodeMaintenancePhaseAwaitingApproval
+	maintenance.Status.Message = fmt.Sprintf("plan ready: %d pods to migrate in about %s, %d to evict, %d skipped",
+		maintenance.Status.MigratePods, maintenance.Status.PredictedDuration.Duration,
+		maintenance.Status.EvictPods, maintenance.Status.SkipPods)
+	meta.SetStatusCondition(&maintenance.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionApproved,
+		Status:  metav1.ConditionUnknown,
+		Reason:  lpmv1.MigrationReasonAwaitingApproval,
+		Message: "set to True to drain the node as planned, or to False to reject the plan",
+	})
+	log.FromContext(ctx).Info("Planned node maintenance", "node", maintenance.Spec.NodeName,
+		"migrate", maintenance.Status.MigratePods, "evict", maintenance.Status.EvictPods, "skip", maintenance.Status.SkipPods)
+	return ctrl.Result{}, r.Status().Update(ctx, maintenance)
+}
+
+// awaitApproval starts the drain once the plan's Approved condition is
+// True, and fails the maintenance if it is False.
+func (r *NodeMaintenanceReconciler) awaitApproval(ctx context.Context, maintenance *lpmv1.NodeMaintenance) error {
+	approved := meta.FindStatusCondition(maintenance.Status.Conditions, lpmv1.MigrationConditionApproved)
+	if approved == nil {
+		return nil
+	}
+	switch approved.Status {
+	case metav1.ConditionFalse:
+		message := "plan was not approved"
+		if approved.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, approved.Message)
+		}
+		return r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseFailed, message)
+	case metav1.ConditionTrue:
+		var node corev1.Node
+		if err := r.Get(ctx, client.ObjectKey{Name: maintenance.Spec.NodeName}, &node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseFailed, "node not found")
+			}
+			return err
+		}
+		log.FromContext(ctx).Info("Node maintenance approved, draining", "node", node.Name)
+		now := metav1.Now()
+		maintenance.Status.StartTime = &now
+		maintenance.Status.WasCordoned = node.Spec.Unschedulable
+		maintenance.Status.Phase = lpmv1.NodeMaintenancePhaseDraining
+		maintenance.Status.Message = "draining the node"
+		return r.Status().Update(ctx, maintenance)
+	}
+	return nil
+}
+
+// drain cordons the node and carries out the plan: it creates the planned
+// migrations, at most spec.parallel running at a time, and once they have
+// all succeeded evicts the pods that cannot be migrated. Evictions wait
+// for the migrations so that the evicted pods' replacements do not take
+// the room the plan counted on. When a migration fails, nothing is evicted
+// and the node is uncordoned again unless it was cordoned before.
+func (r *NodeMaintenanceReconciler) drain(ctx context.Context, maintenance *lpmv1.NodeMaintenance) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: maintenance.Spec.NodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseFailed, "node not found")
+		}
+		return ctrl.Result{}, err
+	}
+	if !node.Spec.Unschedulable {
+		if err := setNodeUnschedulable(ctx, r.Client, &node, true); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+		}
+		logger.Info("Cordoned node", "node", node.Name)
+	}
+
+	migrations, err := r.maintenanceMigrations(ctx, maintenance, false)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	running, failed, migrated := 0, 0, 0
+	for i := range maintenance.Status.Pods {
+		plan := &maintenance.Status.Pods[i]
+		if plan.Action != lpmv1.MaintenanceActionMigrate {
+			continue
+		}
+		migration := migrations[client.ObjectKey{Namespace: plan.Namespace, Name: plan.Pod}]
+		if migration == nil {
+			continue
+		}
+		plan.Migration = migration.Name
+		plan.Phase = string(migration.Status.Phase)
+		switch migration.Status.Phase {
+		case lpmv1.MigrationPhaseSucceeded:
+			migrated++
+		case lpmv1.MigrationPhaseFailed:
+			plan.Message = migration.Status.Message
+			failed++
+		default:
+			running++
+		}
+	}
+
+	for i := range maintenance.Status.Pods {
+		plan := &maintenance.Status.Pods[i]
+		if running >= maintenanceParallel(maintenance) {
+			break
+		}
+		if plan.Action != lpmv1.MaintenanceActionMigrate || plan.Migration != "" {
+			continue
+		}
+		migration := &lpmv1.PodMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: plan.Pod + "-drain-",
+				Namespace:    plan.Namespace,
+				Labels: map[string]string{
+					lpmv1.NodeMaintenanceLabel: maintenance.Name,
+					lpmv1.DrainNodeLabel:       maintenance.Spec.NodeName,
+				},
+			},
+			Spec: lpmv1.PodMigrationSpec{PodName: plan.Pod, TargetNode: plan.TargetNode},
+		}
+		// Not owned by the maintenance, unlike its simulations: deleting the
+		// maintenance must not garbage-collect a migration half way through
+		// moving a pod
+		if err := r.Create(ctx, migration); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create migration of pod %s/%s: %w", plan.Namespace, plan.Pod, err)
+		}
+		plan.Migration = migration.Name
+		plan.Phase = string(lpmv1.MigrationPhasePending)
+		running++
+	}
+
+	if running > 0 || migrated+failed < int(maintenance.Status.MigratePods) {
+		maintenance.Status.Message = fmt.Sprintf("%d of %d migrations ended", migrated+failed, maintenance.Status.MigratePods)
+		return ctrl.Result{}, r.Status().Update(ctx, maintenance)
+	}
+	if failed > 0 {
+		if !maintenance.Status.WasCordoned {
+			if err := setNodeUnschedulable(ctx, r.Client, &node, false); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to uncordon node %s: %w", node.Name, err)
+			}
+			logger.Info("Uncordoned node", "node", node.Name)
+		}
+		return ctrl.Result{}, r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseFailed,
+			fmt.Sprintf("%d of %d pods were not migrated", failed, maintenance.Status.MigratePods))
+	}
+
+	blocked := 0
+	for i := range maintenance.Status.Pods {
+		plan := &maintenance.Status.Pods[i]
+		if plan.Action != lpmv1.MaintenanceActionEvict || plan.Phase == maintenancePhaseEvicted {
+			continue
+		}
+		var pod corev1.Pod
+		err := r.Get(ctx, client.ObjectKey{Namespace: plan.Namespace, Name: plan.Pod}, &pod)
+		if apierrors.IsNotFound(err) || err == nil && pod.Spec.NodeName != node.Name {
+			plan.Phase = maintenancePhaseEvicted
+			plan.Message = ""
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if pod.DeletionTimestamp != nil {
+			blocked++
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		err = client.IgnoreNotFound(r.SubResource("eviction").Create(ctx, &pod, eviction))
+		switch {
+		case apierrors.IsTooManyRequests(err):
+			plan.Message = "eviction blocked by a PodDisruptionBudget, retrying"
+		case err != nil:
+			return ctrl.Result{}, fmt.Errorf("failed to evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		default:
+			logger.Info("Evicted pod", "pod", client.ObjectKeyFromObject(&pod))
+			plan.Message = ""
+		}
+		blocked++
+	}
+	if blocked > 0 {
+		maintenance.Status.Message = fmt.Sprintf("%d pods migrated, waiting for %d evicted pods to go", migrated, blocked)
+		if err := r.Status().Update(ctx, maintenance); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: evictionRetryInterval}, nil
+	}
+	return ctrl.Result{}, r.finish(ctx, maintenance, lpmv1.NodeMaintenancePhaseSucceeded,
+		fmt.Sprintf("node drained: %d pods migrated, %d evicted", migrated, maintenance.Status.EvictPods))
+}
+
+// finish ends the maintenance in phase with message.
+func (r *NodeMaintenanceReconciler) finish(ctx context.Context, maintenance *lpmv1.NodeMaintenance, phase lpmv1.NodeMaintenancePhase, message string) error {
+	log.FromContext(ctx).Info("Node maintenance ended", "node", maintenance.Spec.NodeName, "phase", phase, "message", message)
+	now := metav1.Now()
+	maintenance.Status.CompletionTime = &now
+	maintenance.Status.Phase = phase
+	maintenance.Status.Message = message
+	return r.Status().Update(ctx, maintenance)
+}
+
+// maintenancePods returns the pods on the maintenance's node its selector
+// matches, sorted by namespace and name.
+func (r *NodeMaintenanceReconciler) maintenancePods(ctx context.Context, maintenance *lpmv1.NodeMaintenance) ([]corev1.Pod, error) {
+	selector := labels.Everything()
+	if maintenance.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(maintenance.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{podNodeNameField: maintenance.Spec.NodeName},
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return pods.Items, nil
+}
+
+// maintenanceMigrations returns the simulations or the migrations the
+// maintenance created, by the pod they move. The maintenance owns its
+// simulations; its migrations are those labeled with its name that its plan
+// records, or that are not older than it, unlike those left over from a
+// deleted maintenance of the same name.
+func (r *NodeMaintenanceReconciler) maintenanceMigrations(ctx context.Context, maintenance *lpmv1.NodeMaintenance, simulations bool) (map[client.ObjectKey]*lpmv1.PodMigration, error) {
+	var podMigrations lpmv1.PodMigrationList
+	if err := r.List(ctx, &podMigrations, client.MatchingLabels{lpmv1.NodeMaintenanceLabel: maintenance.Name}); err != nil {
+		return nil, err
+	}
+	planned := map[client.ObjectKey]bool{}
+	for _, plan := range maintenance.Status.Pods {
+		if plan.Migration != "" {
+			planned[client.ObjectKey{Namespace: plan.Namespace, Name: plan.Migration}] = true
+		}
+	}
+	migrations := map[client.ObjectKey]*lpmv1.PodMigration{}
+	for i := range podMigrations.Items {
+		podMigration := &podMigrations.Items[i]
+		if podMigration.Spec.Simulate != simulations {
+			continue
+		}
+		if simulations && !metav1.IsControlledBy(podMigration, maintenance) {
+			continue
+		}
+		if !simulations && !planned[client.ObjectKeyFromObject(podMigration)] &&
+			podMigration.CreationTimestamp.Before(&maintenance.CreationTimestamp) {
+			continue
+		}
+		migrations[client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}] = podMigration
+	}
+	return migrations, nil
+}
+
+// maintenanceSkipReason returns why a drain leaves pod on the node, or ""
+// if it migrates or evicts it: like kubectl drain, it leaves finished pods,
+// DaemonSet pods and static pods, and system critical pods no policy lets
+// it migrate.
+func maintenanceSkipReason(ctx context.Context, c client.Client, pod *corev1.Pod) (string, error) {
+	owner := metav1.GetControllerOf(pod)
+	switch {
+	case pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+		return "finished", nil
+	case pod.DeletionTimestamp != nil:
+		return "being deleted", nil
+	case owner != nil && owner.Kind == "DaemonSet":
+		return "DaemonSet pod", nil
+	case owner != nil && owner.Kind == "Node":
+		return "static pod", nil
+	}
+	if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+		return "static pod", nil
+	}
+	if !policy.IsCritical(pod) {
+		return "", nil
+	}
+	policies, err := policy.Matching(ctx, c, pod)
+	if err != nil {
+		return "", err
+	}
+	return policy.CriticalPodDenied(policies, pod), nil
+}
+
+// maintenanceParallel is the number of migrations the maintenance runs at
+// the same time.
+func maintenanceParallel(maintenance *lpmv1.NodeMaintenance) int {
+	return max(int(maintenance.Spec.Parallel), 1)
+}
+
+// drainDuration predicts how long migrations of durations take when they
+// are started in order, at most parallel at a time.
+func drainDuration(durations []time.Duration, parallel int) time.Duration {
+	slots := make([]time.Duration, parallel)
+	for _, duration := range durations {
+		// The next migration starts when the first running one ends
+		slots[0] += duration
+		slices.Sort(slots)
+	}
+	return slots[parallel-1]
+}
+
+// setNodeUnschedulable cordons or uncordons node.
+func setNodeUnschedulable(ctx context.Context, c client.Client, node *corev1.Node, unschedulable bool) error {
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+	return c.Patch(ctx, node, patch)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeMaintenanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&lpmv1.NodeMaintenance{}).
+		// Simulations and migrations report back to the maintenance labeled
+		// on them as their phase changes
+		Watches(&lpmv1.PodMigration{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				name := obj.GetLabels()[lpmv1.NodeMaintenanceLabel]
+				if name == "" {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: name}}}
+			})).
+		Named("nodemaintenance").
+		Complete(r)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+)
+
+const (
+	// nodeStatusRefreshInterval is how often a node's summary is refreshed
+	// for the changes no watch reports: checkpoints and agent health.
+	nodeStatusRefreshInterval = 30 * time.Second
+
+	// agentHealthTimeout bounds the health check of a node's agent.
+	agentHealthTimeout = 5 * time.Second
+)
+
+// NodeMigrationStatusReconciler maintains a NodeMigrationStatus per node
+// summarizing the migrations, restores and checkpoints involving it, its
+// node-local checkpoint archives and its agent's health.
+type NodeMigrationStatusReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	AgentClient *agent.Client
+}
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=nodemigrationstatuses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=nodemigrationstatuses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations;podrestores;containercheckpoints;containercheckpointcontents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes;pods,verbs=get;list;watch
+
+func (r *NodeMigrationStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var node corev1.Node
+	if err := r.Get(ctx, req.NamespacedName, &node); err != nil {
+		// The NodeMigrationStatus of a deleted node is garbage collected
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status, err := r.summarize(ctx, node.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var nodeStatus lpmv1.NodeMigrationStatus
+	err = r.Get(ctx, client.ObjectKey{Name: node.Name}, &nodeStatus)
+	if apierrors.IsNotFound(err) {
+		nodeStatus = lpmv1.NodeMigrationStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: node.Name,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(&node, corev1.SchemeGroupVersion.WithKind("Node")),
+				},
+			},
+		}
+		if err := r.Create(ctx, &nodeStatus); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	nodeStatus.Status = *status
+	if err := r.Status().Update(ctx, &nodeStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: nodeStatusRefreshInterval}, nil
+}
+
+// summarize gathers the status of nodeName.
+func (r *NodeMigrationStatusReconciler) summarize(ctx context.Context, nodeName string) (*lpmv1.NodeMigrationStatusStatus, error) {
+	status := &lpmv1.NodeMigrationStatusStatus{LastUpdateTime: metav1.Now()}
+	podNodes := map[client.ObjectKey]string{}
+	podNode := func(namespace, name string) (string, error) {
+		key := client.ObjectKey{Namespace: namespace, Name: name}
+		if node, ok := podNodes[key]; ok {
+			return node, nil
+		}
+		var pod corev1.Pod
+		if err := r.Get(ctx, key, &pod); client.IgnoreNotFound(err) != nil {
+			return "", err
+		}
+		podNodes[key] = pod.Spec.NodeName
+		return pod.Spec.NodeName, nil
+	}
+
+	var podMigrations lpmv1.PodMigrationList
+	if err := r.List(ctx, &podMigrations); err != nil {
+		return nil, err
+	}
+	for _, podMigration := range podMigrations.Items {
+		phase := podMigration.Status.Phase
+		if podMigration.Spec.Simulate || phase == lpmv1.MigrationPhaseSucceeded || phase == lpmv1.MigrationPhaseFailed {
+			continue
+		}
+		sourceNode := podMigration.Status.SourceNode
+		if sourceNode == "" {
+			var err error
+			if sourceNode, err = podNode(podMigration.Namespace, podMigration.Spec.PodName); err != nil {
+				return nil, err
+			}
+		}
+		var direction lpmv1.MigrationDirection
+		switch nodeName {
+		case sourceNode:
+			direction = lpmv1.MigrationDirectionOutgoing
+		case podMigration.Spec.TargetNode:
+			direction = lpmv1.MigrationDirectionIncoming
+		default:
+			continue
+		}
+		status.Migrations = append(status.Migrations, lpmv1.NodeMigration{
+			Namespace: podMigration.Namespace,
+			Name:      podMigration.Name,
+			Pod:       podMigration.Spec.PodName,
+			Direction: direction,
+			Phase:     phase,
+		})
+	}
+	sort.Slice(status.Migrations, func(i, j int) bool {
+		a, b := status.Migrations[i], status.Migrations[j]
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+	status.InFlightMigrations = int32(len(status.Migrations))
+
+	var podRestores lpmv1.PodRestoreList
+	if err := r.List(ctx, &podRestores); err != nil {
+		return nil, err
+	}
+	for _, podRestore := range podRestores.Items {
+		phase := podRestore.Status.Phase
+		if podRestore.Spec.NodeName == nodeName && phase != lpmv1.RestorePhaseSucceeded && phase != lpmv1.RestorePhaseFailed {
+			status.Restores = append(status.Restores, podRestore.Namespace+"/"+podRestore.Name)
+		}
+	}
+	sort.Strings(status.Restores)
+
+	var checkpoints lpmv1.ContainerCheckpointList
+	if err := r.List(ctx, &checkpoints); err != nil {
+		return nil, err
+	}
+	for _, checkpoint := range checkpoints.Items {
+		phase := checkpoint.Status.Phase
+		if phase == lpmv1.ContainerCheckpointPhaseSucceeded || phase == lpmv1.ContainerCheckpointPhaseFailed {
+			continue
+		}
+		node, err := podNode(checkpoint.Namespace, checkpoint.Spec.PodName)
+		if err != nil {
+			return nil, err
+		}
+		if node != nodeName {
+			continue
+		}
+		if phase == lpmv1.ContainerCheckpointPhaseRunning {
+			status.RunningCheckpoints++
+		} else {
+			status.QueuedCheckpoints++
+		}
+	}
+
+	var contents lpmv1.ContainerCheckpointContentList
+	if err := r.List(ctx, &contents); err != nil {
+		return nil, err
+	}
+	for _, content := range contents.Items {
+		if content.Spec.NodeName == nodeName && strings.HasPrefix(content.Spec.ArtifactURI, "file://") {
+			status.LocalCheckpoints++
+			status.LocalCheckpointBytes += content.Spec.ArtifactSizeBytes
+		}
+	}
+
+	healthCtx, cancel := context.WithTimeout(ctx, agentHealthTimeout)
+	defer cancel()
+	message, err := r.AgentClient.Health(healthCtx, nodeName)
+	if err != nil {
+		status.AgentMessage = err.Error()
+	} else {
+		status.AgentHealthy, status.AgentMessage = true, message
+	}
+
+	if n := status.InFlightMigrations; n > 0 {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d migrations in flight", n))
+	}
+	if n := len(status.Restores); n > 0 {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d restores in flight", n))
+	}
+	if n := status.QueuedCheckpoints + status.RunningCheckpoints; n > 0 {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("%d checkpoints of its pods queued or running", n))
+	}
+	status.SafeToReboot = len(status.Reasons) == 0
+	return status, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeMigrationStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		// Migrations and restores change what is in flight on their nodes
+		Watches(&lpmv1.PodMigration{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				podMigration := obj.(*lpmv1.PodMigration)
+				var requests []reconcile.Request
+				for _, node := range []string{podMigration.Status.SourceNode, podMigration.Spec.TargetNode} {
+					if node != "" {
+						requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: node}})
+					}
+				}
+				return requests
+			})).
+		Watches(&lpmv1.PodRestore{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: obj.(*lpmv1.PodRestore).Spec.NodeName}}}
+			})).
+		Named("nodemigrationstatus").
+		Complete(r)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+)
+
+var _ = Describe("NodeMigrationStatus Controller", func() {
+	Context("When reconciling an idle node", func() {
+		ctx := context.Background()
+
+		nodeName := types.NamespacedName{Name: "idle-node"}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name}})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName.Name}})).To(Succeed())
+			nodeStatus := &lpmv1.NodeMigrationStatus{}
+			if err := k8sClient.Get(ctx, nodeName, nodeStatus); err == nil {
+				Expect(k8sClient.Delete(ctx, nodeStatus)).To(Succeed())
+			}
+		})
+
+		It("should report it safe to reboot", func() {
+			controllerReconciler := &NodeMigrationStatusReconciler{
+				Client:      k8sClient,
+				Scheme:      k8sClient.Scheme(),
+				AgentClient: agent.NewClient(k8sClient),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: nodeName})
+			Expect(err).NotTo(HaveOccurred())
+
+			nodeStatus := &lpmv1.NodeMigrationStatus{}
+			Expect(k8sClient.Get(ctx, nodeName, nodeStatus)).To(Succeed())
+			Expect(nodeStatus.Status.SafeToReboot).To(BeTrue())
+			Expect(nodeStatus.Status.InFlightMigrations).To(BeZero())
+			// The node has no address to reach an agent on
+			Expect(nodeStatus.Status.AgentHealthy).To(BeFalse())
+		})
+	})
+})
@@ -18,10 +18,14 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,12 +33,40 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+	"my.domain/guestbook/internal/metrics"
+	"my.domain/guestbook/internal/podexec"
+	"my.domain/guestbook/internal/policy"
+)
+
+const (
+	// defaultHookTimeout bounds a quiesce or resume hook that sets no timeout.
+	defaultHookTimeout = 30 * time.Second
+
+	// defaultCheckpointHistoryLimit is the number of completed generations a
+	// PodCheckpoint retains when spec.historyLimit is unset.
+	defaultCheckpointHistoryLimit = 5
+
+	// checkpointGenerationLabel marks the generation a ContainerCheckpoint
+	// belongs to.
+	checkpointGenerationLabel = "podcheckpoint-generation"
+
+	// podFreezeTimeout bounds how long a Pod scope checkpoint keeps the pod
+	// frozen; the agent thaws it after that even if the controller doesn't.
+	podFreezeTimeout = 10 * time.Minute
 )
 
 // PodCheckpointReconciler reconciles a PodCheckpoint object
 type PodCheckpointReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Exec runs quiesce and resume hooks in the source pod. Required for
+	// Application-consistent checkpoints.
+	Exec podexec.Executor
+
+	// AgentClient freezes and thaws the pod of Pod scope checkpoints.
+	AgentClient *agent.Client
 }
 
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpoints,verbs=get;list;watch;create;update;patch;delete
@@ -44,19 +76,30 @@ type PodCheckpointReconciler struct {
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpointcontents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpoints/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
 func (r *PodCheckpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
 	var podCheckpoint lpmv1.PodCheckpoint
 	if err := r.Get(ctx, req.NamespacedName, &podCheckpoint); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.CheckpointTimestamp.DeleteLabelValues(req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx = withMigrationID(ctx, podCheckpoint.Labels[lpmv1.MigrationIDLabel])
+	logger := log.FromContext(ctx)
 
 	if podCheckpoint.Status.Phase == "" {
 		podCheckpoint.Status.Phase = lpmv1.PodCheckpointPhasePending
 	}
+	if podCheckpoint.Status.Generation == 0 {
+		podCheckpoint.Status.Generation = max(podCheckpoint.Spec.DesiredGeneration, 1)
+	}
 
 	switch podCheckpoint.Status.Phase {
 	case lpmv1.PodCheckpointPhasePending:
@@ -85,15 +128,70 @@ func (r *PodCheckpointReconciler) handlePendingPhase(ctx context.Context, podChe
 		return ctrl.Result{}, err
 	}
 
+	// A pod recreated under the name is another instance
+	if message := podReplacedMessage(podCheckpoint.Status.PodUID, &srcPod); message != "" {
+		return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, message)
+	}
+	podCheckpoint.Status.PodUID = srcPod.UID
+
 	// 2. Ensure Pod is running
 	if srcPod.Status.Phase != corev1.PodRunning {
 		return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, "source pod not running")
 	}
 
+	// Per-container dumps of a shared PID namespace are inconsistent
+	if sharesProcessNamespace(&srcPod) {
+		return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, sharedProcessNamespaceMessage)
+	}
+
+	// Freezing a cluster add-on can stall the cluster itself
+	if policy.IsCritical(&srcPod) {
+		policies, err := policy.Matching(ctx, r.Client, &srcPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if denied := policy.CriticalPodDenied(policies, &srcPod); denied != "" {
+			return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, denied)
+		}
+	}
+
+	// Quiesce the application before any container is dumped. Skipped when
+	// re-invoked from the Running phase: the hooks have already run.
+	stateChanged := false
+	if podCheckpoint.Spec.Consistency == lpmv1.CheckpointConsistencyApplication && podCheckpoint.Status.Phase == lpmv1.PodCheckpointPhasePending && !podCheckpoint.Status.Quiesced {
+		if err := r.quiesce(ctx, podCheckpoint, &srcPod); err != nil {
+			return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, err.Error())
+		}
+		podCheckpoint.Status.Quiesced = true
+		stateChanged = true
+	}
+
+	// Freeze every container at once; each is dumped frozen
+	if podCheckpoint.Spec.Scope == lpmv1.CheckpointScopePod && !podCheckpoint.Status.Frozen {
+		if err := r.setPodFrozen(ctx, podCheckpoint, true); err != nil {
+			if podCheckpoint.Status.Quiesced {
+				r.resume(ctx, podCheckpoint)
+				podCheckpoint.Status.Quiesced = false
+			}
+			return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, "failed to freeze pod: "+err.Error())
+		}
+		podCheckpoint.Status.Frozen = true
+		stateChanged = true
+	}
+
+	// Record the quiesce and freeze before creating children: if a later
+	// step errors, the next reconcile must still know to resume and thaw.
+	if stateChanged {
+		if err := r.Status().Update(ctx, podCheckpoint); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// 3. Iterate containers and ensure ContainerCheckpoint objects
+	generation := podCheckpoint.Status.Generation
 	createdAny := false
 	for _, container := range srcPod.Spec.Containers {
-		containerCheckpointName := podCheckpoint.Name + "-" + container.Name
+		containerCheckpointName := generationName(podCheckpoint.Name, generation) + "-" + container.Name
 		var containerCheckpoint lpmv1.ContainerCheckpoint
 		err := r.Get(ctx, client.ObjectKey{Namespace: podCheckpoint.Namespace, Name: containerCheckpointName}, &containerCheckpoint)
 		if apierrors.IsNotFound(err) {
@@ -103,17 +201,25 @@ func (r *PodCheckpointReconciler) handlePendingPhase(ctx context.Context, podChe
 					Name:      containerCheckpointName,
 					Namespace: podCheckpoint.Namespace,
 					Labels: map[string]string{
-						"podcheckpoint": podCheckpoint.Name,
+						"podcheckpoint":           podCheckpoint.Name,
+						checkpointGenerationLabel: strconv.FormatInt(generation, 10),
 					},
 					OwnerReferences: []metav1.OwnerReference{
 						*metav1.NewControllerRef(podCheckpoint, lpmv1.GroupVersion.WithKind("PodCheckpoint")),
 					},
 				},
 				Spec: lpmv1.ContainerCheckpointSpec{
-					PodName:       *podCheckpoint.Spec.PodName,
-					ContainerName: container.Name,
+					PodName:               *podCheckpoint.Spec.PodName,
+					PodUID:                srcPod.UID,
+					ContainerName:         container.Name,
+					TerminateExecSessions: podCheckpoint.Spec.ExecSessionPolicy == lpmv1.ExecSessionPolicyTerminate,
+					Compression:           podCheckpoint.Spec.Compression,
+					ArtifactTransfer:      podCheckpoint.Spec.ArtifactTransfer,
 				},
 			}
+			if id := podCheckpoint.Labels[lpmv1.MigrationIDLabel]; id != "" {
+				containerCheckpoint.Labels[lpmv1.MigrationIDLabel] = id
+			}
 			if err := r.Create(ctx, &containerCheckpoint); err != nil {
 				return ctrl.Result{}, err
 			}
@@ -144,7 +250,11 @@ func (r *PodCheckpointReconciler) handleCheckpointingPhase(ctx context.Context,
 
 	// 1. List all ContainerCheckpoint objects owned by this PodCheckpoint
 	var containerCheckpointList lpmv1.ContainerCheckpointList
-	if err := r.List(ctx, &containerCheckpointList, client.InNamespace(podCheckpoint.Namespace), client.MatchingLabels{"podcheckpoint": podCheckpoint.Name}); err != nil {
+	generation := podCheckpoint.Status.Generation
+	if err := r.List(ctx, &containerCheckpointList, client.InNamespace(podCheckpoint.Namespace), client.MatchingLabels{
+		"podcheckpoint":           podCheckpoint.Name,
+		checkpointGenerationLabel: strconv.FormatInt(generation, 10),
+	}); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -168,7 +278,7 @@ func (r *PodCheckpointReconciler) handleCheckpointingPhase(ctx context.Context,
 				allDone = false // succeeded but no content, wait
 			}
 		case lpmv1.ContainerCheckpointPhaseFailed:
-			allDone = true  // we can finish evaluation now
+			allDone = true // we can finish evaluation now
 			allSucceeded = false
 		default: // Pending or Running or empty phase
 			allDone = false
@@ -180,18 +290,53 @@ func (r *PodCheckpointReconciler) handleCheckpointingPhase(ctx context.Context,
 		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 	}
 
+	// Every container has been dumped (or failed); let the application resume.
+	if podCheckpoint.Status.Frozen {
+		if err := r.setPodFrozen(ctx, podCheckpoint, false); err != nil {
+			return ctrl.Result{}, err
+		}
+		podCheckpoint.Status.Frozen = false
+		if err := r.Status().Update(ctx, podCheckpoint); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if podCheckpoint.Status.Quiesced {
+		r.resume(ctx, podCheckpoint)
+		podCheckpoint.Status.Quiesced = false
+		if err := r.Status().Update(ctx, podCheckpoint); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// If any child failed, mark failed
 	if !allSucceeded {
 		return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhaseFailed, "one or more containers failed (see ContainerCheckpoint statuses)")
 	}
 
 	// 3. Ensure PodCheckpointContent exists & bound
-	if podCheckpoint.Status.BoundContentName == "" {
-		podCheckpointContentName := podCheckpoint.Name
+	podCheckpointContentName := generationName(podCheckpoint.Name, generation)
+	if podCheckpoint.Status.BoundContentName != podCheckpointContentName {
 
 		var podCheckpointContent lpmv1.PodCheckpointContent
 		err := r.Get(ctx, client.ObjectKey{Name: podCheckpointContentName, Namespace: podCheckpoint.Namespace}, &podCheckpointContent)
 		if apierrors.IsNotFound(err) {
+			// Save the pod so a PodRestore can recreate it after it is gone. A
+			// pod that is gone or was replaced since is not saved.
+			var sourcePod *runtime.RawExtension
+			var pod corev1.Pod
+			err := r.Get(ctx, client.ObjectKey{Namespace: podCheckpoint.Namespace, Name: *podCheckpoint.Spec.PodName}, &pod)
+			switch {
+			case apierrors.IsNotFound(err):
+			case err != nil:
+				return ctrl.Result{}, err
+			case podReplacedMessage(podCheckpoint.Status.PodUID, &pod) != "":
+				logger.Info("Not saving replaced source pod", "pod", pod.Name)
+			default:
+				if sourcePod, err = rawObject(&pod); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
 			// build new content
 			podCheckpointContent = lpmv1.PodCheckpointContent{
 				ObjectMeta: metav1.ObjectMeta{
@@ -206,9 +351,10 @@ func (r *PodCheckpointReconciler) handleCheckpointingPhase(ctx context.Context,
 						Namespace: podCheckpoint.Namespace,
 						Name:      podCheckpoint.Name,
 					},
-					PodNamespace: podCheckpoint.Namespace,
-					PodName:      *podCheckpoint.Spec.PodName,
+					PodNamespace:      podCheckpoint.Namespace,
+					PodName:           *podCheckpoint.Spec.PodName,
 					ContainerContents: containerContentNames,
+					SourcePod:         sourcePod,
 				},
 			}
 			if err := r.Create(ctx, &podCheckpointContent); err != nil {
@@ -246,7 +392,22 @@ func (r *PodCheckpointReconciler) handleCheckpointingPhase(ctx context.Context,
 		}
 	}
 
-	// 5. Mark PodCheckpoint complete
+	// 5. Record the generation, drop the ones past the history limit and mark
+	// PodCheckpoint complete
+	if !hasGeneration(podCheckpoint, generation) {
+		creationTime := metav1.Now()
+		if boundContent.Status.CreationTime != nil {
+			creationTime = *boundContent.Status.CreationTime
+		}
+		podCheckpoint.Status.History = append(podCheckpoint.Status.History, lpmv1.PodCheckpointGeneration{
+			Generation:   generation,
+			ContentName:  boundContent.Name,
+			CreationTime: creationTime,
+		})
+	}
+	if err := r.pruneHistory(ctx, podCheckpoint); err != nil {
+		return ctrl.Result{}, err
+	}
 	podCheckpoint.Status.Phase = lpmv1.PodCheckpointPhaseSucceeded
 	podCheckpoint.Status.Message = "checkpoint complete"
 	podCheckpoint.Status.Ready = true
@@ -263,14 +424,28 @@ func (r *PodCheckpointReconciler) handleCompletedOrFailedPhase(ctx context.Conte
 
 	logger.Info("Handling Completed or Failed phase for PodCheckpoint", "name", podCheckpoint.Name)
 
-	// No further action needed, just log the final state
+	// A raised spec.desiredGeneration takes another checkpoint; earlier
+	// generations stay bound until it completes.
+	if podCheckpoint.Spec.DesiredGeneration > podCheckpoint.Status.Generation {
+		logger.Info("Starting new checkpoint generation", "name", podCheckpoint.Name, "generation", podCheckpoint.Spec.DesiredGeneration)
+		podCheckpoint.Status.Generation = podCheckpoint.Spec.DesiredGeneration
+		podCheckpoint.Status.CompletionTime = nil
+		return ctrl.Result{}, r.updatePhase(ctx, podCheckpoint, lpmv1.PodCheckpointPhasePending, "checkpoint generation requested")
+	}
+
+	// Come back when the latest generation goes stale to report it
+	staleIn, err := r.updateFreshness(ctx, podCheckpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if podCheckpoint.Status.Phase == lpmv1.PodCheckpointPhaseSucceeded {
 		logger.Info("PodCheckpoint completed successfully", "name", podCheckpoint.Name)
 	} else {
 		logger.Info("PodCheckpoint failed", "name", podCheckpoint.Name, "message", podCheckpoint.Status.Message)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: staleIn}, nil
 }
 
 func (r *PodCheckpointReconciler) updatePhase(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint, phase lpmv1.PodCheckpointPhase, message string) error {
@@ -279,6 +454,232 @@ func (r *PodCheckpointReconciler) updatePhase(ctx context.Context, podCheckpoint
 	return r.Status().Update(ctx, podCheckpoint)
 }
 
+// pruneHistory deletes the generations beyond the history limit, oldest
+// first, along with their content and ContainerCheckpoint objects.
+// Generations pinned by an in-flight PodMigration are kept past the limit and
+// pruned once a later generation completes.
+func (r *PodCheckpointReconciler) pruneHistory(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint) error {
+	logger := log.FromContext(ctx)
+
+	limit := defaultCheckpointHistoryLimit
+	if podCheckpoint.Spec.HistoryLimit != nil {
+		limit = int(*podCheckpoint.Spec.HistoryLimit)
+	}
+	history := podCheckpoint.Status.History
+	excess := len(history) - limit
+	if excess <= 0 {
+		return nil
+	}
+
+	pinned, err := r.pinnedGenerations(ctx, podCheckpoint)
+	if err != nil {
+		return err
+	}
+
+	// The latest generation is never pruned
+	retained := make([]lpmv1.PodCheckpointGeneration, 0, limit)
+	for _, entry := range history[:len(history)-1] {
+		if excess == 0 || pinned[entry.Generation] {
+			retained = append(retained, entry)
+			continue
+		}
+
+		podCheckpointContent := &lpmv1.PodCheckpointContent{
+			ObjectMeta: metav1.ObjectMeta{Name: entry.ContentName, Namespace: podCheckpoint.Namespace},
+		}
+		if err := r.Delete(ctx, podCheckpointContent); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		var containerCheckpointList lpmv1.ContainerCheckpointList
+		if err := r.List(ctx, &containerCheckpointList, client.InNamespace(podCheckpoint.Namespace), client.MatchingLabels{
+			"podcheckpoint": podCheckpoint.Name,
+		}); err != nil {
+			return err
+		}
+		for i := range containerCheckpointList.Items {
+			if !inGeneration(&containerCheckpointList.Items[i], entry.Generation) {
+				continue
+			}
+			if err := r.Delete(ctx, &containerCheckpointList.Items[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+
+		logger.Info("Pruned checkpoint generation", "name", podCheckpoint.Name, "generation", entry.Generation)
+		excess--
+	}
+	podCheckpoint.Status.History = append(retained, history[len(history)-1])
+	return nil
+}
+
+// pinnedGenerations returns the generations of the checkpoint that PodMigrations
+// which have not ended are restoring.
+func (r *PodCheckpointReconciler) pinnedGenerations(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint) (map[int64]bool, error) {
+	var podMigrationList lpmv1.PodMigrationList
+	if err := r.List(ctx, &podMigrationList, client.InNamespace(podCheckpoint.Namespace)); err != nil {
+		return nil, err
+	}
+	pinned := map[int64]bool{}
+	for _, podMigration := range podMigrationList.Items {
+		switch podMigration.Status.Phase {
+		case lpmv1.MigrationPhaseSucceeded, lpmv1.MigrationPhaseFailed:
+			continue
+		}
+		ref := podMigration.Status.PodCheckpointRef
+		if ref != nil && ref.Name == podCheckpoint.Name && podMigration.Status.CheckpointGeneration != 0 {
+			pinned[podMigration.Status.CheckpointGeneration] = true
+		}
+	}
+	return pinned, nil
+}
+
+// inGeneration reports whether a ContainerCheckpoint belongs to a checkpoint
+// generation. ContainerCheckpoints created before generations were labelled
+// belong to the first.
+func inGeneration(containerCheckpoint *lpmv1.ContainerCheckpoint, generation int64) bool {
+	label, ok := containerCheckpoint.Labels[checkpointGenerationLabel]
+	if !ok {
+		return generation <= 1
+	}
+	return label == strconv.FormatInt(generation, 10)
+}
+
+// generationName names the objects of a checkpoint generation. The first
+// generation keeps the PodCheckpoint's own name.
+func generationName(podCheckpointName string, generation int64) string {
+	if generation <= 1 {
+		return podCheckpointName
+	}
+	return fmt.Sprintf("%s-g%d", podCheckpointName, generation)
+}
+
+func hasGeneration(podCheckpoint *lpmv1.PodCheckpoint, generation int64) bool {
+	_, ok := lookupGeneration(podCheckpoint, &generation)
+	return ok
+}
+
+// lookupGeneration finds a retained generation of the checkpoint, or the
+// latest one when generation is nil.
+func lookupGeneration(podCheckpoint *lpmv1.PodCheckpoint, generation *int64) (lpmv1.PodCheckpointGeneration, bool) {
+	history := podCheckpoint.Status.History
+	if generation == nil {
+		if len(history) == 0 {
+			return lpmv1.PodCheckpointGeneration{}, false
+		}
+		return history[len(history)-1], true
+	}
+	for _, entry := range history {
+		if entry.Generation == *generation {
+			return entry, true
+		}
+	}
+	return lpmv1.PodCheckpointGeneration{}, false
+}
+
+// quiesce runs the quiesce hooks in order. Application consistency is only
+// guaranteed if all of them succeed, so the first failure aborts and the hooks
+// that already ran are undone with the resume hooks.
+func (r *PodCheckpointReconciler) quiesce(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint, pod *corev1.Pod) error {
+	logger := log.FromContext(ctx)
+
+	if len(podCheckpoint.Spec.QuiesceHooks) == 0 {
+		return fmt.Errorf("application-consistent checkpoint requires at least one quiesce hook")
+	}
+	if r.Exec == nil {
+		return fmt.Errorf("application-consistent checkpoint requested but pod exec is not configured")
+	}
+
+	podCheckpoint.Status.HookResults = nil
+	for i, hook := range podCheckpoint.Spec.QuiesceHooks {
+		err := runHook(ctx, r.Exec, pod.Namespace, pod.Name, hook)
+		recordHookResult(podCheckpoint, lpmv1.CheckpointHookStageQuiesce, i, hook, err)
+		if err != nil {
+			if i > 0 {
+				r.resume(ctx, podCheckpoint)
+			}
+			return fmt.Errorf("quiesce hook %d failed in container %s: %w", i, hook.Container, err)
+		}
+		logger.Info("Quiesce hook succeeded", "pod", pod.Name, "container", hook.Container, "index", i)
+	}
+	return nil
+}
+
+// resume runs the resume hooks in order. Failures are logged but do not fail
+// the checkpoint: the dump has already been taken.
+func (r *PodCheckpointReconciler) resume(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint) {
+	logger := log.FromContext(ctx)
+
+	if r.Exec == nil {
+		return
+	}
+	for i, hook := range podCheckpoint.Spec.ResumeHooks {
+		err := runHook(ctx, r.Exec, podCheckpoint.Namespace, *podCheckpoint.Spec.PodName, hook)
+		recordHookResult(podCheckpoint, lpmv1.CheckpointHookStageResume, i, hook, err)
+		if err != nil {
+			logger.Error(err, "Resume hook failed", "pod", *podCheckpoint.Spec.PodName, "container", hook.Container, "index", i)
+		}
+	}
+}
+
+// recordHookResult adds the outcome of a hook to the checkpoint's status.
+func recordHookResult(podCheckpoint *lpmv1.PodCheckpoint, stage lpmv1.CheckpointHookStage, index int, hook lpmv1.CheckpointHook, err error) {
+	result := lpmv1.CheckpointHookResult{
+		Stage:     stage,
+		Index:     int32(index),
+		Container: hook.Container,
+		Succeeded: err == nil,
+		Time:      metav1.Now(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	podCheckpoint.Status.HookResults = append(podCheckpoint.Status.HookResults, result)
+}
+
+// setPodFrozen has the agent on the pod's node freeze or thaw the whole pod.
+// A pod that is gone needs no thaw.
+func (r *PodCheckpointReconciler) setPodFrozen(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint, frozen bool) error {
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podCheckpoint.Namespace, Name: *podCheckpoint.Spec.PodName}, &pod); err != nil {
+		if apierrors.IsNotFound(err) && !frozen {
+			return nil
+		}
+		return err
+	}
+	if r.AgentClient == nil {
+		return fmt.Errorf("checkpoint scope Pod requested but the agent client is not configured")
+	}
+	// The agent finds the pod's cgroup through one of its containers
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.ContainerID != "" && status.State.Running != nil {
+			return r.AgentClient.FreezePod(ctx, pod.Spec.NodeName, string(pod.UID), status.ContainerID, !frozen, podFreezeTimeout)
+		}
+	}
+	if !frozen {
+		return nil
+	}
+	return fmt.Errorf("pod %s has no running container", pod.Name)
+}
+
+// runHook runs a hook command in a container of a pod, bounded by its timeout.
+func runHook(ctx context.Context, executor podexec.Executor, namespace, podName string, hook lpmv1.CheckpointHook) error {
+	timeout := defaultHookTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, stderr, err := executor.Exec(ctx, namespace, podName, hook.Container, hook.Command)
+	if err != nil {
+		if stderr = strings.TrimSpace(stderr); stderr != "" {
+			return fmt.Errorf("%w: %s", err, stderr)
+		}
+		return err
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *PodCheckpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
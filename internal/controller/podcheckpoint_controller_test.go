@@ -21,8 +21,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -82,3 +84,72 @@ var _ = Describe("PodCheckpoint Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("PodCheckpoint Controller with a replaced source pod", func() {
+	const resourceName = "replaced-pod-checkpoint"
+
+	ctx := context.Background()
+	typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+	podName := "replaced-pod"
+
+	BeforeEach(func() {
+		Expect(k8sClient.Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox"}}},
+		})).To(Succeed())
+
+		podCheckpoint := &lpmv1.PodCheckpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			Spec:       lpmv1.PodCheckpointSpec{PodName: &podName},
+		}
+		Expect(k8sClient.Create(ctx, podCheckpoint)).To(Succeed())
+		// The checkpointed instance was deleted and recreated under its name
+		podCheckpoint.Status.Phase = lpmv1.PodCheckpointPhaseRunning
+		podCheckpoint.Status.Generation = 1
+		podCheckpoint.Status.PodUID = "checkpointed-pod-uid"
+		Expect(k8sClient.Status().Update(ctx, podCheckpoint)).To(Succeed())
+
+		containerCheckpoint := &lpmv1.ContainerCheckpoint{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceName + "-app",
+				Namespace: "default",
+				Labels: map[string]string{
+					"podcheckpoint":           resourceName,
+					checkpointGenerationLabel: "1",
+				},
+			},
+			Spec: lpmv1.ContainerCheckpointSpec{PodName: podName, ContainerName: "app", PodUID: "checkpointed-pod-uid"},
+		}
+		Expect(k8sClient.Create(ctx, containerCheckpoint)).To(Succeed())
+		containerCheckpoint.Status.Phase = lpmv1.ContainerCheckpointPhaseSucceeded
+		containerCheckpoint.Status.BoundContentName = resourceName + "-app"
+		Expect(k8sClient.Status().Update(ctx, containerCheckpoint)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, &lpmv1.ContainerCheckpoint{ObjectMeta: metav1.ObjectMeta{Name: resourceName + "-app", Namespace: "default"}})).To(Succeed())
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &lpmv1.PodCheckpointContent{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"}}))).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &lpmv1.PodCheckpoint{ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"}})).To(Succeed())
+		Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default"}})).To(Succeed())
+	})
+
+	It("should bind the content without saving the replacement", func() {
+		controllerReconciler := &PodCheckpointReconciler{
+			Client: k8sClient,
+			Scheme: k8sClient.Scheme(),
+		}
+
+		_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		content := &lpmv1.PodCheckpointContent{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, content)).To(Succeed())
+		Expect(content.Spec.SourcePod).To(BeNil())
+		Expect(content.Spec.ContainerContents).To(Equal([]corev1.LocalObjectReference{{Name: resourceName + "-app"}}))
+
+		_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+		resource := &lpmv1.PodCheckpoint{}
+		Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+		Expect(resource.Status.BoundContentName).To(Equal(resourceName))
+	})
+})
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/metrics"
+	"my.domain/guestbook/internal/policy"
+)
+
+// updateFreshness records when the latest retained generation of
+// podCheckpoint was taken and sets its Fresh condition against the
+// maxCheckpointAge of the pod's MigrationPolicies. It returns how long until
+// the generation goes stale, or 0 if it never will.
+func (r *PodCheckpointReconciler) updateFreshness(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint) (time.Duration, error) {
+	entry, ok := lookupGeneration(podCheckpoint, nil)
+	if !ok {
+		return 0, nil
+	}
+	metrics.CheckpointTimestamp.WithLabelValues(podCheckpoint.Namespace, podCheckpoint.Name).
+		Set(float64(entry.CreationTime.Unix()))
+
+	var maxAge time.Duration
+	pod, err := r.checkpointedPod(ctx, podCheckpoint, entry)
+	if err != nil {
+		return 0, err
+	}
+	if pod != nil {
+		policies, err := policy.Matching(ctx, r.Client, pod)
+		if err != nil {
+			return 0, err
+		}
+		maxAge = policy.MaxCheckpointAge(policies)
+	}
+
+	status := podCheckpoint.Status.DeepCopy()
+	status.LastCheckpointTime = entry.CreationTime.DeepCopy()
+	var staleIn time.Duration
+	if maxAge == 0 {
+		meta.RemoveStatusCondition(&status.Conditions, lpmv1.PodCheckpointConditionFresh)
+	} else {
+		condition := metav1.Condition{Type: lpmv1.PodCheckpointConditionFresh}
+		if age := time.Since(entry.CreationTime.Time); age <= maxAge {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = lpmv1.PodCheckpointReasonFresh
+			condition.Message = fmt.Sprintf("generation %d is within the %s maximum checkpoint age", entry.Generation, maxAge)
+			staleIn = maxAge - age
+		} else {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = lpmv1.PodCheckpointReasonStale
+			condition.Message = fmt.Sprintf("generation %d is older than the %s maximum checkpoint age; restoring it requires allowStale", entry.Generation, maxAge)
+		}
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+	if equality.Semantic.DeepEqual(status, &podCheckpoint.Status) {
+		return staleIn, nil
+	}
+	podCheckpoint.Status = *status
+	return staleIn, r.Status().Update(ctx, podCheckpoint)
+}
+
+// checkpointedPod returns the pod podCheckpoint is of, or the copy of it
+// saved with entry once it is gone, or nil if there is neither.
+func (r *PodCheckpointReconciler) checkpointedPod(ctx context.Context, podCheckpoint *lpmv1.PodCheckpoint, entry lpmv1.PodCheckpointGeneration) (*corev1.Pod, error) {
+	if podCheckpoint.Spec.PodName == nil {
+		return nil, nil
+	}
+	var pod corev1.Pod
+	err := r.Get(ctx, client.ObjectKey{Namespace: podCheckpoint.Namespace, Name: *podCheckpoint.Spec.PodName}, &pod)
+	if err == nil {
+		return &pod, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	var checkpointContent lpmv1.PodCheckpointContent
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podCheckpoint.Namespace, Name: entry.ContentName}, &checkpointContent); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if checkpointContent.Spec.SourcePod == nil {
+		return nil, nil
+	}
+	if err := json.Unmarshal(checkpointContent.Spec.SourcePod.Raw, &pod); err != nil {
+		return nil, fmt.Errorf("invalid saved source pod: %w", err)
+	}
+	return &pod, nil
+}
+
+// staleCheckpointMessage returns why restoring entry of the checkpoint ref
+// names is refused under policies, or "" if the generation is recent enough
+// or ref allows stale checkpoints.
+func staleCheckpointMessage(ref lpmv1.CheckpointReference, entry lpmv1.PodCheckpointGeneration, policies []lpmv1.MigrationPolicy) string {
+	maxAge := policy.MaxCheckpointAge(policies)
+	age := time.Since(entry.CreationTime.Time)
+	if ref.AllowStale || maxAge == 0 || age <= maxAge {
+		return ""
+	}
+	return fmt.Sprintf("generation %d of checkpoint %s was taken %s ago, more than the %s its MigrationPolicies allow; set allowStale to restore it anyway",
+		entry.Generation, ref.Name, age.Round(time.Second), maxAge)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// completeAdoption records the restored pod named by the migration's
+// AdoptedPodAnnotation, which the RestoredPod sweeper found left behind by an
+// earlier migration, as the result of the migration: the source pod it
+// replaces is deleted and the migration succeeds without a checkpoint.
+func (r *PodMigrationReconciler) completeAdoption(ctx context.Context, podMigration *lpmv1.PodMigration, name string) error {
+	var restoredPod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: name}, &restoredPod); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		podMigration.Status.Reason = lpmv1.MigrationReasonAdopted
+		return r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed,
+			fmt.Sprintf("restored pod %s to adopt is gone", name))
+	}
+
+	// The scale-down protection it inherited is released with the
+	// migration that created it, which is gone
+	patch := client.MergeFrom(restoredPod.DeepCopy())
+	if removeProtection(&restoredPod) {
+		if err := r.Patch(ctx, &restoredPod, patch); err != nil {
+			return fmt.Errorf("failed to unprotect restored pod: %w", err)
+		}
+	}
+
+	podMigration.Status.RestoredPodName = restoredPod.Name
+	if err := r.deleteOriginalPod(ctx, podMigration); err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Adopted restored pod left behind by an earlier migration", "pod", restoredPod.Name)
+	podMigration.Status.Reason = lpmv1.MigrationReasonAdopted
+	return r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseSucceeded,
+		fmt.Sprintf("adopted restored pod %s left behind by an earlier migration", restoredPod.Name))
+}
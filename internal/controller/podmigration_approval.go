@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// approvalActions describe what a migration waiting at each stage does once
+// approved.
+var approvalActions = map[lpmv1.ApprovalStage]string{
+	lpmv1.ApprovalStageBeforeCheckpoint: "checkpoint the pod",
+	lpmv1.ApprovalStageBeforeRestore:    "restore the pod on the target node",
+}
+
+// awaitApproval reports whether the migration may go past stage. A migration
+// whose policies require approval at stage waits with an Approved condition
+// of Unknown until a user or external system sets it to True; setting it to
+// False fails the migration. A migration approved in advance does not wait.
+func (r *PodMigrationReconciler) awaitApproval(ctx context.Context, podMigration *lpmv1.PodMigration, stage lpmv1.ApprovalStage) (bool, error) {
+	if podMigration.Status.ApprovalStage != stage {
+		return true, nil
+	}
+
+	approved := meta.FindStatusCondition(podMigration.Status.Conditions, lpmv1.MigrationConditionApproved)
+	if approved != nil {
+		switch approved.Status {
+		case metav1.ConditionTrue:
+			return true, nil
+		case metav1.ConditionFalse:
+			message := "migration was not approved"
+			if approved.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, approved.Message)
+			}
+			return false, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonApprovalDenied, message)
+		}
+	}
+
+	changed := meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionApproved,
+		Status:  metav1.ConditionUnknown,
+		Reason:  lpmv1.MigrationReasonAwaitingApproval,
+		Message: fmt.Sprintf("set to True to let the migration %s, or to False to reject it", approvalActions[stage]),
+	})
+	message := "waiting for approval to " + approvalActions[stage]
+	if !changed && podMigration.Status.Message == message {
+		return false, nil
+	}
+	log.FromContext(ctx).Info("Waiting for approval", "stage", stage)
+	podMigration.Status.Message = message
+	// The status update of the approval requeues the migration
+	return false, r.Status().Update(ctx, podMigration)
+}
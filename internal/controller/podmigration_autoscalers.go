@@ -0,0 +1,398 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;patch
+
+const (
+	// autoscalerLockAnnotation lists the migrations holding an autoscaler's
+	// lock, comma-separated. The autoscaler is unlocked when the last one
+	// releases it.
+	autoscalerLockAnnotation = "lpm.my.domain/locked-by-migrations"
+	// savedScaleDownAnnotation keeps a locked HPA's spec.behavior.scaleDown.
+	savedScaleDownAnnotation = "lpm.my.domain/saved-scale-down"
+	// savedUpdateModeAnnotation keeps a locked VPA's spec.updatePolicy.updateMode.
+	savedUpdateModeAnnotation = "lpm.my.domain/saved-update-mode"
+	// savedPausedAnnotation keeps a locked Deployment's spec.paused.
+	savedPausedAnnotation = "lpm.my.domain/saved-paused"
+	// autoscalerLockFinalizer keeps a migration holding autoscaler locks,
+	// or an orphaned StatefulSet, until it released them.
+	autoscalerLockFinalizer = "lpm.my.domain/autoscaler-lock"
+)
+
+// vpaListGVK is the VerticalPodAutoscaler list. The VPA is an add-on, so it
+// is handled unstructured and skipped when its CRD is not installed.
+var vpaListGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscalerList"}
+
+// workloadRef is a controller of a pod that an autoscaler can target.
+type workloadRef struct {
+	kind string
+	name string
+}
+
+// podWorkloads returns the controllers of pod an autoscaler may target: its
+// controller and, for a ReplicaSet, the ReplicaSet's Deployment.
+func (r *PodMigrationReconciler) podWorkloads(ctx context.Context, pod *corev1.Pod) ([]workloadRef, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+	workloads := []workloadRef{{kind: owner.Kind, name: owner.Name}}
+	if owner.Kind != "ReplicaSet" {
+		return workloads, nil
+	}
+	var replicaSet appsv1.ReplicaSet
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: owner.Name}, &replicaSet); err != nil {
+		return workloads, client.IgnoreNotFound(err)
+	}
+	if deployment := metav1.GetControllerOf(&replicaSet); deployment != nil {
+		workloads = append(workloads, workloadRef{kind: deployment.Kind, name: deployment.Name})
+	}
+	return workloads, nil
+}
+
+// lockHolders returns the migrations holding obj's lock.
+func lockHolders(obj client.Object) []string {
+	annotation := obj.GetAnnotations()[autoscalerLockAnnotation]
+	if annotation == "" {
+		return nil
+	}
+	return strings.Split(annotation, ",")
+}
+
+// setLockHolders records the migrations holding obj's lock, removing the
+// annotation when there are none.
+func setLockHolders(obj client.Object, holders []string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(holders) == 0 {
+		delete(annotations, autoscalerLockAnnotation)
+	} else {
+		annotations[autoscalerLockAnnotation] = strings.Join(holders, ",")
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// lockAutoscalers keeps autoscalers from disturbing the migration until
+// releaseAutoscalers runs at a terminal phase: the workload's HPAs and VPAs
+// are locked and the pods and target node are protected from cluster
+// autoscaler scale-down. Locking is idempotent.
+func (r *PodMigrationReconciler) lockAutoscalers(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) error {
+	// The finalizer goes first, so a migration deleted mid-flight still
+	// releases what it locked. The update returns the stored status, so the
+	// one preflight built is kept aside.
+	if controllerutil.AddFinalizer(podMigration, autoscalerLockFinalizer) {
+		status := podMigration.Status.DeepCopy()
+		if err := r.Update(ctx, podMigration); err != nil {
+			return err
+		}
+		podMigration.Status = *status
+	}
+
+	locked, err := r.lockWorkloadAutoscalers(ctx, podMigration, srcPod)
+	if err != nil {
+		return err
+	}
+	protected, err := r.protectFromScaleDown(ctx, podMigration, srcPod)
+	if err != nil {
+		return err
+	}
+
+	var messages []string
+	if len(locked) > 0 {
+		messages = append(messages, "locked "+strings.Join(locked, ", "))
+	}
+	if len(protected) > 0 {
+		messages = append(messages, "protected "+strings.Join(protected, ", ")+" from scale-down")
+	}
+	meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionAutoscalersLocked,
+		Status:  metav1.ConditionTrue,
+		Reason:  lpmv1.MigrationReasonAutoscalersLocked,
+		Message: strings.Join(messages, "; "),
+	})
+	return nil
+}
+
+// lockWorkloadAutoscalers keeps the autoscalers of the source pod's workload
+// from disturbing the migration: HPAs have scale-down disabled, so the
+// workload's controller doesn't delete the source pod mid-checkpoint, and
+// VPAs are switched to updateMode Off, so the source pod isn't evicted and
+// the restored pod isn't admitted with other resources than were
+// checkpointed. With ownerHandling CoordinateScale the Deployment is paused
+// too, so no rollout replaces the source pod, or the ReplicaSet about to
+// adopt the restored pod, mid-migration. The original settings are saved in
+// annotations on each object and put back by unlockWorkloadAutoscalers.
+func (r *PodMigrationReconciler) lockWorkloadAutoscalers(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) ([]string, error) {
+	workloads, err := r.podWorkloads(ctx, srcPod)
+	if err != nil || len(workloads) == 0 {
+		return nil, err
+	}
+	targets := func(kind, name string) bool {
+		return slices.Contains(workloads, workloadRef{kind: kind, name: name})
+	}
+
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := r.List(ctx, &hpas, client.InNamespace(srcPod.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list HorizontalPodAutoscalers: %w", err)
+	}
+	var toLock []client.Object
+	for i := range hpas.Items {
+		hpa := &hpas.Items[i]
+		if targets(hpa.Spec.ScaleTargetRef.Kind, hpa.Spec.ScaleTargetRef.Name) {
+			toLock = append(toLock, hpa)
+		}
+	}
+
+	vpas := &unstructured.UnstructuredList{}
+	vpas.SetGroupVersionKind(vpaListGVK)
+	if err := r.List(ctx, vpas, client.InNamespace(srcPod.Namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list VerticalPodAutoscalers: %w", err)
+	}
+	for i := range vpas.Items {
+		vpa := &vpas.Items[i]
+		kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		if targets(kind, name) {
+			toLock = append(toLock, vpa)
+		}
+	}
+
+	if podMigration.Spec.OwnerHandling == lpmv1.OwnerHandlingCoordinateScale {
+		for _, workload := range workloads {
+			if workload.kind != "Deployment" {
+				continue
+			}
+			var deployment appsv1.Deployment
+			if err := r.Get(ctx, client.ObjectKey{Namespace: srcPod.Namespace, Name: workload.name}, &deployment); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			toLock = append(toLock, &deployment)
+		}
+	}
+
+	var locked []string
+	for _, obj := range toLock {
+		locked = append(locked, autoscalerName(obj))
+		holders := lockHolders(obj)
+		if slices.Contains(holders, podMigration.Name) {
+			continue
+		}
+		// Concurrent migrations of the same workload share the lock
+		patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		if len(holders) == 0 {
+			if err := lockAutoscaler(obj); err != nil {
+				return nil, err
+			}
+		}
+		setLockHolders(obj, append(holders, podMigration.Name))
+		if err := r.Patch(ctx, obj, patch); err != nil {
+			return nil, fmt.Errorf("failed to lock %s: %w", autoscalerName(obj), err)
+		}
+	}
+	return locked, nil
+}
+
+// lockAutoscaler saves obj's settings and locks it.
+func lockAutoscaler(obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	switch autoscaler := obj.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		if autoscaler.Spec.Behavior == nil {
+			autoscaler.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+		}
+		saved, err := json.Marshal(autoscaler.Spec.Behavior.ScaleDown)
+		if err != nil {
+			return err
+		}
+		annotations[savedScaleDownAnnotation] = string(saved)
+		disabled := autoscalingv2.DisabledPolicySelect
+		autoscaler.Spec.Behavior.ScaleDown = &autoscalingv2.HPAScalingRules{SelectPolicy: &disabled}
+	case *unstructured.Unstructured:
+		mode, _, _ := unstructured.NestedString(autoscaler.Object, "spec", "updatePolicy", "updateMode")
+		annotations[savedUpdateModeAnnotation] = mode
+		if err := unstructured.SetNestedField(autoscaler.Object, "Off", "spec", "updatePolicy", "updateMode"); err != nil {
+			return err
+		}
+	case *appsv1.Deployment:
+		annotations[savedPausedAnnotation] = strconv.FormatBool(autoscaler.Spec.Paused)
+		autoscaler.Spec.Paused = true
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// unlockAutoscaler puts back obj's saved settings.
+func unlockAutoscaler(obj client.Object) error {
+	annotations := obj.GetAnnotations()
+	switch autoscaler := obj.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		var scaleDown *autoscalingv2.HPAScalingRules
+		if saved, ok := annotations[savedScaleDownAnnotation]; ok {
+			if err := json.Unmarshal([]byte(saved), &scaleDown); err != nil {
+				return fmt.Errorf("invalid %s annotation: %w", savedScaleDownAnnotation, err)
+			}
+		}
+		if autoscaler.Spec.Behavior != nil {
+			autoscaler.Spec.Behavior.ScaleDown = scaleDown
+		}
+		delete(annotations, savedScaleDownAnnotation)
+	case *unstructured.Unstructured:
+		if mode := annotations[savedUpdateModeAnnotation]; mode != "" {
+			if err := unstructured.SetNestedField(autoscaler.Object, mode, "spec", "updatePolicy", "updateMode"); err != nil {
+				return err
+			}
+		} else {
+			unstructured.RemoveNestedField(autoscaler.Object, "spec", "updatePolicy", "updateMode")
+		}
+		delete(annotations, savedUpdateModeAnnotation)
+	case *appsv1.Deployment:
+		if saved, ok := annotations[savedPausedAnnotation]; ok {
+			autoscaler.Spec.Paused = saved == "true"
+		}
+		delete(annotations, savedPausedAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// autoscalerName names obj for status messages.
+func autoscalerName(obj client.Object) string {
+	switch obj.(type) {
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return "HorizontalPodAutoscaler/" + obj.GetName()
+	case *appsv1.Deployment:
+		return "Deployment/" + obj.GetName()
+	}
+	return "VerticalPodAutoscaler/" + obj.GetName()
+}
+
+// releaseAutoscalers gives up everything lockAutoscalers locked, then
+// removes the lock finalizer.
+func (r *PodMigrationReconciler) releaseAutoscalers(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	released, err := r.unlockWorkloadAutoscalers(ctx, podMigration)
+	if err != nil {
+		return err
+	}
+	unprotected, err := r.releaseScaleDownProtection(ctx, podMigration)
+	if err != nil {
+		return err
+	}
+
+	if podMigration.DeletionTimestamp.IsZero() {
+		message := "migration ended"
+		if released = append(released, unprotected...); len(released) > 0 {
+			message = "released " + strings.Join(released, ", ")
+		}
+		meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+			Type:    lpmv1.MigrationConditionAutoscalersLocked,
+			Status:  metav1.ConditionFalse,
+			Reason:  lpmv1.MigrationReasonAutoscalersReleased,
+			Message: message,
+		})
+		if err := r.Status().Update(ctx, podMigration); err != nil {
+			return err
+		}
+	}
+	if controllerutil.RemoveFinalizer(podMigration, autoscalerLockFinalizer) {
+		return r.Update(ctx, podMigration)
+	}
+	return nil
+}
+
+// unlockWorkloadAutoscalers gives up the migration's HPA and VPA locks,
+// restoring the settings of autoscalers no other migration holds.
+func (r *PodMigrationReconciler) unlockWorkloadAutoscalers(ctx context.Context, podMigration *lpmv1.PodMigration) ([]string, error) {
+	var held []client.Object
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := r.List(ctx, &hpas, client.InNamespace(podMigration.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list HorizontalPodAutoscalers: %w", err)
+	}
+	for i := range hpas.Items {
+		held = append(held, &hpas.Items[i])
+	}
+	vpas := &unstructured.UnstructuredList{}
+	vpas.SetGroupVersionKind(vpaListGVK)
+	if err := r.List(ctx, vpas, client.InNamespace(podMigration.Namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list VerticalPodAutoscalers: %w", err)
+	}
+	for i := range vpas.Items {
+		held = append(held, &vpas.Items[i])
+	}
+	var deployments appsv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(podMigration.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		held = append(held, &deployments.Items[i])
+	}
+
+	var released []string
+	for _, obj := range held {
+		holders := lockHolders(obj)
+		i := slices.Index(holders, podMigration.Name)
+		if i < 0 {
+			continue
+		}
+		patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		holders = slices.Delete(holders, i, i+1)
+		if len(holders) == 0 {
+			if err := unlockAutoscaler(obj); err != nil {
+				return nil, err
+			}
+		}
+		setLockHolders(obj, holders)
+		if err := r.Patch(ctx, obj, patch); err != nil {
+			return nil, fmt.Errorf("failed to release %s: %w", autoscalerName(obj), err)
+		}
+		released = append(released, autoscalerName(obj))
+	}
+	return released, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// nodeCapabilityProblems returns what the capability labels the agent set on
+// nodeName say it lacks to checkpoint (source) or restore (target) a pod.
+// Labels the agent could not determine, or a node without them, are not
+// reported.
+func (r *PodMigrationReconciler) nodeCapabilityProblems(ctx context.Context, nodeName string, source bool) ([]string, error) {
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var problems []string
+	if node.Labels[lpmv1.NodeCRIUVersionLabel] == lpmv1.NodeCapabilityNone {
+		problems = append(problems, "has no CRIU installed")
+	}
+	if runtime := node.Labels[lpmv1.NodeContainerRuntimeLabel]; runtime != "" && runtime != lpmv1.NodeCapabilityUnknown && runtime != lpmv1.NodeRuntimeCRIO {
+		problems = append(problems, fmt.Sprintf("runs %s, and restoring checkpoints needs CRI-O", runtime))
+	}
+	if source {
+		switch node.Labels[lpmv1.NodeKubeletCheckpointLabel] {
+		case lpmv1.NodeKubeletCheckpointDisabled:
+			problems = append(problems, "has a kubelet without the checkpoint API (ContainerCheckpoint feature gate disabled)")
+		case lpmv1.NodeKubeletCheckpointForbidden:
+			problems = append(problems, "has a kubelet that refuses the agent's checkpoint requests")
+		}
+	}
+	// A checkpoint of a node without shared storage can only leave it
+	// through the checkpoint registry or streamed by its agent, and a target
+	// without it can only receive one that way
+	if node.Labels[lpmv1.NodeSharedStorageLabel] == "false" && r.Registry == nil &&
+		node.Labels[lpmv1.NodeCheckpointStreamingLabel] != "true" {
+		if source {
+			problems = append(problems, "has no shared checkpoint storage mounted, the checkpoint registry is disabled and its agent cannot stream checkpoints")
+		} else {
+			problems = append(problems, "has no shared checkpoint storage mounted to read the checkpoint from, the checkpoint registry is disabled and its agent cannot stream checkpoints")
+		}
+	}
+	return problems, nil
+}
+
+// preflightNodeCapabilities rejects a migration the capability labels of the
+// source or target node rule out, with the reason the agent found, rather
+// than failing later in the checkpoint or restore.
+func (r *PodMigrationReconciler) preflightNodeCapabilities(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	var problems []string
+	// An existing checkpoint was already taken
+	if podMigration.Spec.CheckpointRef == nil && srcPod.Spec.NodeName != "" {
+		sourceProblems, err := r.nodeCapabilityProblems(ctx, srcPod.Spec.NodeName, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, problem := range sourceProblems {
+			problems = append(problems, fmt.Sprintf("source node %s %s", srcPod.Spec.NodeName, problem))
+		}
+	}
+	if podMigration.Spec.TargetNode != "" {
+		targetProblems, err := r.nodeCapabilityProblems(ctx, podMigration.Spec.TargetNode, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, problem := range targetProblems {
+			problems = append(problems, fmt.Sprintf("target node %s %s", podMigration.Spec.TargetNode, problem))
+		}
+	}
+	if len(problems) > 0 {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonNodeCapabilityMissing,
+			message: strings.Join(problems, "; "),
+		}, nil
+	}
+	return nil, nil
+}
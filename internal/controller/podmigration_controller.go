@@ -18,20 +18,31 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"text/template"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	lpmv1 "my.domain/guestbook/api/v1"
 	"my.domain/guestbook/internal/agent"
+	"my.domain/guestbook/internal/podexec"
+	"my.domain/guestbook/internal/policy"
+	"my.domain/guestbook/internal/registry"
+	"my.domain/guestbook/pkg/scoring"
 )
 
 // PodMigrationReconciler reconciles a PodMigration object
@@ -39,6 +50,22 @@ type PodMigrationReconciler struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	AgentClient *agent.Client
+	Exec        podexec.Executor
+
+	// ImageNameTemplate names the images checkpoints are converted to.
+	// Defaults to DefaultImageNameTemplate.
+	ImageNameTemplate *template.Template
+
+	// Registry, when set, carries checkpoints that are not in shared
+	// storage from the node they were taken on to the target node.
+	Registry *registry.Registry
+
+	// Scorers rank the candidate target nodes of migrations without one.
+	Scorers []scoring.Weighted
+
+	// APIReader reads the Secrets of notification sinks without caching
+	// every Secret in the cluster. Defaults to the client.
+	APIReader client.Reader
 }
 
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch;create;update;patch;delete
@@ -47,19 +74,44 @@ type PodMigrationReconciler struct {
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpoints,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpointcontents,verbs=get;list;watch
 // +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpointcontents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=containercheckpointcontents/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
 
 func (r *PodMigrationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	logger := log.FromContext(ctx)
-
 	var podMigration lpmv1.PodMigration
 	if err := r.Get(ctx, req.NamespacedName, &podMigration); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx = withMigrationID(ctx, string(podMigration.UID))
+	logger := log.FromContext(ctx)
+
+	// A deleted migration gives back what it held
+	if !podMigration.DeletionTimestamp.IsZero() {
+		if err := r.recreateStatefulSet(ctx, &podMigration); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.releaseAutoscalers(ctx, &podMigration)
+	}
 
 	if podMigration.Status.Phase == "" {
-		podMigration.Status.Phase = lpmv1.MigrationPhasePending
+		setPhase(&podMigration, lpmv1.MigrationPhasePending)
+	}
+
+	if err := r.notify(ctx, &podMigration); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.publishPodStatus(ctx, &podMigration)
+
+	// A record of a restored pod left behind has nothing to migrate
+	if name := podMigration.Annotations[lpmv1.AdoptedPodAnnotation]; name != "" && podMigration.Status.Phase == lpmv1.MigrationPhasePending {
+		return ctrl.Result{}, r.completeAdoption(ctx, &podMigration, name)
+	}
+
+	// Give up on a migration that ran over its downtime budget while the
+	// source pod can still take over
+	if aborted, err := r.enforceMaxDowntime(ctx, &podMigration); aborted || err != nil {
+		return ctrl.Result{}, err
 	}
 
 	switch podMigration.Status.Phase {
@@ -94,13 +146,44 @@ func (r *PodMigrationReconciler) handlePendingPhase(ctx context.Context, podMigr
 		}
 		return ctrl.Result{}, err
 	}
+	if message := podReplacedMessage(podMigration.Status.SourcePodUID, &srcPod); message != "" {
+		return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonSourcePodReplaced, message)
+	}
 
 	// 2. Validate source Pod running
 	if srcPod.Status.Phase != corev1.PodRunning {
 		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, "source pod not running")
 	}
 
-	// 3. If target node requested, validate it exists
+	// Only one migration of a pod runs at a time; a simulation never touches it
+	if !podMigration.Spec.Simulate {
+		locked, err := r.lockSourcePod(ctx, podMigration, &srcPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !locked {
+			return ctrl.Result{RequeueAfter: sourcePodLockPollInterval}, nil
+		}
+	}
+
+	// 3. Pick a target node if none was requested
+	if podMigration.Spec.TargetNode == "" {
+		targetNode, err := r.resolveTargetNode(ctx, podMigration, &srcPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if targetNode == "" {
+			return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonNoTargetNode,
+				"no node the pod fits on is allowed by its migration policies")
+		}
+		logger.Info("Resolved target node", "node", targetNode)
+		podMigration.Spec.TargetNode = targetNode
+		if err := r.Update(ctx, podMigration); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// If target node requested, validate it exists
 	if podMigration.Spec.TargetNode != "" {
 		var node corev1.Node
 		if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
@@ -111,10 +194,87 @@ func (r *PodMigrationReconciler) handlePendingPhase(ctx context.Context, podMigr
 		}
 	}
 
+	// Reject pods that cannot be checkpointed or restored before touching them
+	failure, err := r.preflight(ctx, podMigration, &srcPod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if failure != nil {
+		return ctrl.Result{}, r.failWithReason(ctx, podMigration, failure.reason, failure.message)
+	}
+
+	// A simulation only measures; it never checkpoints the pod
+	if podMigration.Spec.Simulate {
+		return r.simulate(ctx, podMigration, &srcPod)
+	}
+
+	// Record what applies to the migration before the source pod goes away
+	policies, err := policy.Matching(ctx, r.Client, &srcPod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	podMigration.Status.MigrationPolicies = nil
+	for _, p := range policies {
+		podMigration.Status.MigrationPolicies = append(podMigration.Status.MigrationPolicies, p.Name)
+	}
+	podMigration.Status.ApprovalStage = policy.ApprovalStage(policies)
+	podMigration.Status.SourceNode = srcPod.Spec.NodeName
+	podMigration.Status.SourcePodUID = srcPod.UID
+
+	// Hold the migration for approval if the pod's policies require it
+	if approved, err := r.awaitApproval(ctx, podMigration, lpmv1.ApprovalStageBeforeCheckpoint); !approved || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Choose the strategy, and don't start a migration predicted to run over
+	// its downtime budget
+	if failure, err := r.planMigration(ctx, podMigration, &srcPod); failure != nil || err != nil {
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.failWithReason(ctx, podMigration, failure.reason, failure.message)
+	}
+
+	// Keep autoscalers from removing or resizing the pod until the migration ends
+	if err := r.lockAutoscalers(ctx, podMigration, &srcPod); err != nil {
+		return ctrl.Result{}, err
+	}
+	// A StatefulSet pod keeps its ordinal name; see handOverStatefulSetPod
+	if name := statefulSetOf(&srcPod); name != "" && podMigration.Status.StatefulSet == nil {
+		podMigration.Status.StatefulSet = &lpmv1.StatefulSetHandover{Name: name}
+	}
+
+	// Restoring an existing checkpoint: bind it instead of taking a new one
+	if ref := podMigration.Spec.CheckpointRef; ref != nil {
+		var podCheckpoint lpmv1.PodCheckpoint
+		if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: ref.Name}, &podCheckpoint); err != nil {
+			if apierrors.IsNotFound(err) {
+				return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, "referenced checkpoint not found")
+			}
+			return ctrl.Result{}, err
+		}
+		if podCheckpoint.Spec.PodName == nil || *podCheckpoint.Spec.PodName != podMigration.Spec.PodName {
+			return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, "referenced checkpoint is not of the migrated pod")
+		}
+		if entry, ok := lookupGeneration(&podCheckpoint, ref.Generation); ok {
+			if message := staleCheckpointMessage(*ref, entry, policies); message != "" {
+				return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonCheckpointStale, message)
+			}
+		}
+		podMigration.Status.PodCheckpointRef = &corev1.LocalObjectReference{Name: ref.Name}
+		podMigration.Status.CheckpointStartTime = podCheckpoint.CreationTimestamp.DeepCopy()
+		setPhase(podMigration, lpmv1.MigrationPhaseCheckpointing)
+		podMigration.Status.Message = "waiting for referenced checkpoint"
+		if err := r.Status().Update(ctx, podMigration); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
 	// 4/5. Ensure PodCheckpoint exists and update status accordingly
 	checkpointName := podMigration.Name
 	var podCheckpoint lpmv1.PodCheckpoint
-	err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: checkpointName}, &podCheckpoint)
+	err = r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: checkpointName}, &podCheckpoint)
 
 	if apierrors.IsNotFound(err) {
 		// Create new checkpoint
@@ -122,12 +282,17 @@ func (r *PodMigrationReconciler) handlePendingPhase(ctx context.Context, podMigr
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      checkpointName,
 				Namespace: podMigration.Namespace,
+				Labels:    map[string]string{lpmv1.MigrationIDLabel: string(podMigration.UID)},
 				OwnerReferences: []metav1.OwnerReference{
 					*metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")),
 				},
 			},
 			Spec: lpmv1.PodCheckpointSpec{
-				PodName: &podMigration.Spec.PodName,
+				PodName:           &podMigration.Spec.PodName,
+				Scope:             podMigration.Spec.CheckpointScope,
+				ExecSessionPolicy: podMigration.Spec.ExecSessionPolicy,
+				Compression:       migrationCompression(podMigration),
+				ArtifactTransfer:  podMigration.Spec.ArtifactTransfer,
 			},
 		}
 		if err := r.Create(ctx, &podCheckpoint); err != nil {
@@ -136,7 +301,8 @@ func (r *PodMigrationReconciler) handlePendingPhase(ctx context.Context, podMigr
 		logger.Info("PodCheckpoint created from Pending phase", "name", podCheckpoint.Name)
 
 		podMigration.Status.PodCheckpointRef = &corev1.LocalObjectReference{Name: checkpointName}
-		podMigration.Status.Phase = lpmv1.MigrationPhaseCheckpointing
+		podMigration.Status.CheckpointStartTime = podCheckpoint.CreationTimestamp.DeepCopy()
+		setPhase(podMigration, lpmv1.MigrationPhaseCheckpointing)
 		podMigration.Status.Message = "checkpoint requested"
 		if err := r.Status().Update(ctx, podMigration); err != nil {
 			return ctrl.Result{}, err
@@ -151,7 +317,10 @@ func (r *PodMigrationReconciler) handlePendingPhase(ctx context.Context, podMigr
 	if podMigration.Status.PodCheckpointRef == nil {
 		podMigration.Status.PodCheckpointRef = &corev1.LocalObjectReference{Name: podCheckpoint.Name}
 	}
-	podMigration.Status.Phase = lpmv1.MigrationPhaseCheckpointing
+	if podMigration.Status.CheckpointStartTime == nil {
+		podMigration.Status.CheckpointStartTime = podCheckpoint.CreationTimestamp.DeepCopy()
+	}
+	setPhase(podMigration, lpmv1.MigrationPhaseCheckpointing)
 	podMigration.Status.Message = "checkpoint in progress"
 	if err := r.Status().Update(ctx, podMigration); err != nil {
 		return ctrl.Result{}, err
@@ -163,6 +332,10 @@ func (r *PodMigrationReconciler) handleCheckpointingPhase(ctx context.Context, p
 	logger := log.FromContext(ctx)
 	logger.Info("Handling Checkpointing phase for PodMigration", "name", podMigration.Name)
 
+	if failed, err := r.verifySourcePod(ctx, podMigration); failed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Determine PodCheckpoint name: status ref if set, else fall back to migration name
 	podCheckpointName := podMigration.Name
 	if podMigration.Status.PodCheckpointRef != nil && podMigration.Status.PodCheckpointRef.Name != "" {
@@ -172,18 +345,32 @@ func (r *PodMigrationReconciler) handleCheckpointingPhase(ctx context.Context, p
 	var podCheckpoint lpmv1.PodCheckpoint
 	err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podCheckpointName}, &podCheckpoint)
 
+	if podMigration.Spec.CheckpointRef != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, "referenced checkpoint not found")
+		} else if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.handleReferencedCheckpoint(ctx, podMigration, &podCheckpoint)
+	}
+
 	if apierrors.IsNotFound(err) {
 		// Re-create checkpoint request
 		podCheckpoint = lpmv1.PodCheckpoint{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      podCheckpointName,
 				Namespace: podMigration.Namespace,
+				Labels:    map[string]string{lpmv1.MigrationIDLabel: string(podMigration.UID)},
 				OwnerReferences: []metav1.OwnerReference{
 					*metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")),
 				},
 			},
 			Spec: lpmv1.PodCheckpointSpec{
-				PodName: &podMigration.Spec.PodName,
+				PodName:           &podMigration.Spec.PodName,
+				Scope:             podMigration.Spec.CheckpointScope,
+				ExecSessionPolicy: podMigration.Spec.ExecSessionPolicy,
+				Compression:       migrationCompression(podMigration),
+				ArtifactTransfer:  podMigration.Spec.ArtifactTransfer,
 			},
 		}
 		if err := r.Create(ctx, &podCheckpoint); err != nil {
@@ -209,7 +396,8 @@ func (r *PodMigrationReconciler) handleCheckpointingPhase(ctx context.Context, p
 	case lpmv1.PodCheckpointPhaseSucceeded:
 		// Ensure checkpoint is truly ready
 		if podCheckpoint.Status.Ready {
-			podMigration.Status.Phase = lpmv1.MigrationPhaseCheckpointComplete
+			podMigration.Status.CheckpointGeneration = podCheckpoint.Status.Generation
+			setPhase(podMigration, lpmv1.MigrationPhaseCheckpointComplete)
 			podMigration.Status.Message = "checkpoint complete"
 			if err := r.Status().Update(ctx, podMigration); err != nil {
 				return ctrl.Result{}, err
@@ -224,12 +412,46 @@ func (r *PodMigrationReconciler) handleCheckpointingPhase(ctx context.Context, p
 	return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
 }
 
+// handleReferencedCheckpoint waits for the generation selected by
+// spec.checkpointRef to be available and pins it in the status.
+func (r *PodMigrationReconciler) handleReferencedCheckpoint(ctx context.Context, podMigration *lpmv1.PodMigration, podCheckpoint *lpmv1.PodCheckpoint) (ctrl.Result, error) {
+	ref := podMigration.Spec.CheckpointRef
+
+	if entry, ok := lookupGeneration(podCheckpoint, ref.Generation); ok {
+		podMigration.Status.CheckpointGeneration = entry.Generation
+		setPhase(podMigration, lpmv1.MigrationPhaseCheckpointComplete)
+		podMigration.Status.Message = fmt.Sprintf("using checkpoint %s generation %d", podCheckpoint.Name, entry.Generation)
+		return ctrl.Result{}, r.Status().Update(ctx, podMigration)
+	}
+
+	switch podCheckpoint.Status.Phase {
+	case lpmv1.PodCheckpointPhaseSucceeded, lpmv1.PodCheckpointPhaseFailed:
+		if ref.Generation != nil {
+			return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed,
+				fmt.Sprintf("checkpoint %s has no retained generation %d", podCheckpoint.Name, *ref.Generation))
+		}
+		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed,
+			fmt.Sprintf("checkpoint %s has no completed generation", podCheckpoint.Name))
+	}
+
+	// The first generation is still being taken
+	return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+}
+
 func (r *PodMigrationReconciler) handleCheckpointCompletePhase(ctx context.Context, podMigration *lpmv1.PodMigration) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling CheckpointComplete phase for PodMigration", "name", podMigration.Name)
 
+	if failed, err := r.verifySourcePod(ctx, podMigration); failed || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if approved, err := r.awaitApproval(ctx, podMigration, lpmv1.ApprovalStageBeforeRestore); !approved || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Move to preparing images phase
-	podMigration.Status.Phase = lpmv1.MigrationPhasePreparingImages
+	setPhase(podMigration, lpmv1.MigrationPhasePreparingImages)
 	podMigration.Status.Message = "preparing checkpoint images"
 	if err := r.Status().Update(ctx, podMigration); err != nil {
 		return ctrl.Result{}, err
@@ -242,6 +464,10 @@ func (r *PodMigrationReconciler) handlePreparingImagesPhase(ctx context.Context,
 	logger := log.FromContext(ctx)
 	logger.Info("Handling PreparingImages phase for PodMigration", "name", podMigration.Name)
 
+	if failed, err := r.verifySourcePod(ctx, podMigration); failed || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Get checkpoint content to find container checkpoints
 	checkpointContent, err := r.getCheckpointContent(ctx, podMigration)
 	if err != nil {
@@ -270,19 +496,38 @@ func (r *PodMigrationReconciler) handlePreparingImagesPhase(ctx context.Context,
 			continue
 		}
 
-		// Get checkpoint path for this container
-		checkpointPath := r.getCheckpointPathForContainer(ctx, checkpointContent, container.Name)
-		if checkpointPath == "" {
+		// Get checkpoint content for this container
+		content := r.getContainerContent(ctx, checkpointContent, container.Name)
+		if content == nil || content.Spec.ArtifactURI == "" {
 			return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, fmt.Sprintf("no checkpoint found for container %s", container.Name))
 		}
 
+		// Check that the checkpoint can run on top of the new image first
+		var rebase *lpmv1.ContainerRebaseStatus
+		if spec := rebaseOf(podMigration, container.Name); spec != nil {
+			var failure *preflightFailure
+			rebase, failure, err = r.checkRebase(ctx, podMigration, content, spec)
+			if err != nil {
+				logger.Error(err, "Failed to check rebase of checkpoint", "container", container.Name)
+				imagesReady = false
+				continue
+			}
+			if failure != nil {
+				return ctrl.Result{}, r.failWithReason(ctx, podMigration, failure.reason, failure.message)
+			}
+		}
+
 		// Convert to OCI image
-		checkpointImage, err := r.convertToOCIImage(ctx, checkpointPath, container.Name, podMigration.Spec.TargetNode)
+		// A retried restore may have failed on a corrupted archive
+		checkpointImage, err := r.convertToOCIImage(ctx, content, podMigration.Spec.TargetNode, podMigration.Status.RestoreRetries > 0, rebase)
 		if err != nil {
 			logger.Error(err, "Failed to convert checkpoint to OCI image", "container", container.Name)
 			imagesReady = false
 			continue
 		}
+		if err := r.recordImageReference(ctx, content, checkpointImage); err != nil {
+			logger.Error(err, "Failed to record checkpoint image on content", "content", content.Name)
+		}
 
 		// Store the image reference
 		podMigration.Status.CheckpointImages[container.Name] = checkpointImage
@@ -296,7 +541,7 @@ func (r *PodMigrationReconciler) handlePreparingImagesPhase(ctx context.Context,
 
 	// If all images are ready, move to restoring phase
 	if imagesReady && len(podMigration.Status.CheckpointImages) == len(originalPod.Spec.Containers) {
-		podMigration.Status.Phase = lpmv1.MigrationPhaseRestoring
+		setPhase(podMigration, lpmv1.MigrationPhaseRestoring)
 		podMigration.Status.Message = "checkpoint images ready, creating restored pod"
 		if err := r.Status().Update(ctx, podMigration); err != nil {
 			return ctrl.Result{}, err
@@ -314,15 +559,50 @@ func (r *PodMigrationReconciler) handleRestoringPhase(ctx context.Context, podMi
 
 	// Create restored pod if not already created
 	if podMigration.Status.RestoredPodName == "" {
+		if failed, err := r.verifySourcePod(ctx, podMigration); failed || err != nil {
+			return ctrl.Result{}, err
+		}
+		if podMigration.Status.StatefulSet != nil {
+			free, err := r.handOverStatefulSetPod(ctx, podMigration)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !free {
+				return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+			}
+		}
+
 		restoredPod, err := r.createRestoredPod(ctx, podMigration)
 		if err != nil {
 			return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, fmt.Sprintf("failed to create restored pod: %v", err))
 		}
+		wait, notFit, err := r.makeRoomOnTarget(ctx, podMigration, restoredPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if notFit != "" {
+			return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonTargetDoesNotFit, "target node cannot run the pod: "+notFit)
+		}
+		if wait {
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
 
 		err = r.Create(ctx, restoredPod)
 		if err != nil {
 			if apierrors.IsAlreadyExists(err) {
+				// The pod of a retried restore may still be terminating
+				var existing corev1.Pod
+				if err := r.Get(ctx, client.ObjectKeyFromObject(restoredPod), &existing); err != nil {
+					return ctrl.Result{}, client.IgnoreNotFound(err)
+				}
+				if !existing.DeletionTimestamp.IsZero() {
+					logger.Info("Waiting for the previous restored pod to be deleted", "pod", restoredPod.Name)
+					return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+				}
 				logger.Info("Restored pod already exists", "pod", restoredPod.Name)
+			} else if failure := admissionFailure(err); failure != nil {
+				// The namespace changed since preflight
+				return ctrl.Result{}, r.failWithReason(ctx, podMigration, failure.reason, "restored pod was rejected: "+failure.message)
 			} else {
 				return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, fmt.Sprintf("failed to create restored pod: %v", err))
 			}
@@ -356,16 +636,167 @@ func (r *PodMigrationReconciler) handleRestoringPhase(ctx context.Context, podMi
 	// Check pod status
 	switch restoredPod.Status.Phase {
 	case corev1.PodRunning:
-		// Delete original pod after successful restoration
-		if err := r.deleteOriginalPod(ctx, podMigration); err != nil {
-			logger.Error(err, "Failed to delete original pod, but migration succeeded")
+		// Everything was verified before the StatefulSet was recreated
+		if handover := podMigration.Status.StatefulSet; handover != nil && handover.Manifest != nil && !handover.Orphaned {
+			return r.finishMigration(ctx, podMigration, &restoredPod)
+		}
+
+		// Containers restarted by spec.identityRefresh no longer run from the
+		// checkpoint; the restore was verified before they were restarted.
+		if restoredPod.Annotations[identityRefreshedAnnotation] == "true" {
+			return r.finishMigration(ctx, podMigration, &restoredPod)
 		}
-		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseSucceeded, "pod successfully restored and running")
+
+		// Make sure the runtime actually restored the checkpoint; a wrong
+		// checkpoint path can make it silently boot the image instead.
+		restored, message, err := r.verifyRestore(ctx, &restoredPod)
+		verification := metav1.Condition{
+			Type:   lpmv1.MigrationConditionRestoreVerified,
+			Status: metav1.ConditionTrue,
+			Reason: lpmv1.MigrationReasonRestoreVerified,
+		}
+		if errors.Is(err, errRestoreUnverifiable) {
+			logger.Info("Agent does not support restore verification, skipping", "node", restoredPod.Spec.NodeName)
+			restored, message, err = true, err.Error(), nil
+			verification.Status, verification.Reason = metav1.ConditionUnknown, lpmv1.MigrationReasonRestoreUnverified
+		}
+		if err != nil {
+			logger.Info("Unable to verify restore yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if !restored {
+			meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+				Type:    lpmv1.MigrationConditionRestoreVerified,
+				Status:  metav1.ConditionFalse,
+				Reason:  lpmv1.MigrationReasonRestoreNotApplied,
+				Message: message,
+			})
+			// The freshly booted copy has none of the source's state; remove it
+			// so it doesn't serve traffic next to the still-running source pod.
+			if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete restored pod that was not restored from checkpoint", "pod", restoredPod.Name)
+			}
+			return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonRestoreNotApplied,
+				"restored pod was started without applying the checkpoint: "+message)
+		}
+		if source := podMigration.Status.SourceUserNamespace; source != nil {
+			ok, message, err := r.verifyUserNamespace(ctx, source, &restoredPod)
+			if err != nil {
+				logger.Info("Unable to verify user namespace yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+			if !ok {
+				if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+					logger.Error(err, "Failed to delete restored pod with mismatched user namespace", "pod", restoredPod.Name)
+				}
+				return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonUserNamespaceMismatch,
+					"restored pod's user namespace does not match the source: "+message)
+			}
+		}
+		verification.Message = message
+		meta.SetStatusCondition(&podMigration.Status.Conditions, verification)
+		if err := r.markRestoreComplete(ctx, &restoredPod, message); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// A restored pod with broken CNI or policy plumbing runs but is
+		// unreachable; don't give up the source pod for it.
+		network, err := r.verifyNetwork(ctx, podMigration, &restoredPod)
+		if err != nil {
+			logger.Info("Unable to verify network yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if network.reason != "" {
+			if !network.final && !networkCheckTimedOut(podMigration, &restoredPod) {
+				logger.Info("Restored pod failed network check, retrying", "pod", restoredPod.Name, "message", network.message)
+				podMigration.Status.Message = "waiting for network check: " + network.message
+				if err := r.Status().Update(ctx, podMigration); err != nil {
+					return ctrl.Result{}, err
+				}
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+			meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+				Type:    lpmv1.MigrationConditionNetworkVerified,
+				Status:  metav1.ConditionFalse,
+				Reason:  network.reason,
+				Message: network.message,
+			})
+			if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete restored pod that failed network verification", "pod", restoredPod.Name)
+			}
+			return ctrl.Result{}, r.failWithReason(ctx, podMigration, network.reason,
+				"restored pod failed network verification: "+network.message)
+		}
+		meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+			Type:    lpmv1.MigrationConditionNetworkVerified,
+			Status:  metav1.ConditionTrue,
+			Reason:  lpmv1.MigrationReasonNetworkVerified,
+			Message: network.message,
+		})
+
+		// The source pod's tokens are invalidated when it is deleted; the
+		// restored processes must be able to reach the API server with the
+		// tokens the kubelet projected into the restored pod.
+		tokenStatus, message, err := r.verifyServiceAccountToken(ctx, &restoredPod)
+		if err != nil {
+			logger.Info("Unable to verify service account token yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		tokenCondition := metav1.Condition{
+			Type:    lpmv1.MigrationConditionServiceAccountTokenVerified,
+			Status:  tokenStatus,
+			Reason:  lpmv1.MigrationReasonServiceAccountTokenVerified,
+			Message: message,
+		}
+		switch tokenStatus {
+		case metav1.ConditionFalse:
+			tokenCondition.Reason = lpmv1.MigrationReasonServiceAccountTokenInvalid
+			meta.SetStatusCondition(&podMigration.Status.Conditions, tokenCondition)
+			if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete restored pod with invalid service account token", "pod", restoredPod.Name)
+			}
+			return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonServiceAccountTokenInvalid,
+				"restored pod cannot access the API server: "+message)
+		case metav1.ConditionUnknown:
+			tokenCondition.Reason = lpmv1.MigrationReasonServiceAccountTokenNotVerified
+		}
+		meta.SetStatusCondition(&podMigration.Status.Conditions, tokenCondition)
+
+		if podMigration.Spec.IdentityRefresh != nil {
+			message, err := r.refreshIdentity(ctx, podMigration, &restoredPod)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+				Type:    lpmv1.MigrationConditionIdentityRefreshed,
+				Status:  metav1.ConditionTrue,
+				Reason:  lpmv1.MigrationReasonIdentityRefreshed,
+				Message: message,
+			})
+		}
+
+		return r.finishMigration(ctx, podMigration, &restoredPod)
 
 	case corev1.PodFailed:
+		if cause := restoreArtifactError(&restoredPod); cause != "" {
+			return ctrl.Result{}, r.retryRestore(ctx, podMigration, &restoredPod, cause)
+		}
 		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, "restored pod failed to start")
 
 	case corev1.PodPending:
+		if cause := restoreArtifactError(&restoredPod); cause != "" {
+			return ctrl.Result{}, r.retryRestore(ctx, podMigration, &restoredPod, cause)
+		}
+		if waitingForVolumes(&restoredPod) {
+			if err := r.restoreMemoryVolumes(ctx, podMigration, &restoredPod); err != nil {
+				logger.Info("Unable to restore memory volumes yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+				podMigration.Status.Message = "waiting to restore memory volumes: " + err.Error()
+				if err := r.Status().Update(ctx, podMigration); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
 		logger.Info("Restored pod is pending", "pod", restoredPod.Name, "reason", restoredPod.Status.Reason)
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 
@@ -375,48 +806,136 @@ func (r *PodMigrationReconciler) handleRestoringPhase(ctx context.Context, podMi
 	}
 }
 
+// finishMigration completes a migration whose restored pod was verified,
+// first handing a StatefulSet or Job pod back to its controller, and a
+// ReplicaSet pod when spec.replicaSetAdoption asks for it.
+func (r *PodMigrationReconciler) finishMigration(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (ctrl.Result, error) {
+	if aborted, err := r.enforceMaxDowntime(ctx, podMigration); aborted || err != nil {
+		return ctrl.Result{}, err
+	}
+	if podMigration.Status.StatefulSet != nil {
+		adopted, err := r.adoptRestoredPod(ctx, podMigration, restoredPod)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !adopted {
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+	handedOver, err := r.handOverToReplicaSet(ctx, podMigration, restoredPod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !handedOver {
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if err := r.reparentJobPod(ctx, podMigration, restoredPod); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.completeMigration(ctx, podMigration)
+}
+
+// completeMigration deletes the source pod once the restored pod is verified
+// and marks the migration succeeded.
+func (r *PodMigrationReconciler) completeMigration(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	logger := log.FromContext(ctx)
+
+	// Delete original pod after successful restoration
+	if err := r.deleteOriginalPod(ctx, podMigration); err != nil {
+		logger.Error(err, "Failed to delete original pod, but migration succeeded")
+	}
+	return r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseSucceeded, "pod successfully restored and running")
+}
+
 func (r *PodMigrationReconciler) handleCompletedOrFailedPhase(ctx context.Context, podMigration *lpmv1.PodMigration) (ctrl.Result, error) {
-	// Logic to handle the Succeeded or Failed phase
-	// No further action needed for completed migrations
+	// A failed StatefulSet handover leaves the StatefulSet deleted
+	if err := r.recreateStatefulSet(ctx, podMigration); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Record how the migration went
+	if podMigration.Status.ReportConfigMap == "" {
+		if err := r.writeReport(ctx, podMigration); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	// The source pod's migration lock and autoscaler locks only last while
+	// the migration is in flight
+	if err := r.unlockSourcePod(ctx, podMigration); err != nil {
+		return ctrl.Result{}, err
+	}
+	if controllerutil.ContainsFinalizer(podMigration, autoscalerLockFinalizer) {
+		return ctrl.Result{}, r.releaseAutoscalers(ctx, podMigration)
+	}
 	return ctrl.Result{}, nil
 }
 
 func (r *PodMigrationReconciler) updatePhase(ctx context.Context, podMigration *lpmv1.PodMigration, phase lpmv1.PodMigrationPhase, message string) error {
-	podMigration.Status.Phase = phase
+	setPhase(podMigration, phase)
 	podMigration.Status.Message = message
 	return r.Status().Update(ctx, podMigration)
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *PodMigrationReconciler) createRestoredPod(ctx context.Context, podMigration *lpmv1.PodMigration) (*corev1.Pod, error) {
-	var originalPod corev1.Pod
-	err := r.Get(ctx, client.ObjectKey{
-		Namespace: podMigration.Namespace,
-		Name:      podMigration.Spec.PodName,
-	}, &originalPod)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get original pod: %w", err)
+// setPhase moves the migration to phase, recording when it did.
+func setPhase(podMigration *lpmv1.PodMigration, phase lpmv1.PodMigrationPhase) {
+	if podMigration.Status.Phase == phase {
+		return
 	}
+	podMigration.Status.Phase = phase
+	podMigration.Status.PhaseTransitions = append(podMigration.Status.PhaseTransitions,
+		lpmv1.PhaseTransition{Phase: phase, Time: metav1.Now()})
+}
 
-	// START WITH THE ORIGINAL POD - preserve all runtime context
-	restoredPod := originalPod.DeepCopy()
+// failWithReason moves the migration to Failed with a machine-readable reason.
+func (r *PodMigrationReconciler) failWithReason(ctx context.Context, podMigration *lpmv1.PodMigration, reason, message string) error {
+	podMigration.Status.Reason = reason
+	return r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, message)
+}
 
-	// Change only what's absolutely necessary
-	restoredPod.ObjectMeta.Name = fmt.Sprintf("%s-restored", originalPod.Name)
-	restoredPod.ObjectMeta.ResourceVersion = ""  // Required for creation
-	restoredPod.ObjectMeta.UID = ""              // Required for creation
-	restoredPod.Spec.NodeName = podMigration.Spec.TargetNode // Target node
+// errRestoreUnverifiable is returned by verifyRestore when the agent on the
+// restored pod's node predates restore verification.
+var errRestoreUnverifiable = errors.New("restore not verified: the node's agent does not support restore verification")
+
+// verifyRestore asks the agent on the restored pod's node whether every
+// container was restored from its checkpoint. An error means the verdict is
+// not available yet (e.g. container IDs not reported, agent unreachable or
+// unable to read the container's state), or, if it is
+// errRestoreUnverifiable, never will be.
+func (r *PodMigrationReconciler) verifyRestore(ctx context.Context, restoredPod *corev1.Pod) (bool, string, error) {
+	for _, container := range restoredPod.Spec.Containers {
+		var containerID string
+		for _, status := range restoredPod.Status.ContainerStatuses {
+			if status.Name == container.Name {
+				containerID = status.ContainerID
+				break
+			}
+		}
+		if containerID == "" {
+			return false, "", fmt.Errorf("container %s has no container ID yet", container.Name)
+		}
 
-	// Add migration tracking annotations
-	if restoredPod.ObjectMeta.Annotations == nil {
-		restoredPod.ObjectMeta.Annotations = make(map[string]string)
+		restored, message, err := r.AgentClient.VerifyRestore(ctx, restoredPod.Spec.NodeName, containerID)
+		if status.Code(err) == codes.Unimplemented {
+			return false, "", errRestoreUnverifiable
+		}
+		if err != nil {
+			return false, "", err
+		}
+		if !restored {
+			return false, fmt.Sprintf("container %s: %s", container.Name, message), nil
+		}
 	}
-	restoredPod.ObjectMeta.Annotations["migration.source-pod"] = originalPod.Name
-	restoredPod.ObjectMeta.Annotations["migration.target-node"] = podMigration.Spec.TargetNode
+	return true, "all containers restored from checkpoint", nil
+}
 
-	// Set owner reference
-	restoredPod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
-		*metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")),
+func (r *PodMigrationReconciler) createRestoredPod(ctx context.Context, podMigration *lpmv1.PodMigration) (*corev1.Pod, error) {
+	originalPod, err := r.getSourcePod(ctx, podMigration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original pod: %w", err)
+	}
+
+	restoredPod := newRestoredPod(podMigration, originalPod)
+	if err := r.tolerateTargetTaints(ctx, podMigration, restoredPod); err != nil {
+		return nil, err
 	}
 
 	// Apply checkpoint images to containers (existing logic)
@@ -431,12 +950,66 @@ func (r *PodMigrationReconciler) createRestoredPod(ctx context.Context, podMigra
 		}
 
 		restoredPod.Spec.Containers[i].Image = checkpointImage
-		restoredPod.Spec.Containers[i].ImagePullPolicy = corev1.PullNever
+	}
+	if err := r.setCheckpointPullPolicies(ctx, restoredPod); err != nil {
+		return nil, err
+	}
+
+	if err := r.addVolumeRestore(ctx, podMigration, restoredPod); err != nil {
+		return nil, err
+	}
+	if err := r.configureRestoreCriu(ctx, podMigration); err != nil {
+		return nil, err
 	}
 
 	return restoredPod, nil
 }
 
+// newRestoredPod returns the pod that replaces originalPod on the target
+// node, still running the original images.
+func newRestoredPod(podMigration *lpmv1.PodMigration, originalPod *corev1.Pod) *corev1.Pod {
+	// START WITH THE ORIGINAL POD - preserve all runtime context
+	restoredPod := originalPod.DeepCopy()
+
+	// Change only what's absolutely necessary
+	restoredPod.ObjectMeta.Name = fmt.Sprintf("%s-restored", originalPod.Name)
+	if podMigration.Status.StatefulSet != nil {
+		// The StatefulSet adopts it under the source pod's ordinal name
+		restoredPod.ObjectMeta.Name = originalPod.Name
+	}
+	restoredPod.ObjectMeta.ResourceVersion = ""              // Required for creation
+	restoredPod.ObjectMeta.UID = ""                          // Required for creation
+	restoredPod.Spec.NodeName = podMigration.Spec.TargetNode // Target node
+	applyOverrides(restoredPod, podMigration.Spec.RestoredPodOverrides)
+
+	// Add migration tracking annotations
+	if restoredPod.ObjectMeta.Annotations == nil {
+		restoredPod.ObjectMeta.Annotations = make(map[string]string)
+	}
+	restoredPod.ObjectMeta.Annotations[sourcePodAnnotation] = originalPod.Name
+	restoredPod.ObjectMeta.Annotations["migration.target-node"] = podMigration.Spec.TargetNode
+	// Multus reports the restored pod's own attachments; the requested
+	// networks annotation is kept so the same networks are attached.
+	delete(restoredPod.ObjectMeta.Annotations, networkStatusAnnotation)
+	delete(restoredPod.ObjectMeta.Annotations, legacyNetworkStatusAnnotation)
+	// The restored pod is free to be migrated once the migration ended
+	delete(restoredPod.ObjectMeta.Annotations, lpmv1.MigrationLockAnnotation)
+	// Only a pod the Job owns may carry its finalizer; see reparentJobPod
+	controllerutil.RemoveFinalizer(restoredPod, batchv1.JobTrackingFinalizer)
+
+	guardRestoredPodProbes(restoredPod, podMigration.Spec.RestoreStartupProbe)
+	pinSELinuxLevel(podMigration, restoredPod)
+	// Normally inherited from the source pod; released at a terminal phase
+	addProtection(restoredPod, podScaleDownProtection)
+
+	// Set owner reference
+	restoredPod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")),
+	}
+
+	return restoredPod
+}
+
 func (r *PodMigrationReconciler) getCheckpointContent(ctx context.Context, podMigration *lpmv1.PodMigration) (*lpmv1.PodCheckpointContent, error) {
 	if podMigration.Status.PodCheckpointRef == nil {
 		return nil, fmt.Errorf("no checkpoint reference in migration status")
@@ -453,14 +1026,24 @@ func (r *PodMigrationReconciler) getCheckpointContent(ctx context.Context, podMi
 		return nil, fmt.Errorf("failed to get pod checkpoint: %w", err)
 	}
 
-	if podCheckpoint.Status.BoundContentName == "" {
+	// Restore the pinned generation; checkpoints from before generations were
+	// tracked only have the bound content.
+	contentName := podCheckpoint.Status.BoundContentName
+	if generation := podMigration.Status.CheckpointGeneration; generation != 0 {
+		entry, ok := lookupGeneration(&podCheckpoint, &generation)
+		if !ok {
+			return nil, fmt.Errorf("checkpoint generation %d is no longer retained", generation)
+		}
+		contentName = entry.ContentName
+	}
+	if contentName == "" {
 		return nil, fmt.Errorf("checkpoint has no bound content")
 	}
 
 	var checkpointContent lpmv1.PodCheckpointContent
 	err = r.Get(ctx, client.ObjectKey{
 		Namespace: podMigration.Namespace,
-		Name:      podCheckpoint.Status.BoundContentName,
+		Name:      contentName,
 	}, &checkpointContent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get checkpoint content: %w", err)
@@ -469,7 +1052,7 @@ func (r *PodMigrationReconciler) getCheckpointContent(ctx context.Context, podMi
 	return &checkpointContent, nil
 }
 
-func (r *PodMigrationReconciler) getCheckpointPathForContainer(ctx context.Context, checkpointContent *lpmv1.PodCheckpointContent, containerName string) string {
+func (r *PodMigrationReconciler) getContainerContent(ctx context.Context, checkpointContent *lpmv1.PodCheckpointContent, containerName string) *lpmv1.ContainerCheckpointContent {
 	for _, containerContent := range checkpointContent.Spec.ContainerContents {
 		var content lpmv1.ContainerCheckpointContent
 		err := r.Get(ctx, client.ObjectKey{
@@ -480,24 +1063,52 @@ func (r *PodMigrationReconciler) getCheckpointPathForContainer(ctx context.Conte
 			continue
 		}
 
-		if strings.Contains(content.Name, containerName) {
-			return content.Spec.ArtifactURI
+		if content.Spec.ContainerName == containerName {
+			return &content
 		}
 	}
-	return ""
+	return nil
 }
 
-func (r *PodMigrationReconciler) convertToOCIImage(ctx context.Context, checkpointURI, containerName, targetNode string) (string, error) {
+// convertToOCIImage makes content's checkpoint available to the target node
+// as an image. With verifyDigest set the archive is checked against its
+// recorded digest before it is converted. A non-nil rebase rebuilds the
+// image on top of the rebase image.
+func (r *PodMigrationReconciler) convertToOCIImage(ctx context.Context, content *lpmv1.ContainerCheckpointContent, targetNode string, verifyDigest bool, rebase *lpmv1.ContainerRebaseStatus) (string, error) {
+	opts := agent.ConvertOptions{}
+	if verifyDigest {
+		opts.ArtifactDigest = content.Spec.ArtifactDigest
+	}
+	if rebase != nil {
+		opts.BaseImage = rebase.Image
+		opts.BaseImageID = rebase.ImageID
+	}
+	checkpointURI := content.Spec.ArtifactURI
+	if strings.HasPrefix(checkpointURI, "file://") && r.Registry != nil && content.Spec.ArtifactTransfer != lpmv1.CheckpointTransferDirect {
+		return r.transferThroughRegistry(ctx, content, targetNode, opts)
+	}
+	streaming, err := r.needsStreaming(ctx, content, targetNode)
+	if err != nil {
+		return "", err
+	}
+	if streaming {
+		return r.transferByStreaming(ctx, content, targetNode, opts, rebase)
+	}
 	if !strings.HasPrefix(checkpointURI, "shared://") {
 		return checkpointURI, nil
 	}
 
 	// Generate OCI image name
-	filename := strings.TrimPrefix(checkpointURI, "shared://")
-	imageName := fmt.Sprintf("localhost/checkpoint:%s", strings.TrimSuffix(filename, ".tar"))
+	imageName, err := r.checkpointImageName(content)
+	if err != nil {
+		return "", err
+	}
+	if rebase != nil {
+		imageName = rebasedImageName(imageName, rebase)
+	}
 
 	// Use agent to convert checkpoint to OCI image
-	imageRef, err := r.AgentClient.ConvertCheckpointToImage(ctx, targetNode, checkpointURI, containerName, imageName)
+	imageRef, err := r.AgentClient.ConvertCheckpointToImage(ctx, targetNode, checkpointURI, content.Spec.ContainerName, imageName, opts)
 	if err != nil {
 		return "", fmt.Errorf("failed to convert checkpoint to OCI image: %w", err)
 	}
@@ -506,6 +1117,10 @@ func (r *PodMigrationReconciler) convertToOCIImage(ctx context.Context, checkpoi
 }
 
 func (r *PodMigrationReconciler) deleteOriginalPod(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	// A StatefulSet pod's name now belongs to the restored pod
+	if podMigration.Status.RestoredPodName == podMigration.Spec.PodName {
+		return nil
+	}
 	var originalPod corev1.Pod
 	err := r.Get(ctx, client.ObjectKey{
 		Namespace: podMigration.Namespace,
@@ -519,14 +1134,14 @@ func (r *PodMigrationReconciler) deleteOriginalPod(ctx context.Context, podMigra
 		return fmt.Errorf("failed to get original pod for deletion: %w", err)
 	}
 
-	err = r.Delete(ctx, &originalPod)
-	if err != nil {
+	if err := r.deleteSourcePod(ctx, podMigration, &originalPod); err != nil {
 		return fmt.Errorf("failed to delete original pod: %w", err)
 	}
 
 	return nil
 }
 
+// SetupWithManager sets up the controller with the Manager.
 func (r *PodMigrationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&lpmv1.PodMigration{}).
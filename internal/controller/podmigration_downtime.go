@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// downtimeExceeded describes how downtime runs over spec.maxDowntimeMs, or
+// returns "" if it does not or no budget is set.
+func downtimeExceeded(podMigration *lpmv1.PodMigration, kind string, downtime time.Duration) string {
+	if podMigration.Spec.MaxDowntimeMs == nil {
+		return ""
+	}
+	budget := time.Duration(*podMigration.Spec.MaxDowntimeMs) * time.Millisecond
+	if downtime <= budget {
+		return ""
+	}
+	return fmt.Sprintf("%s downtime %s exceeds maxDowntimeMs %d", kind, downtime.Round(time.Millisecond), *podMigration.Spec.MaxDowntimeMs)
+}
+
+// predictDowntime records the downtime of a checkpoint of the pod estimate
+// measured in status.predictedDowntime.
+func predictDowntime(ctx context.Context, podMigration *lpmv1.PodMigration, estimate *pb.EstimateMigrationResponse) {
+	var checkpointBytes int64
+	for _, container := range estimate.Containers {
+		checkpointBytes += container.MemoryBytes
+	}
+	downtime := checkpointDowntime(checkpointBytes, estimate.StorageWriteBytesPerSecond, estimate.StorageReadBytesPerSecond)
+	podMigration.Status.PredictedDowntime = &metav1.Duration{Duration: downtime.Round(time.Millisecond)}
+	log.FromContext(ctx).Info("Predicted downtime", "downtime", podMigration.Status.PredictedDowntime.Duration,
+		"maxDowntimeMs", *podMigration.Spec.MaxDowntimeMs)
+}
+
+// enforceMaxDowntime aborts a migration that ran over spec.maxDowntimeMs
+// since its checkpoint, before the cutover: the restored pod is deleted and
+// the source pod keeps running. A StatefulSet pod already handed over has
+// no source pod left to keep, so its migration carries on.
+func (r *PodMigrationReconciler) enforceMaxDowntime(ctx context.Context, podMigration *lpmv1.PodMigration) (bool, error) {
+	switch podMigration.Status.Phase {
+	case lpmv1.MigrationPhaseCheckpointComplete, lpmv1.MigrationPhasePreparingImages, lpmv1.MigrationPhaseRestoring:
+	default:
+		return false, nil
+	}
+	if podMigration.Status.CheckpointStartTime == nil {
+		return false, nil
+	}
+	if handover := podMigration.Status.StatefulSet; handover != nil && handover.SourcePod != nil {
+		return false, nil
+	}
+	message := downtimeExceeded(podMigration, "measured", time.Since(podMigration.Status.CheckpointStartTime.Time))
+	if message == "" {
+		return false, nil
+	}
+
+	if name := podMigration.Status.RestoredPodName; name != "" {
+		restoredPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: podMigration.Namespace, Name: name}}
+		if err := r.Delete(ctx, restoredPod); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	log.FromContext(ctx).Info("Aborting migration over its downtime budget", "message", message)
+	return true, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonSLOExceeded,
+		message+"; aborted before the cutover, the source pod keeps running")
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// preflightHostNetwork rejects host network pods unless
+// spec.hostNetworkPolicy is BestEffort. Their sockets are then examined by
+// preflightContainers.
+func (r *PodMigrationReconciler) preflightHostNetwork(_ context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if !srcPod.Spec.HostNetwork || podMigration.Spec.HostNetworkPolicy == lpmv1.HostNetworkPolicyBestEffort {
+		return nil, nil
+	}
+	return &preflightFailure{
+		reason: lpmv1.MigrationReasonHostNetworkUnsupported,
+		message: fmt.Sprintf("pod uses the network namespace of node %s; set hostNetworkPolicy to BestEffort to migrate it without its network state",
+			srcPod.Spec.NodeName),
+	}, nil
+}
+
+// hostSocketFindings sorts the sockets of a host network container by what
+// happens to them on the target node. Sockets bound to a specific address
+// cannot be rebound there, since the address belongs to the source node, and
+// are returned as unsupported. Listening sockets on a wildcard or loopback
+// address are rebound and need their port free on the target node, and
+// connections are closed; both are recorded as warnings.
+func hostSocketFindings(podMigration *lpmv1.PodMigration, srcPod *corev1.Pod, container string, sockets []*pb.HostSocket) []string {
+	var unsupported []string
+	for _, socket := range sockets {
+		if socket.RemoteAddress != "" {
+			addWarning(podMigration, fmt.Sprintf("container %s: %s connection %s -> %s is closed by the migration",
+				container, socket.Protocol, socket.LocalAddress, socket.RemoteAddress))
+			continue
+		}
+		host, port, err := net.SplitHostPort(socket.LocalAddress)
+		if err != nil {
+			continue
+		}
+		ip := net.ParseIP(host)
+		if ip != nil && !ip.IsUnspecified() && !ip.IsLoopback() {
+			unsupported = append(unsupported, fmt.Sprintf("container %s: %s socket bound to %s, an address of node %s",
+				container, socket.Protocol, socket.LocalAddress, srcPod.Spec.NodeName))
+			continue
+		}
+		if socket.Listening {
+			addWarning(podMigration, fmt.Sprintf("container %s listens on %s port %s, which must be free on the target node",
+				container, socket.Protocol, port))
+		}
+	}
+	return unsupported
+}
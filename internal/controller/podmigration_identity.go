@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+
+// identityRefreshedAnnotation marks a restored pod whose containers were
+// restarted by spec.identityRefresh, so its restore is not verified again.
+const identityRefreshedAnnotation = "migration.identity-refreshed"
+
+// changedIdentityFields are the downward API fields whose value differs
+// between the source and the restored pod. spec.nodeName is added when the
+// pod changes node.
+var changedIdentityFields = []string{
+	"metadata.name", "metadata.uid", "metadata.annotations",
+	"status.podIP", "status.podIPs", "status.hostIP", "status.hostIPs",
+}
+
+// staleIdentityFields lists, per container, the downward API values the
+// checkpointed processes read before the migration that the restored pod
+// changes. Environment variables keep the source pod's value; downward API
+// files are rewritten by the kubelet, but a process that read them before
+// the checkpoint keeps what it read.
+func staleIdentityFields(pod *corev1.Pod, targetNode string) map[string][]string {
+	changed := slices.Clone(changedIdentityFields)
+	if targetNode != pod.Spec.NodeName {
+		changed = append(changed, "spec.nodeName")
+	}
+	isChanged := func(fieldPath string) bool {
+		// metadata.annotations['key'] selects one annotation
+		fieldPath, _, _ = strings.Cut(fieldPath, "[")
+		return slices.Contains(changed, fieldPath)
+	}
+
+	downwardVolumes := map[string]*corev1.DownwardAPIVolumeSource{}
+	for i := range pod.Spec.Volumes {
+		if volume := pod.Spec.Volumes[i]; volume.DownwardAPI != nil {
+			downwardVolumes[volume.Name] = volume.DownwardAPI
+		}
+	}
+
+	stale := map[string][]string{}
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil && isChanged(env.ValueFrom.FieldRef.FieldPath) {
+				stale[container.Name] = append(stale[container.Name],
+					fmt.Sprintf("env %s (%s)", env.Name, env.ValueFrom.FieldRef.FieldPath))
+			}
+		}
+		for _, mount := range container.VolumeMounts {
+			volume, ok := downwardVolumes[mount.Name]
+			if !ok {
+				continue
+			}
+			for _, item := range volume.Items {
+				if item.FieldRef != nil && isChanged(item.FieldRef.FieldPath) {
+					stale[container.Name] = append(stale[container.Name],
+						fmt.Sprintf("file %s/%s (%s)", mount.MountPath, item.Path, item.FieldRef.FieldPath))
+				}
+			}
+		}
+	}
+	return stale
+}
+
+// preflightIdentity warns about downward API values that go stale in the
+// restored processes, unless spec.identityRefresh restarts their container,
+// and checks that containers to restart can start from their original
+// image on the target node.
+func (r *PodMigrationReconciler) preflightIdentity(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	var restart []string
+	if refresh := podMigration.Spec.IdentityRefresh; refresh != nil {
+		restart = refresh.RestartContainers
+	}
+
+	stale := staleIdentityFields(srcPod, podMigration.Spec.TargetNode)
+	for _, container := range srcPod.Spec.Containers {
+		if fields := stale[container.Name]; len(fields) > 0 && !slices.Contains(restart, container.Name) {
+			addWarning(podMigration, fmt.Sprintf("container %s keeps the source pod's %s", container.Name, strings.Join(fields, ", ")))
+		}
+	}
+
+	if len(restart) == 0 || podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		return nil, err
+	}
+	for _, name := range restart {
+		i := slices.IndexFunc(srcPod.Spec.Containers, func(c corev1.Container) bool { return c.Name == name })
+		if i < 0 {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("identityRefresh.restartContainers names unknown container %s", name),
+			}, nil
+		}
+		// Restored pods never pull, so the restart needs the image on the node
+		image := srcPod.Spec.Containers[i].Image
+		if !nodeHasImage(&node, image) {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("container %s is restarted after the restore but image %s is not present on node %s", name, image, node.Name),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// nodeHasImage reports whether node lists image among its images. The list
+// is capped by the kubelet, so a large node may hold images it doesn't list.
+func nodeHasImage(node *corev1.Node, image string) bool {
+	for _, nodeImage := range node.Status.Images {
+		for _, name := range nodeImage.Names {
+			if name == image || strings.TrimPrefix(name, "docker.io/library/") == image ||
+				strings.TrimPrefix(name, "docker.io/") == image {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// refreshIdentity runs spec.identityRefresh once the restored pod is
+// verified: its hooks first, then the listed containers are switched back
+// to their original image, which makes the kubelet restart them fresh with
+// the restored pod's downward API values. Hook failures are recorded as
+// warnings.
+func (r *PodMigrationReconciler) refreshIdentity(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (string, error) {
+	refresh := podMigration.Spec.IdentityRefresh
+
+	for _, hook := range refresh.Hooks {
+		if err := runHook(ctx, r.Exec, restoredPod.Namespace, restoredPod.Name, hook); err != nil {
+			addWarning(podMigration, fmt.Sprintf("identity refresh hook %q in container %s failed: %v",
+				strings.Join(hook.Command, " "), hook.Container, err))
+		}
+	}
+
+	if len(refresh.RestartContainers) == 0 {
+		return fmt.Sprintf("ran %d hook(s)", len(refresh.Hooks)), nil
+	}
+	sourcePod, err := r.getSourcePod(ctx, podMigration)
+	if err != nil {
+		return "", fmt.Errorf("failed to get source pod: %w", err)
+	}
+	patch := client.StrategicMergeFrom(restoredPod.DeepCopy())
+	restoredPod.Annotations[identityRefreshedAnnotation] = "true"
+	for i := range restoredPod.Spec.Containers {
+		container := &restoredPod.Spec.Containers[i]
+		if !slices.Contains(refresh.RestartContainers, container.Name) {
+			continue
+		}
+		for _, source := range sourcePod.Spec.Containers {
+			if source.Name == container.Name {
+				container.Image = source.Image
+			}
+		}
+		if rebase := rebaseOf(podMigration, container.Name); rebase != nil {
+			container.Image = rebase.Image
+		}
+	}
+	if err := r.Patch(ctx, restoredPod, patch); err != nil {
+		return "", fmt.Errorf("failed to restart containers of restored pod: %w", err)
+	}
+	return fmt.Sprintf("ran %d hook(s), restarting containers %s", len(refresh.Hooks), strings.Join(refresh.RestartContainers, ", ")), nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/agent"
+	"my.domain/guestbook/internal/registry"
+)
+
+// DefaultImageNameTemplate names the images checkpoints are converted to
+// after the pod and container they were taken of and when.
+const DefaultImageNameTemplate = "localhost/checkpoint/{{.Namespace}}/{{.Pod}}:{{.Container}}-{{.Timestamp}}"
+
+// imageNameData holds the fields an image name template can use.
+type imageNameData struct {
+	Namespace string
+	Pod       string
+	Container string
+	// Timestamp is the time the checkpoint was taken, in UTC, formatted as
+	// 20060102-150405.
+	Timestamp string
+	// Digest is the hex sha256 digest of the checkpoint archive, empty for
+	// archives from agents that did not record it.
+	Digest string
+}
+
+// imageReferencePattern matches a tagged image reference with a registry
+// host, as buildah and the kubelet accept it.
+var imageReferencePattern = regexp.MustCompile(
+	`^[a-zA-Z0-9.-]+(:[0-9]+)?(/[a-z0-9]+(([._]|__|-+)[a-z0-9]+)*)+:[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+
+// ParseImageNameTemplate parses the template checkpoint images are named
+// with and checks it renders a valid image reference.
+func ParseImageNameTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("image").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := renderImageName(tmpl, imageNameData{
+		Namespace: "default",
+		Pod:       "pod",
+		Container: "container",
+		Timestamp: "20060102-150405",
+		Digest:    strings.Repeat("0", 64),
+	}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func renderImageName(tmpl *template.Template, data imageNameData) (string, error) {
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", err
+	}
+	if !imageReferencePattern.MatchString(name.String()) {
+		return "", fmt.Errorf("image name template renders %q, which is not a tagged image reference", name.String())
+	}
+	return name.String(), nil
+}
+
+// checkpointImageName names the image content's checkpoint is converted to.
+func (r *PodMigrationReconciler) checkpointImageName(content *lpmv1.ContainerCheckpointContent) (string, error) {
+	tmpl := r.ImageNameTemplate
+	if tmpl == nil {
+		var err error
+		if tmpl, err = ParseImageNameTemplate(DefaultImageNameTemplate); err != nil {
+			return "", err
+		}
+	}
+	return renderImageName(tmpl, imageNameData{
+		Namespace: content.Spec.PodNamespace,
+		Pod:       content.Spec.PodName,
+		Container: content.Spec.ContainerName,
+		Timestamp: content.CreationTimestamp.UTC().Format("20060102-150405"),
+		Digest:    strings.TrimPrefix(content.Spec.ArtifactDigest, "sha256:"),
+	})
+}
+
+// recordImageReference records on content the image its checkpoint was
+// converted to.
+func (r *PodMigrationReconciler) recordImageReference(ctx context.Context, content *lpmv1.ContainerCheckpointContent, imageRef string) error {
+	if content.Status.ImageReference == imageRef {
+		return nil
+	}
+	content.Status.ImageReference = imageRef
+	return r.Status().Update(ctx, content)
+}
+
+// transferThroughRegistry has the agent on the node content's checkpoint was
+// taken on convert it and push the image to the checkpoint registry, under
+// the template's name with the registry as its host, then has the target
+// node's agent pull it. A checkpoint already pushed by an earlier migration
+// is only pulled. A non-empty opts.ArtifactDigest is checked against the
+// archive before it is converted, and a rebased checkpoint's base image is
+// pulled too.
+func (r *PodMigrationReconciler) transferThroughRegistry(ctx context.Context, content *lpmv1.ContainerCheckpointContent, targetNode string, opts agent.ConvertOptions) (string, error) {
+	host, err := r.Registry.Host(ctx)
+	if err != nil {
+		return "", err
+	}
+	imageName, err := r.checkpointImageName(content)
+	if err != nil {
+		return "", err
+	}
+	if opts.BaseImage != "" {
+		imageName = rebasedImageName(imageName, &lpmv1.ContainerRebaseStatus{ImageID: opts.BaseImageID})
+	}
+	imageRef := registry.Reference(host, imageName)
+
+	auth, err := r.Registry.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if content.Status.ImageReference != imageRef {
+		if content.Spec.NodeName == "" {
+			return "", fmt.Errorf("checkpoint %s is not in shared storage and its node is not recorded", content.Spec.ArtifactURI)
+		}
+		opts.Push = true
+		opts.TLSVerify = r.Registry.TLSVerify()
+		opts.RegistryAuth = auth
+		imageRef, err = r.AgentClient.ConvertCheckpointToImage(ctx, content.Spec.NodeName, content.Spec.ArtifactURI, content.Spec.ContainerName, imageRef, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to push checkpoint image from node %s: %w", content.Spec.NodeName, err)
+		}
+	}
+
+	if err := r.AgentClient.PullImage(ctx, targetNode, imageRef, r.Registry.TLSVerify(), auth); err != nil {
+		return "", fmt.Errorf("failed to pull checkpoint image to node %s: %w", targetNode, err)
+	}
+	// The runtime restores the checkpoint on top of its base image
+	if opts.BaseImage != "" {
+		if err := r.AgentClient.PullBaseImage(ctx, targetNode, opts.BaseImage); err != nil {
+			return "", fmt.Errorf("failed to pull base image %s to node %s: %w", opts.BaseImage, targetNode, err)
+		}
+	}
+	return imageRef, nil
+}
+
+// setCheckpointPullPolicies sets the pull policy of the containers of pod,
+// which run the checkpoint images prepared on its node. An image the node's
+// agent pulled from the checkpoint registry is pulled again by the kubelet
+// should the node have removed it since; any other exists only in the
+// node's container storage.
+func (r *PodMigrationReconciler) setCheckpointPullPolicies(ctx context.Context, pod *corev1.Pod) error {
+	host := ""
+	if r.Registry != nil {
+		var err error
+		if host, err = r.Registry.Host(ctx); err != nil {
+			return err
+		}
+	}
+	for i, container := range pod.Spec.Containers {
+		pod.Spec.Containers[i].ImagePullPolicy = corev1.PullNever
+		if host != "" && registry.Holds(host, container.Image) {
+			pod.Spec.Containers[i].ImagePullPolicy = corev1.PullIfNotPresent
+		}
+	}
+	return nil
+}
+
+// needsStreaming reports whether content's checkpoint reaches targetNode by
+// streaming it between the agents: it is kept on another node and there is
+// no checkpoint registry or it was kept there to be transferred directly, or
+// it is in shared storage the target has not mounted.
+func (r *PodMigrationReconciler) needsStreaming(ctx context.Context, content *lpmv1.ContainerCheckpointContent, targetNode string) (bool, error) {
+	uri := content.Spec.ArtifactURI
+	if strings.HasPrefix(uri, "file://") {
+		direct := content.Spec.ArtifactTransfer == lpmv1.CheckpointTransferDirect
+		return (r.Registry == nil || direct) && content.Spec.NodeName != "" && content.Spec.NodeName != targetNode, nil
+	}
+	if !strings.HasPrefix(uri, "shared://") || content.Spec.NodeName == "" {
+		return false, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: targetNode}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return node.Labels[lpmv1.NodeSharedStorageLabel] == "false", nil
+}
+
+// transferByStreaming streams content's checkpoint from the agent of the
+// node it was taken on to the target node's agent, which checks it against
+// its recorded digest, and has the target convert it to the template's
+// image. The streamed archive is removed once converted, so a retry streams
+// it again.
+func (r *PodMigrationReconciler) transferByStreaming(ctx context.Context, content *lpmv1.ContainerCheckpointContent, targetNode string, opts agent.ConvertOptions, rebase *lpmv1.ContainerRebaseStatus) (string, error) {
+	logger := log.FromContext(ctx)
+	imageName, err := r.checkpointImageName(content)
+	if err != nil {
+		return "", err
+	}
+	if rebase != nil {
+		imageName = rebasedImageName(imageName, rebase)
+	}
+
+	streamedURI, err := r.streamCheckpoint(ctx, content, targetNode)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if _, err := r.AgentClient.DeleteCheckpoint(ctx, targetNode, []string{streamedURI}); err != nil {
+			logger.Error(err, "Failed to remove streamed checkpoint archive", "node", targetNode, "artifactURI", streamedURI)
+		}
+	}()
+
+	// Already checked as it was received
+	opts.ArtifactDigest = ""
+	imageRef, err := r.AgentClient.ConvertCheckpointToImage(ctx, targetNode, streamedURI, content.Spec.ContainerName, imageName, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert checkpoint to OCI image: %w", err)
+	}
+	return imageRef, nil
+}
+
+// streamCheckpoint gets content's checkpoint to targetNode and returns where
+// the target keeps it. The source agent pushes it straight to the target,
+// encrypted with the key of a transfer the target opens for it; agents that
+// predate that have it relayed through the controller instead.
+func (r *PodMigrationReconciler) streamCheckpoint(ctx context.Context, content *lpmv1.ContainerCheckpointContent, targetNode string) (string, error) {
+	logger := log.FromContext(ctx)
+	sourceNode := content.Spec.NodeName
+
+	transfer, err := r.AgentClient.OpenTransfer(ctx, targetNode, string(content.UID))
+	if err == nil {
+		var transferred *pb.TransferCheckpointResponse
+		transferred, err = r.AgentClient.TransferCheckpoint(ctx, sourceNode, targetNode, content.Spec.ArtifactURI, content.Spec.ArtifactDigest, transfer)
+		if err == nil {
+			logger.Info("Transferred checkpoint to target node", "container", content.Spec.ContainerName,
+				"sourceNode", sourceNode, "targetNode", targetNode, "artifactURI", transferred.ArtifactUri,
+				"size", transferred.Size, "durationMs", transferred.DurationMs, "cipherSuite", transferred.Encryption.GetCipherSuite())
+			return transferred.ArtifactUri, nil
+		}
+	}
+	if status.Code(err) != codes.Unimplemented {
+		return "", fmt.Errorf("failed to transfer checkpoint from node %s to node %s: %w", sourceNode, targetNode, err)
+	}
+	logger.Info("Agents do not support direct transfers, relaying checkpoint", "sourceNode", sourceNode, "targetNode", targetNode)
+
+	streamed, err := r.AgentClient.StreamCheckpoint(ctx, sourceNode, targetNode, content.Spec.ArtifactURI, content.Spec.ArtifactDigest)
+	if err != nil {
+		return "", fmt.Errorf("failed to stream checkpoint from node %s to node %s: %w", sourceNode, targetNode, err)
+	}
+	logger.Info("Streamed checkpoint to target node", "container", content.Spec.ContainerName,
+		"sourceNode", sourceNode, "targetNode", targetNode, "artifactURI", streamed.ArtifactUri, "size", streamed.Size)
+	return streamed.ArtifactUri, nil
+}
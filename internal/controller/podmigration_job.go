@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// legacyControllerUIDLabel selects the pods of Jobs created before
+// Kubernetes 1.27, next to batchv1.ControllerUidLabel.
+const legacyControllerUIDLabel = "controller-uid"
+
+// jobOwner returns the controller reference of pod when it is a Job pod.
+func jobOwner(pod *corev1.Pod) *metav1.OwnerReference {
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "Job" {
+		return owner
+	}
+	return nil
+}
+
+// preflightJob warns about Job pods whose failed containers are restarted in
+// place: the restarted container is created from the checkpoint image again,
+// so it resumes from the checkpoint instead of starting afresh.
+func (r *PodMigrationReconciler) preflightJob(_ context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if jobOwner(srcPod) != nil && srcPod.Spec.RestartPolicy == corev1.RestartPolicyOnFailure {
+		addWarning(podMigration, "Job pod has restartPolicy OnFailure; a container of the restored pod that fails is restarted from the checkpoint, not from its image")
+	}
+	return nil, nil
+}
+
+// reparentJobPod hands a Job's running pod over to the restored pod before
+// the source pod is deleted. The restored pod takes over the source pod's
+// controller reference and Job tracking finalizer, so the Job counts it as
+// its active pod and its completion or failure. The source pod is then
+// released from the Job, so deleting it neither counts as a failure against
+// the Job's backoffLimit nor makes the Job create a replacement. Both
+// updates are made back to back, within the Job controller's one second
+// batching of pod events, so it doesn't see one pod too many or too few.
+func (r *PodMigrationReconciler) reparentJobPod(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) error {
+	var sourcePod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &sourcePod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ref := jobOwner(&sourcePod)
+	if ref == nil {
+		return nil
+	}
+	owner := *ref
+
+	patch := client.MergeFrom(restoredPod.DeepCopy())
+	restoredPod.OwnerReferences = []metav1.OwnerReference{owner}
+	controllerutil.AddFinalizer(restoredPod, batchv1.JobTrackingFinalizer)
+	if err := r.Patch(ctx, restoredPod, patch); err != nil {
+		return fmt.Errorf("failed to hand restored pod to Job %s: %w", owner.Name, err)
+	}
+
+	// Without the selector labels the Job doesn't adopt it back
+	patch = client.MergeFrom(sourcePod.DeepCopy())
+	sourcePod.OwnerReferences = slices.DeleteFunc(sourcePod.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == owner.UID
+	})
+	controllerutil.RemoveFinalizer(&sourcePod, batchv1.JobTrackingFinalizer)
+	delete(sourcePod.Labels, batchv1.ControllerUidLabel)
+	delete(sourcePod.Labels, legacyControllerUIDLabel)
+	if err := r.Patch(ctx, &sourcePod, patch); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to release source pod from Job %s: %w", owner.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// sourcePodLockPollInterval is how often a migration waiting for another
+// migration of its pod checks whether the lock was given up.
+const sourcePodLockPollInterval = 5 * time.Second
+
+// lockSourcePod takes the migration lock of srcPod for the migration and
+// reports whether it holds it. The lock of a migration that ended or was
+// deleted is taken over; while another migration holds it, the migration
+// waits with a SourcePodLocked condition of False naming that migration.
+// The pod is patched with optimistic locking, so of two migrations taking
+// the lock at once only one succeeds.
+func (r *PodMigrationReconciler) lockSourcePod(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (bool, error) {
+	if holder := srcPod.Annotations[lpmv1.MigrationLockAnnotation]; holder != podMigration.Name {
+		if holder != "" {
+			active, err := r.migrationInFlight(ctx, podMigration.Namespace, holder)
+			if err != nil {
+				return false, err
+			}
+			if active {
+				return false, r.waitForSourcePod(ctx, podMigration, holder)
+			}
+		}
+
+		patch := client.MergeFromWithOptions(srcPod.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if srcPod.Annotations == nil {
+			srcPod.Annotations = map[string]string{}
+		}
+		srcPod.Annotations[lpmv1.MigrationLockAnnotation] = podMigration.Name
+		if err := r.Patch(ctx, srcPod, patch); err != nil {
+			return false, fmt.Errorf("failed to lock source pod: %w", err)
+		}
+		log.FromContext(ctx).Info("Locked source pod", "pod", srcPod.Name, "previousHolder", holder)
+	}
+
+	if !meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionSourcePodLocked,
+		Status:  metav1.ConditionTrue,
+		Reason:  lpmv1.MigrationReasonSourcePodLocked,
+		Message: fmt.Sprintf("the migration holds the migration lock of pod %s", srcPod.Name),
+	}) {
+		return true, nil
+	}
+	return true, r.Status().Update(ctx, podMigration)
+}
+
+// migrationInFlight reports whether the PodMigration name exists and has not
+// ended.
+func (r *PodMigrationReconciler) migrationInFlight(ctx context.Context, namespace, name string) (bool, error) {
+	var holder lpmv1.PodMigration
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &holder); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	switch holder.Status.Phase {
+	case lpmv1.MigrationPhaseSucceeded, lpmv1.MigrationPhaseFailed:
+		return false, nil
+	}
+	return true, nil
+}
+
+// waitForSourcePod records that the migration waits for the migration holder
+// to release the source pod.
+func (r *PodMigrationReconciler) waitForSourcePod(ctx context.Context, podMigration *lpmv1.PodMigration, holder string) error {
+	changed := meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionSourcePodLocked,
+		Status:  metav1.ConditionFalse,
+		Reason:  lpmv1.MigrationReasonWaitingForMigration,
+		Message: fmt.Sprintf("PodMigration %s is migrating pod %s", holder, podMigration.Spec.PodName),
+	})
+	message := fmt.Sprintf("waiting for PodMigration %s of the pod to end", holder)
+	if !changed && podMigration.Status.Message == message {
+		return nil
+	}
+	log.FromContext(ctx).Info("Waiting for another migration of the pod", "holder", holder)
+	podMigration.Status.Message = message
+	return r.Status().Update(ctx, podMigration)
+}
+
+// unlockSourcePod gives up the migration lock of the source pod once the
+// migration ended. The pod under the source pod's name may be gone, or be
+// the restored pod of a StatefulSet handover, which never carries the lock.
+func (r *PodMigrationReconciler) unlockSourcePod(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	if !meta.IsStatusConditionTrue(podMigration.Status.Conditions, lpmv1.MigrationConditionSourcePodLocked) {
+		return nil
+	}
+
+	var pod corev1.Pod
+	err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &pod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && pod.Annotations[lpmv1.MigrationLockAnnotation] == podMigration.Name {
+		patch := client.MergeFrom(pod.DeepCopy())
+		delete(pod.Annotations, lpmv1.MigrationLockAnnotation)
+		if err := r.Patch(ctx, &pod, patch); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to unlock source pod: %w", err)
+		}
+	}
+
+	meta.SetStatusCondition(&podMigration.Status.Conditions, metav1.Condition{
+		Type:    lpmv1.MigrationConditionSourcePodLocked,
+		Status:  metav1.ConditionFalse,
+		Reason:  lpmv1.MigrationReasonSourcePodReleased,
+		Message: "migration ended",
+	})
+	return r.Status().Update(ctx, podMigration)
+}
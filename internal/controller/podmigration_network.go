@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch
+
+const (
+	defaultNetworkCheckTimeout = 60 * time.Second
+	networkProbeTimeout        = 3 * time.Second
+)
+
+// networkCheckResult is the outcome of one round of network checks. A
+// non-empty reason means the check failed.
+type networkCheckResult struct {
+	reason  string
+	message string
+	// final is set when retrying cannot change the outcome.
+	final bool
+}
+
+// verifyNetwork checks that the restored pod got the source pod's network
+// identity: it must be selected by the same NetworkPolicies, have the same
+// secondary interfaces, and pass the connectivity checks of
+// spec.networkCheck.
+func (r *PodMigrationReconciler) verifyNetwork(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (networkCheckResult, error) {
+	var policies networkingv1.NetworkPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(restoredPod.Namespace)); err != nil {
+		return networkCheckResult{}, err
+	}
+	// The source pod is only deleted once the migration succeeded, or
+	// saved when a StatefulSet pod was handed over
+	sourcePod, err := r.getSourcePod(ctx, podMigration)
+	if err != nil {
+		return networkCheckResult{}, fmt.Errorf("failed to get source pod: %w", err)
+	}
+	sourcePolicies, err := selectingPolicies(policies.Items, sourcePod.Labels)
+	if err != nil {
+		return networkCheckResult{}, err
+	}
+	restoredPolicies, err := selectingPolicies(policies.Items, restoredPod.Labels)
+	if err != nil {
+		return networkCheckResult{}, err
+	}
+	if !slices.Equal(sourcePolicies, restoredPolicies) {
+		return networkCheckResult{
+			reason: lpmv1.MigrationReasonNetworkPolicyMismatch,
+			message: fmt.Sprintf("restored pod is selected by NetworkPolicies [%s], the source pod by [%s]",
+				strings.Join(restoredPolicies, ", "), strings.Join(sourcePolicies, ", ")),
+			final: true,
+		}, nil
+	}
+
+	if restoredPod.Status.PodIP == "" {
+		return networkCheckResult{reason: lpmv1.MigrationReasonNetworkUnreachable, message: "restored pod has no IP"}, nil
+	}
+
+	sourceNetworks, err := secondaryNetworks(sourcePod)
+	if err != nil {
+		return networkCheckResult{}, err
+	}
+	if len(sourceNetworks) > 0 {
+		restoredNetworks, err := secondaryNetworks(restoredPod)
+		if err != nil {
+			return networkCheckResult{reason: lpmv1.MigrationReasonNetworkUnreachable, message: err.Error()}, nil
+		}
+		if message := compareSecondaryNetworks(sourceNetworks, restoredNetworks); message != "" {
+			return networkCheckResult{reason: lpmv1.MigrationReasonNetworkUnreachable, message: message}, nil
+		}
+	}
+
+	check := podMigration.Spec.NetworkCheck
+	if check == nil {
+		return networkCheckResult{message: policySummary(restoredPolicies)}, nil
+	}
+
+	if len(check.Ports) > 0 {
+		var addresses []string
+		for _, port := range check.Ports {
+			addresses = append(addresses, net.JoinHostPort(restoredPod.Status.PodIP, strconv.Itoa(int(port))))
+		}
+		resp, err := r.AgentClient.ProbeConnectivity(ctx, restoredPod.Spec.NodeName, addresses, networkProbeTimeout)
+		if err != nil {
+			return networkCheckResult{}, err
+		}
+		for _, result := range resp.Results {
+			if !result.Reachable {
+				return networkCheckResult{
+					reason:  lpmv1.MigrationReasonNetworkUnreachable,
+					message: fmt.Sprintf("cannot connect to %s: %s", result.Address, result.Error),
+				}, nil
+			}
+		}
+	}
+
+	if check.Exec != nil {
+		if err := runHook(ctx, r.Exec, restoredPod.Namespace, restoredPod.Name, *check.Exec); err != nil {
+			return networkCheckResult{
+				reason:  lpmv1.MigrationReasonNetworkUnreachable,
+				message: fmt.Sprintf("network check command failed: %v", err),
+			}, nil
+		}
+	}
+
+	return networkCheckResult{message: policySummary(restoredPolicies) + "; connectivity checks passed"}, nil
+}
+
+// networkCheckTimedOut reports whether a restored pod has been failing its
+// network checks for longer than the migration allows.
+func networkCheckTimedOut(podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) bool {
+	timeout := defaultNetworkCheckTimeout
+	if check := podMigration.Spec.NetworkCheck; check != nil && check.TimeoutSeconds != nil {
+		timeout = time.Duration(*check.TimeoutSeconds) * time.Second
+	}
+	return restoredPod.Status.StartTime != nil && time.Since(restoredPod.Status.StartTime.Time) > timeout
+}
+
+// selectingPolicies returns the sorted names of the policies whose pod
+// selector matches podLabels.
+func selectingPolicies(policies []networkingv1.NetworkPolicy, podLabels map[string]string) ([]string, error) {
+	var names []string
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod selector in NetworkPolicy %s: %w", policy.Name, err)
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			names = append(names, policy.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+func policySummary(policies []string) string {
+	if len(policies) == 0 {
+		return "restored pod is not selected by any NetworkPolicy"
+	}
+	return "restored pod is selected by NetworkPolicies " + strings.Join(policies, ", ")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/notify"
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// notificationTimeout bounds the delivery of a notification to one sink.
+const notificationTimeout = 10 * time.Second
+
+// dueEvent returns the event the migration's phase calls for.
+func dueEvent(podMigration *lpmv1.PodMigration) lpmv1.MigrationEvent {
+	switch podMigration.Status.Phase {
+	case lpmv1.MigrationPhaseCheckpointing, lpmv1.MigrationPhaseCheckpointComplete,
+		lpmv1.MigrationPhasePreparingImages, lpmv1.MigrationPhaseRestoring:
+		return lpmv1.MigrationEventStarted
+	case lpmv1.MigrationPhaseSucceeded:
+		return lpmv1.MigrationEventSucceeded
+	case lpmv1.MigrationPhaseFailed:
+		// Failures after the restored pod was created remove it and keep
+		// the source pod
+		if podMigration.Status.RestoredPodName != "" {
+			return lpmv1.MigrationEventRolledBack
+		}
+		return lpmv1.MigrationEventFailed
+	}
+	return ""
+}
+
+// notify sends the notifications the migration's policies configure for its
+// current phase, once. Delivery is best effort: a sink that fails is logged
+// and not retried, so it cannot hold up the migration.
+func (r *PodMigrationReconciler) notify(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	logger := log.FromContext(ctx)
+
+	if podMigration.Spec.Simulate || len(podMigration.Status.MigrationPolicies) == 0 {
+		return nil
+	}
+	eventType := dueEvent(podMigration)
+	if eventType == "" || slices.Contains(podMigration.Status.NotifiedEvents, eventType) {
+		return nil
+	}
+
+	now := time.Now()
+	event := notify.Event{
+		Type:            eventType,
+		Namespace:       podMigration.Namespace,
+		Migration:       podMigration.Name,
+		Pod:             podMigration.Spec.PodName,
+		SourceNode:      podMigration.Status.SourceNode,
+		TargetNode:      podMigration.Spec.TargetNode,
+		RestoredPod:     podMigration.Status.RestoredPodName,
+		Reason:          podMigration.Status.Reason,
+		Message:         podMigration.Status.Message,
+		StartTime:       podMigration.CreationTimestamp.Time,
+		Time:            now,
+		DurationSeconds: now.Sub(podMigration.CreationTimestamp.Time).Seconds(),
+	}
+
+	for _, name := range podMigration.Status.MigrationPolicies {
+		var migrationPolicy lpmv1.MigrationPolicy
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, &migrationPolicy); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		for i, sink := range migrationPolicy.Spec.Notifications {
+			if len(sink.Events) > 0 && !slices.Contains(sink.Events, eventType) {
+				continue
+			}
+			if err := r.sendNotification(ctx, &sink, event); err != nil {
+				logger.Error(err, "Failed to send migration notification", "policy", name, "sink", i, "event", eventType)
+			}
+		}
+	}
+
+	podMigration.Status.NotifiedEvents = append(podMigration.Status.NotifiedEvents, eventType)
+	return r.Status().Update(ctx, podMigration)
+}
+
+// sendNotification delivers event to sink.
+func (r *PodMigrationReconciler) sendNotification(ctx context.Context, sink *lpmv1.NotificationSink, event notify.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	httpClient := &http.Client{Timeout: notificationTimeout}
+
+	var notifier notify.Notifier
+	switch {
+	case sink.Webhook != nil:
+		notifier = &notify.Webhook{URL: sink.Webhook.URL, Client: httpClient}
+	case sink.Slack != nil:
+		url, err := r.secretValue(ctx, &sink.Slack.URLSecret)
+		if err != nil {
+			return err
+		}
+		notifier = &notify.Slack{WebhookURL: url, Client: httpClient}
+	case sink.Email != nil:
+		email := &notify.Email{Address: sink.Email.SMTPAddress, From: sink.Email.From, To: sink.Email.To}
+		if sink.Email.Username != "" && sink.Email.PasswordSecret != nil {
+			password, err := r.secretValue(ctx, sink.Email.PasswordSecret)
+			if err != nil {
+				return err
+			}
+			email.Auth = notify.PlainAuth(sink.Email.SMTPAddress, sink.Email.Username, password)
+		}
+		notifier = email
+	default:
+		return errors.New("sink sets none of webhook, slack and email")
+	}
+	return notifier.Notify(ctx, event)
+}
+
+// secretValue reads the key ref selects.
+func (r *PodMigrationReconciler) secretValue(ctx context.Context, ref *lpmv1.SecretKeyReference) (string, error) {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	var secret corev1.Secret
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
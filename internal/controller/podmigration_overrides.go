@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// preflightOverrides rejects restored pod overrides naming containers the
+// pod does not have.
+func (r *PodMigrationReconciler) preflightOverrides(_ context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	overrides := podMigration.Spec.RestoredPodOverrides
+	if overrides == nil {
+		return nil, nil
+	}
+	for _, override := range overrides.Containers {
+		if !slices.ContainsFunc(srcPod.Spec.Containers, func(c corev1.Container) bool { return c.Name == override.Name }) {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonInvalidOverrides,
+				message: fmt.Sprintf("restoredPodOverrides name container %s, which the pod does not have", override.Name),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// withOverrides returns a copy of srcPod with the migration's restored pod
+// overrides applied, to check that the target node fits the restored pod.
+func withOverrides(podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) *corev1.Pod {
+	if podMigration.Spec.RestoredPodOverrides == nil {
+		return srcPod
+	}
+	pod := srcPod.DeepCopy()
+	applyOverrides(pod, podMigration.Spec.RestoredPodOverrides)
+	return pod
+}
+
+// applyOverrides applies overrides to pod.
+func applyOverrides(pod *corev1.Pod, overrides *lpmv1.RestoredPodOverrides) {
+	if overrides == nil {
+		return
+	}
+	if len(overrides.Labels) > 0 {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		maps.Copy(pod.Labels, overrides.Labels)
+	}
+	if len(overrides.Annotations) > 0 {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		maps.Copy(pod.Annotations, overrides.Annotations)
+	}
+	if overrides.NodeSelector != nil {
+		pod.Spec.NodeSelector = maps.Clone(overrides.NodeSelector)
+	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, overrides.Tolerations...)
+
+	for _, override := range overrides.Containers {
+		i := slices.IndexFunc(pod.Spec.Containers, func(c corev1.Container) bool { return c.Name == override.Name })
+		if i < 0 {
+			continue
+		}
+		container := &pod.Spec.Containers[i]
+		if override.Resources != nil {
+			container.Resources = *override.Resources.DeepCopy()
+		}
+		for _, env := range override.Env {
+			j := slices.IndexFunc(container.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name })
+			if j < 0 {
+				container.Env = append(container.Env, *env.DeepCopy())
+			} else {
+				container.Env[j] = *env.DeepCopy()
+			}
+		}
+	}
+}
@@ -0,0 +1,580 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// namespaceNodeSelectorAnnotation is the node selector the PodNodeSelector
+// admission plugin adds to the nodeSelector of a namespace's pods. The
+// plugin only checks nodeSelector, not a pod bound to a node directly.
+const namespaceNodeSelectorAnnotation = "scheduler.alpha.kubernetes.io/node-selector"
+
+// placementCheck evaluates the constraints the scheduler would check before
+// binding a pod to a node, against a snapshot of the cluster's nodes and
+// pods. The source pod and its earlier restored pods are left out of the
+// snapshot: the source pod goes away when the migration succeeds.
+type placementCheck struct {
+	pod               *corev1.Pod
+	nodes             map[string]*corev1.Node
+	pods              []*corev1.Pod
+	namespaces        map[string]labels.Set
+	namespaceSelector labels.Selector
+}
+
+// newPlacementCheck snapshots the cluster to evaluate the placement of pod,
+// the restored pod of srcPod.
+func newPlacementCheck(ctx context.Context, c client.Client, pod, srcPod *corev1.Pod) (*placementCheck, error) {
+	p := &placementCheck{pod: pod, nodes: map[string]*corev1.Node{}, namespaces: map[string]labels.Set{}}
+
+	var nodes corev1.NodeList
+	if err := c.List(ctx, &nodes); err != nil {
+		return nil, err
+	}
+	for i := range nodes.Items {
+		p.nodes[nodes.Items[i].Name] = &nodes.Items[i]
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := c.List(ctx, &namespaces); err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces.Items {
+		p.namespaces[namespace.Name] = labels.Set(namespace.Labels)
+		if namespace.Name != pod.Namespace {
+			continue
+		}
+		if annotation, ok := namespace.Annotations[namespaceNodeSelectorAnnotation]; ok && annotation != "" {
+			set, err := labels.ConvertSelectorToLabelsMap(annotation)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s annotation on namespace %s: %w", namespaceNodeSelectorAnnotation, namespace.Name, err)
+			}
+			p.namespaceSelector = labels.SelectorFromSet(set)
+		}
+	}
+
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		other := &pods.Items[i]
+		switch {
+		case other.UID == srcPod.UID,
+			other.Namespace == srcPod.Namespace && other.Annotations[sourcePodAnnotation] == srcPod.Name,
+			other.Spec.NodeName == "",
+			other.DeletionTimestamp != nil,
+			other.Status.Phase == corev1.PodSucceeded || other.Status.Phase == corev1.PodFailed:
+			continue
+		}
+		p.pods = append(p.pods, other)
+	}
+	return p, nil
+}
+
+// evaluate returns the constraints placing the pod on node violates and the
+// preferences it leaves unmet.
+func (p *placementCheck) evaluate(node *corev1.Node) (violations, unmet []string, err error) {
+	message, err := p.nodeAffinityMismatch(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	if message != "" {
+		violations = append(violations, message)
+	}
+
+	if affinity := p.pod.Spec.Affinity; affinity != nil {
+		if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+			for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				matches, err := nodeSelectorMatches(&corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{term.Preference}}, node)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !matches {
+					unmet = append(unmet, fmt.Sprintf("target node does not match a preferred node affinity term (weight %d)", term.Weight))
+				}
+			}
+		}
+		if podAffinity := affinity.PodAffinity; podAffinity != nil {
+			for i := range podAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+				term := &podAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i]
+				satisfied, err := p.affinitySatisfied(term, node)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !satisfied {
+					violations = append(violations, "no pod the pod requires affinity with runs in the target node's "+topologyDomain(node, term.TopologyKey))
+				}
+			}
+			for i := range podAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				term := &podAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i]
+				satisfied, err := p.affinitySatisfied(&term.PodAffinityTerm, node)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !satisfied {
+					unmet = append(unmet, fmt.Sprintf("no pod the pod prefers affinity with (weight %d) runs in the target node's %s",
+						term.Weight, topologyDomain(node, term.PodAffinityTerm.TopologyKey)))
+				}
+			}
+		}
+		if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+			for i := range podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+				term := &podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i]
+				conflict, err := p.antiAffinityConflict(term, p.pod, node)
+				if err != nil {
+					return nil, nil, err
+				}
+				if conflict != nil {
+					violations = append(violations, fmt.Sprintf("pod %s/%s on node %s runs in the target node's %s, which the pod's anti-affinity forbids",
+						conflict.Namespace, conflict.Name, conflict.Spec.NodeName, topologyDomain(node, term.TopologyKey)))
+				}
+			}
+			for i := range podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				term := &podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i]
+				conflict, err := p.antiAffinityConflict(&term.PodAffinityTerm, p.pod, node)
+				if err != nil {
+					return nil, nil, err
+				}
+				if conflict != nil {
+					unmet = append(unmet, fmt.Sprintf("pod %s/%s on node %s runs in the target node's %s, which the pod prefers to avoid (weight %d)",
+						conflict.Namespace, conflict.Name, conflict.Spec.NodeName, topologyDomain(node, term.PodAffinityTerm.TopologyKey), term.Weight))
+				}
+			}
+		}
+	}
+
+	// Pods already running may keep the pod out of their topology domain
+	for _, other := range p.pods {
+		if other.Spec.Affinity == nil || other.Spec.Affinity.PodAntiAffinity == nil {
+			continue
+		}
+		otherNode := p.nodes[other.Spec.NodeName]
+		for i := range other.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			term := &other.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i]
+			selects, err := p.termSelects(term, other, p.pod)
+			if err != nil {
+				return nil, nil, err
+			}
+			if selects && sameDomain(node, otherNode, term.TopologyKey) {
+				violations = append(violations, fmt.Sprintf("the anti-affinity of pod %s/%s on node %s forbids the pod in its %s",
+					other.Namespace, other.Name, other.Spec.NodeName, topologyDomain(node, term.TopologyKey)))
+			}
+		}
+	}
+
+	for i := range p.pod.Spec.TopologySpreadConstraints {
+		constraint := &p.pod.Spec.TopologySpreadConstraints[i]
+		message, err := p.spreadViolation(constraint, node)
+		if err != nil {
+			return nil, nil, err
+		}
+		if message == "" {
+			continue
+		}
+		if constraint.WhenUnsatisfiable == corev1.ScheduleAnyway {
+			unmet = append(unmet, message)
+		} else {
+			violations = append(violations, message)
+		}
+	}
+	return violations, unmet, nil
+}
+
+// nodeAffinityMismatch reports why node fails the pod's nodeSelector, its
+// namespace's node selector or its required node affinity, or "" if it
+// passes them.
+func (p *placementCheck) nodeAffinityMismatch(node *corev1.Node) (string, error) {
+	nodeLabels := labels.Set(node.Labels)
+	if !labels.SelectorFromSet(p.pod.Spec.NodeSelector).Matches(nodeLabels) {
+		return fmt.Sprintf("target node does not match the pod's nodeSelector %s", labels.Set(p.pod.Spec.NodeSelector)), nil
+	}
+	if p.namespaceSelector != nil && !p.namespaceSelector.Matches(nodeLabels) {
+		return fmt.Sprintf("target node does not match the node selector %s of namespace %s", p.namespaceSelector, p.pod.Namespace), nil
+	}
+	affinity := p.pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return "", nil
+	}
+	matches, err := nodeSelectorMatches(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution, node)
+	if err != nil {
+		return "", err
+	}
+	if !matches {
+		return "target node does not match the pod's required node affinity", nil
+	}
+	return "", nil
+}
+
+// affinitySatisfied reports whether a pod term selects runs in node's
+// topology domain. Like the scheduler, it lets the first pod of a group its
+// term selects, itself included, run anywhere.
+func (p *placementCheck) affinitySatisfied(term *corev1.PodAffinityTerm, node *corev1.Node) (bool, error) {
+	if _, ok := node.Labels[term.TopologyKey]; !ok {
+		return false, nil
+	}
+	found := false
+	for _, other := range p.pods {
+		selects, err := p.termSelects(term, p.pod, other)
+		if err != nil {
+			return false, err
+		}
+		if !selects {
+			continue
+		}
+		if sameDomain(node, p.nodes[other.Spec.NodeName], term.TopologyKey) {
+			return true, nil
+		}
+		found = true
+	}
+	if found {
+		return false, nil
+	}
+	return p.termSelects(term, p.pod, p.pod)
+}
+
+// antiAffinityConflict returns a pod term of owner selects that runs in
+// node's topology domain, or nil.
+func (p *placementCheck) antiAffinityConflict(term *corev1.PodAffinityTerm, owner *corev1.Pod, node *corev1.Node) (*corev1.Pod, error) {
+	for _, other := range p.pods {
+		selects, err := p.termSelects(term, owner, other)
+		if err != nil {
+			return nil, err
+		}
+		if selects && sameDomain(node, p.nodes[other.Spec.NodeName], term.TopologyKey) {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// termSelects reports whether the pod affinity term of owner selects pod.
+func (p *placementCheck) termSelects(term *corev1.PodAffinityTerm, owner, pod *corev1.Pod) (bool, error) {
+	switch {
+	case len(term.Namespaces) == 0 && term.NamespaceSelector == nil:
+		if pod.Namespace != owner.Namespace {
+			return false, nil
+		}
+	default:
+		inNamespaces := false
+		for _, namespace := range term.Namespaces {
+			inNamespaces = inNamespaces || namespace == pod.Namespace
+		}
+		if !inNamespaces && term.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(term.NamespaceSelector)
+			if err != nil {
+				return false, fmt.Errorf("invalid namespace selector: %w", err)
+			}
+			inNamespaces = selector.Matches(p.namespaces[pod.Namespace])
+		}
+		if !inNamespaces {
+			return false, nil
+		}
+	}
+	selector, err := podSelector(term.LabelSelector, owner, term.MatchLabelKeys, term.MismatchLabelKeys)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(pod.Labels)), nil
+}
+
+// spreadViolation reports how placing the pod on node would skew the pods a
+// topology spread constraint selects beyond its maxSkew, or "" if it would
+// not. Like the scheduler, it counts pods on the nodes the pod could be
+// scheduled to, honoring node affinity and ignoring taints unless the
+// constraint says otherwise.
+func (p *placementCheck) spreadViolation(constraint *corev1.TopologySpreadConstraint, node *corev1.Node) (string, error) {
+	domain, ok := node.Labels[constraint.TopologyKey]
+	if !ok {
+		return fmt.Sprintf("target node has no %s label to spread the pod by", constraint.TopologyKey), nil
+	}
+	counts, err := p.spreadCounts(constraint)
+	if err != nil {
+		return "", err
+	}
+	counts[domain] += 0
+
+	minimum := -1
+	for _, count := range counts {
+		if minimum < 0 || count < minimum {
+			minimum = count
+		}
+	}
+	if constraint.MinDomains != nil && int32(len(counts)) < *constraint.MinDomains {
+		minimum = 0
+	}
+	if skew := counts[domain] + 1 - minimum; skew > int(constraint.MaxSkew) {
+		return fmt.Sprintf("restoring the pod in %s=%s would skew the pods its topology spread constraint selects by %d, more than maxSkew %d",
+			constraint.TopologyKey, domain, skew, constraint.MaxSkew), nil
+	}
+	return "", nil
+}
+
+// spreadCounts counts the pods a topology spread constraint selects in each
+// domain of its topology key, over the nodes the constraint counts.
+func (p *placementCheck) spreadCounts(constraint *corev1.TopologySpreadConstraint) (map[string]int, error) {
+	selector, err := podSelector(constraint.LabelSelector, p.pod, constraint.MatchLabelKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.domainCounts(constraint.TopologyKey,
+		func(candidate *corev1.Node) (bool, error) { return p.spreadEligible(constraint, candidate) },
+		func(other *corev1.Pod) bool {
+			return other.Namespace == p.pod.Namespace && selector.Matches(labels.Set(other.Labels))
+		})
+}
+
+// domainCounts counts the pods selects picks in each domain of topologyKey,
+// over the nodes with the key that eligible accepts. Domains without any
+// pod count 0.
+func (p *placementCheck) domainCounts(topologyKey string, eligible func(*corev1.Node) (bool, error), selects func(*corev1.Pod) bool) (map[string]int, error) {
+	counts := map[string]int{}
+	counted := map[string]bool{}
+	for _, candidate := range p.nodes {
+		value, ok := candidate.Labels[topologyKey]
+		if !ok {
+			continue
+		}
+		ok, err := eligible(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			counted[candidate.Name] = true
+			counts[value] += 0
+		}
+	}
+	for _, other := range p.pods {
+		if counted[other.Spec.NodeName] && selects(other) {
+			counts[p.nodes[other.Spec.NodeName].Labels[topologyKey]]++
+		}
+	}
+	return counts, nil
+}
+
+// spreadReports reports how moving the pod from source to target changes
+// the skew of each of its topology spread constraints and, unless one
+// spreads it by zone, the zone balance of the workload controlling it.
+func (p *placementCheck) spreadReports(source, target *corev1.Node) ([]lpmv1.TopologySpreadReport, error) {
+	var reports []lpmv1.TopologySpreadReport
+	spreadByZone := false
+	for i := range p.pod.Spec.TopologySpreadConstraints {
+		constraint := &p.pod.Spec.TopologySpreadConstraints[i]
+		spreadByZone = spreadByZone || constraint.TopologyKey == corev1.LabelTopologyZone
+		counts, err := p.spreadCounts(constraint)
+		if err != nil {
+			return nil, err
+		}
+		report := spreadReport(fmt.Sprintf("topologySpreadConstraints[%d]", i), constraint.TopologyKey, counts, source, target)
+		maxSkew := constraint.MaxSkew
+		report.MaxSkew = &maxSkew
+		reports = append(reports, report)
+	}
+
+	owner := metav1.GetControllerOf(p.pod)
+	if spreadByZone || owner == nil {
+		return reports, nil
+	}
+	counts, err := p.domainCounts(corev1.LabelTopologyZone,
+		func(candidate *corev1.Node) (bool, error) {
+			message, err := p.nodeAffinityMismatch(candidate)
+			return message == "", err
+		},
+		func(other *corev1.Pod) bool {
+			ref := metav1.GetControllerOf(other)
+			return other.Namespace == p.pod.Namespace && ref != nil && ref.UID == owner.UID
+		})
+	if err != nil {
+		return nil, err
+	}
+	// A workload of one pod has no balance to keep
+	siblings := 0
+	for _, count := range counts {
+		siblings += count
+	}
+	if siblings > 0 {
+		reports = append(reports, spreadReport(owner.Kind+"/"+owner.Name, corev1.LabelTopologyZone, counts, source, target))
+	}
+	return reports, nil
+}
+
+// spreadReport reports the skew of counts, which leave out the migrated
+// pod, with the pod on source and then on target.
+func spreadReport(subject, topologyKey string, counts map[string]int, source, target *corev1.Node) lpmv1.TopologySpreadReport {
+	report := lpmv1.TopologySpreadReport{Subject: subject, TopologyKey: topologyKey}
+	before, after := maps.Clone(counts), maps.Clone(counts)
+	if source != nil {
+		if domain, ok := source.Labels[topologyKey]; ok {
+			report.SourceDomain = domain
+			if _, counted := before[domain]; counted {
+				before[domain]++
+			}
+		}
+	}
+	if domain, ok := target.Labels[topologyKey]; ok {
+		report.TargetDomain = domain
+		after[domain]++
+	}
+	report.SkewBefore = int32(skew(before))
+	report.SkewAfter = int32(skew(after))
+	for _, domain := range slices.Sorted(maps.Keys(after)) {
+		report.Domains = append(report.Domains, lpmv1.TopologyDomainCount{Domain: domain, Pods: int32(after[domain])})
+	}
+	return report
+}
+
+// skew returns the difference between the largest and smallest of counts.
+func skew(counts map[string]int) int {
+	if len(counts) == 0 {
+		return 0
+	}
+	values := slices.Collect(maps.Values(counts))
+	return slices.Max(values) - slices.Min(values)
+}
+
+// spreadEligible reports whether the pods on candidate count towards a
+// topology spread constraint.
+func (p *placementCheck) spreadEligible(constraint *corev1.TopologySpreadConstraint, candidate *corev1.Node) (bool, error) {
+	if constraint.NodeAffinityPolicy == nil || *constraint.NodeAffinityPolicy == corev1.NodeInclusionPolicyHonor {
+		message, err := p.nodeAffinityMismatch(candidate)
+		if err != nil || message != "" {
+			return false, err
+		}
+	}
+	if constraint.NodeTaintsPolicy != nil && *constraint.NodeTaintsPolicy == corev1.NodeInclusionPolicyHonor {
+		return len(untoleratedTaints(p.pod.Spec.Tolerations, candidate)) == 0, nil
+	}
+	return true, nil
+}
+
+// podSelector returns the label selector of a pod affinity term or topology
+// spread constraint of owner, narrowed to pods sharing the values of owner's
+// matchLabelKeys and not sharing those of its mismatchLabelKeys. A nil
+// selector selects nothing.
+func podSelector(selector *metav1.LabelSelector, owner *corev1.Pod, matchLabelKeys, mismatchLabelKeys []string) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Nothing(), nil
+	}
+	result, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	for _, keys := range []struct {
+		keys     []string
+		operator selection.Operator
+	}{{matchLabelKeys, selection.In}, {mismatchLabelKeys, selection.NotIn}} {
+		for _, key := range keys.keys {
+			value, ok := owner.Labels[key]
+			if !ok {
+				continue
+			}
+			requirement, err := labels.NewRequirement(key, keys.operator, []string{value})
+			if err != nil {
+				return nil, fmt.Errorf("invalid label key %s: %w", key, err)
+			}
+			result = result.Add(*requirement)
+		}
+	}
+	return result, nil
+}
+
+// sameDomain reports whether both nodes have the topology key and the same
+// value for it.
+func sameDomain(node, other *corev1.Node, topologyKey string) bool {
+	if other == nil {
+		return false
+	}
+	value, ok := node.Labels[topologyKey]
+	otherValue, otherOK := other.Labels[topologyKey]
+	return ok && otherOK && value == otherValue
+}
+
+func topologyDomain(node *corev1.Node, topologyKey string) string {
+	value, ok := node.Labels[topologyKey]
+	if !ok {
+		return fmt.Sprintf("topology (it has no %s label)", topologyKey)
+	}
+	return fmt.Sprintf("topology %s=%s", topologyKey, value)
+}
+
+// preflightPlacement checks that restoring the pod on the target node keeps
+// the placement constraints the scheduler would have enforced. Violations
+// fail the migration or, with spec.placementConstraints Warn, are listed in
+// status.warnings; unmet preferences are listed either way.
+func (r *PodMigrationReconciler) preflightPlacement(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	check, err := newPlacementCheck(ctx, r.Client, withOverrides(podMigration, srcPod), srcPod)
+	if err != nil {
+		return nil, err
+	}
+	violations, unmet, err := check.evaluate(&node)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := check.spreadReports(check.nodes[srcPod.Spec.NodeName], &node)
+	if err != nil {
+		return nil, err
+	}
+	podMigration.Status.TopologySpread = reports
+	for _, report := range reports {
+		// Constraints the move breaks are already among the violations
+		if report.MaxSkew == nil && report.SkewAfter > report.SkewBefore {
+			addWarning(podMigration, fmt.Sprintf("moving the pod from zone %s to %s raises the zone skew of %s from %d to %d",
+				report.SourceDomain, report.TargetDomain, report.Subject, report.SkewBefore, report.SkewAfter))
+		}
+	}
+
+	for _, message := range unmet {
+		addWarning(podMigration, message)
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	if podMigration.Spec.PlacementConstraints == lpmv1.PlacementConstraintPolicyWarn {
+		for _, message := range violations {
+			addWarning(podMigration, "placement constraint violated: "+message)
+		}
+		return nil, nil
+	}
+	return &preflightFailure{
+		reason:  lpmv1.MigrationReasonPlacementViolation,
+		message: strings.Join(violations, "; "),
+	}, nil
+}
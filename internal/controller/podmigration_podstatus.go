@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// migrationStatusAnnotations are the annotations publishPodStatus keeps on
+// the pods of a migration.
+var migrationStatusAnnotations = []string{
+	lpmv1.MigrationAnnotation,
+	lpmv1.MigrationStatusAnnotation,
+	lpmv1.MigrationMessageAnnotation,
+	lpmv1.MigrationTargetNodeAnnotation,
+	lpmv1.MigrationRestoredPodAnnotation,
+}
+
+// publishPodStatus mirrors the migration's phase, message, target node and
+// restored pod onto the annotations of the source pod and of the restored
+// pod, so teams can follow the migration of their pods without access to
+// PodMigrations. A pod a later migration has taken over is left to that one.
+// Publishing is best effort: a pod that cannot be patched is logged and
+// tried again on the next reconcile.
+func (r *PodMigrationReconciler) publishPodStatus(ctx context.Context, podMigration *lpmv1.PodMigration) {
+	if podMigration.Spec.Simulate {
+		return
+	}
+	annotations := map[string]string{
+		lpmv1.MigrationAnnotation:            podMigration.Name,
+		lpmv1.MigrationStatusAnnotation:      string(podMigration.Status.Phase),
+		lpmv1.MigrationMessageAnnotation:     podMigration.Status.Message,
+		lpmv1.MigrationTargetNodeAnnotation:  podMigration.Spec.TargetNode,
+		lpmv1.MigrationRestoredPodAnnotation: podMigration.Status.RestoredPodName,
+	}
+
+	names := []string{podMigration.Spec.PodName}
+	if restored := podMigration.Status.RestoredPodName; restored != "" && restored != podMigration.Spec.PodName {
+		names = append(names, restored)
+	}
+	for _, name := range names {
+		if err := r.annotatePod(ctx, podMigration, name, annotations); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to publish migration status on pod", "pod", name)
+		}
+	}
+}
+
+// annotatePod sets annotations on the pod name unless it is gone or was
+// taken over by a migration created after podMigration.
+func (r *PodMigrationReconciler) annotatePod(ctx context.Context, podMigration *lpmv1.PodMigration, name string, annotations map[string]string) error {
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: name}, &pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !pod.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	if other := pod.Annotations[lpmv1.MigrationAnnotation]; other != "" && other != podMigration.Name {
+		var otherMigration lpmv1.PodMigration
+		err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: other}, &otherMigration)
+		if err == nil && podMigration.CreationTimestamp.Before(&otherMigration.CreationTimestamp) {
+			return nil
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	changed := false
+	for key, value := range annotations {
+		current, ok := pod.Annotations[key]
+		if value == "" {
+			changed = changed || ok
+		} else {
+			changed = changed || current != value
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	patch := client.MergeFrom(pod.DeepCopy())
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	for key, value := range annotations {
+		if value == "" {
+			delete(pod.Annotations, key)
+		} else {
+			pod.Annotations[key] = value
+		}
+	}
+	return client.IgnoreNotFound(r.Patch(ctx, &pod, patch))
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+
+// makeRoomOnTarget evicts pods from the target node that restoredPod does
+// not fit on, when its MigrationPolicies enable target preemption. The
+// restored pod is bound to the node directly, so the scheduler never
+// preempts for it. It returns whether to wait for evicted pods to go, or why
+// preemption cannot make room. Without target preemption the pod is created
+// as before and the kubelet admits or rejects it.
+func (r *PodMigrationReconciler) makeRoomOnTarget(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (bool, string, error) {
+	logger := log.FromContext(ctx)
+
+	if preemption := restoredPod.Spec.PreemptionPolicy; preemption != nil && *preemption == corev1.PreemptNever {
+		return false, "", nil
+	}
+	policies, err := policy.Matching(ctx, r.Client, restoredPod)
+	if err != nil {
+		return false, "", err
+	}
+	maxVictimPriority, enabled := policy.MaxVictimPriority(policies)
+	if !enabled {
+		return false, "", nil
+	}
+
+	nodeName := podMigration.Spec.TargetNode
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		return false, "", err
+	}
+	var boundPods corev1.PodList
+	if err := r.List(ctx, &boundPods, client.MatchingFields{podNodeNameField: nodeName}); err != nil {
+		return false, "", err
+	}
+	// Pods evicted before only free their resources once gone
+	for i := range boundPods.Items {
+		bound := &boundPods.Items[i]
+		if !bound.DeletionTimestamp.IsZero() && slices.Contains(podMigration.Status.PreemptedPods, bound.Namespace+"/"+bound.Name) {
+			logger.Info("Waiting for preempted pod to terminate", "pod", bound.Namespace+"/"+bound.Name, "node", nodeName)
+			return true, "", nil
+		}
+	}
+
+	used, podCount, err := nodeUsage(ctx, r.Client, restoredPod, nodeName)
+	if err != nil {
+		return false, "", err
+	}
+	notFit := checkResourceFit(restoredPod, &node, used, podCount)
+	if notFit == "" {
+		return false, "", nil
+	}
+
+	// Like the scheduler, evict the lowest priorities first, and among
+	// them the pods that started last, which lose the least work
+	priority := podPriority(restoredPod)
+	var candidates []*corev1.Pod
+	for i := range boundPods.Items {
+		bound := &boundPods.Items[i]
+		if preemptible(bound, priority, maxVictimPriority) {
+			candidates = append(candidates, bound)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b *corev1.Pod) int {
+		if pa, pb := podPriority(a), podPriority(b); pa != pb {
+			return int(pa) - int(pb)
+		}
+		return b.CreationTimestamp.Compare(a.CreationTimestamp.Time)
+	})
+	var victims []*corev1.Pod
+	message := notFit
+	for _, candidate := range candidates {
+		if message == "" {
+			break
+		}
+		victims = append(victims, candidate)
+		subtractResources(used, podRequests(candidate))
+		podCount--
+		message = checkResourceFit(restoredPod, &node, used, podCount)
+	}
+	if message != "" {
+		return false, fmt.Sprintf("%s, even after preempting the pods of priority up to %d below the pod's %d",
+			message, maxVictimPriority, priority), nil
+	}
+
+	// Evicting only some of the victims would disrupt them for nothing
+	for _, victim := range victims {
+		if err := r.evict(ctx, victim, client.DryRunAll); err != nil {
+			if apierrors.IsTooManyRequests(err) {
+				return false, fmt.Sprintf("%s, and preempting pod %s/%s would violate its PodDisruptionBudget",
+					notFit, victim.Namespace, victim.Name), nil
+			}
+			return false, "", err
+		}
+	}
+	for _, victim := range victims {
+		if err := r.evict(ctx, victim); err != nil {
+			return false, "", err
+		}
+		logger.Info("Preempted pod on target node", "pod", victim.Namespace+"/"+victim.Name, "node", nodeName, "priority", podPriority(victim))
+		podMigration.Status.PreemptedPods = append(podMigration.Status.PreemptedPods, victim.Namespace+"/"+victim.Name)
+	}
+	podMigration.Status.Message = fmt.Sprintf("preempted %d pods on node %s to make room for the restored pod", len(victims), nodeName)
+	return true, "", r.Status().Update(ctx, podMigration)
+}
+
+// preemptible reports whether a migration of a pod of priority may evict
+// pod: it must be of lower priority, at most maxVictimPriority and not
+// system critical, and a pod an eviction removes for good. DaemonSet and
+// static pods come back on the node as soon as they are gone.
+func preemptible(pod *corev1.Pod, priority, maxVictimPriority int32) bool {
+	victimPriority := podPriority(pod)
+	if victimPriority >= priority || victimPriority > maxVictimPriority || policy.IsCritical(pod) {
+		return false
+	}
+	if !pod.DeletionTimestamp.IsZero() || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+		return false
+	}
+	owner := metav1.GetControllerOf(pod)
+	return owner == nil || (owner.Kind != "DaemonSet" && owner.Kind != "Node")
+}
+
+// evict evicts pod through the eviction API, which refuses evictions its
+// PodDisruptionBudgets do not allow. A pod already gone is not an error.
+func (r *PodMigrationReconciler) evict(ctx context.Context, pod *corev1.Pod, opts ...client.SubResourceCreateOption) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	return client.IgnoreNotFound(r.SubResource("eviction").Create(ctx, pod, eviction, opts...))
+}
+
+// podPriority returns the priority of pod, 0 if it has none.
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
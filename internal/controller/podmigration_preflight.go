@@ -0,0 +1,547 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// +kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;watch
+
+// preflightFailure explains why a migration must not start.
+type preflightFailure struct {
+	reason  string
+	message string
+}
+
+// preflight checks, before anything is checkpointed, that the source pod can
+// be checkpointed and restored on the target node. A non-nil failure fails
+// the migration; an error is retried.
+func (r *PodMigrationReconciler) preflight(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	checks := []func(context.Context, *lpmv1.PodMigration, *corev1.Pod) (*preflightFailure, error){
+		r.preflightPodKind,
+		r.preflightOverrides,
+		r.preflightRebase,
+		r.preflightHostNetwork,
+		r.preflightTargetPolicy,
+		r.preflightTargetTaints,
+		r.preflightPlacement,
+		r.preflightNodeCapabilities,
+		r.preflightProcessNamespace,
+		r.preflightTargetUserNamespace,
+		r.preflightSecondaryNetworks,
+		r.preflightReservedResources,
+		r.preflightNodeConflicts,
+		r.preflightPersistentVolumes,
+		r.preflightContainers,
+		r.preflightSecurityProfiles,
+		r.preflightClock,
+		r.preflightIdentity,
+		r.preflightJob,
+		r.preflightAdmission,
+	}
+	for _, check := range checks {
+		failure, err := check(ctx, podMigration, srcPod)
+		if failure != nil || err != nil {
+			return failure, err
+		}
+	}
+	return nil, nil
+}
+
+// preflightPodKind rejects pods that are tied to their node or to the
+// cluster's operation, whose restored copy the cluster would fight: DaemonSet
+// pods, mirror pods of static pods, and system critical pods no
+// MigrationPolicy allows.
+func (r *PodMigrationReconciler) preflightPodKind(ctx context.Context, _ *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	owner := metav1.GetControllerOf(srcPod)
+	if _, mirror := srcPod.Annotations[corev1.MirrorPodAnnotationKey]; mirror || (owner != nil && owner.Kind == "Node") {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonStaticPod,
+			message: fmt.Sprintf("pod is the mirror of a static pod of node %s; move its manifest instead", srcPod.Spec.NodeName),
+		}, nil
+	}
+	if owner != nil && owner.Kind == "DaemonSet" {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonDaemonSetPod,
+			message: fmt.Sprintf("pod belongs to DaemonSet %s, which runs its own pod on every node", owner.Name),
+		}, nil
+	}
+	if !policy.IsCritical(srcPod) {
+		return nil, nil
+	}
+	policies, err := policy.Matching(ctx, r.Client, srcPod)
+	if err != nil {
+		return nil, err
+	}
+	if denied := policy.CriticalPodDenied(policies, srcPod); denied != "" {
+		return &preflightFailure{reason: lpmv1.MigrationReasonCriticalPod, message: denied}, nil
+	}
+	return nil, nil
+}
+
+// sharedProcessNamespaceMessage explains why pods with shareProcessNamespace
+// are neither checkpointed nor migrated.
+const sharedProcessNamespaceMessage = "pod shares one PID namespace between its containers (shareProcessNamespace); " +
+	"the kubelet checkpoints each container on its own, which cannot dump or restore part of a shared PID namespace consistently"
+
+// sharesProcessNamespace reports whether pod's containers share one PID
+// namespace, owned by the pod sandbox.
+func sharesProcessNamespace(pod *corev1.Pod) bool {
+	return pod.Spec.ShareProcessNamespace != nil && *pod.Spec.ShareProcessNamespace
+}
+
+// preflightProcessNamespace rejects pods whose containers share a PID
+// namespace. CRIU needs the whole PID namespace of the processes it dumps,
+// but the kubelet checkpoint API dumps one container at a time and the pod
+// sandbox, whose pause process is the namespace's init, is never dumped.
+func (r *PodMigrationReconciler) preflightProcessNamespace(_ context.Context, _ *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if !sharesProcessNamespace(srcPod) {
+		return nil, nil
+	}
+	return &preflightFailure{
+		reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+		message: sharedProcessNamespaceMessage,
+	}, nil
+}
+
+// preflightAdmission submits the restored pod as a server-side dry run so
+// the namespace's Pod Security Admission level, ResourceQuotas and
+// LimitRanges are evaluated against exactly what will be created. Quota
+// usage includes the source pod, which runs until the restored pod is
+// verified, so the namespace must fit both pods. Other admission errors are
+// left to the real Create.
+func (r *PodMigrationReconciler) preflightAdmission(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	logger := log.FromContext(ctx)
+
+	restoredPod := newRestoredPod(podMigration, srcPod)
+	restoredPod.Status = corev1.PodStatus{}
+	err := r.Create(ctx, restoredPod, client.DryRunAll)
+	if err == nil || apierrors.IsAlreadyExists(err) {
+		return nil, nil
+	}
+	if failure := admissionFailure(err); failure != nil {
+		failure.message = "restored pod would be rejected: " + failure.message
+		return failure, nil
+	}
+	logger.Info("Dry-run creation of restored pod failed, skipping admission check", "error", err.Error())
+	return nil, nil
+}
+
+// limitRangeViolations are fragments of the messages the LimitRanger
+// admission plugin rejects pods with.
+var limitRangeViolations = []string{"usage per Container is", "usage per Pod is", "limit to request ratio per"}
+
+// admissionFailure classifies an error creating the restored pod, or
+// returns nil for errors that do not fail the migration by themselves.
+func admissionFailure(err error) *preflightFailure {
+	if !apierrors.IsForbidden(err) {
+		return nil
+	}
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "violates PodSecurity"):
+		return &preflightFailure{reason: lpmv1.MigrationReasonPodSecurityViolation, message: message}
+	case strings.Contains(message, "exceeded quota"), strings.Contains(message, "failed quota"):
+		return &preflightFailure{reason: lpmv1.MigrationReasonQuotaExceeded, message: message}
+	}
+	for _, violation := range limitRangeViolations {
+		if strings.Contains(message, violation) {
+			return &preflightFailure{reason: lpmv1.MigrationReasonQuotaExceeded, message: message}
+		}
+	}
+	return nil
+}
+
+// preflightTargetUserNamespace rejects pods with a user namespace when the
+// target node cannot create one.
+func (r *PodMigrationReconciler) preflightTargetUserNamespace(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	hostUsers := srcPod.Spec.HostUsers == nil || *srcPod.Spec.HostUsers
+	if !hostUsers && podMigration.Spec.TargetNode != "" {
+		supported, err := r.nodeSupportsUserNamespaces(ctx, srcPod, podMigration.Spec.TargetNode)
+		if err != nil {
+			return nil, err
+		}
+		if !supported {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("pod uses a user namespace (hostUsers: false) but node %s does not support user namespaces", podMigration.Spec.TargetNode),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// preflightSecondaryNetworks rejects secondary network attachments CRIU cannot
+// treat as external to the checkpoint. Multus recreates the pod's interfaces
+// on the target node, which works for virtual interfaces but not for ones
+// backed by a host device.
+func (r *PodMigrationReconciler) preflightSecondaryNetworks(_ context.Context, _ *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	networks, err := secondaryNetworks(srcPod)
+	if err != nil {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+			message: "cannot determine the pod's secondary networks: " + err.Error(),
+		}, nil
+	}
+	for _, network := range networks {
+		if info := network.status.DeviceInfo; info != nil {
+			return &preflightFailure{
+				reason: lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("secondary network %s on interface %s is backed by a %s device, which cannot be checkpointed",
+					network.status.Name, network.status.Interface, info.Type),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// preflightReservedResources rejects pods whose device plugin resources or
+// hugepages the target node cannot reserve. The restored pod keeps the
+// source's requests, so the target kubelet allocates equivalent devices and
+// the runtime injects their device nodes and environment into the new
+// containers; processes holding a device open are rejected by
+// preflightContainers, since CRIU cannot carry device state.
+func (r *PodMigrationReconciler) preflightReservedResources(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	message, err := checkReservedResourceFit(ctx, r.Client, withOverrides(podMigration, srcPod), podMigration.Spec.TargetNode)
+	if err != nil || message == "" {
+		return nil, err
+	}
+	return &preflightFailure{reason: lpmv1.MigrationReasonTargetDoesNotFit, message: message}, nil
+}
+
+// preflightNodeConflicts rejects pods whose host ports or exclusive hostPath
+// files and devices a pod on the target node already holds.
+func (r *PodMigrationReconciler) preflightNodeConflicts(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	message, err := checkNodeConflicts(ctx, r.Client, withOverrides(podMigration, srcPod), podMigration.Spec.TargetNode)
+	if err != nil || message == "" {
+		return nil, err
+	}
+	return &preflightFailure{reason: lpmv1.MigrationReasonTargetDoesNotFit, message: message}, nil
+}
+
+// preflightContainers asks the source node's agent for what CRIU cannot
+// handle in the running containers: user namespace setups, open device files,
+// shared memory outside the container, hugetlb memory the pod does not
+// request, sockets bound to secondary network addresses the restored pod
+// will not get back, exec sessions, and the sockets of host network pods. It
+// records the source pod's ID mappings so the restore can be checked against
+// them.
+func (r *PodMigrationReconciler) preflightContainers(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	logger := log.FromContext(ctx)
+
+	external := hostPathResources(srcPod)
+	ttyWarnings(podMigration, srcPod)
+
+	var containerIDs []string
+	for _, containerStatus := range srcPod.Status.ContainerStatuses {
+		if containerStatus.ContainerID != "" {
+			containerIDs = append(containerIDs, containerStatus.ContainerID)
+		}
+	}
+	if len(containerIDs) == 0 {
+		return externalResourcesFailure(podMigration, external), nil
+	}
+
+	// Already validated by preflightSecondaryNetworks
+	networks, _ := secondaryNetworks(srcPod)
+
+	resp, err := r.AgentClient.PreflightContainers(ctx, srcPod.Spec.NodeName, containerIDs, unstableAddresses(networks), srcPod.Spec.HostNetwork)
+	if status.Code(err) == codes.Unimplemented {
+		logger.Info("Agent does not support preflight checks, skipping", "node", srcPod.Spec.NodeName)
+		return externalResourcesFailure(podMigration, external), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	unsupported := resp.Unsupported
+	var hostNetwork, sessions []string
+	hugepages := map[int64]int64{}
+	for _, container := range resp.Containers {
+		unsupported = append(unsupported, container.Unsupported...)
+		hostNetwork = append(hostNetwork, hostSocketFindings(podMigration, srcPod, containerName(srcPod, container.ContainerId), container.HostSockets)...)
+		sessions = append(sessions, execSessionFindings(podMigration, containerName(srcPod, container.ContainerId), container.ExecSessions)...)
+		if podMigration.Spec.ClockHandling == lpmv1.ClockHandlingPreserve && !container.TimeNamespace {
+			unsupported = append(unsupported, fmt.Sprintf("container %s does not run in a time namespace, so its clocks cannot be preserved",
+				containerName(srcPod, container.ContainerId)))
+		}
+		for _, resource := range container.ExternalResources {
+			external = append(external, fmt.Sprintf("container %s: %s", containerName(srcPod, container.ContainerId), resource))
+		}
+		for _, usage := range container.Hugepages {
+			hugepages[usage.PageSizeBytes] += usage.Bytes
+		}
+		recordSourceSecurity(podMigration, container)
+		// Containers of a pod share its user namespace
+		if container.UserNamespace && podMigration.Status.SourceUserNamespace == nil {
+			podMigration.Status.SourceUserNamespace = &lpmv1.UserNamespaceStatus{
+				UIDMappings: toIDMappings(container.UidMappings),
+				GIDMappings: toIDMappings(container.GidMappings),
+			}
+		}
+	}
+	// The restored pod only gets the hugepages it requests, which must hold
+	// every hugetlb page CRIU restores
+	requests := podRequests(srcPod)
+	for pageSize, bytes := range hugepages {
+		name := corev1.ResourceName(corev1.ResourceHugePagesPrefix + resource.NewQuantity(pageSize, resource.BinarySI).String())
+		requested := requests[name]
+		if requested.Value() < bytes {
+			unsupported = append(unsupported, fmt.Sprintf("containers use %s of %s hugetlb memory but the pod requests %s",
+				resource.NewQuantity(bytes, resource.BinarySI), name, requested.String()))
+		}
+	}
+	if len(hostNetwork) > 0 {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonHostNetworkUnsupported,
+			message: "cannot restore host network pod: " + strings.Join(hostNetwork, "; "),
+		}, nil
+	}
+	if len(unsupported) > 0 {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+			message: "cannot checkpoint pod: " + strings.Join(unsupported, "; "),
+		}, nil
+	}
+	if len(sessions) > 0 {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonExecSessions,
+			message: "cannot checkpoint pod: " + strings.Join(sessions, "; ") + "; end the sessions or set execSessionPolicy to Terminate",
+		}, nil
+	}
+	return externalResourcesFailure(podMigration, external), nil
+}
+
+// preflightClock compares the source and target nodes' monotonic clocks and,
+// with clockHandling Preserve, requires the target kernel to support time
+// namespaces. Whether the containers run in one is checked by
+// preflightContainers.
+func (r *PodMigrationReconciler) preflightClock(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	logger := log.FromContext(ctx)
+
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	source, err := r.AgentClient.GetNodeClock(ctx, srcPod.Spec.NodeName)
+	if status.Code(err) == codes.Unimplemented {
+		logger.Info("Agent does not support reading clocks, skipping clock check", "node", srcPod.Spec.NodeName)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	target, err := r.AgentClient.GetNodeClock(ctx, podMigration.Spec.TargetNode)
+	if status.Code(err) == codes.Unimplemented {
+		logger.Info("Agent does not support reading clocks, skipping clock check", "node", podMigration.Spec.TargetNode)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if podMigration.Spec.ClockHandling == lpmv1.ClockHandlingPreserve {
+		if !target.TimeNamespaces {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("clockHandling is Preserve but node %s's kernel does not support time namespaces", podMigration.Spec.TargetNode),
+			}, nil
+		}
+		return nil, nil
+	}
+
+	// Offsetting each monotonic reading by its wall clock reading cancels
+	// the time between the two calls; node wall clocks are assumed in sync.
+	jump := time.Duration((target.MonotonicNs - target.RealtimeNs) - (source.MonotonicNs - source.RealtimeNs))
+	podMigration.Status.ClockJump = &metav1.Duration{Duration: jump.Round(time.Millisecond)}
+	if jump < -time.Second {
+		addWarning(podMigration, fmt.Sprintf("CLOCK_MONOTONIC is %s behind on node %s; timers armed against it fire that much later after the restore",
+			(-jump).Round(time.Millisecond), podMigration.Spec.TargetNode))
+	}
+	return nil, nil
+}
+
+// hostPathResources lists the pod's hostPath mounts. The restored pod mounts
+// the target node's path, whose content may differ, and files the source held
+// open there may not exist.
+func hostPathResources(pod *corev1.Pod) []string {
+	hostPaths := map[string]string{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			hostPaths[volume.Name] = volume.HostPath.Path
+		}
+	}
+	var resources []string
+	for _, container := range pod.Spec.Containers {
+		for _, mount := range container.VolumeMounts {
+			if path, ok := hostPaths[mount.Name]; ok {
+				resources = append(resources, fmt.Sprintf("container %s: hostPath %s mounted at %s", container.Name, path, mount.MountPath))
+			}
+		}
+	}
+	return resources
+}
+
+// externalResourcesFailure applies spec.externalResourcePolicy to what
+// preflight found outside the pod's containers.
+func externalResourcesFailure(podMigration *lpmv1.PodMigration, external []string) *preflightFailure {
+	if len(external) == 0 {
+		return nil
+	}
+	if podMigration.Spec.ExternalResourcePolicy == lpmv1.ExternalResourcePolicyWarn {
+		for _, resource := range external {
+			addWarning(podMigration, resource)
+		}
+		return nil
+	}
+	return &preflightFailure{
+		reason:  lpmv1.MigrationReasonExternalResources,
+		message: "pod uses state outside its containers (set externalResourcePolicy: Warn to migrate anyway): " + strings.Join(external, "; "),
+	}
+}
+
+// addWarning records message in the migration's status once.
+func addWarning(podMigration *lpmv1.PodMigration, message string) {
+	if !slices.Contains(podMigration.Status.Warnings, message) {
+		podMigration.Status.Warnings = append(podMigration.Status.Warnings, message)
+	}
+}
+
+// containerName returns the name of the pod's container with containerID, as
+// reported by the agent without the runtime prefix, or the ID if it is not
+// found.
+func containerName(pod *corev1.Pod, containerID string) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		_, id, _ := strings.Cut(containerStatus.ContainerID, "://")
+		if id == containerID || containerStatus.ContainerID == containerID {
+			return containerStatus.Name
+		}
+	}
+	return containerID
+}
+
+// nodeSupportsUserNamespaces reports whether the node's runtime handler for
+// the pod supports user namespaces. Nodes that don't report their runtime
+// handlers' features are assumed to support them.
+func (r *PodMigrationReconciler) nodeSupportsUserNamespaces(ctx context.Context, pod *corev1.Pod, nodeName string) (bool, error) {
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		return false, err
+	}
+	if len(node.Status.RuntimeHandlers) == 0 {
+		return true, nil
+	}
+
+	handler := ""
+	if pod.Spec.RuntimeClassName != nil {
+		var runtimeClass nodev1.RuntimeClass
+		if err := r.Get(ctx, client.ObjectKey{Name: *pod.Spec.RuntimeClassName}, &runtimeClass); err != nil {
+			return false, err
+		}
+		handler = runtimeClass.Handler
+	}
+	for _, h := range node.Status.RuntimeHandlers {
+		if h.Name == handler {
+			return h.Features != nil && h.Features.UserNamespaces != nil && *h.Features.UserNamespaces, nil
+		}
+	}
+	return false, nil
+}
+
+// verifyUserNamespace checks that the restored pod's containers run in a user
+// namespace mapping every container ID the source pod's did, so restored
+// processes and files keep valid owners. An error means the verdict is not
+// available yet.
+func (r *PodMigrationReconciler) verifyUserNamespace(ctx context.Context, source *lpmv1.UserNamespaceStatus, restoredPod *corev1.Pod) (bool, string, error) {
+	var containerIDs []string
+	for _, containerStatus := range restoredPod.Status.ContainerStatuses {
+		containerIDs = append(containerIDs, containerStatus.ContainerID)
+	}
+	resp, err := r.AgentClient.PreflightContainers(ctx, restoredPod.Spec.NodeName, containerIDs, nil, false)
+	if err != nil {
+		return false, "", err
+	}
+	for _, container := range resp.Containers {
+		if !container.UserNamespace {
+			return false, fmt.Sprintf("container %s runs in the host user namespace", container.ContainerId), nil
+		}
+		if !idMappingsCover(toIDMappings(container.UidMappings), source.UIDMappings) {
+			return false, fmt.Sprintf("container %s does not map all UIDs of the source pod", container.ContainerId), nil
+		}
+		if !idMappingsCover(toIDMappings(container.GidMappings), source.GIDMappings) {
+			return false, fmt.Sprintf("container %s does not map all GIDs of the source pod", container.ContainerId), nil
+		}
+	}
+	return true, "", nil
+}
+
+// idMappingsCover reports whether every container ID mapped by source is
+// also mapped by target. Host IDs may differ.
+func idMappingsCover(target, source []lpmv1.IDMapping) bool {
+	for _, s := range source {
+		for id := s.ContainerID; id < s.ContainerID+s.Size; {
+			next := id
+			for _, t := range target {
+				if id >= t.ContainerID && id < t.ContainerID+t.Size {
+					next = t.ContainerID + t.Size
+					break
+				}
+			}
+			if next == id {
+				return false
+			}
+			id = next
+		}
+	}
+	return true
+}
+
+func toIDMappings(mappings []*pb.IDMapping) []lpmv1.IDMapping {
+	var out []lpmv1.IDMapping
+	for _, m := range mappings {
+		out = append(out, lpmv1.IDMapping{
+			ContainerID: int64(m.ContainerId),
+			HostID:      int64(m.HostId),
+			Size:        int64(m.Size),
+		})
+	}
+	return out
+}
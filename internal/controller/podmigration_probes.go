@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;patch
+
+// restoreCompleteCondition is the readiness gate of restored pods. The
+// controller sets it once the agent confirmed every container was restored
+// from its checkpoint, so the pod receives no traffic before.
+var restoreCompleteCondition = corev1.PodConditionType(lpmv1.GroupVersion.Group + "/restore-complete")
+
+// defaultRestoreGracePeriod is how long liveness probes of a restored
+// container are held off while the restored application catches up, probing
+// every defaultRestoreProbePeriod seconds.
+const (
+	defaultRestoreGracePeriod = 5 * time.Minute
+	defaultRestoreProbePeriod = 10
+)
+
+// guardRestoredPodProbes adds the restore readiness gate to a restored pod
+// and holds off its liveness probes: a restored application did not go
+// through its normal startup, and the kubelet would otherwise kill it for
+// probes failing while the restore completes. Liveness probes only start once
+// a startup probe succeeded, so containers without one get a startup probe
+// running the liveness check with a generous failure threshold. With
+// spec.restoreStartupProbe, existing startup probes are retimed as well.
+func guardRestoredPodProbes(pod *corev1.Pod, tuning *lpmv1.RestoreStartupProbe) {
+	pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: restoreCompleteCondition})
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		var startup *corev1.Probe
+		switch {
+		case container.StartupProbe != nil && tuning != nil:
+			startup = container.StartupProbe.DeepCopy()
+		case container.StartupProbe == nil && container.LivenessProbe != nil:
+			startup = container.LivenessProbe.DeepCopy()
+		default:
+			continue
+		}
+		startup.InitialDelaySeconds = 0
+		startup.SuccessThreshold = 1
+		startup.PeriodSeconds = defaultRestoreProbePeriod
+		startup.FailureThreshold = int32(defaultRestoreGracePeriod/time.Second) / defaultRestoreProbePeriod
+		if tuning != nil && tuning.PeriodSeconds != nil {
+			startup.PeriodSeconds = *tuning.PeriodSeconds
+		}
+		if tuning != nil && tuning.FailureThreshold != nil {
+			startup.FailureThreshold = *tuning.FailureThreshold
+		}
+		container.StartupProbe = startup
+	}
+}
+
+// markRestoreComplete sets the restore readiness gate of a restored pod.
+func (r *PodMigrationReconciler) markRestoreComplete(ctx context.Context, restoredPod *corev1.Pod, message string) error {
+	for _, condition := range restoredPod.Status.Conditions {
+		if condition.Type == restoreCompleteCondition && condition.Status == corev1.ConditionTrue {
+			return nil
+		}
+	}
+	patch := client.StrategicMergeFrom(restoredPod.DeepCopy())
+	restoredPod.Status.Conditions = append(restoredPod.Status.Conditions, corev1.PodCondition{
+		Type:               restoreCompleteCondition,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             lpmv1.MigrationReasonRestoreVerified,
+		Message:            message,
+	})
+	return r.Status().Patch(ctx, restoredPod, patch)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// maxReportedIncompatibleFiles caps the files listed when a rebase fails.
+const maxReportedIncompatibleFiles = 5
+
+// preflightRebase rejects rebases of containers the pod does not have and
+// warns about base images not pinned by digest, which nodes may resolve to
+// different images.
+func (r *PodMigrationReconciler) preflightRebase(_ context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	for _, rebase := range podMigration.Spec.Rebase {
+		if !slices.ContainsFunc(srcPod.Spec.Containers, func(c corev1.Container) bool { return c.Name == rebase.Container }) {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonRebaseIncompatible,
+				message: fmt.Sprintf("rebase names container %s, which the pod does not have", rebase.Container),
+			}, nil
+		}
+		if !strings.Contains(rebase.Image, "@sha256:") {
+			addWarning(podMigration, fmt.Sprintf("rebase image %s of container %s is not pinned by digest", rebase.Image, rebase.Container))
+		}
+	}
+	return nil, nil
+}
+
+// rebaseOf returns the rebase the migration requests for container, or nil.
+func rebaseOf(podMigration *lpmv1.PodMigration, container string) *lpmv1.ContainerRebase {
+	for i := range podMigration.Spec.Rebase {
+		if podMigration.Spec.Rebase[i].Container == container {
+			return &podMigration.Spec.Rebase[i]
+		}
+	}
+	return nil
+}
+
+// checkRebase has the agent that will convert content's checkpoint check,
+// once, that the checkpointed processes find the files they use unchanged
+// in the rebase image, and records the result in status. A failure means
+// the checkpoint cannot be rebased.
+func (r *PodMigrationReconciler) checkRebase(ctx context.Context, podMigration *lpmv1.PodMigration, content *lpmv1.ContainerCheckpointContent, rebase *lpmv1.ContainerRebase) (*lpmv1.ContainerRebaseStatus, *preflightFailure, error) {
+	for i, checked := range podMigration.Status.Rebase {
+		if checked.Container == rebase.Container && checked.Image == rebase.Image {
+			return &podMigration.Status.Rebase[i], nil, nil
+		}
+	}
+
+	node := r.conversionNode(content, podMigration.Spec.TargetNode)
+	if node == "" {
+		return nil, &preflightFailure{
+			reason: lpmv1.MigrationReasonRebaseIncompatible,
+			message: fmt.Sprintf("checkpoint of container %s is only on its node; rebasing it needs shared storage, a checkpoint registry or a direct transfer",
+				rebase.Container),
+		}, nil
+	}
+	resp, err := r.AgentClient.CheckRebase(ctx, node, content.Spec.ArtifactURI, rebase.Image)
+	if err != nil {
+		return nil, nil, err
+	}
+	if incompatible := resp.IncompatibleFiles; len(incompatible) > 0 {
+		listed := incompatible[:min(len(incompatible), maxReportedIncompatibleFiles)]
+		message := fmt.Sprintf("container %s cannot be restored on top of %s: %d of the %d files its processes use differ: %s",
+			rebase.Container, rebase.Image, len(incompatible), resp.CheckedFiles, strings.Join(listed, "; "))
+		return nil, &preflightFailure{reason: lpmv1.MigrationReasonRebaseIncompatible, message: message}, nil
+	}
+
+	log.FromContext(ctx).Info("Checkpoint can be rebased", "container", rebase.Container, "from", resp.OriginalImage,
+		"image", rebase.Image, "imageID", resp.BaseImageId, "checkedFiles", resp.CheckedFiles)
+	podMigration.Status.Rebase = append(podMigration.Status.Rebase, lpmv1.ContainerRebaseStatus{
+		Container:    rebase.Container,
+		Image:        rebase.Image,
+		ImageID:      resp.BaseImageId,
+		FromImage:    resp.OriginalImage,
+		CheckedFiles: resp.CheckedFiles,
+	})
+	return &podMigration.Status.Rebase[len(podMigration.Status.Rebase)-1], nil, nil
+}
+
+// conversionNode returns the node whose agent converts content's checkpoint
+// to an image, or "" if it is restored from the archive as is.
+func (r *PodMigrationReconciler) conversionNode(content *lpmv1.ContainerCheckpointContent, targetNode string) string {
+	switch uri := content.Spec.ArtifactURI; {
+	case strings.HasPrefix(uri, "shared://"):
+		return targetNode
+	case strings.HasPrefix(uri, "file://") && (r.Registry != nil || content.Spec.ArtifactTransfer == lpmv1.CheckpointTransferDirect):
+		return content.Spec.NodeName
+	}
+	return ""
+}
+
+// rebasedImageName names the image of a checkpoint rebased onto rebase's
+// image after the image of the checkpoint itself.
+func rebasedImageName(imageName string, rebase *lpmv1.ContainerRebaseStatus) string {
+	id := strings.TrimPrefix(rebase.ImageID, "sha256:")
+	return imageName + "-rebased-" + id[:min(len(id), 12)]
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+const (
+	// podDeletionCostAnnotation ranks the pods a ReplicaSet deletes when it
+	// has more than it wants; the lowest cost goes first.
+	podDeletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
+
+	// replicaSetReadyTimeout is how long a restored pod may take to become
+	// Ready after its restore was verified before it is left to the
+	// migration rather than handed to its ReplicaSet.
+	replicaSetReadyTimeout = 2 * time.Minute
+)
+
+// handOverToReplicaSet makes the ReplicaSet controlling the source pod adopt
+// the restored pod before the source pod is deleted, so it keeps its replica
+// count instead of starting a new replica from its template, when
+// spec.replicaSetAdoption or spec.ownerHandling asks for it. It returns
+// false while the restored pod is not Ready yet.
+//
+// For a moment the ReplicaSet controls one pod more than it wants and scales
+// down. It deletes pods that aren't Ready before Ready ones, so the restored
+// pod is only handed over once Ready, and the source pod is first given the
+// lowest deletion cost so the ReplicaSet deletes it rather than the restored
+// pod. The migration deletes the source pod right after either way, and a
+// ReplicaSet scaled up meanwhile replaces it then.
+func (r *PodMigrationReconciler) handOverToReplicaSet(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if podMigration.Spec.ReplicaSetAdoption != lpmv1.ReplicaSetAdoptionAdopt &&
+		podMigration.Spec.OwnerHandling != lpmv1.OwnerHandlingCoordinateScale {
+		return true, nil
+	}
+	if handover := podMigration.Status.ReplicaSet; handover != nil && (handover.Adopted || handover.Message != "") {
+		return true, nil
+	}
+	var sourcePod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &sourcePod); err != nil {
+		return true, client.IgnoreNotFound(err)
+	}
+	owner := metav1.GetControllerOf(&sourcePod)
+	if owner == nil || owner.Kind != "ReplicaSet" {
+		addWarning(podMigration, "the restored pod was to be adopted by a ReplicaSet but the source pod is not controlled by one")
+		return true, nil
+	}
+	handover := &lpmv1.ReplicaSetHandover{Name: owner.Name}
+	podMigration.Status.ReplicaSet = handover
+
+	var replicaSet appsv1.ReplicaSet
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: owner.Name}, &replicaSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			handover.Message = "ReplicaSet is gone; the restored pod stays with the migration"
+			return true, nil
+		}
+		return false, err
+	}
+	replicas := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		replicas = *replicaSet.Spec.Replicas
+	}
+	handover.Replicas = &replicas
+	if replicaSet.UID != owner.UID || !replicaSet.DeletionTimestamp.IsZero() {
+		handover.Message = "ReplicaSet is being deleted; the restored pod stays with the migration"
+		return true, nil
+	}
+	if replicas == 0 {
+		handover.Message = "ReplicaSet was scaled to zero; the restored pod stays with the migration"
+		return true, nil
+	}
+
+	if !podReady(restoredPod) {
+		verified := restoreVerifiedTime(restoredPod)
+		if verified.IsZero() || time.Since(verified) < replicaSetReadyTimeout {
+			podMigration.Status.Message = fmt.Sprintf("waiting for the restored pod to become Ready before handing it to ReplicaSet %s", owner.Name)
+			return false, r.Status().Update(ctx, podMigration)
+		}
+		handover.Message = fmt.Sprintf("restored pod did not become Ready within %s, so the ReplicaSet would delete it first; it stays with the migration", replicaSetReadyTimeout)
+		return true, nil
+	}
+
+	// The ReplicaSet only keeps pods its selector still matches, and the
+	// restored pod's labels may have been overridden
+	selector, err := metav1.LabelSelectorAsSelector(replicaSet.Spec.Selector)
+	if err != nil {
+		handover.Message = fmt.Sprintf("ReplicaSet has an invalid selector: %v", err)
+		return true, nil
+	}
+	adoptedLabels := labels.Merge(restoredPod.Labels, replicaSet.Spec.Selector.MatchLabels)
+	if hash, ok := sourcePod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+		adoptedLabels[appsv1.DefaultDeploymentUniqueLabelKey] = hash
+	}
+	if !selector.Matches(labels.Set(adoptedLabels)) {
+		handover.Message = fmt.Sprintf("restored pod's labels do not match the ReplicaSet's selector %s; it stays with the migration", selector)
+		return true, nil
+	}
+
+	patch := client.MergeFrom(sourcePod.DeepCopy())
+	if sourcePod.Annotations == nil {
+		sourcePod.Annotations = map[string]string{}
+	}
+	sourcePod.Annotations[podDeletionCostAnnotation] = strconv.Itoa(math.MinInt32)
+	if err := r.Patch(ctx, &sourcePod, patch); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	patch = client.MergeFrom(restoredPod.DeepCopy())
+	restoredPod.Labels = adoptedLabels
+	restoredPod.OwnerReferences = []metav1.OwnerReference{*owner}
+	if err := r.Patch(ctx, restoredPod, patch); err != nil {
+		return false, fmt.Errorf("failed to hand restored pod to ReplicaSet %s: %w", owner.Name, err)
+	}
+	handover.Adopted = true
+	handover.Message = fmt.Sprintf("ReplicaSet adopted the restored pod in place of the source pod at %d replicas", replicas)
+	logger.Info("Handed restored pod to ReplicaSet", "pod", restoredPod.Name, "replicaSet", owner.Name)
+	return true, nil
+}
+
+// podReady reports whether pod's Ready condition is True.
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// restoreVerifiedTime returns when the restore readiness gate of a restored
+// pod was set, or the zero time.
+func restoreVerifiedTime(pod *corev1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == restoreCompleteCondition && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
+
+// reportConfigMapSuffix is appended to a migration's name to name the
+// ConfigMap holding its report.
+const reportConfigMapSuffix = "-report"
+
+// writeReport writes the MigrationReport of an ended migration to a
+// ConfigMap owned by it, so the report is deleted with the migration, and
+// records the ConfigMap's name in status.
+func (r *PodMigrationReconciler) writeReport(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	report := r.buildReport(ctx, podMigration)
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podMigration.Name + reportConfigMapSuffix,
+			Namespace: podMigration.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")),
+			},
+		},
+		Data: map[string]string{lpmv1.MigrationReportKey: string(data)},
+	}
+	if err := r.Create(ctx, configMap); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		// Written before the status update recording it failed
+		var existing corev1.ConfigMap
+		if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), &existing); err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(&existing, podMigration) {
+			log.FromContext(ctx).Info("Report ConfigMap exists and is not owned by the migration, not writing the report",
+				"configMap", configMap.Name)
+			return nil
+		}
+		existing.Data = configMap.Data
+		if err := r.Update(ctx, &existing); err != nil {
+			return err
+		}
+	}
+
+	podMigration.Status.ReportConfigMap = configMap.Name
+	return r.Status().Update(ctx, podMigration)
+}
+
+// buildReport gathers the report of an ended migration from its status and
+// its checkpoint. Checkpoint objects that are gone are left out.
+func (r *PodMigrationReconciler) buildReport(ctx context.Context, podMigration *lpmv1.PodMigration) *lpmv1.MigrationReport {
+	logger := log.FromContext(ctx)
+	status := &podMigration.Status
+
+	report := &lpmv1.MigrationReport{
+		Namespace:          podMigration.Namespace,
+		Migration:          podMigration.Name,
+		Pod:                podMigration.Spec.PodName,
+		SourceNode:         status.SourceNode,
+		TargetNode:         podMigration.Spec.TargetNode,
+		RestoredPod:        status.RestoredPodName,
+		Phase:              status.Phase,
+		Reason:             status.Reason,
+		Message:            status.Message,
+		StartTime:          podMigration.CreationTimestamp,
+		CompletionTime:     metav1.Now(),
+		PredictedDowntime:  status.PredictedDowntime,
+		StrategyDecision:   status.StrategyDecision,
+		Simulation:         status.Simulation,
+		TransferEncryption: status.TransferEncryption,
+		Generation:         status.CheckpointGeneration,
+		Warnings:           status.Warnings,
+		MigrationPolicies:  status.MigrationPolicies,
+		Conditions:         status.Conditions,
+	}
+
+	for i, transition := range status.PhaseTransitions {
+		phase := lpmv1.PhaseReport{Phase: transition.Phase, StartTime: transition.Time}
+		if i+1 < len(status.PhaseTransitions) {
+			phase.Duration = &metav1.Duration{Duration: status.PhaseTransitions[i+1].Time.Sub(transition.Time.Time)}
+		} else {
+			report.CompletionTime = transition.Time
+		}
+		report.Phases = append(report.Phases, phase)
+	}
+	report.Duration = metav1.Duration{Duration: report.CompletionTime.Sub(report.StartTime.Time)}
+	if status.CheckpointStartTime != nil && status.Phase == lpmv1.MigrationPhaseSucceeded {
+		report.Downtime = &metav1.Duration{Duration: report.CompletionTime.Sub(status.CheckpointStartTime.Time)}
+	}
+
+	if status.PodCheckpointRef == nil {
+		return report
+	}
+	report.Checkpoint = status.PodCheckpointRef.Name
+
+	var podCheckpoint lpmv1.PodCheckpoint
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: report.Checkpoint}, &podCheckpoint); err == nil {
+		report.Hooks = podCheckpoint.Status.HookResults
+	} else {
+		logger.Info("Checkpoint of the report not found", "checkpoint", report.Checkpoint, "error", err.Error())
+	}
+
+	checkpointContent, err := r.getCheckpointContent(ctx, podMigration)
+	if err != nil {
+		logger.Info("Checkpoint content of the report not found", "error", err.Error())
+		return report
+	}
+	for _, ref := range checkpointContent.Spec.ContainerContents {
+		var content lpmv1.ContainerCheckpointContent
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+			logger.Info("Container checkpoint content of the report not found", "content", ref.Name, "error", err.Error())
+			continue
+		}
+		report.Containers = append(report.Containers, lpmv1.ContainerReport{
+			Name:              content.Spec.ContainerName,
+			Content:           content.Name,
+			ArtifactURI:       content.Spec.ArtifactURI,
+			ArtifactDigest:    content.Spec.ArtifactDigest,
+			ArtifactSizeBytes: content.Spec.ArtifactSizeBytes,
+			Compression:       content.Spec.Compression,
+			Image:             status.CheckpointImages[content.Spec.ContainerName],
+		})
+		report.BytesTransferred += content.Spec.ArtifactSizeBytes
+	}
+	return report
+}
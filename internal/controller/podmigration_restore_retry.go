@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// maxRestoreRetries is how many times a restore that failed on its
+// checkpoint images is retried with freshly converted ones.
+const maxRestoreRetries = 1
+
+// restoreArtifactReasons are the waiting and termination reasons of a
+// restored container whose checkpoint image could not be found, pulled or
+// restored, e.g. after a transient shared storage error corrupted it.
+var restoreArtifactReasons = sets.New(
+	"ErrImagePull", "ImagePullBackOff", "ErrImageNeverPull",
+	"CreateContainerError", "RunContainerError", "StartError", "ContainerCannotRun",
+)
+
+// restoreArtifactError describes how a container of the restored pod failed
+// on its checkpoint image, or returns "" if none did.
+func restoreArtifactError(restoredPod *corev1.Pod) string {
+	for _, status := range restoredPod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && restoreArtifactReasons.Has(waiting.Reason) {
+			return fmt.Sprintf("container %s: %s: %s", status.Name, waiting.Reason, waiting.Message)
+		}
+		if terminated := status.State.Terminated; terminated != nil && restoreArtifactReasons.Has(terminated.Reason) {
+			return fmt.Sprintf("container %s: %s: %s", status.Name, terminated.Reason, terminated.Message)
+		}
+	}
+	return ""
+}
+
+// retryRestore handles a restored pod that failed on its checkpoint images.
+// The first times it deletes the pod, forgets the images and sends the
+// migration back to PreparingImages, where they are converted again from
+// archives checked against their digests; it then fails the migration.
+func (r *PodMigrationReconciler) retryRestore(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod, cause string) error {
+	logger := log.FromContext(ctx)
+
+	if err := r.Delete(ctx, restoredPod); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if podMigration.Status.RestoreRetries >= maxRestoreRetries {
+		return r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonRestoreArtifactError,
+			"restored pod failed on its checkpoint images after they were converted again: "+cause)
+	}
+
+	// Images pushed to the registry are only pulled again unless forgotten
+	checkpointContent, err := r.getCheckpointContent(ctx, podMigration)
+	if err != nil {
+		return r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonRestoreArtifactError,
+			fmt.Sprintf("restored pod failed on its checkpoint images (%s) and the checkpoint is gone: %v", cause, err))
+	}
+	for _, ref := range checkpointContent.Spec.ContainerContents {
+		var content lpmv1.ContainerCheckpointContent
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+			return err
+		}
+		if err := r.recordImageReference(ctx, &content, ""); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Restored pod failed on its checkpoint images, converting them again", "pod", restoredPod.Name, "cause", cause)
+	addWarning(podMigration, "restore retried after the restored pod failed on its checkpoint images: "+cause)
+	podMigration.Status.RestoreRetries++
+	podMigration.Status.RestoredPodName = ""
+	podMigration.Status.CheckpointImages = nil
+	return r.updatePhase(ctx, podMigration, lpmv1.MigrationPhasePreparingImages, "converting checkpoint images again after a failed restore")
+}
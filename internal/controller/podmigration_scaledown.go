@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=patch
+
+// addedProtectionAnnotation lists the scale-down protection annotations the
+// controller added to an object, so releasing it leaves those its owner set
+// untouched.
+const addedProtectionAnnotation = "lpm.my.domain/added-protection"
+
+// podScaleDownProtection keeps the cluster autoscaler and Karpenter from
+// evicting a pod, and from removing its node, to consolidate nodes.
+var podScaleDownProtection = map[string]string{
+	"cluster-autoscaler.kubernetes.io/safe-to-evict": "false",
+	"karpenter.sh/do-not-disrupt":                    "true",
+}
+
+// nodeScaleDownProtection keeps the cluster autoscaler and Karpenter from
+// removing a node, even while it runs no pods.
+var nodeScaleDownProtection = map[string]string{
+	"cluster-autoscaler.kubernetes.io/scale-down-disabled": "true",
+	"karpenter.sh/do-not-disrupt":                          "true",
+}
+
+// addProtection sets the protection annotations obj doesn't have yet and
+// records which it set. It reports false when obj is already protected.
+func addProtection(obj client.Object, protection map[string]string) bool {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if _, ok := annotations[addedProtectionAnnotation]; ok {
+		return false
+	}
+	var added []string
+	for _, key := range slices.Sorted(maps.Keys(protection)) {
+		if _, ok := annotations[key]; !ok {
+			annotations[key] = protection[key]
+			added = append(added, key)
+		}
+	}
+	annotations[addedProtectionAnnotation] = strings.Join(added, ",")
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// removeProtection removes the protection annotations addProtection set. It
+// reports false when obj was not protected by the controller.
+func removeProtection(obj client.Object) bool {
+	annotations := obj.GetAnnotations()
+	added, ok := annotations[addedProtectionAnnotation]
+	if !ok {
+		return false
+	}
+	for _, key := range strings.Split(added, ",") {
+		delete(annotations, key)
+	}
+	delete(annotations, addedProtectionAnnotation)
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// nodeLockHolder names podMigration among the holders of a node's lock;
+// nodes are shared by migrations of every namespace.
+func nodeLockHolder(podMigration *lpmv1.PodMigration) string {
+	return podMigration.Namespace + "/" + podMigration.Name
+}
+
+// protectFromScaleDown keeps cluster autoscalers from removing the pods and
+// nodes of the migration while it is in flight: the source pod, and through
+// the annotations it inherits the restored pod, are marked not evictable, and
+// a requested target node is marked not to be scaled down, since it may sit
+// empty between the checkpoint and the restore.
+func (r *PodMigrationReconciler) protectFromScaleDown(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) ([]string, error) {
+	protected := []string{"pod " + srcPod.Name}
+	patch := client.MergeFrom(srcPod.DeepCopy())
+	if addProtection(srcPod, podScaleDownProtection) {
+		if err := r.Patch(ctx, srcPod, patch); err != nil {
+			return nil, fmt.Errorf("failed to protect source pod: %w", err)
+		}
+	}
+
+	if podMigration.Spec.TargetNode == "" {
+		return protected, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		return nil, err
+	}
+	protected = append(protected, "node "+node.Name)
+	holders := lockHolders(&node)
+	if slices.Contains(holders, nodeLockHolder(podMigration)) {
+		return protected, nil
+	}
+	// Migrations to the same node share its protection
+	nodePatch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if len(holders) == 0 {
+		addProtection(&node, nodeScaleDownProtection)
+	}
+	setLockHolders(&node, append(holders, nodeLockHolder(podMigration)))
+	if err := r.Patch(ctx, &node, nodePatch); err != nil {
+		return nil, fmt.Errorf("failed to protect target node: %w", err)
+	}
+	return protected, nil
+}
+
+// releaseScaleDownProtection removes the protection protectFromScaleDown
+// added from the source and restored pods, and from the target node once no
+// other migration holds it.
+func (r *PodMigrationReconciler) releaseScaleDownProtection(ctx context.Context, podMigration *lpmv1.PodMigration) ([]string, error) {
+	var released []string
+	for _, name := range []string{podMigration.Spec.PodName, podMigration.Status.RestoredPodName} {
+		if name == "" {
+			continue
+		}
+		var pod corev1.Pod
+		if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: name}, &pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if !removeProtection(&pod) {
+			continue
+		}
+		if err := r.Patch(ctx, &pod, patch); err != nil {
+			return nil, fmt.Errorf("failed to unprotect pod %s: %w", name, err)
+		}
+		released = append(released, "pod "+name)
+	}
+
+	if podMigration.Spec.TargetNode == "" {
+		return released, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return released, nil
+		}
+		return nil, err
+	}
+	holders := lockHolders(&node)
+	i := slices.Index(holders, nodeLockHolder(podMigration))
+	if i < 0 {
+		return released, nil
+	}
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	holders = slices.Delete(holders, i, i+1)
+	if len(holders) == 0 {
+		removeProtection(&node)
+	}
+	setLockHolders(&node, holders)
+	if err := r.Patch(ctx, &node, patch); err != nil {
+		return nil, fmt.Errorf("failed to unprotect target node: %w", err)
+	}
+	return append(released, "node "+node.Name), nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// recordSourceSecurity records the security module labels a source
+// container ran with, as reported by the agent's preflight.
+func recordSourceSecurity(podMigration *lpmv1.PodMigration, container *pb.ContainerPreflight) {
+	if container.SelinuxLabel == "" && container.ApparmorProfile == "" {
+		return
+	}
+	if podMigration.Status.SourceSecurityContext == nil {
+		podMigration.Status.SourceSecurityContext = &lpmv1.SecurityContextStatus{}
+	}
+	source := podMigration.Status.SourceSecurityContext
+	// user:role:type:level, where the level itself may contain colons
+	if parts := strings.SplitN(container.SelinuxLabel, ":", 4); len(parts) == 4 && source.SELinuxLevel == "" {
+		source.SELinuxLevel = parts[3]
+	}
+	if container.ApparmorProfile != "" && !slices.Contains(source.AppArmorProfiles, container.ApparmorProfile) {
+		source.AppArmorProfiles = append(source.AppArmorProfiles, container.ApparmorProfile)
+	}
+}
+
+// pinSELinuxLevel sets the source containers' SELinux level on the restored
+// pod unless the pod sets one. The runtime otherwise picks a new random
+// level, which the restored processes and their files would not match.
+func pinSELinuxLevel(podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) {
+	source := podMigration.Status.SourceSecurityContext
+	if source == nil || source.SELinuxLevel == "" {
+		return
+	}
+	if restoredPod.Spec.SecurityContext == nil {
+		restoredPod.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	if restoredPod.Spec.SecurityContext.SELinuxOptions == nil {
+		restoredPod.Spec.SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{}
+	}
+	if restoredPod.Spec.SecurityContext.SELinuxOptions.Level == "" {
+		restoredPod.Spec.SecurityContext.SELinuxOptions.Level = source.SELinuxLevel
+	}
+}
+
+// localhostProfiles returns the node-local seccomp and AppArmor profiles pod
+// references, through security contexts or the deprecated AppArmor
+// annotations.
+func localhostProfiles(pod *corev1.Pod) ([]string, []string) {
+	var seccomp, appArmor []string
+	add := func(profiles []string, profile string) []string {
+		if profile == "" || slices.Contains(profiles, profile) {
+			return profiles
+		}
+		return append(profiles, profile)
+	}
+	addSeccomp := func(profile *corev1.SeccompProfile) {
+		if profile != nil && profile.Type == corev1.SeccompProfileTypeLocalhost && profile.LocalhostProfile != nil {
+			seccomp = add(seccomp, *profile.LocalhostProfile)
+		}
+	}
+	addAppArmor := func(profile *corev1.AppArmorProfile) {
+		if profile != nil && profile.Type == corev1.AppArmorProfileTypeLocalhost && profile.LocalhostProfile != nil {
+			appArmor = add(appArmor, *profile.LocalhostProfile)
+		}
+	}
+
+	if sc := pod.Spec.SecurityContext; sc != nil {
+		addSeccomp(sc.SeccompProfile)
+		addAppArmor(sc.AppArmorProfile)
+	}
+	for _, container := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		if sc := container.SecurityContext; sc != nil {
+			addSeccomp(sc.SeccompProfile)
+			addAppArmor(sc.AppArmorProfile)
+		}
+	}
+	for key, value := range pod.Annotations {
+		if !strings.HasPrefix(key, corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix) {
+			continue
+		}
+		if name, ok := strings.CutPrefix(value, corev1.DeprecatedAppArmorBetaProfileNamePrefix); ok {
+			appArmor = add(appArmor, name)
+		}
+	}
+	slices.Sort(appArmor)
+	return seccomp, appArmor
+}
+
+// preflightSecurityProfiles checks that the target node has the Localhost
+// seccomp and AppArmor profiles the pod references, the AppArmor profiles its
+// containers ran under, and SELinux if they ran under an SELinux label. The
+// restored pod carries the source pod's security contexts and annotations,
+// and the runtime has CRIU restore the processes under the same labels.
+func (r *PodMigrationReconciler) preflightSecurityProfiles(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	logger := log.FromContext(ctx)
+
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	seccomp, appArmor := localhostProfiles(srcPod)
+	source := podMigration.Status.SourceSecurityContext
+	if source != nil {
+		for _, profile := range source.AppArmorProfiles {
+			if !slices.Contains(appArmor, profile) {
+				appArmor = append(appArmor, profile)
+			}
+		}
+	}
+	if len(seccomp) == 0 && len(appArmor) == 0 && (source == nil || source.SELinuxLevel == "") {
+		return nil, nil
+	}
+
+	resp, err := r.AgentClient.CheckSecurityProfiles(ctx, podMigration.Spec.TargetNode, seccomp, appArmor)
+	if status.Code(err) == codes.Unimplemented {
+		logger.Info("Agent does not support checking security profiles, skipping", "node", podMigration.Spec.TargetNode)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, profile := range resp.MissingSeccompProfiles {
+		missing = append(missing, fmt.Sprintf("seccomp profile %s", profile))
+	}
+	for _, profile := range resp.MissingApparmorProfiles {
+		missing = append(missing, fmt.Sprintf("AppArmor profile %s", profile))
+	}
+	if source != nil && source.SELinuxLevel != "" && !resp.SelinuxEnabled {
+		missing = append(missing, "SELinux, which the containers run under")
+	}
+	if len(missing) > 0 {
+		return &preflightFailure{
+			reason:  lpmv1.MigrationReasonSecurityProfileMissing,
+			message: fmt.Sprintf("node %s lacks %s", podMigration.Spec.TargetNode, strings.Join(missing, ", ")),
+		}, nil
+	}
+	return nil, nil
+}
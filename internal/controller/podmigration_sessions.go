@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// criuShellJob lets CRIU dump and restore a process tree whose terminal is
+// outside of it, as the runtime's pty is for a container with tty set.
+const criuShellJob = "shell-job"
+
+// containerCriuOptions returns the CRIU options needed to dump and restore
+// containerName of pod.
+func containerCriuOptions(pod *corev1.Pod, containerName string) []string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == containerName && container.TTY {
+			return []string{criuShellJob}
+		}
+	}
+	return nil
+}
+
+// execSessionFindings returns the exec sessions of a container as
+// unsupported unless spec.execSessionPolicy terminates them, in which case
+// they are recorded as warnings. Sessions opened after preflight are handled
+// the same way by the agent when the container is dumped.
+func execSessionFindings(podMigration *lpmv1.PodMigration, container string, sessions []*pb.ExecSession) []string {
+	var unsupported []string
+	for _, session := range sessions {
+		description := fmt.Sprintf("container %s has exec session pid %d (%s)", container, session.Pid, session.Command)
+		if session.Tty {
+			description += " with a terminal"
+		}
+		if podMigration.Spec.ExecSessionPolicy == lpmv1.ExecSessionPolicyTerminate {
+			addWarning(podMigration, description+", which is terminated by the checkpoint")
+			continue
+		}
+		unsupported = append(unsupported, description)
+	}
+	return unsupported
+}
+
+// ttyWarnings records the containers of pod with a terminal: they are dumped
+// as shell jobs, and clients attached to them are disconnected when the
+// source pod is deleted.
+func ttyWarnings(podMigration *lpmv1.PodMigration, pod *corev1.Pod) {
+	for _, container := range pod.Spec.Containers {
+		if container.TTY {
+			addWarning(podMigration, fmt.Sprintf("container %s has a terminal; attached clients are disconnected and must attach to the restored pod", container.Name))
+		}
+	}
+}
+
+// configureRestoreCriu sets the CRIU options the checkpoint's containers
+// were dumped with on the target node, which the runtime restores them with.
+func (r *PodMigrationReconciler) configureRestoreCriu(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	contents, err := r.containerContents(ctx, podMigration)
+	if err != nil {
+		return err
+	}
+	var options []string
+	for _, content := range contents {
+		for _, option := range content.Spec.CriuOptions {
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return r.AgentClient.ConfigureCriu(ctx, podMigration.Spec.TargetNode, options)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/metrics"
+)
+
+const (
+	// simulationSampleWindow is how long the source pod's memory writes are
+	// tracked to estimate its dirty rate.
+	simulationSampleWindow = 5 * time.Second
+
+	// bandwidthProbeDuration is how long data is streamed between the
+	// source and target nodes' agents to measure the bandwidth.
+	bandwidthProbeDuration = 2 * time.Second
+
+	// migrationPhaseOverhead approximates the time a migration spends outside
+	// data movement: requeue delays between phases and restored pod startup.
+	migrationPhaseOverhead = 10 * time.Second
+)
+
+// simulate measures the source pod and the target node and reports the
+// predicted timeline of the migration without touching the workload.
+func (r *PodMigrationReconciler) simulate(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	restoredPod := withOverrides(podMigration, srcPod).DeepCopy()
+	if err := r.tolerateTargetTaints(ctx, podMigration, restoredPod); err != nil {
+		return ctrl.Result{}, err
+	}
+	fitMessage, err := checkTargetFit(ctx, r.Client, restoredPod, podMigration.Spec.TargetNode)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var containerIDs []string
+	for _, status := range srcPod.Status.ContainerStatuses {
+		if status.ContainerID == "" {
+			logger.Info("Source container has no ID yet, retrying", "container", status.Name)
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		containerIDs = append(containerIDs, status.ContainerID)
+	}
+
+	estimate, err := r.AgentClient.EstimateMigration(ctx, srcPod.Spec.NodeName, containerIDs, simulationSampleWindow)
+	if err != nil {
+		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, fmt.Sprintf("simulation failed: %v", err))
+	}
+
+	bandwidth, err := r.probeBandwidth(ctx, podMigration, srcPod.Spec.NodeName, podMigration.Spec.TargetNode)
+	if err != nil {
+		return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseFailed, fmt.Sprintf("simulation failed: %v", err))
+	}
+
+	simulation := &lpmv1.MigrationSimulation{
+		StorageWriteBytesPerSecond: estimate.StorageWriteBytesPerSecond,
+		StorageReadBytesPerSecond:  estimate.StorageReadBytesPerSecond,
+		NetworkBytesPerSecond:      bandwidth,
+		TargetFits:                 fitMessage == "",
+		TargetFitMessage:           fitMessage,
+	}
+	for _, container := range estimate.Containers {
+		simulation.EstimatedCheckpointBytes += container.MemoryBytes
+		simulation.DirtyBytesPerSecond += container.DirtyBytesPerSecond
+	}
+
+	downtime := checkpointDowntime(simulation.EstimatedCheckpointBytes, simulation.StorageWriteBytesPerSecond, simulation.StorageReadBytesPerSecond)
+	simulation.PredictedDowntime = metav1.Duration{Duration: downtime.Round(time.Millisecond)}
+	simulation.PredictedDuration = metav1.Duration{Duration: (downtime + migrationPhaseOverhead).Round(time.Millisecond)}
+
+	podMigration.Status.Simulation = simulation
+	if podMigration.Spec.Strategy == lpmv1.MigrationStrategyAuto {
+		if podMigration.Status.StrategyDecision, err = r.decideStrategy(ctx, podMigration, estimate, bandwidth); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	logger.Info("Simulated migration", "name", podMigration.Name,
+		"checkpointBytes", simulation.EstimatedCheckpointBytes, "networkBytesPerSecond", simulation.NetworkBytesPerSecond,
+		"downtime", simulation.PredictedDowntime.Duration,
+		"targetFits", simulation.TargetFits)
+
+	if !simulation.TargetFits {
+		return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonTargetDoesNotFit,
+			"simulation: target node cannot run the pod: "+fitMessage)
+	}
+	if message := downtimeExceeded(podMigration, "predicted", downtime); message != "" {
+		return ctrl.Result{}, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonSLOExceeded, "simulation: "+message)
+	}
+	podMigration.Status.Reason = lpmv1.MigrationReasonSimulated
+	return ctrl.Result{}, r.updatePhase(ctx, podMigration, lpmv1.MigrationPhaseSucceeded,
+		fmt.Sprintf("simulation: predicted duration %s, downtime %s",
+			simulation.PredictedDuration.Duration, simulation.PredictedDowntime.Duration))
+}
+
+// checkpointDowntime predicts the downtime of a checkpoint of bytes: it is
+// written to shared storage, then read back once to build the checkpoint
+// image and once more by the runtime to restore it.
+func checkpointDowntime(bytes, writeBytesPerSecond, readBytesPerSecond int64) time.Duration {
+	return transferTime(bytes, writeBytesPerSecond) + 2*transferTime(bytes, readBytesPerSecond)
+}
+
+func transferTime(bytes, bytesPerSecond int64) time.Duration {
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(float64(bytes) / float64(bytesPerSecond) * float64(time.Second))
+}
+
+// probeBandwidth measures the throughput from the agent on sourceNode to the
+// agent on targetNode and exports it as a metric. The probe is encrypted
+// with a key the target's agent generates for the migration, as the
+// migration's streams are, and how is recorded in the migration's status.
+func (r *PodMigrationReconciler) probeBandwidth(ctx context.Context, podMigration *lpmv1.PodMigration, sourceNode, targetNode string) (int64, error) {
+	encryption := &lpmv1.TransferEncryption{}
+	transfer, err := r.AgentClient.OpenTransfer(ctx, targetNode, string(podMigration.UID))
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		encryption.Message = fmt.Sprintf("the agent on node %s does not support encrypted transfers", targetNode)
+	case err != nil:
+		return 0, err
+	}
+
+	resp, err := r.AgentClient.ProbeBandwidth(ctx, sourceNode, targetNode, bandwidthProbeDuration, transfer)
+	if err != nil {
+		return 0, err
+	}
+	metrics.NodeBandwidth.WithLabelValues(sourceNode, targetNode).Set(float64(resp.BytesPerSecond))
+
+	if resp.Encryption != nil {
+		encryption.Encrypted = true
+		encryption.Protocol = resp.Encryption.Protocol
+		encryption.CipherSuite = resp.Encryption.CipherSuite
+	} else if transfer != nil {
+		encryption.Message = fmt.Sprintf("the agent on node %s does not support encrypted transfers", sourceNode)
+	}
+	podMigration.Status.TransferEncryption = encryption
+	return resp.BytesPerSecond, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// podReplacedMessage returns why pod is not the instance with uid, or "" if
+// it is or no UID was recorded yet. A pod deleted and recreated under the
+// same name, e.g. by its controller, keeps the name but gets a new UID.
+func podReplacedMessage(uid types.UID, pod *corev1.Pod) string {
+	if uid == "" || pod.UID == uid {
+		return ""
+	}
+	return fmt.Sprintf("pod %s was recreated (UID %s, was %s)", pod.Name, pod.UID, uid)
+}
+
+// verifySourcePod fails the migration if the pod under the source pod's name
+// is no longer the one the migration started with, so a replacement is never
+// checkpointed, restored from or deleted. It reports whether the migration
+// failed. A source pod that is gone is left to the phase to handle.
+func (r *PodMigrationReconciler) verifySourcePod(ctx context.Context, podMigration *lpmv1.PodMigration) (bool, error) {
+	if podMigration.Status.SourcePodUID == "" {
+		return false, nil
+	}
+	var pod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &pod); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	message := podReplacedMessage(podMigration.Status.SourcePodUID, &pod)
+	if message == "" {
+		return false, nil
+	}
+	return true, r.failWithReason(ctx, podMigration, lpmv1.MigrationReasonSourcePodReplaced, message)
+}
+
+// deleteSourcePod deletes pod if it is the migration's source pod. The
+// deletion is preconditioned on the UID, so a pod recreated since it was
+// read is not deleted either.
+func (r *PodMigrationReconciler) deleteSourcePod(ctx context.Context, podMigration *lpmv1.PodMigration, pod *corev1.Pod) error {
+	uid := podMigration.Status.SourcePodUID
+	if message := podReplacedMessage(uid, pod); message != "" {
+		log.FromContext(ctx).Info("Not deleting replaced source pod", "reason", message)
+		return nil
+	}
+	if uid == "" {
+		uid = pod.UID
+	}
+	err := r.Delete(ctx, pod, client.Preconditions{UID: &uid})
+	if apierrors.IsConflict(err) {
+		log.FromContext(ctx).Info("Not deleting source pod recreated while deleting it", "pod", pod.Name)
+		return nil
+	}
+	return client.IgnoreNotFound(err)
+}
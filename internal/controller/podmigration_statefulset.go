@@ -0,0 +1,325 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims;persistentvolumes,verbs=get;list;watch
+
+// statefulSetOf returns the name of the StatefulSet controlling pod, if any.
+func statefulSetOf(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "StatefulSet" {
+		return owner.Name
+	}
+	return ""
+}
+
+// preflightPersistentVolumes checks that the restored pod can use the source
+// pod's claims on the target node: bound volumes must be reachable from it,
+// and a volume only one node or pod may use must be released by the source
+// pod first, which only StatefulSet pods do.
+func (r *PodMigrationReconciler) preflightPersistentVolumes(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	var node *corev1.Node
+	if podMigration.Spec.TargetNode != "" {
+		node = &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, node); err != nil {
+			return nil, err
+		}
+	}
+	handover := statefulSetOf(srcPod) != ""
+
+	for _, volume := range srcPod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		var claim corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, client.ObjectKey{Namespace: srcPod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, &claim); err != nil {
+			return nil, err
+		}
+		if !handover && slices.Contains(claim.Spec.AccessModes, corev1.ReadWriteOncePod) {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonUnsupportedConfiguration,
+				message: fmt.Sprintf("claim %s is ReadWriteOncePod and stays in use by the source pod while the restored pod starts", claim.Name),
+			}, nil
+		}
+		if !handover && node != nil && node.Name != srcPod.Spec.NodeName &&
+			slices.Equal(claim.Spec.AccessModes, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}) {
+			addWarning(podMigration, fmt.Sprintf("claim %s is ReadWriteOnce; the restored pod cannot attach it on node %s until the source pod is deleted", claim.Name, node.Name))
+		}
+		if node == nil || claim.Spec.VolumeName == "" {
+			continue
+		}
+
+		var persistentVolume corev1.PersistentVolume
+		if err := r.Get(ctx, client.ObjectKey{Name: claim.Spec.VolumeName}, &persistentVolume); err != nil {
+			return nil, err
+		}
+		affinity := persistentVolume.Spec.NodeAffinity
+		if affinity == nil || affinity.Required == nil {
+			continue
+		}
+		ok, err := nodeSelectorMatches(affinity.Required, node)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return &preflightFailure{
+				reason:  lpmv1.MigrationReasonTargetDoesNotFit,
+				message: fmt.Sprintf("volume %s of claim %s is not accessible from node %s", persistentVolume.Name, claim.Name, node.Name),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// getSourcePod returns the source pod. Once a StatefulSet pod was handed
+// over, its name belongs to the restored pod and the copy saved before the
+// source pod was deleted is returned.
+func (r *PodMigrationReconciler) getSourcePod(ctx context.Context, podMigration *lpmv1.PodMigration) (*corev1.Pod, error) {
+	var pod corev1.Pod
+	if handover := podMigration.Status.StatefulSet; handover != nil && handover.SourcePod != nil {
+		if err := json.Unmarshal(handover.SourcePod.Raw, &pod); err != nil {
+			return nil, fmt.Errorf("invalid saved source pod: %w", err)
+		}
+		return &pod, nil
+	}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &pod); err != nil {
+		return nil, err
+	}
+	return &pod, nil
+}
+
+// rawObject encodes obj for the handover status.
+func rawObject(obj runtime.Object) (*runtime.RawExtension, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+// handOverStatefulSetPod frees the source pod's ordinal name for the
+// restored pod. The StatefulSet and the source pod are saved in the status,
+// then the StatefulSet is deleted with its pods orphaned, so it does not
+// start the pod afresh, and the source pod is deleted. It reports true once
+// the name is free.
+func (r *PodMigrationReconciler) handOverStatefulSetPod(ctx context.Context, podMigration *lpmv1.PodMigration) (bool, error) {
+	handover := podMigration.Status.StatefulSet
+
+	if handover.Manifest == nil {
+		var statefulSet appsv1.StatefulSet
+		if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: handover.Name}, &statefulSet); err != nil {
+			return false, fmt.Errorf("failed to get StatefulSet %s: %w", handover.Name, err)
+		}
+		var sourcePod corev1.Pod
+		if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &sourcePod); err != nil {
+			return false, fmt.Errorf("failed to get source pod: %w", err)
+		}
+
+		manifest, err := rawObject(&appsv1.StatefulSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            statefulSet.Name,
+				Namespace:       statefulSet.Namespace,
+				Labels:          statefulSet.Labels,
+				Annotations:     statefulSet.Annotations,
+				OwnerReferences: statefulSet.OwnerReferences,
+			},
+			Spec: statefulSet.Spec,
+		})
+		if err != nil {
+			return false, err
+		}
+		sourcePod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+		sourcePod.ManagedFields = nil
+		sourcePod.Status = corev1.PodStatus{}
+		saved, err := rawObject(&sourcePod)
+		if err != nil {
+			return false, err
+		}
+		// Saved before anything is deleted, so it can always be recreated
+		handover.Manifest, handover.SourcePod, handover.Orphaned = manifest, saved, true
+		podMigration.Status.Message = "handing StatefulSet pod over to the restored pod"
+		if err := r.Status().Update(ctx, podMigration); err != nil {
+			return false, err
+		}
+	}
+
+	var statefulSet appsv1.StatefulSet
+	err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: handover.Name}, &statefulSet)
+	if err == nil {
+		if statefulSet.DeletionTimestamp.IsZero() {
+			if err := r.Delete(ctx, &statefulSet, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !apierrors.IsNotFound(err) {
+				return false, fmt.Errorf("failed to orphan StatefulSet %s: %w", handover.Name, err)
+			}
+		}
+		// Deleted once the garbage collector orphaned its pods
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	var sourcePod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &sourcePod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if sourcePod.DeletionTimestamp.IsZero() {
+		if err := r.deleteSourcePod(ctx, podMigration, &sourcePod); err != nil {
+			return false, fmt.Errorf("failed to delete source pod: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// recreateStatefulSet recreates an orphaned StatefulSet from its saved
+// manifest. The restored pod is released by the migration so the
+// StatefulSet adopts it, unless it failed, in which case it is deleted and
+// the StatefulSet starts the pod afresh.
+func (r *PodMigrationReconciler) recreateStatefulSet(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	handover := podMigration.Status.StatefulSet
+	if handover == nil || !handover.Orphaned {
+		return nil
+	}
+
+	if podMigration.Status.RestoredPodName != "" {
+		var restoredPod corev1.Pod
+		err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Status.RestoredPodName}, &restoredPod)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && restoredPod.DeletionTimestamp.IsZero() && metav1.IsControlledBy(&restoredPod, podMigration) {
+			if restoredPod.Status.Phase == corev1.PodFailed {
+				if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+					return err
+				}
+			} else {
+				patch := client.MergeFrom(restoredPod.DeepCopy())
+				restoredPod.OwnerReferences = slices.DeleteFunc(restoredPod.OwnerReferences, func(ref metav1.OwnerReference) bool {
+					return ref.UID == podMigration.UID
+				})
+				if err := r.Patch(ctx, &restoredPod, patch); err != nil {
+					return fmt.Errorf("failed to release restored pod: %w", err)
+				}
+			}
+		}
+	}
+
+	var statefulSet appsv1.StatefulSet
+	if err := json.Unmarshal(handover.Manifest.Raw, &statefulSet); err != nil {
+		return fmt.Errorf("invalid saved StatefulSet: %w", err)
+	}
+	if err := r.Create(ctx, &statefulSet); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to recreate StatefulSet %s: %w", handover.Name, err)
+	}
+	handover.Orphaned = false
+	return r.Status().Update(ctx, podMigration)
+}
+
+// adoptRestoredPod hands the verified restored pod to the recreated
+// StatefulSet and waits until the StatefulSet adopted it and its headless
+// Service publishes the pod's DNS name. It reports true when the migration
+// can complete; a DNS record still missing after the network check timeout
+// is recorded as a warning.
+func (r *PodMigrationReconciler) adoptRestoredPod(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) (bool, error) {
+	if err := r.recreateStatefulSet(ctx, podMigration); err != nil {
+		return false, err
+	}
+
+	handover := podMigration.Status.StatefulSet
+	var statefulSet appsv1.StatefulSet
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: handover.Name}, &statefulSet); err != nil {
+		return false, err
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(restoredPod), restoredPod); err != nil {
+		return false, err
+	}
+	if !metav1.IsControlledBy(restoredPod, &statefulSet) {
+		podMigration.Status.Message = fmt.Sprintf("waiting for StatefulSet %s to adopt the restored pod", statefulSet.Name)
+		return false, r.Status().Update(ctx, podMigration)
+	}
+
+	published, message, err := r.dnsPublished(ctx, &statefulSet, restoredPod)
+	if err != nil {
+		return false, err
+	}
+	condition := metav1.Condition{
+		Type:    lpmv1.MigrationConditionStatefulSetAdopted,
+		Status:  metav1.ConditionTrue,
+		Reason:  lpmv1.MigrationReasonStatefulSetAdopted,
+		Message: message,
+	}
+	if !published {
+		if !networkCheckTimedOut(podMigration, restoredPod) {
+			podMigration.Status.Message = "waiting for DNS: " + message
+			return false, r.Status().Update(ctx, podMigration)
+		}
+		condition.Status, condition.Reason = metav1.ConditionFalse, lpmv1.MigrationReasonDNSNotPublished
+		addWarning(podMigration, message)
+	}
+	meta.SetStatusCondition(&podMigration.Status.Conditions, condition)
+	return true, nil
+}
+
+// dnsPublished reports whether the StatefulSet's headless Service has an
+// endpoint for pod under the pod's hostname, which is what its DNS name
+// resolves through.
+func (r *PodMigrationReconciler) dnsPublished(ctx context.Context, statefulSet *appsv1.StatefulSet, pod *corev1.Pod) (bool, string, error) {
+	service := statefulSet.Spec.ServiceName
+	if service == "" {
+		return true, fmt.Sprintf("adopted by StatefulSet %s", statefulSet.Name), nil
+	}
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := r.List(ctx, &endpointSlices, client.InNamespace(pod.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service}); err != nil {
+		return false, "", err
+	}
+	for _, slice := range endpointSlices.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.UID != pod.UID ||
+				endpoint.Hostname == nil || *endpoint.Hostname != pod.Spec.Hostname {
+				continue
+			}
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				break
+			}
+			return true, fmt.Sprintf("adopted by StatefulSet %s; %s.%s resolves to the restored pod", statefulSet.Name, pod.Spec.Hostname, service), nil
+		}
+	}
+	return false, fmt.Sprintf("headless Service %s does not publish the restored pod as %s yet", service, pod.Spec.Hostname), nil
+}
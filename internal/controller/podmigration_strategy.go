@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	pb "my.domain/guestbook/api/proto"
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+const (
+	// stopAndCopyMaxTransfer is the transfer time under which stopping the
+	// pod for the whole checkpoint is cheaper than copying it in rounds.
+	stopAndCopyMaxTransfer = time.Second
+
+	// preCopyMaxDirtyRatio is the largest share of the bandwidth the pod may
+	// dirty memory at for pre-copy rounds to shrink quickly enough.
+	preCopyMaxDirtyRatio = 0.5
+)
+
+// planMigration measures the source pod, once, when the migration's strategy
+// is Auto or it has a downtime budget, records the chosen strategy and the
+// predicted downtime, and rejects a migration predicted to run over its
+// budget. A migration from an existing checkpoint has nothing to measure.
+func (r *PodMigrationReconciler) planMigration(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.CheckpointRef != nil {
+		return nil, nil
+	}
+
+	decide := podMigration.Spec.Strategy == lpmv1.MigrationStrategyAuto && podMigration.Status.StrategyDecision == nil
+	predict := podMigration.Spec.MaxDowntimeMs != nil && podMigration.Status.PredictedDowntime == nil
+	if decide || predict {
+		estimate, err := r.measureSourcePod(ctx, srcPod)
+		if err != nil {
+			return nil, err
+		}
+		if decide {
+			bandwidth, err := r.probeBandwidth(ctx, podMigration, srcPod.Spec.NodeName, podMigration.Spec.TargetNode)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Failed to probe bandwidth to the target node")
+			}
+			decision, err := r.decideStrategy(ctx, podMigration, estimate, bandwidth)
+			if err != nil {
+				return nil, err
+			}
+			podMigration.Status.StrategyDecision = decision
+		}
+		if predict {
+			predictDowntime(ctx, podMigration, estimate)
+		}
+	}
+
+	if podMigration.Status.PredictedDowntime != nil {
+		if message := downtimeExceeded(podMigration, "predicted", podMigration.Status.PredictedDowntime.Duration); message != "" {
+			return &preflightFailure{reason: lpmv1.MigrationReasonSLOExceeded, message: message}, nil
+		}
+	}
+	return nil, nil
+}
+
+// measureSourcePod asks the source node's agent for the memory and dirty
+// rate of the pod's containers and the shared storage throughput.
+func (r *PodMigrationReconciler) measureSourcePod(ctx context.Context, srcPod *corev1.Pod) (*pb.EstimateMigrationResponse, error) {
+	var containerIDs []string
+	for _, status := range srcPod.Status.ContainerStatuses {
+		if status.ContainerID == "" {
+			return nil, fmt.Errorf("source container %s has no ID yet", status.Name)
+		}
+		containerIDs = append(containerIDs, status.ContainerID)
+	}
+	estimate, err := r.AgentClient.EstimateMigration(ctx, srcPod.Spec.NodeName, containerIDs, simulationSampleWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure source pod: %w", err)
+	}
+	return estimate, nil
+}
+
+// decideStrategy picks the strategy of a migration from the measurements of
+// its source pod, the bandwidth to its target node and the target node's
+// capabilities.
+func (r *PodMigrationReconciler) decideStrategy(ctx context.Context, podMigration *lpmv1.PodMigration, estimate *pb.EstimateMigrationResponse, bandwidth int64) (*lpmv1.StrategyDecision, error) {
+	var target corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &target); err != nil {
+		return nil, fmt.Errorf("failed to get target node %s: %w", podMigration.Spec.TargetNode, err)
+	}
+
+	decision := &lpmv1.StrategyDecision{
+		BandwidthBytesPerSecond: bandwidth,
+		TargetLazyPages:         target.Labels[lpmv1.NodeLazyPagesLabel] == "true",
+	}
+	for _, container := range estimate.Containers {
+		decision.CheckpointBytes += container.MemoryBytes
+		decision.DirtyBytesPerSecond += container.DirtyBytesPerSecond
+	}
+	decision.Recommended, decision.Message = chooseStrategy(decision)
+
+	decision.Strategy = decision.Recommended
+	if decision.Recommended != lpmv1.MigrationStrategyStopAndCopy {
+		// Checkpoints are taken and restored through the kubelet, which
+		// only dumps and restores whole containers
+		decision.Strategy = lpmv1.MigrationStrategyStopAndCopy
+		decision.Message += fmt.Sprintf("; %s is not supported yet, so the migration uses %s",
+			decision.Recommended, decision.Strategy)
+	}
+
+	log.FromContext(ctx).Info("Chose migration strategy", "recommended", decision.Recommended, "strategy", decision.Strategy,
+		"checkpointBytes", decision.CheckpointBytes, "dirtyBytesPerSecond", decision.DirtyBytesPerSecond,
+		"bandwidthBytesPerSecond", decision.BandwidthBytesPerSecond, "targetLazyPages", decision.TargetLazyPages)
+	return decision, nil
+}
+
+// chooseStrategy returns the strategy the measurements in decision favour
+// and why. A checkpoint that transfers quickly is cheapest to stop and
+// copy. Otherwise pre-copy shortens the downtime if each round copies
+// memory faster than the pod dirties it, and post-copy if it does not,
+// provided the target can restore memory lazily.
+func chooseStrategy(decision *lpmv1.StrategyDecision) (lpmv1.MigrationStrategy, string) {
+	if decision.BandwidthBytesPerSecond <= 0 {
+		return lpmv1.MigrationStrategyStopAndCopy, "no bandwidth was measured"
+	}
+	transfer := transferTime(decision.CheckpointBytes, decision.BandwidthBytesPerSecond)
+	if transfer <= stopAndCopyMaxTransfer {
+		return lpmv1.MigrationStrategyStopAndCopy,
+			fmt.Sprintf("the checkpoint transfers in %s", transfer.Round(time.Millisecond))
+	}
+
+	dirtyRatio := float64(decision.DirtyBytesPerSecond) / float64(decision.BandwidthBytesPerSecond)
+	switch {
+	case dirtyRatio <= preCopyMaxDirtyRatio:
+		return lpmv1.MigrationStrategyPreCopy,
+			fmt.Sprintf("the checkpoint transfers in %s and memory is dirtied at %.0f%% of the bandwidth, so pre-copy rounds converge",
+				transfer.Round(time.Millisecond), dirtyRatio*100)
+	case decision.TargetLazyPages:
+		return lpmv1.MigrationStrategyPostCopy,
+			fmt.Sprintf("the checkpoint transfers in %s and memory is dirtied at %.0f%% of the bandwidth, too fast for pre-copy to converge",
+				transfer.Round(time.Millisecond), dirtyRatio*100)
+	}
+	return lpmv1.MigrationStrategyStopAndCopy,
+		fmt.Sprintf("memory is dirtied at %.0f%% of the bandwidth, too fast for pre-copy to converge, and the target node cannot restore memory lazily",
+			dirtyRatio*100)
+}
+
+// migrationCompression returns the compression of the checkpoint archives of
+// podMigration: the one it asks for, or else the one its strategy calls for.
+// Every migration is stopped and copied for now, so the copy is part of the
+// downtime and gets the fast LZ4; scheduled PodCheckpoints default to Zstd.
+func migrationCompression(podMigration *lpmv1.PodMigration) lpmv1.CheckpointCompression {
+	if podMigration.Spec.Compression != "" {
+		return podMigration.Spec.Compression
+	}
+	return lpmv1.CheckpointCompressionLZ4
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// untoleratedTaints returns the NoSchedule and NoExecute taints of node that
+// tolerations do not tolerate. Binding the restored pod to the node bypasses
+// the scheduler, so nothing else keeps it off such a node; a NoExecute
+// taint gets it evicted right away.
+func untoleratedTaints(tolerations []corev1.Toleration, node *corev1.Node) []corev1.Taint {
+	var taints []corev1.Taint
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		if !toleratesTaint(tolerations, taint) {
+			taints = append(taints, *taint)
+		}
+	}
+	return taints
+}
+
+// tolerationFor returns the toleration of exactly taint.
+func tolerationFor(taint corev1.Taint) corev1.Toleration {
+	toleration := corev1.Toleration{Key: taint.Key, Effect: taint.Effect, Operator: corev1.TolerationOpExists}
+	if taint.Value != "" {
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = taint.Value
+	}
+	return toleration
+}
+
+func formatTaint(taint corev1.Taint) string {
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// preflightTargetTaints rejects a target node whose taints the restored pod
+// does not tolerate, unless the migration tolerates them.
+func (r *PodMigrationReconciler) preflightTargetTaints(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.TargetNode == "" || podMigration.Spec.TargetTaints == lpmv1.TargetTaintPolicyTolerate {
+		return nil, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	taints := untoleratedTaints(withOverrides(podMigration, srcPod).Spec.Tolerations, &node)
+	if len(taints) == 0 {
+		return nil, nil
+	}
+	var names []string
+	for _, taint := range taints {
+		names = append(names, formatTaint(taint))
+	}
+	return &preflightFailure{
+		reason: lpmv1.MigrationReasonTargetDoesNotFit,
+		message: fmt.Sprintf("pod does not tolerate taints %s of node %s; set targetTaints: Tolerate to add tolerations",
+			strings.Join(names, ", "), node.Name),
+	}, nil
+}
+
+// tolerateTargetTaints adds to the restored pod tolerations of the target
+// node's taints it does not tolerate, when the migration tolerates them, and
+// lists them in status.warnings.
+func (r *PodMigrationReconciler) tolerateTargetTaints(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) error {
+	if podMigration.Spec.TargetTaints != lpmv1.TargetTaintPolicyTolerate {
+		return nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		// A missing target node is reported by the fit checks
+		return client.IgnoreNotFound(err)
+	}
+	for _, taint := range untoleratedTaints(restoredPod.Spec.Tolerations, &node) {
+		restoredPod.Spec.Tolerations = append(restoredPod.Spec.Tolerations, tolerationFor(taint))
+		addWarning(podMigration, fmt.Sprintf("restored pod tolerates taint %s of node %s", formatTaint(taint), node.Name))
+	}
+	return nil
+}
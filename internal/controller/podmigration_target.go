@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+	"my.domain/guestbook/pkg/scoring"
+)
+
+// resolveTargetNode picks the node to restore srcPod on when the migration
+// names none. The candidates are the nodes, other than the source node, that
+// the pod's MigrationPolicies allow, whose agent reports no missing
+// capability, that the pod fits on, and where it keeps its placement
+// constraints, in order of the topology preference, then by name; r.Scorers
+// then rank them and the first wins. It returns "" when there is none.
+func (r *PodMigrationReconciler) resolveTargetNode(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (string, error) {
+	logger := log.FromContext(ctx)
+
+	policies, err := policy.Matching(ctx, r.Client, srcPod)
+	if err != nil {
+		return "", err
+	}
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes); err != nil {
+		return "", err
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) int { return strings.Compare(a.Name, b.Name) })
+
+	preference := podMigration.Spec.TopologyPreference
+	if preference == "" {
+		preference = policy.TopologyPreference(policies)
+	}
+	if preference == "" {
+		preference = lpmv1.TopologyPreferenceZone
+	}
+	if preference != lpmv1.TopologyPreferenceNone {
+		zones, regions, err := r.preferredTopology(ctx, srcPod)
+		if err != nil {
+			return "", err
+		}
+		tier := func(node *corev1.Node) int {
+			if preference == lpmv1.TopologyPreferenceZone && zones.Has(node.Labels[corev1.LabelTopologyZone]) {
+				return 0
+			}
+			if regions.Has(node.Labels[corev1.LabelTopologyRegion]) {
+				return 1
+			}
+			return 2
+		}
+		slices.SortStableFunc(nodes.Items, func(a, b corev1.Node) int { return tier(&a) - tier(&b) })
+	}
+
+	restoredPod := withOverrides(podMigration, srcPod)
+	placement, err := newPlacementCheck(ctx, r.Client, restoredPod, srcPod)
+	if err != nil {
+		return "", err
+	}
+	var candidates []corev1.Node
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if node.Name == srcPod.Spec.NodeName {
+			continue
+		}
+		denied, err := policy.TargetDenied(policies, node)
+		if err != nil {
+			return "", err
+		}
+		if denied != "" {
+			logger.V(1).Info("Skipping target candidate", "node", node.Name, "reason", denied)
+			continue
+		}
+		problems, err := r.nodeCapabilityProblems(ctx, node.Name, false)
+		if err != nil {
+			return "", err
+		}
+		if len(problems) > 0 {
+			logger.V(1).Info("Skipping target candidate", "node", node.Name, "reason", strings.Join(problems, "; "))
+			continue
+		}
+		violations, _, err := placement.evaluate(node)
+		if err != nil {
+			return "", err
+		}
+		if len(violations) > 0 {
+			logger.V(1).Info("Skipping target candidate", "node", node.Name, "reason", strings.Join(violations, "; "))
+			continue
+		}
+		message, err := checkTargetFit(ctx, r.Client, restoredPod, node.Name)
+		if err != nil {
+			return "", err
+		}
+		if message != "" {
+			logger.V(1).Info("Skipping target candidate", "node", node.Name, "reason", message)
+			continue
+		}
+		candidates = append(candidates, *node)
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	// Scoring only refines the order; a failing scorer is not worth
+	// failing the migration over
+	candidates, err = scoring.Rank(ctx, r.Scorers, srcPod, candidates)
+	if err != nil {
+		logger.Error(err, "Failed to score target candidates, ranking without the failing scorers")
+	}
+	return candidates[0].Name, nil
+}
+
+// preferredTopology returns the zones and regions a target node should be
+// in: those the pod's bound volumes are restricted to, or else the source
+// node's.
+func (r *PodMigrationReconciler) preferredTopology(ctx context.Context, srcPod *corev1.Pod) (sets.Set[string], sets.Set[string], error) {
+	zones, regions := sets.New[string](), sets.New[string]()
+	for _, volume := range srcPod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		var claim corev1.PersistentVolumeClaim
+		if err := r.Get(ctx, client.ObjectKey{Namespace: srcPod.Namespace, Name: volume.PersistentVolumeClaim.ClaimName}, &claim); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		if claim.Spec.VolumeName == "" {
+			continue
+		}
+		var persistentVolume corev1.PersistentVolume
+		if err := r.Get(ctx, client.ObjectKey{Name: claim.Spec.VolumeName}, &persistentVolume); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, err
+		}
+		affinity := persistentVolume.Spec.NodeAffinity
+		if affinity == nil || affinity.Required == nil {
+			continue
+		}
+		for _, term := range affinity.Required.NodeSelectorTerms {
+			for _, requirement := range term.MatchExpressions {
+				if requirement.Operator != corev1.NodeSelectorOpIn {
+					continue
+				}
+				switch requirement.Key {
+				case corev1.LabelTopologyZone:
+					zones.Insert(requirement.Values...)
+				case corev1.LabelTopologyRegion:
+					regions.Insert(requirement.Values...)
+				}
+			}
+		}
+	}
+
+	if zones.Len() > 0 && regions.Len() > 0 {
+		return zones, regions, nil
+	}
+	var source corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: srcPod.Spec.NodeName}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return zones, regions, nil
+		}
+		return nil, nil, err
+	}
+	if zone := source.Labels[corev1.LabelTopologyZone]; zones.Len() == 0 && zone != "" {
+		zones.Insert(zone)
+	}
+	if region := source.Labels[corev1.LabelTopologyRegion]; regions.Len() == 0 && region != "" {
+		regions.Insert(region)
+	}
+	return zones, regions, nil
+}
+
+// preflightTargetPolicy rejects a target node a MigrationPolicy of the pod
+// forbids. The webhook rejects such migrations when they are created; this
+// catches policies and node labels that changed since.
+func (r *PodMigrationReconciler) preflightTargetPolicy(ctx context.Context, podMigration *lpmv1.PodMigration, srcPod *corev1.Pod) (*preflightFailure, error) {
+	if podMigration.Spec.TargetNode == "" {
+		return nil, nil
+	}
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	policies, err := policy.Matching(ctx, r.Client, srcPod)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := policy.TargetDenied(policies, &node)
+	if err != nil {
+		return nil, err
+	}
+	if denied != "" {
+		return &preflightFailure{reason: lpmv1.MigrationReasonTargetNotAllowed, message: denied}, nil
+	}
+	return nil, nil
+}
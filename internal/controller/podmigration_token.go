@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+
+// podUIDExtraKey is the TokenReview extra field naming the pod a bound
+// service account token was issued for.
+const podUIDExtraKey = "authentication.kubernetes.io/pod-uid"
+
+// projectedToken is a service account token projected into a container.
+type projectedToken struct {
+	container string
+	path      string
+	audience  string
+}
+
+// projectedTokens returns the first container mounting each projected
+// service account token of pod.
+func projectedTokens(pod *corev1.Pod) []projectedToken {
+	var tokens []projectedToken
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken == nil {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				i := slices.IndexFunc(container.VolumeMounts, func(m corev1.VolumeMount) bool {
+					return m.Name == volume.Name && m.SubPath == ""
+				})
+				if i < 0 {
+					continue
+				}
+				tokens = append(tokens, projectedToken{
+					container: container.Name,
+					path:      path.Join(container.VolumeMounts[i].MountPath, source.ServiceAccountToken.Path),
+					audience:  source.ServiceAccountToken.Audience,
+				})
+				break
+			}
+		}
+	}
+	return tokens
+}
+
+// verifyServiceAccountToken checks that the service account tokens projected
+// into the restored pod authenticate and are bound to the restored pod. The
+// kubelet writes projected volumes afresh for the new pod, and the restored
+// processes re-read the files, so a token still bound to the source pod
+// would stop working once it is deleted. A token that cannot be read, e.g.
+// because the image has no cat, is reported as Unknown rather than failing
+// the migration.
+func (r *PodMigrationReconciler) verifyServiceAccountToken(ctx context.Context, restoredPod *corev1.Pod) (metav1.ConditionStatus, string, error) {
+	tokens := projectedTokens(restoredPod)
+	if len(tokens) == 0 {
+		return metav1.ConditionTrue, "pod mounts no service account token", nil
+	}
+
+	var usernames []string
+	for _, token := range tokens {
+		stdout, _, err := r.Exec.Exec(ctx, restoredPod.Namespace, restoredPod.Name, token.container, []string{"cat", token.path})
+		if err != nil {
+			return metav1.ConditionUnknown, fmt.Sprintf("cannot read token %s in container %s: %v", token.path, token.container, err), nil
+		}
+
+		review := &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: strings.TrimSpace(stdout)},
+		}
+		if token.audience != "" {
+			review.Spec.Audiences = []string{token.audience}
+		}
+		if err := r.Create(ctx, review); err != nil {
+			return "", "", fmt.Errorf("failed to review service account token: %w", err)
+		}
+		if !review.Status.Authenticated {
+			return metav1.ConditionFalse, fmt.Sprintf("token %s in container %s does not authenticate: %s", token.path, token.container, review.Status.Error), nil
+		}
+		if podUIDs, bound := review.Status.User.Extra[podUIDExtraKey]; bound && !slices.Contains(podUIDs, string(restoredPod.UID)) {
+			return metav1.ConditionFalse, fmt.Sprintf("token %s in container %s is bound to pod %s, not the restored pod", token.path, token.container, strings.Join(podUIDs, ",")), nil
+		}
+		if !slices.Contains(usernames, review.Status.User.Username) {
+			usernames = append(usernames, review.Status.User.Username)
+		}
+	}
+	return metav1.ConditionTrue, "service account tokens authenticate as " + strings.Join(usernames, ", "), nil
+}
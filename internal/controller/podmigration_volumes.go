@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+const (
+	// volumeRestoreContainer is the init container that holds back the
+	// restored containers until their memory-backed volumes are filled.
+	volumeRestoreContainer = "lpm-restore-volumes"
+	// volumeRestoreMarkerVolume is where the agent signals the init
+	// container that the volumes are filled.
+	volumeRestoreMarkerVolume = "lpm-volumes-restored"
+)
+
+// isMemoryEmptyDir reports whether volume is a tmpfs emptyDir, whose content
+// lives on the node rather than in the checkpointed containers.
+func isMemoryEmptyDir(volume *corev1.Volume) bool {
+	return volume.EmptyDir != nil && volume.EmptyDir.Medium == corev1.StorageMediumMemory
+}
+
+// memoryVolumes returns the memory-backed emptyDir volumes of pod that
+// containerName is the first container to mount, so a volume shared by
+// several containers is archived once.
+func memoryVolumes(pod *corev1.Pod, containerName string) []string {
+	var volumes []string
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if !isMemoryEmptyDir(volume) {
+			continue
+		}
+	mounts:
+		for _, container := range pod.Spec.Containers {
+			for _, mount := range container.VolumeMounts {
+				if mount.Name == volume.Name {
+					if container.Name == containerName {
+						volumes = append(volumes, volume.Name)
+					}
+					break mounts
+				}
+			}
+		}
+	}
+	return volumes
+}
+
+// containerContents returns the container checkpoint contents of the
+// migration's checkpoint.
+func (r *PodMigrationReconciler) containerContents(ctx context.Context, podMigration *lpmv1.PodMigration) ([]lpmv1.ContainerCheckpointContent, error) {
+	checkpointContent, err := r.getCheckpointContent(ctx, podMigration)
+	if err != nil {
+		return nil, err
+	}
+	var contents []lpmv1.ContainerCheckpointContent
+	for _, ref := range checkpointContent.Spec.ContainerContents {
+		var content lpmv1.ContainerCheckpointContent
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+			return nil, fmt.Errorf("failed to get container checkpoint content %s: %w", ref.Name, err)
+		}
+		contents = append(contents, content)
+	}
+	return contents, nil
+}
+
+// volumeArchives returns the archives of memory-backed volumes taken along
+// with the migration's checkpoint.
+func (r *PodMigrationReconciler) volumeArchives(ctx context.Context, podMigration *lpmv1.PodMigration) ([]string, error) {
+	contents, err := r.containerContents(ctx, podMigration)
+	if err != nil {
+		return nil, err
+	}
+	var archives []string
+	for _, content := range contents {
+		if content.Spec.VolumesArtifactURI != "" {
+			archives = append(archives, content.Spec.VolumesArtifactURI)
+		}
+	}
+	return archives, nil
+}
+
+// addVolumeRestore makes the restored pod wait, before any of its containers
+// is restored, for the agent to fill its memory-backed emptyDir volumes from
+// the checkpoint's archives. The init container runs the agent's image, which
+// is present on the target node, and waits for the marker file the agent
+// creates once it is done.
+func (r *PodMigrationReconciler) addVolumeRestore(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) error {
+	// A pod restored before carries the previous migration's waiter
+	restoredPod.Spec.InitContainers = slices.DeleteFunc(restoredPod.Spec.InitContainers, func(c corev1.Container) bool {
+		return c.Name == volumeRestoreContainer
+	})
+	restoredPod.Spec.Volumes = slices.DeleteFunc(restoredPod.Spec.Volumes, func(v corev1.Volume) bool {
+		return v.Name == volumeRestoreMarkerVolume
+	})
+
+	var volumes []string
+	for i := range restoredPod.Spec.Volumes {
+		if isMemoryEmptyDir(&restoredPod.Spec.Volumes[i]) {
+			volumes = append(volumes, restoredPod.Spec.Volumes[i].Name)
+		}
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+	archives, err := r.volumeArchives(ctx, podMigration)
+	if err != nil {
+		return err
+	}
+	if len(archives) == 0 {
+		addWarning(podMigration, fmt.Sprintf("checkpoint has no archive of memory-backed volumes %s; they are restored empty",
+			strings.Join(volumes, ", ")))
+		return nil
+	}
+	image, err := r.AgentClient.AgentImage(ctx, podMigration.Spec.TargetNode)
+	if err != nil {
+		return fmt.Errorf("failed to find agent image: %w", err)
+	}
+
+	sizeLimit := resource.MustParse("1Mi")
+	runAsNonRoot, allowPrivilegeEscalation, nobody := true, false, int64(65534)
+	restoredPod.Spec.Volumes = append(restoredPod.Spec.Volumes, corev1.Volume{
+		Name: volumeRestoreMarkerVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+			Medium:    corev1.StorageMediumMemory,
+			SizeLimit: &sizeLimit,
+		}},
+	})
+	waiter := corev1.Container{
+		Name:            volumeRestoreContainer,
+		Image:           image,
+		ImagePullPolicy: corev1.PullNever,
+		Command:         []string{"sh", "-c", "until [ -e /lpm/restored ]; do sleep 1; done"},
+		VolumeMounts:    []corev1.VolumeMount{{Name: volumeRestoreMarkerVolume, MountPath: "/lpm"}},
+		// Admitted under the restricted Pod Security level
+		SecurityContext: &corev1.SecurityContext{
+			RunAsNonRoot:             &runAsNonRoot,
+			RunAsUser:                &nobody,
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}
+	restoredPod.Spec.InitContainers = append([]corev1.Container{waiter}, restoredPod.Spec.InitContainers...)
+	return nil
+}
+
+// waitingForVolumes reports whether the restored pod's volume restore init
+// container is running, i.e. its volumes are mounted and not filled yet.
+func waitingForVolumes(restoredPod *corev1.Pod) bool {
+	for _, status := range restoredPod.Status.InitContainerStatuses {
+		if status.Name == volumeRestoreContainer {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// restoreMemoryVolumes has the target node's agent fill the restored pod's
+// memory-backed volumes and release its init container.
+func (r *PodMigrationReconciler) restoreMemoryVolumes(ctx context.Context, podMigration *lpmv1.PodMigration, restoredPod *corev1.Pod) error {
+	archives, err := r.volumeArchives(ctx, podMigration)
+	if err != nil {
+		return err
+	}
+	return r.AgentClient.RestoreVolumes(ctx, restoredPod.Spec.NodeName, string(restoredPod.UID), archives, volumeRestoreMarkerVolume)
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// podRestoreAnnotation on a pod names the PodRestore that created it.
+const podRestoreAnnotation = "restore.pod-restore"
+
+// PodRestoreReconciler reconciles a PodRestore object. Checkpoint images are
+// prepared and restores verified as for a migration, with the migration
+// reconciler's agent client and image settings.
+type PodRestoreReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Migrations *PodMigrationReconciler
+}
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podrestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podcheckpoints;podcheckpointcontents;migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+
+func (r *PodRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var podRestore lpmv1.PodRestore
+	if err := r.Get(ctx, req.NamespacedName, &podRestore); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch podRestore.Status.Phase {
+	case "", lpmv1.RestorePhasePending:
+		return r.handlePendingPhase(ctx, &podRestore)
+	case lpmv1.RestorePhasePreparingImages:
+		return r.handlePreparingImagesPhase(ctx, &podRestore)
+	case lpmv1.RestorePhaseRestoring:
+		return r.handleRestoringPhase(ctx, &podRestore)
+	default:
+		return ctrl.Result{}, nil
+	}
+}
+
+func (r *PodRestoreReconciler) handlePendingPhase(ctx context.Context, podRestore *lpmv1.PodRestore) (ctrl.Result, error) {
+	ref := podRestore.Spec.CheckpointRef
+	var podCheckpoint lpmv1.PodCheckpoint
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podRestore.Namespace, Name: ref.Name}, &podCheckpoint); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, fmt.Sprintf("checkpoint %s not found", ref.Name))
+		}
+		return ctrl.Result{}, err
+	}
+	entry, ok := lookupGeneration(&podCheckpoint, ref.Generation)
+	if !ok {
+		message := fmt.Sprintf("checkpoint %s has no completed generation", ref.Name)
+		if ref.Generation != nil {
+			message = fmt.Sprintf("generation %d of checkpoint %s is no longer retained", *ref.Generation, ref.Name)
+		}
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, message)
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: podRestore.Spec.NodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, fmt.Sprintf("node %s not found", podRestore.Spec.NodeName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	podRestore.Status.Generation = entry.Generation
+	_, sourcePod, err := r.restoreSource(ctx, podRestore)
+	if err != nil {
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, err.Error())
+	}
+	if podRestore.Spec.Quarantine != nil {
+		if sourcePod.Spec.HostNetwork {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed,
+				fmt.Sprintf("pod %s uses the host network, which no NetworkPolicy can isolate it from", sourcePod.Name))
+		}
+		// Isolated before anything is restored in it
+		message, err := r.ensureQuarantine(ctx, restoredPodNamespace(podRestore))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if message != "" {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, message)
+		}
+	}
+	policies, err := policy.Matching(ctx, r.Client, sourcePod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if message := staleCheckpointMessage(ref, entry, policies); message != "" {
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, message)
+	}
+	podRestore.Status.SourcePod = sourcePod.Name
+	return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhasePreparingImages,
+		fmt.Sprintf("preparing images of generation %d of checkpoint %s", entry.Generation, ref.Name))
+}
+
+func (r *PodRestoreReconciler) handlePreparingImagesPhase(ctx context.Context, podRestore *lpmv1.PodRestore) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	checkpointContent, sourcePod, err := r.restoreSource(ctx, podRestore)
+	if err != nil {
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, err.Error())
+	}
+	if podRestore.Status.CheckpointImages == nil {
+		podRestore.Status.CheckpointImages = make(map[string]string)
+	}
+
+	imagesReady := true
+	for _, container := range sourcePod.Spec.Containers {
+		if _, exists := podRestore.Status.CheckpointImages[container.Name]; exists {
+			continue
+		}
+		content := r.Migrations.getContainerContent(ctx, checkpointContent, container.Name)
+		if content == nil || content.Spec.ArtifactURI == "" {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, fmt.Sprintf("no checkpoint found for container %s", container.Name))
+		}
+		// The archive may have sat in storage for long; check it first
+		checkpointImage, err := r.Migrations.convertToOCIImage(ctx, content, podRestore.Spec.NodeName, true, nil)
+		if err != nil {
+			logger.Error(err, "Failed to convert checkpoint to OCI image", "container", container.Name)
+			imagesReady = false
+			continue
+		}
+		if err := r.Migrations.recordImageReference(ctx, content, checkpointImage); err != nil {
+			logger.Error(err, "Failed to record checkpoint image on content", "content", content.Name)
+		}
+		podRestore.Status.CheckpointImages[container.Name] = checkpointImage
+		logger.Info("Checkpoint image prepared", "container", container.Name, "image", checkpointImage)
+	}
+
+	if imagesReady && len(podRestore.Status.CheckpointImages) == len(sourcePod.Spec.Containers) {
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseRestoring,
+			"checkpoint images ready, creating restored pod")
+	}
+	if err := r.Status().Update(ctx, podRestore); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: 3 * time.Second}, nil
+}
+
+func (r *PodRestoreReconciler) handleRestoringPhase(ctx context.Context, podRestore *lpmv1.PodRestore) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if podRestore.Status.RestoredPodName == "" {
+		checkpointContent, sourcePod, err := r.restoreSource(ctx, podRestore)
+		if err != nil {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, err.Error())
+		}
+		if err := r.configureCriu(ctx, podRestore, checkpointContent); err != nil {
+			return ctrl.Result{}, err
+		}
+		if podRestore.Spec.Quarantine != nil {
+			// The policy may have been removed while the images were prepared
+			message, err := r.ensureQuarantine(ctx, restoredPodNamespace(podRestore))
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if message != "" {
+				return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, message)
+			}
+		}
+
+		restoredPod := newPodFromCheckpoint(podRestore, sourcePod)
+		if err := r.Migrations.setCheckpointPullPolicies(ctx, restoredPod); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, restoredPod); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, fmt.Sprintf("failed to create restored pod: %v", err))
+			}
+			// Ours if the status update after creating it failed
+			var existing corev1.Pod
+			if err := r.Get(ctx, client.ObjectKeyFromObject(restoredPod), &existing); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+			if existing.Annotations[podRestoreAnnotation] != podRestore.Name {
+				return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed,
+					fmt.Sprintf("pod %s already exists; set spec.podName to restore under another name", restoredPod.Name))
+			}
+		}
+
+		podRestore.Status.RestoredPodName = restoredPod.Name
+		podRestore.Status.Message = "restored pod created"
+		if err := r.Status().Update(ctx, podRestore); err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("Restored pod created", "pod", restoredPod.Name)
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	var restoredPod corev1.Pod
+	if err := r.Get(ctx, client.ObjectKey{Namespace: restoredPodNamespace(podRestore), Name: podRestore.Status.RestoredPodName}, &restoredPod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, "restored pod not found")
+		}
+		return ctrl.Result{}, err
+	}
+
+	switch restoredPod.Status.Phase {
+	case corev1.PodRunning:
+		// A wrong checkpoint path can make the runtime boot the image instead
+		restored, message, err := r.Migrations.verifyRestore(ctx, &restoredPod)
+		if errors.Is(err, errRestoreUnverifiable) {
+			logger.Info("Agent does not support restore verification, skipping", "node", restoredPod.Spec.NodeName)
+			restored, message, err = true, err.Error(), nil
+		}
+		if err != nil {
+			logger.Info("Unable to verify restore yet, retrying", "pod", restoredPod.Name, "error", err.Error())
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+		if !restored {
+			if err := r.Delete(ctx, &restoredPod); err != nil && !apierrors.IsNotFound(err) {
+				logger.Error(err, "Failed to delete restored pod that was not restored from checkpoint", "pod", restoredPod.Name)
+			}
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed,
+				"restored pod was started without applying the checkpoint: "+message)
+		}
+		if err := r.Migrations.markRestoreComplete(ctx, &restoredPod, message); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseSucceeded, "pod successfully restored and running")
+
+	case corev1.PodFailed:
+		message := "restored pod failed to start"
+		if cause := restoreArtifactError(&restoredPod); cause != "" {
+			message += ": " + cause
+		}
+		return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, message)
+
+	default:
+		if cause := restoreArtifactError(&restoredPod); cause != "" {
+			return ctrl.Result{}, r.updatePhase(ctx, podRestore, lpmv1.RestorePhaseFailed, "restored pod failed to start: "+cause)
+		}
+		logger.Info("Restored pod in progress", "pod", restoredPod.Name, "phase", restoredPod.Status.Phase)
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+}
+
+// restoreSource returns the PodCheckpointContent of the generation being
+// restored and the pod it was taken of: the copy saved with the checkpoint,
+// or the live pod for checkpoints taken before pods were saved.
+func (r *PodRestoreReconciler) restoreSource(ctx context.Context, podRestore *lpmv1.PodRestore) (*lpmv1.PodCheckpointContent, *corev1.Pod, error) {
+	name := podRestore.Spec.CheckpointRef.Name
+	var podCheckpoint lpmv1.PodCheckpoint
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podRestore.Namespace, Name: name}, &podCheckpoint); err != nil {
+		return nil, nil, fmt.Errorf("failed to get checkpoint %s: %w", name, err)
+	}
+	generation := podRestore.Status.Generation
+	entry, ok := lookupGeneration(&podCheckpoint, &generation)
+	if !ok {
+		return nil, nil, fmt.Errorf("generation %d of checkpoint %s is no longer retained", generation, name)
+	}
+	var checkpointContent lpmv1.PodCheckpointContent
+	if err := r.Get(ctx, client.ObjectKey{Namespace: podRestore.Namespace, Name: entry.ContentName}, &checkpointContent); err != nil {
+		return nil, nil, fmt.Errorf("failed to get checkpoint content: %w", err)
+	}
+
+	var sourcePod corev1.Pod
+	if saved := checkpointContent.Spec.SourcePod; saved != nil {
+		if err := json.Unmarshal(saved.Raw, &sourcePod); err != nil {
+			return nil, nil, fmt.Errorf("invalid saved source pod: %w", err)
+		}
+		return &checkpointContent, &sourcePod, nil
+	}
+	err := r.Get(ctx, client.ObjectKey{Namespace: podRestore.Namespace, Name: checkpointContent.Spec.PodName}, &sourcePod)
+	if apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("checkpoint %s does not include its pod and pod %s no longer exists", name, checkpointContent.Spec.PodName)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &checkpointContent, &sourcePod, nil
+}
+
+// configureCriu sets the CRIU options the checkpoint's containers were
+// dumped with on the node the pod is restored on.
+func (r *PodRestoreReconciler) configureCriu(ctx context.Context, podRestore *lpmv1.PodRestore, checkpointContent *lpmv1.PodCheckpointContent) error {
+	var options []string
+	for _, ref := range checkpointContent.Spec.ContainerContents {
+		var content lpmv1.ContainerCheckpointContent
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, &content); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		for _, option := range content.Spec.CriuOptions {
+			if !slices.Contains(options, option) {
+				options = append(options, option)
+			}
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	return r.Migrations.AgentClient.ConfigureCriu(ctx, podRestore.Spec.NodeName, options)
+}
+
+// newPodFromCheckpoint returns the pod podRestore creates: sourcePod's
+// labels, annotations and spec on the requested node, running the
+// checkpoint images, or a quarantined copy of them. Owners are not carried
+// over, so the restored pod doesn't count towards a controller's replicas.
+func newPodFromCheckpoint(podRestore *lpmv1.PodRestore, sourcePod *corev1.Pod) *corev1.Pod {
+	name := podRestore.Spec.PodName
+	if name == "" {
+		name = sourcePod.Name
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   podRestore.Namespace,
+			Labels:      sourcePod.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec: *sourcePod.Spec.DeepCopy(),
+	}
+	for key, value := range sourcePod.Annotations {
+		pod.Annotations[key] = value
+	}
+	pod.Annotations[podRestoreAnnotation] = podRestore.Name
+	// The restored pod takes no part in the migrations of the checkpointed pod
+	for _, key := range migrationStatusAnnotations {
+		delete(pod.Annotations, key)
+	}
+	// Multus reports the restored pod's own attachments
+	delete(pod.Annotations, networkStatusAnnotation)
+	delete(pod.Annotations, legacyNetworkStatusAnnotation)
+
+	pod.Spec.NodeName = podRestore.Spec.NodeName
+	for i, container := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Image = podRestore.Status.CheckpointImages[container.Name]
+		pod.Spec.Containers[i].ImagePullPolicy = corev1.PullNever
+	}
+	guardRestoredPodProbes(pod, nil)
+	if podRestore.Spec.Quarantine != nil {
+		quarantinePod(pod, podRestore, sourcePod)
+	}
+	return pod
+}
+
+func (r *PodRestoreReconciler) updatePhase(ctx context.Context, podRestore *lpmv1.PodRestore, phase lpmv1.PodRestorePhase, message string) error {
+	podRestore.Status.Phase = phase
+	podRestore.Status.Message = message
+	if phase == lpmv1.RestorePhaseSucceeded || phase == lpmv1.RestorePhaseFailed {
+		now := metav1.Now()
+		podRestore.Status.CompletionTime = &now
+	}
+	return r.Status().Update(ctx, podRestore)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PodRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&lpmv1.PodRestore{}).
+		Named("podrestore").
+		Complete(r)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var _ = Describe("PodRestore Controller", func() {
+	Context("When restoring a checkpoint that does not exist", func() {
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: "missing-checkpoint-restore", Namespace: "default"}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &lpmv1.PodRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      typeNamespacedName.Name,
+					Namespace: typeNamespacedName.Namespace,
+				},
+				Spec: lpmv1.PodRestoreSpec{
+					CheckpointRef: lpmv1.CheckpointReference{Name: "missing"},
+					NodeName:      "worker-2",
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &lpmv1.PodRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should fail the restore", func() {
+			controllerReconciler := &PodRestoreReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				Migrations: &PodMigrationReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &lpmv1.PodRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(lpmv1.RestorePhaseFailed))
+			Expect(resource.Status.Message).To(ContainSubstring("checkpoint missing not found"))
+			Expect(resource.Status.CompletionTime).NotTo(BeNil())
+		})
+	})
+
+	Context("When restoring a checkpoint older than its policies allow", func() {
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: "stale-checkpoint-restore", Namespace: "default"}
+		podName := "stale-checkpoint-pod"
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "stale-restore-node"}})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default", Labels: map[string]string{"app": "stale"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox"}}},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &lpmv1.MigrationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoints"},
+				Spec: lpmv1.MigrationPolicySpec{
+					PodSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "stale"}},
+					MaxCheckpointAge: &metav1.Duration{Duration: time.Hour},
+				},
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &lpmv1.PodCheckpointContent{
+				ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoint", Namespace: "default"},
+				Spec: lpmv1.PodCheckpointContentSpec{
+					PodCheckpointRef:  corev1.ObjectReference{Name: "stale-checkpoint", Namespace: "default"},
+					PodNamespace:      "default",
+					PodName:           podName,
+					ContainerContents: []corev1.LocalObjectReference{},
+				},
+			})).To(Succeed())
+			podCheckpoint := &lpmv1.PodCheckpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoint", Namespace: "default"},
+				Spec:       lpmv1.PodCheckpointSpec{PodName: &podName},
+			}
+			Expect(k8sClient.Create(ctx, podCheckpoint)).To(Succeed())
+			podCheckpoint.Status.Phase = lpmv1.PodCheckpointPhaseSucceeded
+			podCheckpoint.Status.History = []lpmv1.PodCheckpointGeneration{{
+				Generation:   1,
+				ContentName:  "stale-checkpoint",
+				CreationTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			}}
+			Expect(k8sClient.Status().Update(ctx, podCheckpoint)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &lpmv1.PodRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      typeNamespacedName.Name,
+					Namespace: typeNamespacedName.Namespace,
+				},
+				Spec: lpmv1.PodRestoreSpec{
+					CheckpointRef: lpmv1.CheckpointReference{Name: "stale-checkpoint"},
+					NodeName:      "stale-restore-node",
+					PodName:       "stale-checkpoint-copy",
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &lpmv1.PodRestore{ObjectMeta: metav1.ObjectMeta{Name: typeNamespacedName.Name, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &lpmv1.PodCheckpoint{ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoint", Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &lpmv1.PodCheckpointContent{ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoint", Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &lpmv1.MigrationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "stale-checkpoints"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default"}})).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "stale-restore-node"}})).To(Succeed())
+		})
+
+		It("should refuse the checkpoint", func() {
+			controllerReconciler := &PodRestoreReconciler{
+				Client:     k8sClient,
+				Scheme:     k8sClient.Scheme(),
+				Migrations: &PodMigrationReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &lpmv1.PodRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Phase).To(Equal(lpmv1.RestorePhaseFailed))
+			Expect(resource.Status.Message).To(ContainSubstring("set allowStale"))
+		})
+	})
+
+	Context("When restoring a checkpoint in quarantine", func() {
+		It("should strip the copy of what connects it to the pod", func() {
+			podRestore := &lpmv1.PodRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-restore", Namespace: "prod"},
+				Spec: lpmv1.PodRestoreSpec{
+					NodeName:   "worker-3",
+					Quarantine: &lpmv1.RestoreQuarantine{},
+				},
+				Status: lpmv1.PodRestoreStatus{CheckpointImages: map[string]string{"app": "localhost/checkpoint/web:app"}},
+			}
+			sourcePod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "web",
+					Namespace:   "prod",
+					Labels:      map[string]string{"app": "web"},
+					Annotations: map[string]string{"k8s.v1.cni.cncf.io/networks": "backend"},
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "web",
+					ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry"}},
+					Volumes: []corev1.Volume{
+						{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+						{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}}},
+					},
+					Containers: []corev1.Container{{
+						Name:    "app",
+						Image:   "web:1.0",
+						EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web"}}}},
+						Env: []corev1.EnvVar{
+							{Name: "MODE", Value: "debug"},
+							{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "password"}}},
+						},
+						Ports: []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 80}},
+					}},
+				},
+			}
+
+			pod := newPodFromCheckpoint(podRestore, sourcePod)
+			Expect(pod.Namespace).To(Equal(lpmv1.DefaultQuarantineNamespace))
+			Expect(pod.Labels).To(Equal(map[string]string{lpmv1.QuarantineLabel: "true"}))
+			Expect(pod.Annotations).To(HaveKeyWithValue(lpmv1.QuarantinedFromAnnotation, "prod/web"))
+			Expect(pod.Annotations).NotTo(HaveKey("k8s.v1.cni.cncf.io/networks"))
+			Expect(pod.Spec.ServiceAccountName).To(BeEmpty())
+			Expect(*pod.Spec.AutomountServiceAccountToken).To(BeFalse())
+			Expect(pod.Spec.ImagePullSecrets).To(BeEmpty())
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).To(BeNil())
+			Expect(pod.Spec.Volumes[0].EmptyDir).NotTo(BeNil())
+			Expect(pod.Spec.Volumes[1].EmptyDir.Medium).To(Equal(corev1.StorageMediumMemory))
+			container := pod.Spec.Containers[0]
+			Expect(container.Image).To(Equal("localhost/checkpoint/web:app"))
+			Expect(container.EnvFrom).To(BeEmpty())
+			Expect(container.Env).To(Equal([]corev1.EnvVar{{Name: "MODE", Value: "debug"}}))
+			Expect(container.Ports[0].HostPort).To(BeZero())
+
+			// The checkpointed pod is left as it was
+			Expect(sourcePod.Labels).To(HaveKey("app"))
+			Expect(sourcePod.Spec.Containers[0].Env).To(HaveLen(2))
+		})
+	})
+})
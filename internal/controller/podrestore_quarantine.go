@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=create
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;update
+
+// quarantinePolicyName names the NetworkPolicy denying all traffic of the
+// pods in a quarantine namespace.
+const quarantinePolicyName = "lpm-quarantine-deny-all"
+
+// restoredPodNamespace is the namespace podRestore restores its pod in.
+func restoredPodNamespace(podRestore *lpmv1.PodRestore) string {
+	if podRestore.Spec.Quarantine == nil {
+		return podRestore.Namespace
+	}
+	if podRestore.Spec.Quarantine.Namespace == "" {
+		return lpmv1.DefaultQuarantineNamespace
+	}
+	return podRestore.Spec.Quarantine.Namespace
+}
+
+// ensureQuarantine creates the quarantine namespace if it doesn't exist and
+// the NetworkPolicy isolating every pod in it, restoring the policy if it
+// was changed. It returns why a namespace it did not create cannot be used:
+// the policy would cut off the pods already running there.
+func (r *PodRestoreReconciler) ensureQuarantine(ctx context.Context, namespace string) (string, error) {
+	var ns corev1.Namespace
+	err := r.Get(ctx, client.ObjectKey{Name: namespace}, &ns)
+	if apierrors.IsNotFound(err) {
+		ns = corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: map[string]string{lpmv1.QuarantineLabel: "true"},
+		}}
+		if err := r.Create(ctx, &ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", err
+		}
+		log.FromContext(ctx).Info("Created quarantine namespace", "namespace", namespace)
+	} else if err != nil {
+		return "", err
+	} else if ns.Labels[lpmv1.QuarantineLabel] != "true" {
+		return fmt.Sprintf("namespace %s is not a quarantine namespace; label it %s=true or pick another", namespace, lpmv1.QuarantineLabel), nil
+	}
+
+	// No rules: nothing may reach the pods, nor they anything
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	}
+	var policy networkingv1.NetworkPolicy
+	err = r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: quarantinePolicyName}, &policy)
+	if apierrors.IsNotFound(err) {
+		policy = networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      quarantinePolicyName,
+				Namespace: namespace,
+				Labels:    map[string]string{lpmv1.QuarantineLabel: "true"},
+			},
+			Spec: spec,
+		}
+		return "", client.IgnoreAlreadyExists(r.Create(ctx, &policy))
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(policy.Spec.PodSelector.MatchLabels) > 0 || len(policy.Spec.PodSelector.MatchExpressions) > 0 ||
+		len(policy.Spec.Ingress) > 0 || len(policy.Spec.Egress) > 0 || len(policy.Spec.PolicyTypes) != len(spec.PolicyTypes) {
+		policy.Spec = spec
+		return "", r.Update(ctx, &policy)
+	}
+	return "", nil
+}
+
+// quarantinePod moves pod, restored by podRestore from sourcePod, to the
+// quarantine namespace and strips it of what would let it serve traffic or
+// reach the pod's dependencies: its labels, which Services and controllers
+// select it by; its annotations, which may attach secondary networks or
+// sidecars; its service account and pull secrets; the Secrets and
+// ConfigMaps its environment refers to; its host ports; and its volumes,
+// each replaced by an empty directory. The restored processes keep what
+// they had already read into memory.
+func quarantinePod(pod *corev1.Pod, podRestore *lpmv1.PodRestore, sourcePod *corev1.Pod) {
+	pod.Namespace = restoredPodNamespace(podRestore)
+	pod.Labels = map[string]string{lpmv1.QuarantineLabel: "true"}
+	pod.Annotations = map[string]string{
+		podRestoreAnnotation:            podRestore.Name,
+		lpmv1.QuarantinedFromAnnotation: podRestore.Namespace + "/" + sourcePod.Name,
+	}
+
+	automountToken := false
+	pod.Spec.ServiceAccountName = ""
+	pod.Spec.DeprecatedServiceAccount = ""
+	pod.Spec.AutomountServiceAccountToken = &automountToken
+	pod.Spec.ImagePullSecrets = nil
+	for i, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir == nil {
+			pod.Spec.Volumes[i].VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for i := range containers {
+			quarantineContainer(&containers[i])
+		}
+	}
+}
+
+// quarantineContainer drops the parts of container quarantinePod strips.
+func quarantineContainer(container *corev1.Container) {
+	container.EnvFrom = nil
+	env := container.Env[:0]
+	for _, variable := range container.Env {
+		if from := variable.ValueFrom; from != nil && (from.SecretKeyRef != nil || from.ConfigMapKeyRef != nil) {
+			continue
+		}
+		env = append(env, variable)
+	}
+	container.Env = env
+	// Block devices need a PersistentVolumeClaim
+	container.VolumeDevices = nil
+	for i := range container.Ports {
+		container.Ports[i].HostPort = 0
+		container.Ports[i].HostIP = ""
+	}
+}
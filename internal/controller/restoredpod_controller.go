@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+// sourcePodAnnotation on a restored pod names the pod it was restored from.
+const sourcePodAnnotation = "migration.source-pod"
+
+// adoptedMigrationSuffix is appended to a restored pod's name to name the
+// PodMigration adopting it.
+const adoptedMigrationSuffix = "-adopted"
+
+// RestoredPodReconciler sweeps restored pods whose PodMigration failed or is
+// gone, e.g. because the controller crashed between creating the pod and
+// deleting it again, so they don't run next to their source pod. Each is
+// deleted, adopted into a new PodMigration or kept according to the
+// orphanedRestoredPods field of the MigrationPolicies selecting it.
+type RestoredPodReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;delete
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=podmigrations,verbs=get;list;watch;create
+
+func (r *RestoredPodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var pod corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	source := pod.Annotations[sourcePodAnnotation]
+	// A StatefulSet pod is restored under its source pod's name and owned
+	// by the StatefulSet once it adopts it
+	if source == "" || source == pod.Name || !pod.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	orphaned, err := r.orphaned(ctx, &pod)
+	if err != nil || !orphaned {
+		return ctrl.Result{}, err
+	}
+
+	policies, err := policy.Matching(ctx, r.Client, &pod)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	action := policy.OrphanedRestoredPods(policies)
+	if action == lpmv1.OrphanedRestoredPodKeep {
+		logger.Info("Keeping orphaned restored pod", "pod", pod.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if action == lpmv1.OrphanedRestoredPodDelete {
+		var srcPod corev1.Pod
+		err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: source}, &srcPod)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// Without its source pod the restored pod is the only copy left
+		if err == nil && srcPod.DeletionTimestamp.IsZero() {
+			logger.Info("Deleting orphaned restored pod", "pod", pod.Name, "sourcePod", source)
+			return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, &pod))
+		}
+	}
+	return ctrl.Result{}, r.adopt(ctx, &pod, source)
+}
+
+// orphaned reports whether the PodMigration controlling a restored pod
+// failed or is gone. Restored pods handed to another controller, such as a
+// Job, are not orphaned.
+func (r *RestoredPodReconciler) orphaned(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return true, nil
+	}
+	if owner.APIVersion != lpmv1.GroupVersion.String() || owner.Kind != "PodMigration" {
+		return false, nil
+	}
+	var podMigration lpmv1.PodMigration
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: owner.Name}, &podMigration); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return podMigration.UID != owner.UID || podMigration.Status.Phase == lpmv1.MigrationPhaseFailed, nil
+}
+
+// adopt creates a PodMigration recording pod as the result of migrating its
+// source pod and makes it the pod's controller. The PodMigration controller
+// then deletes the source pod and marks the migration succeeded.
+func (r *RestoredPodReconciler) adopt(ctx context.Context, pod *corev1.Pod, source string) error {
+	podMigration := &lpmv1.PodMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pod.Name + adoptedMigrationSuffix,
+			Namespace:   pod.Namespace,
+			Annotations: map[string]string{lpmv1.AdoptedPodAnnotation: pod.Name},
+		},
+		Spec: lpmv1.PodMigrationSpec{
+			PodName:    source,
+			TargetNode: pod.Spec.NodeName,
+		},
+	}
+	if err := r.Create(ctx, podMigration); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		// Created before the pod update recording it failed
+		if err := r.Get(ctx, client.ObjectKeyFromObject(podMigration), podMigration); err != nil {
+			return err
+		}
+		if podMigration.Annotations[lpmv1.AdoptedPodAnnotation] != pod.Name {
+			return fmt.Errorf("PodMigration %s exists and does not adopt pod %s", podMigration.Name, pod.Name)
+		}
+	}
+
+	var owners []metav1.OwnerReference
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			owners = append(owners, owner)
+		}
+	}
+	pod.OwnerReferences = append(owners, *metav1.NewControllerRef(podMigration, lpmv1.GroupVersion.WithKind("PodMigration")))
+	log.FromContext(ctx).Info("Adopting orphaned restored pod", "pod", pod.Name, "sourcePod", source, "podMigration", podMigration.Name)
+	return r.Update(ctx, pod)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RestoredPodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	restored := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return obj.GetAnnotations()[sourcePodAnnotation] != ""
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(restored)).
+		// A migration failing leaves its restored pod unchanged
+		Watches(&lpmv1.PodMigration{}, handler.EnqueueRequestsFromMapFunc(
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				podMigration := obj.(*lpmv1.PodMigration)
+				if podMigration.Status.RestoredPodName == "" {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: client.ObjectKey{
+					Namespace: podMigration.Namespace,
+					Name:      podMigration.Status.RestoredPodName,
+				}}}
+			})).
+		Named("restoredpod").
+		Complete(r)
+}
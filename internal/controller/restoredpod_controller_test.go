@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var _ = Describe("RestoredPod Controller", func() {
+	Context("When reconciling an orphaned restored pod", func() {
+		ctx := context.Background()
+
+		sourceName := types.NamespacedName{Name: "orphan-source", Namespace: "default"}
+		restoredName := types.NamespacedName{Name: "orphan-source-restored", Namespace: "default"}
+		adoptedName := types.NamespacedName{Name: "orphan-source-restored-adopted", Namespace: "default"}
+
+		newPod := func(name types.NamespacedName, annotations map[string]string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name.Name,
+					Namespace:   name.Namespace,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "busybox"}},
+				},
+			}
+		}
+		restoredPod := func() *corev1.Pod {
+			pod := newPod(restoredName, map[string]string{sourcePodAnnotation: sourceName.Name})
+			isController := true
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: lpmv1.GroupVersion.String(),
+				Kind:       "PodMigration",
+				Name:       "gone",
+				UID:        "6b6f2c1e-0000-4000-8000-000000000000",
+				Controller: &isController,
+			}}
+			return pod
+		}
+
+		AfterEach(func() {
+			for _, name := range []types.NamespacedName{sourceName, restoredName} {
+				pod := &corev1.Pod{}
+				if err := k8sClient.Get(ctx, name, pod); err == nil {
+					Expect(k8sClient.Delete(ctx, pod, client.GracePeriodSeconds(0))).To(Succeed())
+				}
+			}
+			podMigration := &lpmv1.PodMigration{}
+			if err := k8sClient.Get(ctx, adoptedName, podMigration); err == nil {
+				Expect(k8sClient.Delete(ctx, podMigration)).To(Succeed())
+			}
+		})
+
+		It("should delete it while its source pod runs", func() {
+			Expect(k8sClient.Create(ctx, newPod(sourceName, nil))).To(Succeed())
+			Expect(k8sClient.Create(ctx, restoredPod())).To(Succeed())
+
+			controllerReconciler := &RestoredPodReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: restoredName})
+			Expect(err).NotTo(HaveOccurred())
+
+			pod := &corev1.Pod{}
+			err = k8sClient.Get(ctx, restoredName, pod)
+			if err == nil {
+				Expect(pod.DeletionTimestamp).NotTo(BeNil())
+			} else {
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+		})
+
+		It("should adopt it when its source pod is gone", func() {
+			Expect(k8sClient.Create(ctx, restoredPod())).To(Succeed())
+
+			controllerReconciler := &RestoredPodReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: restoredName})
+			Expect(err).NotTo(HaveOccurred())
+
+			podMigration := &lpmv1.PodMigration{}
+			Expect(k8sClient.Get(ctx, adoptedName, podMigration)).To(Succeed())
+			Expect(podMigration.Spec.PodName).To(Equal(sourceName.Name))
+			Expect(podMigration.Annotations[lpmv1.AdoptedPodAnnotation]).To(Equal(restoredName.Name))
+
+			pod := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, restoredName, pod)).To(Succeed())
+			Expect(metav1.IsControlledBy(pod, podMigration)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Multus annotations. The networks annotation is the pod's request and is
+// carried to the restored pod unchanged; the status annotations describe the
+// source pod's attachments and are recreated by Multus on the target node.
+const (
+	networksAnnotation            = "k8s.v1.cni.cncf.io/networks"
+	networkStatusAnnotation       = "k8s.v1.cni.cncf.io/network-status"
+	legacyNetworkStatusAnnotation = "k8s.v1.cni.cncf.io/networks-status"
+)
+
+// networkSelection is one entry of the networks annotation.
+type networkSelection struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	MAC       string   `json:"mac,omitempty"`
+}
+
+// networkStatus is one entry of the network-status annotation.
+type networkStatus struct {
+	Name       string   `json:"name"`
+	Interface  string   `json:"interface,omitempty"`
+	IPs        []string `json:"ips,omitempty"`
+	Default    bool     `json:"default,omitempty"`
+	DeviceInfo *struct {
+		Type string `json:"type"`
+	} `json:"device-info,omitempty"`
+}
+
+// secondaryNetwork is an attachment of a pod besides its cluster network.
+type secondaryNetwork struct {
+	status networkStatus
+	// staticIPs is set when the pod requested its addresses, so the
+	// attachment on the target node gets them back.
+	staticIPs bool
+}
+
+// parseNetworkSelections parses the networks annotation, either the JSON
+// form or the short "[namespace/]name[@interface]" comma-separated form.
+func parseNetworkSelections(annotation, podNamespace string) ([]networkSelection, error) {
+	annotation = strings.TrimSpace(annotation)
+	if annotation == "" {
+		return nil, nil
+	}
+
+	var selections []networkSelection
+	if strings.HasPrefix(annotation, "[") {
+		if err := json.Unmarshal([]byte(annotation), &selections); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", networksAnnotation, err)
+		}
+	} else {
+		for _, item := range strings.Split(annotation, ",") {
+			var selection networkSelection
+			item = strings.TrimSpace(item)
+			item, selection.Interface, _ = strings.Cut(item, "@")
+			if namespace, name, ok := strings.Cut(item, "/"); ok {
+				selection.Namespace, selection.Name = namespace, name
+			} else {
+				selection.Name = item
+			}
+			if selection.Name == "" {
+				return nil, fmt.Errorf("invalid %s annotation: empty network name", networksAnnotation)
+			}
+			selections = append(selections, selection)
+		}
+	}
+
+	for i := range selections {
+		if selections[i].Namespace == "" {
+			selections[i].Namespace = podNamespace
+		}
+	}
+	return selections, nil
+}
+
+// secondaryNetworks returns the pod's attachments besides its cluster
+// network, as reported by Multus.
+func secondaryNetworks(pod *corev1.Pod) ([]secondaryNetwork, error) {
+	selections, err := parseNetworkSelections(pod.Annotations[networksAnnotation], pod.Namespace)
+	if err != nil || len(selections) == 0 {
+		return nil, err
+	}
+
+	annotation := pod.Annotations[networkStatusAnnotation]
+	if annotation == "" {
+		annotation = pod.Annotations[legacyNetworkStatusAnnotation]
+	}
+	if annotation == "" {
+		return nil, fmt.Errorf("pod requests secondary networks but has no %s annotation", networkStatusAnnotation)
+	}
+	var statuses []networkStatus
+	if err := json.Unmarshal([]byte(annotation), &statuses); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", networkStatusAnnotation, err)
+	}
+
+	var networks []secondaryNetwork
+	for _, status := range statuses {
+		if status.Default {
+			continue
+		}
+		network := secondaryNetwork{status: status}
+		for _, selection := range selections {
+			if selection.Namespace+"/"+selection.Name != status.Name && selection.Name != status.Name {
+				continue
+			}
+			if selection.Interface != "" && selection.Interface != status.Interface {
+				continue
+			}
+			network.staticIPs = len(selection.IPs) > 0
+			break
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// unstableAddresses returns the secondary network addresses the restored pod
+// will not get back.
+func unstableAddresses(networks []secondaryNetwork) []string {
+	var addresses []string
+	for _, network := range networks {
+		if network.staticIPs {
+			continue
+		}
+		for _, ip := range network.status.IPs {
+			// Multus reports addresses with or without a prefix length
+			if addr, _, err := net.ParseCIDR(ip); err == nil {
+				ip = addr.String()
+			}
+			addresses = append(addresses, ip)
+		}
+	}
+	return addresses
+}
+
+// compareSecondaryNetworks reports the first difference between the source
+// pod's secondary attachments and the restored pod's that would break the
+// restored processes: a missing interface or a static address that did not
+// come back.
+func compareSecondaryNetworks(source, restored []secondaryNetwork) string {
+	for _, s := range source {
+		i := slices.IndexFunc(restored, func(r secondaryNetwork) bool {
+			return r.status.Interface == s.status.Interface
+		})
+		if i < 0 {
+			return fmt.Sprintf("restored pod has no interface %s for network %s", s.status.Interface, s.status.Name)
+		}
+		if !s.staticIPs {
+			continue
+		}
+		for _, ip := range s.status.IPs {
+			if !slices.Contains(restored[i].status.IPs, ip) {
+				return fmt.Sprintf("restored pod's interface %s did not get static address %s", s.status.Interface, ip)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// exclusiveHostPathTypes are the hostPath volume types that name a single
+// file or device rather than a directory: lock files, sockets a process
+// listens on, and devices. Two pods mounting one of them writable on a node
+// contend for it; directories are assumed to be shared on purpose.
+var exclusiveHostPathTypes = map[corev1.HostPathType]bool{
+	corev1.HostPathFile:         true,
+	corev1.HostPathFileOrCreate: true,
+	corev1.HostPathSocket:       true,
+	corev1.HostPathCharDev:      true,
+	corev1.HostPathBlockDev:     true,
+}
+
+// hostPort is a port a pod claims on its node's addresses.
+type hostPort struct {
+	ip       string
+	protocol corev1.Protocol
+	port     int32
+}
+
+func (p hostPort) String() string {
+	if p.ip == "" {
+		return fmt.Sprintf("%d/%s", p.port, p.protocol)
+	}
+	return fmt.Sprintf("%s:%d/%s", p.ip, p.port, p.protocol)
+}
+
+// conflicts reports whether p and other cannot both be bound: the same port
+// and protocol on the same address, or on the wildcard address.
+func (p hostPort) conflicts(other hostPort) bool {
+	if p.port != other.port || p.protocol != other.protocol {
+		return false
+	}
+	return p.ip == "" || other.ip == "" || p.ip == other.ip
+}
+
+// podHostPorts returns the host ports pod's containers claim. A host network
+// pod's container ports are defaulted to host ports by the API server.
+func podHostPorts(pod *corev1.Pod) []hostPort {
+	var ports []hostPort
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, port := range container.Ports {
+				if port.HostPort <= 0 {
+					continue
+				}
+				p := hostPort{ip: port.HostIP, protocol: port.Protocol, port: port.HostPort}
+				if p.ip == "0.0.0.0" || p.ip == "::" {
+					p.ip = ""
+				}
+				if p.protocol == "" {
+					p.protocol = corev1.ProtocolTCP
+				}
+				ports = append(ports, p)
+			}
+		}
+	}
+	return ports
+}
+
+// exclusiveHostPaths returns the exclusive hostPath files and devices pod
+// mounts writable, by cleaned path.
+func exclusiveHostPaths(pod *corev1.Pod) map[string]bool {
+	writable := map[string]bool{}
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, mount := range container.VolumeMounts {
+				if !mount.ReadOnly {
+					writable[mount.Name] = true
+				}
+			}
+		}
+	}
+
+	paths := map[string]bool{}
+	for _, volume := range pod.Spec.Volumes {
+		hostPath := volume.HostPath
+		if hostPath == nil || hostPath.Type == nil || !exclusiveHostPathTypes[*hostPath.Type] || !writable[volume.Name] {
+			continue
+		}
+		paths[filepath.Clean(hostPath.Path)] = true
+	}
+	return paths
+}
+
+// checkNodeConflicts reports why pod could not run on nodeName next to the
+// pods already bound to it, or "" if it can: a host port another pod binds,
+// or an exclusive hostPath file or device another pod mounts writable. The
+// restored pod is bound to the node directly, so the scheduler never checks
+// these and a conflict only shows once its containers fail to start.
+func checkNodeConflicts(ctx context.Context, c client.Client, pod *corev1.Pod, nodeName string) (string, error) {
+	ports := podHostPorts(pod)
+	paths := exclusiveHostPaths(pod)
+	if len(ports) == 0 && len(paths) == 0 {
+		return "", nil
+	}
+
+	var boundPods corev1.PodList
+	if err := c.List(ctx, &boundPods, client.MatchingFields{podNodeNameField: nodeName}); err != nil {
+		return "", err
+	}
+	for i := range boundPods.Items {
+		bound := &boundPods.Items[i]
+		if bound.UID == pod.UID || bound.Status.Phase == corev1.PodSucceeded || bound.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, port := range ports {
+			for _, other := range podHostPorts(bound) {
+				if port.conflicts(other) {
+					return fmt.Sprintf("host port %s is already used by pod %s/%s on the target node", port, bound.Namespace, bound.Name), nil
+				}
+			}
+		}
+		for _, path := range slices.Sorted(maps.Keys(exclusiveHostPaths(bound))) {
+			if paths[path] {
+				return fmt.Sprintf("host path %s is already mounted writable by pod %s/%s on the target node", path, bound.Namespace, bound.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
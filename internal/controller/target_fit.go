@@ -0,0 +1,283 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podNodeNameField indexes pods by the node they are bound to.
+const podNodeNameField = "spec.nodeName"
+
+// SetupPodIndexes registers the pod indexes the controllers and the agent
+// client list by with the Manager. It must be called once, whichever
+// controllers run.
+func SetupPodIndexes(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, podNodeNameField, func(obj client.Object) []string {
+		nodeName := obj.(*corev1.Pod).Spec.NodeName
+		if nodeName == "" {
+			return nil
+		}
+		return []string{nodeName}
+	})
+}
+
+// checkTargetFit reports why pod could not run on nodeName, or "" if it
+// fits: the node must be ready and schedulable, its NoSchedule/NoExecute
+// taints tolerated, its allocatable CPU, memory, extended resources,
+// hugepages and pod count must cover the pod on top of what is already bound
+// to it, and none of the pods bound to it may hold the pod's host ports or
+// exclusive hostPaths.
+func checkTargetFit(ctx context.Context, c client.Client, pod *corev1.Pod, nodeName string) (string, error) {
+	var node corev1.Node
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "target node not found", nil
+		}
+		return "", err
+	}
+
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			ready = true
+		}
+	}
+	if !ready {
+		return "target node is not ready", nil
+	}
+	if node.Spec.Unschedulable {
+		return "target node is cordoned", nil
+	}
+
+	if taints := untoleratedTaints(pod.Spec.Tolerations, &node); len(taints) > 0 {
+		return "pod does not tolerate taint " + formatTaint(taints[0]), nil
+	}
+
+	used, podCount, err := nodeUsage(ctx, c, pod, nodeName)
+	if err != nil {
+		return "", err
+	}
+	if message := checkResourceFit(pod, &node, used, podCount); message != "" {
+		return message, nil
+	}
+	return checkNodeConflicts(ctx, c, pod, nodeName)
+}
+
+// checkResourceFit reports why node's allocatable resources and pod count
+// could not cover pod on top of the podCount pods requesting used, or "" if
+// they can.
+func checkResourceFit(pod *corev1.Pod, node *corev1.Node, used corev1.ResourceList, podCount int64) string {
+	if allocatable, ok := node.Status.Allocatable[corev1.ResourcePods]; ok && podCount+1 > allocatable.Value() {
+		return fmt.Sprintf("target node already runs %d pods", podCount)
+	}
+	for name, request := range podRequests(pod) {
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			continue
+		}
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok {
+			continue
+		}
+		if message := checkFree(name, request, allocatable, used); message != "" {
+			return message
+		}
+	}
+	return checkReservedResources(pod, node, used)
+}
+
+// checkReservedResourceFit reports why nodeName could not reserve the
+// extended resources (device plugin resources such as SR-IOV VFs or GPUs) and
+// hugepages pod requests, or "" if it can. The kubelet rejects a pod bound to
+// a node lacking them instead of leaving it pending.
+func checkReservedResourceFit(ctx context.Context, c client.Client, pod *corev1.Pod, nodeName string) (string, error) {
+	hasReserved := false
+	for name := range podRequests(pod) {
+		hasReserved = hasReserved || isReservedResource(name)
+	}
+	if !hasReserved {
+		return "", nil
+	}
+
+	var node corev1.Node
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, &node); err != nil {
+		return "", err
+	}
+	used, _, err := nodeUsage(ctx, c, pod, nodeName)
+	if err != nil {
+		return "", err
+	}
+	return checkReservedResources(pod, &node, used), nil
+}
+
+func checkReservedResources(pod *corev1.Pod, node *corev1.Node, used corev1.ResourceList) string {
+	for name, request := range podRequests(pod) {
+		if !isReservedResource(name) {
+			continue
+		}
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok || allocatable.IsZero() {
+			return fmt.Sprintf("target node has no %s", name)
+		}
+		if message := checkFree(name, request, allocatable, used); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+func checkFree(name corev1.ResourceName, request, allocatable resource.Quantity, used corev1.ResourceList) string {
+	free := allocatable.DeepCopy()
+	if inUse, ok := used[name]; ok {
+		free.Sub(inUse)
+	}
+	if request.Cmp(free) > 0 {
+		return fmt.Sprintf("insufficient %s on target node: requested %s, free %s", name, request.String(), free.String())
+	}
+	return ""
+}
+
+// nodeUsage sums the requests of the pods other than pod that are bound to
+// nodeName and not finished.
+func nodeUsage(ctx context.Context, c client.Client, pod *corev1.Pod, nodeName string) (corev1.ResourceList, int64, error) {
+	var boundPods corev1.PodList
+	if err := c.List(ctx, &boundPods, client.MatchingFields{podNodeNameField: nodeName}); err != nil {
+		return nil, 0, err
+	}
+	used := corev1.ResourceList{}
+	podCount := int64(0)
+	for i := range boundPods.Items {
+		bound := &boundPods.Items[i]
+		if bound.UID == pod.UID || bound.Status.Phase == corev1.PodSucceeded || bound.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		addResources(used, podRequests(bound))
+		podCount++
+	}
+	return used, podCount, nil
+}
+
+// isReservedResource reports whether name is a resource the kubelet reserves
+// for the pod at admission: an extended resource advertised by a device
+// plugin or the node, or hugepages.
+func isReservedResource(name corev1.ResourceName) bool {
+	s := string(name)
+	if strings.HasPrefix(s, corev1.ResourceHugePagesPrefix) {
+		return true
+	}
+	return strings.Contains(s, "/") && !strings.HasPrefix(s, corev1.ResourceDefaultNamespacePrefix) &&
+		!strings.HasPrefix(s, "requests.")
+}
+
+func toleratesTaint(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSelectorOperators maps node selector operators to label selector ones.
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// nodeSelectorMatches reports whether node matches any of the selector's
+// terms. A term matches when all of its expressions and fields do.
+func nodeSelectorMatches(selector *corev1.NodeSelector, node *corev1.Node) (bool, error) {
+	fields := labels.Set{"metadata.name": node.Name}
+	for _, term := range selector.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			// An empty term matches no objects
+			continue
+		}
+		matches := true
+		for _, requirements := range []struct {
+			expressions []corev1.NodeSelectorRequirement
+			set         labels.Set
+		}{{term.MatchExpressions, node.Labels}, {term.MatchFields, fields}} {
+			for _, expression := range requirements.expressions {
+				requirement, err := labels.NewRequirement(expression.Key, nodeSelectorOperators[expression.Operator], expression.Values)
+				if err != nil {
+					return false, fmt.Errorf("invalid node selector requirement on %s: %w", expression.Key, err)
+				}
+				if !requirement.Matches(requirements.set) {
+					matches = false
+				}
+			}
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// podRequests returns the effective resource requests of a pod: the sum over
+// its containers, or the largest init container request if higher, plus the
+// pod overhead.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	addResources(requests, pod.Spec.Overhead)
+	return requests
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		current, ok := total[name]
+		if !ok {
+			current = resource.Quantity{}
+		}
+		current.Add(quantity)
+		total[name] = current
+	}
+}
+
+func subtractResources(total, sub corev1.ResourceList) {
+	for name, quantity := range sub {
+		if current, ok := total[name]; ok {
+			current.Sub(quantity)
+			total[name] = current
+		}
+	}
+}
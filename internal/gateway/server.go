@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gateway exposes PodMigration operations over HTTP/JSON for
+// orchestration systems and dashboards that cannot talk to the Kubernetes API.
+package gateway
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/pkg/client/clientset/versioned"
+)
+
+// gatewayLog logs what the gateway does outside of a request. The logger of
+// a request is taken from its context.
+var gatewayLog = log.Log.WithName("gateway")
+
+// Server serves the gateway HTTP API.
+type Server struct {
+	client versioned.Interface
+	tokens [][]byte
+}
+
+// NewServer creates a gateway server. Requests must present one of tokens as
+// an "Authorization: Bearer <token>" header.
+func NewServer(client versioned.Interface, tokens []string) (*Server, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("at least one API token is required")
+	}
+	s := &Server{client: client}
+	for _, t := range tokens {
+		s.tokens = append(s.tokens, []byte(t))
+	}
+	return s, nil
+}
+
+// Migration is the JSON representation of a PodMigration returned by the gateway.
+type Migration struct {
+	Name            string    `json:"name"`
+	Namespace       string    `json:"namespace"`
+	PodName         string    `json:"podName"`
+	TargetNode      string    `json:"targetNode"`
+	Phase           string    `json:"phase,omitempty"`
+	Message         string    `json:"message,omitempty"`
+	RestoredPodName string    `json:"restoredPodName,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+// CreateMigrationRequest is the body accepted by the create endpoint.
+type CreateMigrationRequest struct {
+	Name    string `json:"name,omitempty"`
+	PodName string `json:"podName"`
+	// TargetNode may be omitted to let the controller pick one.
+	TargetNode string `json:"targetNode,omitempty"`
+}
+
+// Handler returns the authenticated HTTP handler for the gateway API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/migrations", s.listMigrations)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/migrations", s.createMigration)
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/migrations/{name}", s.getMigration)
+	mux.HandleFunc("DELETE /api/v1/namespaces/{namespace}/migrations/{name}", s.cancelMigration)
+	return s.authenticate(mux)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := gatewayLog.WithValues("method", r.Method, "path", r.URL.Path, "remoteAddr", r.RemoteAddr)
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !s.validToken(token) {
+			logger.Info("Rejected request without a valid bearer token")
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(log.IntoContext(r.Context(), logger)))
+	})
+}
+
+func (s *Server) validToken(token string) bool {
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare(t, []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listMigrations(w http.ResponseWriter, r *http.Request) {
+	list, err := s.client.LpmV1().PodMigrations(r.PathValue("namespace")).List(r.Context(), metav1.ListOptions{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	// Newest first, which is what history views want.
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[j].CreationTimestamp.Before(&list.Items[i].CreationTimestamp)
+	})
+
+	migrations := make([]Migration, 0, len(list.Items))
+	for i := range list.Items {
+		migrations = append(migrations, toMigration(&list.Items[i]))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": migrations})
+}
+
+func (s *Server) createMigration(w http.ResponseWriter, r *http.Request) {
+	var req CreateMigrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.PodName == "" {
+		writeError(w, http.StatusBadRequest, "podName is required")
+		return
+	}
+
+	podMigration := &lpmv1.PodMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: r.PathValue("namespace"),
+		},
+		Spec: lpmv1.PodMigrationSpec{
+			PodName:    req.PodName,
+			TargetNode: req.TargetNode,
+		},
+	}
+	if podMigration.Name == "" {
+		podMigration.GenerateName = req.PodName + "-migration-"
+	}
+
+	created, err := s.client.LpmV1().PodMigrations(podMigration.Namespace).Create(r.Context(), podMigration, metav1.CreateOptions{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	log.FromContext(r.Context()).Info("Created PodMigration", "podMigration", created.Namespace+"/"+created.Name, "pod", created.Spec.PodName)
+	writeJSON(w, http.StatusCreated, toMigration(created))
+}
+
+func (s *Server) getMigration(w http.ResponseWriter, r *http.Request) {
+	podMigration, err := s.client.LpmV1().PodMigrations(r.PathValue("namespace")).Get(r.Context(), r.PathValue("name"), metav1.GetOptions{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toMigration(podMigration))
+}
+
+// cancelMigration deletes an in-flight PodMigration. Finished migrations are
+// refused: the restored pod is owned by the PodMigration, so deleting a
+// succeeded migration would garbage-collect the workload it produced.
+func (s *Server) cancelMigration(w http.ResponseWriter, r *http.Request) {
+	namespace, name := r.PathValue("namespace"), r.PathValue("name")
+	podMigration, err := s.client.LpmV1().PodMigrations(namespace).Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	switch podMigration.Status.Phase {
+	case lpmv1.MigrationPhaseSucceeded, lpmv1.MigrationPhaseFailed:
+		writeError(w, http.StatusConflict, fmt.Sprintf("migration already %s", podMigration.Status.Phase))
+		return
+	}
+
+	if err := s.client.LpmV1().PodMigrations(namespace).Delete(r.Context(), name, metav1.DeleteOptions{}); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	log.FromContext(r.Context()).Info("Cancelled PodMigration", "podMigration", namespace+"/"+name)
+	writeJSON(w, http.StatusAccepted, toMigration(podMigration))
+}
+
+func toMigration(pm *lpmv1.PodMigration) Migration {
+	return Migration{
+		Name:            pm.Name,
+		Namespace:       pm.Namespace,
+		PodName:         pm.Spec.PodName,
+		TargetNode:      pm.Spec.TargetNode,
+		Phase:           string(pm.Status.Phase),
+		Message:         pm.Status.Message,
+		RestoredPodName: pm.Status.RestoredPodName,
+		CreatedAt:       pm.CreationTimestamp.Time,
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Code != 0 {
+		code = int(status.Status().Code)
+	}
+	writeError(w, code, err.Error())
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		gatewayLog.Error(err, "Failed to encode response")
+	}
+}
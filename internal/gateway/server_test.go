@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/pkg/client/clientset/versioned/fake"
+)
+
+var _ = Describe("Server", func() {
+	ctx := context.Background()
+	var (
+		cs      *fake.Clientset
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		cs = fake.NewSimpleClientset()
+		server, err := NewServer(cs, []string{"secret-token"})
+		Expect(err).NotTo(HaveOccurred())
+		handler = server.Handler()
+	})
+
+	// serve sends a request with token, if set, and returns the response.
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("refuses to start without tokens", func() {
+		_, err := NewServer(cs, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("rejects requests without a valid bearer token",
+		func(header string) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/migrations", nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+			Expect(cs.Actions()).To(BeEmpty())
+		},
+		Entry("no header", ""),
+		Entry("wrong token", "Bearer other-token"),
+		Entry("not a bearer token", "Basic secret-token"),
+	)
+
+	It("creates a migration", func() {
+		rec := serve(http.MethodPost, "/api/v1/namespaces/default/migrations", "secret-token",
+			`{"name":"mig","podName":"app","targetNode":"node-b"}`)
+		Expect(rec.Code).To(Equal(http.StatusCreated))
+		var migration Migration
+		Expect(json.Unmarshal(rec.Body.Bytes(), &migration)).To(Succeed())
+		Expect(migration.Name).To(Equal("mig"))
+		Expect(migration.PodName).To(Equal("app"))
+
+		created, err := cs.LpmV1().PodMigrations("default").Get(ctx, "mig", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Spec.PodName).To(Equal("app"))
+		Expect(created.Spec.TargetNode).To(Equal("node-b"))
+	})
+
+	It("rejects a migration without a pod", func() {
+		rec := serve(http.MethodPost, "/api/v1/namespaces/default/migrations", "secret-token", `{"name":"mig"}`)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("gets a migration and reports a missing one", func() {
+		_, err := cs.LpmV1().PodMigrations("default").Create(ctx, &lpmv1.PodMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "mig", Namespace: "default"},
+			Spec:       lpmv1.PodMigrationSpec{PodName: "app"},
+			Status:     lpmv1.PodMigrationStatus{Phase: lpmv1.MigrationPhaseRestoring},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rec := serve(http.MethodGet, "/api/v1/namespaces/default/migrations/mig", "secret-token", "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var migration Migration
+		Expect(json.Unmarshal(rec.Body.Bytes(), &migration)).To(Succeed())
+		Expect(migration.Phase).To(Equal(string(lpmv1.MigrationPhaseRestoring)))
+
+		rec = serve(http.MethodGet, "/api/v1/namespaces/default/migrations/other", "secret-token", "")
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("lists the migrations of a namespace newest first", func() {
+		now := time.Now()
+		for i, name := range []string{"older", "newer"} {
+			_, err := cs.LpmV1().PodMigrations("default").Create(ctx, &lpmv1.PodMigration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(now.Add(time.Duration(i) * time.Minute)),
+				},
+				Spec: lpmv1.PodMigrationSpec{PodName: "app"},
+			}, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := cs.LpmV1().PodMigrations("other").Create(ctx, &lpmv1.PodMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other"},
+		}, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		rec := serve(http.MethodGet, "/api/v1/namespaces/default/migrations", "secret-token", "")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var list struct {
+			Items []Migration `json:"items"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &list)).To(Succeed())
+		Expect(list.Items).To(HaveLen(2))
+		Expect(list.Items[0].Name).To(Equal("newer"))
+		Expect(list.Items[1].Name).To(Equal("older"))
+	})
+})
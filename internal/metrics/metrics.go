@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the controller's Prometheus metrics. They are
+// registered with controller-runtime's registry and served on the manager's
+// metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// CheckpointQueueWait is the time a container checkpoint waited for a
+	// free slot on its node before the checkpoint RPC was dispatched.
+	CheckpointQueueWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lpm_checkpoint_queue_wait_seconds",
+		Help:    "Time container checkpoints waited for a per-node checkpoint slot.",
+		Buckets: []float64{0, 1, 2, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"node"})
+
+	// CheckpointsInFlight is the number of checkpoint RPCs currently
+	// dispatched to each node.
+	CheckpointsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lpm_checkpoints_in_flight",
+		Help: "Checkpoint RPCs currently running on each node.",
+	}, []string{"node"})
+
+	// NodeBandwidth is the throughput last probed from one node's agent to
+	// another's.
+	NodeBandwidth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lpm_node_bandwidth_bytes_per_second",
+		Help: "Throughput last probed between the agents of two nodes.",
+	}, []string{"source_node", "target_node"})
+
+	// CheckpointTimestamp is when the latest retained generation of each
+	// PodCheckpoint was taken; time() minus it is the checkpoint's age.
+	CheckpointTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lpm_checkpoint_timestamp_seconds",
+		Help: "Unix time the latest retained generation of each PodCheckpoint was taken.",
+	}, []string{"namespace", "podcheckpoint"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(CheckpointQueueWait, CheckpointsInFlight, NodeBandwidth, CheckpointTimestamp)
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notify sends notifications about migrations to the sinks
+// configured in MigrationPolicies.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// Event is the payload of a notification.
+type Event struct {
+	Type            lpmv1.MigrationEvent `json:"type"`
+	Namespace       string               `json:"namespace"`
+	Migration       string               `json:"migration"`
+	Pod             string               `json:"pod"`
+	SourceNode      string               `json:"sourceNode,omitempty"`
+	TargetNode      string               `json:"targetNode,omitempty"`
+	RestoredPod     string               `json:"restoredPod,omitempty"`
+	Reason          string               `json:"reason,omitempty"`
+	Message         string               `json:"message,omitempty"`
+	StartTime       time.Time            `json:"startTime"`
+	Time            time.Time            `json:"time"`
+	DurationSeconds float64              `json:"durationSeconds"`
+}
+
+// Summary is a one-line description of the event for people.
+func (e Event) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Migration %s/%s of pod %s", e.Namespace, e.Migration, e.Pod)
+	if e.SourceNode != "" || e.TargetNode != "" {
+		fmt.Fprintf(&b, " from %s to %s", orUnknown(e.SourceNode), orUnknown(e.TargetNode))
+	}
+	switch e.Type {
+	case lpmv1.MigrationEventStarted:
+		b.WriteString(" started")
+	case lpmv1.MigrationEventSucceeded:
+		fmt.Fprintf(&b, " succeeded after %.1fs", e.DurationSeconds)
+	case lpmv1.MigrationEventFailed:
+		fmt.Fprintf(&b, " failed after %.1fs", e.DurationSeconds)
+	case lpmv1.MigrationEventRolledBack:
+		fmt.Fprintf(&b, " was rolled back after %.1fs, keeping the source pod", e.DurationSeconds)
+	}
+	if e.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", e.Reason)
+	}
+	if e.Message != "" && e.Type != lpmv1.MigrationEventStarted {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "an unknown node"
+	}
+	return s
+}
+
+// Notifier delivers events to a sink.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Webhook posts events as JSON.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(ctx context.Context, event Event) error {
+	return postJSON(ctx, w.Client, w.URL, event)
+}
+
+// Slack posts event summaries to a Slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+// Notify implements Notifier.
+func (s *Slack) Notify(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.Client, s.WebhookURL, map[string]string{"text": event.Summary()})
+}
+
+// Email mails event summaries.
+type Email struct {
+	// Address is the host:port of the SMTP server.
+	Address string
+	From    string
+	To      []string
+	// Auth is nil for servers that do not require authentication.
+	Auth smtp.Auth
+}
+
+// Notify implements Notifier. The SMTP client cannot be cancelled, so ctx is
+// only checked before sending.
+func (e *Email) Notify(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	body, err := json.MarshalIndent(event, "", "  ")
+	if err != nil {
+		return err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&msg, "Subject: [%s] Pod migration %s/%s\r\n", event.Type, event.Namespace, event.Migration)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n%s\r\n", event.Summary(), body)
+	return smtp.SendMail(e.Address, e.Auth, e.From, e.To, msg.Bytes())
+}
+
+// PlainAuth returns PLAIN authentication for the server at address.
+func PlainAuth(address, username, password string) smtp.Auth {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	return smtp.PlainAuth("", username, password, host)
+}
+
+func postJSON(ctx context.Context, c *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
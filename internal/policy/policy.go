@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates the MigrationPolicies that apply to a migrated pod.
+// The controller and the PodMigration webhook share it, so a target node the
+// webhook admits is one the controller restores on.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+// +kubebuilder:rbac:groups=lpm.my.domain,resources=migrationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// Matching returns the MigrationPolicies selecting pod, by name.
+func Matching(ctx context.Context, c client.Reader, pod *corev1.Pod) ([]lpmv1.MigrationPolicy, error) {
+	var list lpmv1.MigrationPolicyList
+	if err := c.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list migration policies: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	var namespace corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: pod.Namespace}, &namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", pod.Namespace, err)
+	}
+
+	var matching []lpmv1.MigrationPolicy
+	for i := range list.Items {
+		matches, err := Selects(&list.Items[i], &namespace, pod)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			matching = append(matching, list.Items[i])
+		}
+	}
+	slices.SortFunc(matching, func(a, b lpmv1.MigrationPolicy) int { return strings.Compare(a.Name, b.Name) })
+	return matching, nil
+}
+
+// Selects reports whether policy selects pod, which runs in namespace.
+func Selects(policy *lpmv1.MigrationPolicy, namespace *corev1.Namespace, pod *corev1.Pod) (bool, error) {
+	namespaceMatches, err := selects(policy.Spec.NamespaceSelector, namespace.Labels)
+	if err != nil {
+		return false, fmt.Errorf("migration policy %s: invalid namespaceSelector: %w", policy.Name, err)
+	}
+	podMatches, err := selects(policy.Spec.PodSelector, pod.Labels)
+	if err != nil {
+		return false, fmt.Errorf("migration policy %s: invalid podSelector: %w", policy.Name, err)
+	}
+	return namespaceMatches && podMatches, nil
+}
+
+// TargetDenied reports why policies forbid restoring on node, or "" if they
+// allow it.
+func TargetDenied(policies []lpmv1.MigrationPolicy, node *corev1.Node) (string, error) {
+	for _, policy := range policies {
+		target := policy.Spec.TargetNodes
+		if target == nil {
+			continue
+		}
+		if slices.Contains(target.DeniedNodes, node.Name) {
+			return fmt.Sprintf("migration policy %s denies node %s", policy.Name, node.Name), nil
+		}
+		if target.DeniedSelector != nil {
+			denied, err := selects(target.DeniedSelector, node.Labels)
+			if err != nil {
+				return "", fmt.Errorf("migration policy %s: invalid deniedSelector: %w", policy.Name, err)
+			}
+			if denied {
+				return fmt.Sprintf("migration policy %s denies nodes matching %s", policy.Name, metav1.FormatLabelSelector(target.DeniedSelector)), nil
+			}
+		}
+		if target.AllowedSelector != nil {
+			allowed, err := selects(target.AllowedSelector, node.Labels)
+			if err != nil {
+				return "", fmt.Errorf("migration policy %s: invalid allowedSelector: %w", policy.Name, err)
+			}
+			if !allowed {
+				return fmt.Sprintf("migration policy %s only allows nodes matching %s", policy.Name, metav1.FormatLabelSelector(target.AllowedSelector)), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// TopologyPreference returns the topology preference of the first of
+// policies setting one, or "".
+func TopologyPreference(policies []lpmv1.MigrationPolicy) lpmv1.TopologyPreference {
+	for _, policy := range policies {
+		if target := policy.Spec.TargetNodes; target != nil && target.TopologyPreference != "" {
+			return target.TopologyPreference
+		}
+	}
+	return ""
+}
+
+// OrphanedRestoredPods returns the orphaned restored pod policy of the first
+// of policies setting one, or Delete.
+func OrphanedRestoredPods(policies []lpmv1.MigrationPolicy) lpmv1.OrphanedRestoredPodPolicy {
+	for _, policy := range policies {
+		if policy.Spec.OrphanedRestoredPods != "" {
+			return policy.Spec.OrphanedRestoredPods
+		}
+	}
+	return lpmv1.OrphanedRestoredPodDelete
+}
+
+// ApprovalStage returns the earliest stage policies require approval at, or
+// "".
+func ApprovalStage(policies []lpmv1.MigrationPolicy) lpmv1.ApprovalStage {
+	var stage lpmv1.ApprovalStage
+	for _, policy := range policies {
+		switch policy.Spec.RequireApproval {
+		case lpmv1.ApprovalStageBeforeCheckpoint:
+			return lpmv1.ApprovalStageBeforeCheckpoint
+		case lpmv1.ApprovalStageBeforeRestore:
+			stage = lpmv1.ApprovalStageBeforeRestore
+		}
+	}
+	return stage
+}
+
+// MaxCheckpointAge returns the shortest maximum checkpoint age policies set,
+// or 0 if none sets one.
+func MaxCheckpointAge(policies []lpmv1.MigrationPolicy) time.Duration {
+	var maxAge time.Duration
+	for _, policy := range policies {
+		if age := policy.Spec.MaxCheckpointAge; age != nil && (maxAge == 0 || age.Duration < maxAge) {
+			maxAge = age.Duration
+		}
+	}
+	return maxAge
+}
+
+// SystemCriticalPriority is the lowest priority of the system-node-critical
+// and system-cluster-critical priority classes.
+const SystemCriticalPriority = 2000000000
+
+// IsCritical reports whether pod has a system critical priority.
+func IsCritical(pod *corev1.Pod) bool {
+	return pod.Spec.Priority != nil && *pod.Spec.Priority >= SystemCriticalPriority
+}
+
+// CriticalPodDenied reports why policies forbid checkpointing or migrating
+// pod, or "" if they allow it. Pods without a system critical priority are
+// always allowed; those with one only if a policy allows them and none
+// refuses them.
+func CriticalPodDenied(policies []lpmv1.MigrationPolicy, pod *corev1.Pod) string {
+	if !IsCritical(pod) {
+		return ""
+	}
+	allowed := false
+	for _, policy := range policies {
+		switch policy.Spec.CriticalPods {
+		case lpmv1.CriticalPodsRefuse:
+			return fmt.Sprintf("pod has system critical priority class %s, which migration policy %s refuses", pod.Spec.PriorityClassName, policy.Name)
+		case lpmv1.CriticalPodsAllow:
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Sprintf("pod has system critical priority class %s; a migration policy must allow criticalPods", pod.Spec.PriorityClassName)
+	}
+	return ""
+}
+
+// MaxVictimPriority returns the highest priority of the pods policies let a
+// migration preempt on its target node, the lowest any of them sets, and
+// false if none enables target preemption.
+func MaxVictimPriority(policies []lpmv1.MigrationPolicy) (int32, bool) {
+	var maxPriority int32
+	enabled := false
+	for _, policy := range policies {
+		preemption := policy.Spec.TargetPreemption
+		if preemption == nil {
+			continue
+		}
+		if !enabled || preemption.MaxVictimPriority < maxPriority {
+			maxPriority = preemption.MaxVictimPriority
+		}
+		enabled = true
+	}
+	return maxPriority, enabled
+}
+
+// selects reports whether selector matches set; a nil selector matches
+// everything.
+func selects(selector *metav1.LabelSelector, set map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(set)), nil
+}
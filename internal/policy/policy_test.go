@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+func targetPolicy(name string, target *lpmv1.TargetNodePolicy) lpmv1.MigrationPolicy {
+	return lpmv1.MigrationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       lpmv1.MigrationPolicySpec{TargetNodes: target},
+	}
+}
+
+func priority(value int32) *int32 {
+	return &value
+}
+
+func criticalPodsPolicy(name string, criticalPods lpmv1.CriticalPodPolicy) lpmv1.MigrationPolicy {
+	return lpmv1.MigrationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       lpmv1.MigrationPolicySpec{CriticalPods: criticalPods},
+	}
+}
+
+var _ = Describe("TargetDenied", func() {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a"}}}
+
+	DescribeTable("evaluates the target node policies",
+		func(policies []lpmv1.MigrationPolicy, expected string) {
+			denied, err := TargetDenied(policies, node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(denied).To(Equal(expected))
+		},
+		Entry("allows any node without policies", nil, ""),
+		Entry("allows any node without target node policies",
+			[]lpmv1.MigrationPolicy{targetPolicy("p", nil)}, ""),
+		Entry("denies a denied node",
+			[]lpmv1.MigrationPolicy{targetPolicy("p", &lpmv1.TargetNodePolicy{DeniedNodes: []string{"node-a"}})},
+			"migration policy p denies node node-a"),
+		Entry("denies a node matching the denied selector",
+			[]lpmv1.MigrationPolicy{targetPolicy("p", &lpmv1.TargetNodePolicy{
+				DeniedSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "a"}},
+			})},
+			"migration policy p denies nodes matching zone=a"),
+		Entry("denies a node not matching the allowed selector",
+			[]lpmv1.MigrationPolicy{targetPolicy("p", &lpmv1.TargetNodePolicy{
+				AllowedSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "b"}},
+			})},
+			"migration policy p only allows nodes matching zone=b"),
+		Entry("allows a node matching the allowed selector",
+			[]lpmv1.MigrationPolicy{targetPolicy("p", &lpmv1.TargetNodePolicy{
+				AllowedSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "a"}},
+			})},
+			""),
+		Entry("denies a node any policy denies",
+			[]lpmv1.MigrationPolicy{
+				targetPolicy("a", &lpmv1.TargetNodePolicy{DeniedNodes: []string{"node-b"}}),
+				targetPolicy("b", &lpmv1.TargetNodePolicy{DeniedNodes: []string{"node-a"}}),
+			},
+			"migration policy b denies node node-a"),
+	)
+
+	It("reports an invalid selector", func() {
+		_, err := TargetDenied([]lpmv1.MigrationPolicy{targetPolicy("p", &lpmv1.TargetNodePolicy{
+			DeniedSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "zone", Operator: "Near"},
+			}},
+		})}, node)
+		Expect(err).To(MatchError(ContainSubstring("migration policy p: invalid deniedSelector")))
+	})
+})
+
+var _ = Describe("Selects", func() {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "app", Labels: map[string]string{"app": "web"}}}
+
+	DescribeTable("matches the namespace and pod selectors",
+		func(namespaceSelector, podSelector *metav1.LabelSelector, expected bool) {
+			policy := &lpmv1.MigrationPolicy{Spec: lpmv1.MigrationPolicySpec{
+				NamespaceSelector: namespaceSelector,
+				PodSelector:       podSelector,
+			}}
+			matches, err := Selects(policy, namespace, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal(expected))
+		},
+		Entry("selects every pod with nil selectors", nil, nil, true),
+		Entry("selects every pod of a matching namespace with a nil pod selector",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}, nil, true),
+		Entry("selects matching pods of any namespace with a nil namespace selector",
+			nil, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, true),
+		Entry("skips pods of other namespaces",
+			&metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}}, nil, false),
+		Entry("skips other pods",
+			nil, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}, false),
+		Entry("selects every pod with empty selectors",
+			&metav1.LabelSelector{}, &metav1.LabelSelector{}, true),
+	)
+})
+
+var _ = Describe("CriticalPodDenied", func() {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{PriorityClassName: "system-node-critical", Priority: priority(SystemCriticalPriority + 1000)}}
+
+	DescribeTable("evaluates the critical pod policies",
+		func(pod *corev1.Pod, policies []lpmv1.MigrationPolicy, expected string) {
+			Expect(CriticalPodDenied(policies, pod)).To(Equal(expected))
+		},
+		Entry("allows pods without a priority", &corev1.Pod{}, nil, ""),
+		Entry("allows pods below the system critical priority",
+			&corev1.Pod{Spec: corev1.PodSpec{Priority: priority(SystemCriticalPriority - 1)}},
+			[]lpmv1.MigrationPolicy{criticalPodsPolicy("p", lpmv1.CriticalPodsRefuse)}, ""),
+		Entry("denies critical pods no policy allows", pod, nil,
+			"pod has system critical priority class system-node-critical; a migration policy must allow criticalPods"),
+		Entry("allows critical pods a policy allows", pod,
+			[]lpmv1.MigrationPolicy{criticalPodsPolicy("p", lpmv1.CriticalPodsAllow)}, ""),
+		Entry("denies critical pods a policy refuses even if another allows them", pod,
+			[]lpmv1.MigrationPolicy{
+				criticalPodsPolicy("a", lpmv1.CriticalPodsAllow),
+				criticalPodsPolicy("b", lpmv1.CriticalPodsRefuse),
+			},
+			"pod has system critical priority class system-node-critical, which migration policy b refuses"),
+	)
+})
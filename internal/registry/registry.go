@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry deploys and locates the OCI registry that checkpoint
+// images move through between nodes when there is no shared checkpoint
+// storage: an in-cluster one, or an external one pushed to with credentials
+// from a Secret.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Name of the registry's Deployment, Service and PersistentVolumeClaim.
+	Name = "checkpoint-registry"
+	// Port the registry serves on, without TLS.
+	Port = 5000
+
+	// DefaultImage is the registry server image.
+	DefaultImage = "docker.io/library/registry:2"
+	// DefaultStorageSize is the size of the volume images are stored on.
+	DefaultStorageSize = "20Gi"
+)
+
+// Options configure the registry.
+type Options struct {
+	// Namespace the registry is deployed in.
+	Namespace string
+	// Image of the registry server.
+	Image string
+	// StorageSize of the registry's volume.
+	StorageSize resource.Quantity
+	// StorageClassName of the registry's volume; empty for the default class.
+	StorageClassName string
+
+	// URL, as host[:port][/path], of an external registry used instead of
+	// deploying one. It is served with TLS.
+	URL string
+	// CredentialsSecret names a kubernetes.io/dockerconfigjson Secret in
+	// Namespace with the credentials agents push to and pull from the
+	// external registry with.
+	CredentialsSecret string
+}
+
+// Registry deploys the checkpoint registry when the manager starts and
+// reports the address agents reach it at.
+type Registry struct {
+	client client.Client
+	// reader reads the registry's objects without starting informers for
+	// every Deployment, Service and claim in the cluster.
+	reader client.Reader
+	opts   Options
+}
+
+// New creates a Registry. reader should be the manager's API reader.
+func New(c client.Client, reader client.Reader, opts Options) *Registry {
+	return &Registry{client: c, reader: reader, opts: opts}
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;create
+
+// Start creates the registry's objects that do not exist. Objects that exist
+// are left alone, so they can be tuned after deployment. Nothing is deployed
+// for an external registry.
+func (r *Registry) Start(ctx context.Context) error {
+	if r.opts.URL != "" {
+		return nil
+	}
+	logger := log.FromContext(ctx).WithValues("namespace", r.opts.Namespace, "name", Name)
+
+	for _, obj := range []client.Object{r.claim(), r.deployment(), r.service()} {
+		err := r.client.Create(ctx, obj)
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create checkpoint registry %T: %w", obj, err)
+		}
+		logger.Info("Created checkpoint registry object", "kind", fmt.Sprintf("%T", obj))
+	}
+	return nil
+}
+
+// Host returns the host:port agents push checkpoint images to and pull them
+// from. buildah resolves names with the node's resolver when the agent runs
+// on the host network, so this is the Service's cluster IP, and an IPv4 one:
+// image references cannot carry IPv6 addresses. An external registry is at
+// its URL.
+func (r *Registry) Host(ctx context.Context) (string, error) {
+	if r.opts.URL != "" {
+		return r.opts.URL, nil
+	}
+	var svc corev1.Service
+	if err := r.reader.Get(ctx, client.ObjectKey{Namespace: r.opts.Namespace, Name: Name}, &svc); err != nil {
+		return "", fmt.Errorf("failed to get checkpoint registry service: %w", err)
+	}
+	clusterIPs := svc.Spec.ClusterIPs
+	if len(clusterIPs) == 0 && svc.Spec.ClusterIP != "" {
+		clusterIPs = []string{svc.Spec.ClusterIP}
+	}
+	for _, ip := range clusterIPs {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+			return net.JoinHostPort(ip, fmt.Sprint(Port)), nil
+		}
+	}
+	return "", fmt.Errorf("checkpoint registry service %s/%s has no IPv4 cluster IP", r.opts.Namespace, Name)
+}
+
+// TLSVerify reports whether the registry's certificate is verified: the
+// in-cluster registry is served without TLS.
+func (r *Registry) TLSVerify() bool {
+	return r.opts.URL != ""
+}
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get
+
+// Credentials returns the containers auth file, in the format of a Docker
+// config.json, agents push to and pull from the registry with; nil if the
+// registry takes no credentials.
+func (r *Registry) Credentials(ctx context.Context) ([]byte, error) {
+	if r.opts.CredentialsSecret == "" {
+		return nil, nil
+	}
+	var secret corev1.Secret
+	if err := r.reader.Get(ctx, client.ObjectKey{Namespace: r.opts.Namespace, Name: r.opts.CredentialsSecret}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint registry credentials: %w", err)
+	}
+	auth, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if secret.Type != corev1.SecretTypeDockerConfigJson || !ok {
+		return nil, fmt.Errorf("checkpoint registry credentials %s/%s are not a %s Secret",
+			r.opts.Namespace, r.opts.CredentialsSecret, corev1.SecretTypeDockerConfigJson)
+	}
+	return auth, nil
+}
+
+// Holds reports whether imageRef names an image in the registry at host.
+func Holds(host, imageRef string) bool {
+	return strings.HasPrefix(imageRef, host+"/")
+}
+
+// ValidateURL checks url names an external registry as image references
+// start with it: a host, with an optional port and repository path.
+func ValidateURL(url string) error {
+	if strings.Contains(url, "://") {
+		return fmt.Errorf("registry URL %q must not have a scheme", url)
+	}
+	if url == "" || strings.HasSuffix(url, "/") || !imageReferencePrefix.MatchString(url) {
+		return fmt.Errorf("registry URL %q is not a registry host with an optional repository path", url)
+	}
+	return nil
+}
+
+// imageReferencePrefix matches a registry host, with an optional port, and
+// the repository path components image references may start with.
+var imageReferencePrefix = regexp.MustCompile(`^[a-zA-Z0-9.-]+(:[0-9]+)?(/[a-z0-9]+(([._]|__|-+)[a-z0-9]+)*)*$`)
+
+// Reference moves imageName into the registry at host, replacing the registry
+// host it names.
+func Reference(host, imageName string) string {
+	_, repository, _ := strings.Cut(imageName, "/")
+	return host + "/" + repository
+}
+
+func (r *Registry) labels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       Name,
+		"app.kubernetes.io/part-of":    "live-pod-migration-controller",
+		"app.kubernetes.io/managed-by": "live-pod-migration-controller",
+	}
+}
+
+func (r *Registry) claim() *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: r.opts.Namespace, Name: Name, Labels: r.labels()},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: r.opts.StorageSize},
+			},
+		},
+	}
+	if r.opts.StorageClassName != "" {
+		storageClassName := r.opts.StorageClassName
+		claim.Spec.StorageClassName = &storageClassName
+	}
+	return claim
+}
+
+func (r *Registry) deployment() *appsv1.Deployment {
+	replicas := int32(1)
+	allowPrivilegeEscalation := false
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: r.opts.Namespace, Name: Name, Labels: r.labels()},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: r.labels()},
+			// The volume is ReadWriteOnce
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: r.labels()},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "registry",
+						Image: r.opts.Image,
+						Env: []corev1.EnvVar{
+							{Name: "REGISTRY_HTTP_ADDR", Value: fmt.Sprintf(":%d", Port)},
+							{Name: "REGISTRY_STORAGE_DELETE_ENABLED", Value: "true"},
+						},
+						Ports: []corev1.ContainerPort{{Name: "registry", ContainerPort: Port, Protocol: corev1.ProtocolTCP}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/v2/", Port: intstr.FromString("registry")},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "storage", MountPath: "/var/lib/registry"}},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+							Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "storage",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: Name},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (r *Registry) service() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: r.opts.Namespace, Name: Name, Labels: r.labels()},
+		Spec: corev1.ServiceSpec{
+			Selector: r.labels(),
+			Ports: []corev1.ServicePort{{
+				Name:       "registry",
+				Port:       Port,
+				TargetPort: intstr.FromString("registry"),
+				Protocol:   corev1.ProtocolTCP,
+			}},
+		},
+	}
+}
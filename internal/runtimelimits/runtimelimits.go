@@ -0,0 +1,192 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runtimelimits sizes the Go runtime to the CPU and memory limits
+// of the container it runs in. The runtime sizes itself to the node
+// instead: GOMAXPROCS to the node's CPUs, which a CPU quota then throttles,
+// and no soft memory limit, so the heap of a large checkpoint copy or
+// conversion can grow until the container is OOM-killed.
+package runtimelimits
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// cgroupRoot is where the cgroup hierarchies are mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// unlimited is the smallest value cgroup v1 reports for no memory limit.
+const unlimited = int64(1) << 62
+
+// Limits are the values Apply set; zero values were left as they were.
+type Limits struct {
+	// GOMAXPROCS is the number of CPUs Go code runs on at once.
+	GOMAXPROCS int
+	// MemoryLimit is the runtime's soft memory limit in bytes.
+	MemoryLimit int64
+}
+
+// Apply sets GOMAXPROCS to the container's CPU quota, rounded up, and the
+// runtime's soft memory limit to memoryRatio of the container's memory
+// limit. A GOMAXPROCS or GOMEMLIMIT environment variable wins over the
+// cgroup, and a memoryRatio of 0 leaves the memory limit alone.
+func Apply(memoryRatio float64) (Limits, error) {
+	var limits Limits
+	if memoryRatio < 0 || memoryRatio > 1 {
+		return limits, fmt.Errorf("memory limit ratio %g is not between 0 and 1", memoryRatio)
+	}
+	paths, err := cgroupPaths()
+	if err != nil {
+		return limits, err
+	}
+
+	if os.Getenv("GOMAXPROCS") == "" {
+		cpus, err := cpuQuota(paths)
+		if err != nil {
+			return limits, err
+		}
+		if procs := int(math.Ceil(cpus)); cpus > 0 && procs < runtime.GOMAXPROCS(0) {
+			runtime.GOMAXPROCS(procs)
+			limits.GOMAXPROCS = procs
+		}
+	}
+
+	if os.Getenv("GOMEMLIMIT") == "" && memoryRatio > 0 {
+		memory, err := memoryLimit(paths)
+		if err != nil {
+			return limits, err
+		}
+		if memory > 0 {
+			limits.MemoryLimit = int64(float64(memory) * memoryRatio)
+			debug.SetMemoryLimit(limits.MemoryLimit)
+		}
+	}
+	return limits, nil
+}
+
+// cgroupPaths returns the directories the process's cgroup may be found in,
+// by cgroup v1 controller, or under "" for cgroup v2, from /proc/self/cgroup:
+// the cgroup's path under the hierarchy's mount, and the mount itself for a
+// container whose hierarchy is mounted at its own cgroup.
+func cgroupPaths() (map[string][]string, error) {
+	f, err := os.Open("/proc/self/cgroup")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	paths := map[string][]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		mount := filepath.Join(cgroupRoot, fields[1])
+		for _, controller := range strings.Split(fields[1], ",") {
+			paths[controller] = []string{filepath.Join(mount, fields[2]), mount}
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// cpuQuota returns the CPUs the cgroup's quota allows, or 0 if unlimited.
+func cpuQuota(paths map[string][]string) (float64, error) {
+	// cpu.max: "<quota> <period>", the quota "max" if unlimited
+	data, err := readCgroupFile(paths[""], "cpu.max")
+	if err != nil {
+		return 0, err
+	}
+	if data != "" {
+		fields := strings.Fields(data)
+		if len(fields) != 2 || fields[0] == "max" {
+			return 0, nil
+		}
+		return ratio(fields[0], fields[1])
+	}
+
+	quota, err := readCgroupFile(paths["cpu"], "cpu.cfs_quota_us")
+	if err != nil || quota == "" || quota == "-1" {
+		return 0, err
+	}
+	period, err := readCgroupFile(paths["cpu"], "cpu.cfs_period_us")
+	if err != nil || period == "" {
+		return 0, err
+	}
+	return ratio(quota, period)
+}
+
+// memoryLimit returns the cgroup's memory limit in bytes, or 0 if
+// unlimited.
+func memoryLimit(paths map[string][]string) (int64, error) {
+	data, err := readCgroupFile(paths[""], "memory.max")
+	if err == nil && data == "" {
+		data, err = readCgroupFile(paths["memory"], "memory.limit_in_bytes")
+	}
+	if err != nil || data == "" || data == "max" {
+		return 0, err
+	}
+	limit, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q: %w", data, err)
+	}
+	if limit >= unlimited {
+		return 0, nil
+	}
+	return limit, nil
+}
+
+// readCgroupFile returns the trimmed content of the file name in the first
+// of dirs having it, or "" if none does, as for the root cgroup or a
+// controller of the other cgroup version.
+func readCgroupFile(dirs []string, name string) (string, error) {
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", nil
+}
+
+func ratio(numerator, denominator string) (float64, error) {
+	n, err := strconv.ParseFloat(numerator, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid CPU quota %q: %w", numerator, err)
+	}
+	d, err := strconv.ParseFloat(denominator, 64)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid CPU period %q", denominator)
+	}
+	return n / d, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/internal/policy"
+)
+
+var podmigrationlog = logf.Log.WithName("podmigration-resource")
+
+// SetupPodMigrationWebhookWithManager registers the webhook for PodMigration in the manager.
+func SetupPodMigrationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&lpmv1.PodMigration{}).
+		WithValidator(&PodMigrationCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-lpm-my-domain-v1-podmigration,mutating=false,failurePolicy=fail,sideEffects=None,groups=lpm.my.domain,resources=podmigrations,verbs=create;update,versions=v1,name=vpodmigration-v1.kb.io,admissionReviewVersions=v1
+
+// PodMigrationCustomValidator rejects PodMigrations of system critical pods
+// and to target nodes that the MigrationPolicies of the migrated pod forbid.
+type PodMigrationCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &PodMigrationCustomValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *PodMigrationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	podMigration, ok := obj.(*lpmv1.PodMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a PodMigration object but got %T", obj)
+	}
+	podmigrationlog.V(1).Info("Validation for PodMigration upon creation", "name", podMigration.GetName())
+
+	if err := v.validateCriticalPod(ctx, podMigration); err != nil {
+		return nil, err
+	}
+	return nil, v.validateTargetNode(ctx, podMigration)
+}
+
+// ValidateUpdate implements admission.CustomValidator. Only a changed target
+// node is checked, so migrations admitted before a policy changed can still
+// be updated; the controller's preflight rejects them.
+func (v *PodMigrationCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldMigration, ok := oldObj.(*lpmv1.PodMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a PodMigration object for the oldObj but got %T", oldObj)
+	}
+	podMigration, ok := newObj.(*lpmv1.PodMigration)
+	if !ok {
+		return nil, fmt.Errorf("expected a PodMigration object for the newObj but got %T", newObj)
+	}
+	podmigrationlog.V(1).Info("Validation for PodMigration upon update", "name", podMigration.GetName())
+
+	if podMigration.Spec.TargetNode == oldMigration.Spec.TargetNode {
+		return nil, nil
+	}
+	return nil, v.validateTargetNode(ctx, podMigration)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *PodMigrationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateCriticalPod rejects migrating a pod with a system critical
+// priority unless its policies allow it. A missing pod is left for the
+// controller to report.
+func (v *PodMigrationCustomValidator) validateCriticalPod(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	var pod corev1.Pod
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !policy.IsCritical(&pod) {
+		return nil
+	}
+	policies, err := policy.Matching(ctx, v.Client, &pod)
+	if err != nil {
+		return err
+	}
+	if denied := policy.CriticalPodDenied(policies, &pod); denied != "" {
+		return apierrors.NewForbidden(lpmv1.GroupVersion.WithResource("podmigrations").GroupResource(), podMigration.Name,
+			fmt.Errorf("pod %s: %s", pod.Name, denied))
+	}
+	return nil
+}
+
+// validateTargetNode rejects a target node the migrated pod's policies
+// forbid. A missing pod or node is left for the controller to report.
+func (v *PodMigrationCustomValidator) validateTargetNode(ctx context.Context, podMigration *lpmv1.PodMigration) error {
+	if podMigration.Spec.TargetNode == "" {
+		return nil
+	}
+	var pod corev1.Pod
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: podMigration.Namespace, Name: podMigration.Spec.PodName}, &pod); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	var node corev1.Node
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: podMigration.Spec.TargetNode}, &node); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	policies, err := policy.Matching(ctx, v.Client, &pod)
+	if err != nil {
+		return err
+	}
+	denied, err := policy.TargetDenied(policies, &node)
+	if err != nil {
+		return err
+	}
+	if denied != "" {
+		return apierrors.NewForbidden(lpmv1.GroupVersion.WithResource("podmigrations").GroupResource(), podMigration.Name,
+			fmt.Errorf("target node %s: %s", node.Name, denied))
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+)
+
+var _ = Describe("PodMigration Webhook", func() {
+	var validator *PodMigrationCustomValidator
+	ctx := context.Background()
+
+	podMigration := func(targetNode string) *lpmv1.PodMigration {
+		return &lpmv1.PodMigration{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "migrate-app"},
+			Spec:       lpmv1.PodMigrationSpec{PodName: "app", TargetNode: targetNode},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(lpmv1.AddToScheme(scheme)).To(Succeed())
+
+		validator = &PodMigrationCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app", Labels: map[string]string{"app": "web"}}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+			&lpmv1.MigrationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-node-b"},
+				Spec: lpmv1.MigrationPolicySpec{
+					PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					TargetNodes: &lpmv1.TargetNodePolicy{DeniedNodes: []string{"node-b"}},
+				},
+			},
+		).Build()}
+	})
+
+	It("admits a migration to an allowed target node", func() {
+		_, err := validator.ValidateCreate(ctx, podMigration("node-a"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects a migration to a forbidden target node on create", func() {
+		_, err := validator.ValidateCreate(ctx, podMigration("node-b"))
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("migration policy no-node-b denies node node-b")))
+	})
+
+	It("rejects changing the target node to a forbidden one", func() {
+		_, err := validator.ValidateUpdate(ctx, podMigration("node-a"), podMigration("node-b"))
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+	})
+
+	It("admits updates that keep a forbidden target node", func() {
+		_, err := validator.ValidateUpdate(ctx, podMigration("node-b"), podMigration("node-b"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("leaves a missing target node to the controller", func() {
+		_, err := validator.ValidateCreate(ctx, podMigration("node-c"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"net/http"
+
+	rest "k8s.io/client-go/rest"
+	v1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/pkg/client/clientset/versioned/scheme"
+)
+
+type LpmV1Interface interface {
+	RESTClient() rest.Interface
+	ContainerCheckpointsGetter
+	ContainerCheckpointContentsGetter
+	MigrationPoliciesGetter
+	NodeMaintenancesGetter
+	NodeMigrationStatusesGetter
+	PodCheckpointsGetter
+	PodCheckpointContentsGetter
+	PodMigrationsGetter
+	PodRestoresGetter
+}
+
+// LpmV1Client is used to interact with features provided by the lpm.my.domain group.
+type LpmV1Client struct {
+	restClient rest.Interface
+}
+
+func (c *LpmV1Client) ContainerCheckpoints(namespace string) ContainerCheckpointInterface {
+	return newContainerCheckpoints(c, namespace)
+}
+
+func (c *LpmV1Client) ContainerCheckpointContents() ContainerCheckpointContentInterface {
+	return newContainerCheckpointContents(c)
+}
+
+func (c *LpmV1Client) MigrationPolicies() MigrationPolicyInterface {
+	return newMigrationPolicies(c)
+}
+
+func (c *LpmV1Client) NodeMaintenances() NodeMaintenanceInterface {
+	return newNodeMaintenances(c)
+}
+
+func (c *LpmV1Client) NodeMigrationStatuses() NodeMigrationStatusInterface {
+	return newNodeMigrationStatuses(c)
+}
+
+func (c *LpmV1Client) PodCheckpoints(namespace string) PodCheckpointInterface {
+	return newPodCheckpoints(c, namespace)
+}
+
+func (c *LpmV1Client) PodCheckpointContents(namespace string) PodCheckpointContentInterface {
+	return newPodCheckpointContents(c, namespace)
+}
+
+func (c *LpmV1Client) PodMigrations(namespace string) PodMigrationInterface {
+	return newPodMigrations(c, namespace)
+}
+
+func (c *LpmV1Client) PodRestores(namespace string) PodRestoreInterface {
+	return newPodRestores(c, namespace)
+}
+
+// NewForConfig creates a new LpmV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*LpmV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new LpmV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*LpmV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &LpmV1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new LpmV1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *LpmV1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new LpmV1Client for the given RESTClient.
+func New(c rest.Interface) *LpmV1Client {
+	return &LpmV1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *LpmV1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/pkg/client/clientset/versioned/typed/api/v1"
+)
+
+type FakeLpmV1 struct {
+	*testing.Fake
+}
+
+func (c *FakeLpmV1) ContainerCheckpoints(namespace string) v1.ContainerCheckpointInterface {
+	return &FakeContainerCheckpoints{c, namespace}
+}
+
+func (c *FakeLpmV1) ContainerCheckpointContents() v1.ContainerCheckpointContentInterface {
+	return &FakeContainerCheckpointContents{c}
+}
+
+func (c *FakeLpmV1) MigrationPolicies() v1.MigrationPolicyInterface {
+	return &FakeMigrationPolicies{c}
+}
+
+func (c *FakeLpmV1) NodeMaintenances() v1.NodeMaintenanceInterface {
+	return &FakeNodeMaintenances{c}
+}
+
+func (c *FakeLpmV1) NodeMigrationStatuses() v1.NodeMigrationStatusInterface {
+	return &FakeNodeMigrationStatuses{c}
+}
+
+func (c *FakeLpmV1) PodCheckpoints(namespace string) v1.PodCheckpointInterface {
+	return &FakePodCheckpoints{c, namespace}
+}
+
+func (c *FakeLpmV1) PodCheckpointContents(namespace string) v1.PodCheckpointContentInterface {
+	return &FakePodCheckpointContents{c, namespace}
+}
+
+func (c *FakeLpmV1) PodMigrations(namespace string) v1.PodMigrationInterface {
+	return &FakePodMigrations{c, namespace}
+}
+
+func (c *FakeLpmV1) PodRestores(namespace string) v1.PodRestoreInterface {
+	return &FakePodRestores{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeLpmV1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakeContainerCheckpoints implements ContainerCheckpointInterface
+type FakeContainerCheckpoints struct {
+	Fake *FakeLpmV1
+	ns   string
+}
+
+var containercheckpointsResource = v1.SchemeGroupVersion.WithResource("containercheckpoints")
+
+var containercheckpointsKind = v1.SchemeGroupVersion.WithKind("ContainerCheckpoint")
+
+// Get takes name of the containerCheckpoint, and returns the corresponding containerCheckpoint object, and an error if there is any.
+func (c *FakeContainerCheckpoints) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ContainerCheckpoint, err error) {
+	emptyResult := &v1.ContainerCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(containercheckpointsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpoint), err
+}
+
+// List takes label and field selectors, and returns the list of ContainerCheckpoints that match those selectors.
+func (c *FakeContainerCheckpoints) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ContainerCheckpointList, err error) {
+	emptyResult := &v1.ContainerCheckpointList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(containercheckpointsResource, containercheckpointsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.ContainerCheckpointList{ListMeta: obj.(*v1.ContainerCheckpointList).ListMeta}
+	for _, item := range obj.(*v1.ContainerCheckpointList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested containerCheckpoints.
+func (c *FakeContainerCheckpoints) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(containercheckpointsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a containerCheckpoint and creates it.  Returns the server's representation of the containerCheckpoint, and an error, if there is any.
+func (c *FakeContainerCheckpoints) Create(ctx context.Context, containerCheckpoint *v1.ContainerCheckpoint, opts metav1.CreateOptions) (result *v1.ContainerCheckpoint, err error) {
+	emptyResult := &v1.ContainerCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(containercheckpointsResource, c.ns, containerCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpoint), err
+}
+
+// Update takes the representation of a containerCheckpoint and updates it. Returns the server's representation of the containerCheckpoint, and an error, if there is any.
+func (c *FakeContainerCheckpoints) Update(ctx context.Context, containerCheckpoint *v1.ContainerCheckpoint, opts metav1.UpdateOptions) (result *v1.ContainerCheckpoint, err error) {
+	emptyResult := &v1.ContainerCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(containercheckpointsResource, c.ns, containerCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpoint), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeContainerCheckpoints) UpdateStatus(ctx context.Context, containerCheckpoint *v1.ContainerCheckpoint, opts metav1.UpdateOptions) (result *v1.ContainerCheckpoint, err error) {
+	emptyResult := &v1.ContainerCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(containercheckpointsResource, "status", c.ns, containerCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpoint), err
+}
+
+// Delete takes name of the containerCheckpoint and deletes it. Returns an error if one occurs.
+func (c *FakeContainerCheckpoints) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(containercheckpointsResource, c.ns, name, opts), &v1.ContainerCheckpoint{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeContainerCheckpoints) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(containercheckpointsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.ContainerCheckpointList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched containerCheckpoint.
+func (c *FakeContainerCheckpoints) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ContainerCheckpoint, err error) {
+	emptyResult := &v1.ContainerCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(containercheckpointsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpoint), err
+}
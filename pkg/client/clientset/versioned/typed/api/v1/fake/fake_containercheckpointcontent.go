@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakeContainerCheckpointContents implements ContainerCheckpointContentInterface
+type FakeContainerCheckpointContents struct {
+	Fake *FakeLpmV1
+}
+
+var containercheckpointcontentsResource = v1.SchemeGroupVersion.WithResource("containercheckpointcontents")
+
+var containercheckpointcontentsKind = v1.SchemeGroupVersion.WithKind("ContainerCheckpointContent")
+
+// Get takes name of the containerCheckpointContent, and returns the corresponding containerCheckpointContent object, and an error if there is any.
+func (c *FakeContainerCheckpointContents) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.ContainerCheckpointContent, err error) {
+	emptyResult := &v1.ContainerCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetActionWithOptions(containercheckpointcontentsResource, name, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpointContent), err
+}
+
+// List takes label and field selectors, and returns the list of ContainerCheckpointContents that match those selectors.
+func (c *FakeContainerCheckpointContents) List(ctx context.Context, opts metav1.ListOptions) (result *v1.ContainerCheckpointContentList, err error) {
+	emptyResult := &v1.ContainerCheckpointContentList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListActionWithOptions(containercheckpointcontentsResource, containercheckpointcontentsKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.ContainerCheckpointContentList{ListMeta: obj.(*v1.ContainerCheckpointContentList).ListMeta}
+	for _, item := range obj.(*v1.ContainerCheckpointContentList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested containerCheckpointContents.
+func (c *FakeContainerCheckpointContents) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchActionWithOptions(containercheckpointcontentsResource, opts))
+}
+
+// Create takes the representation of a containerCheckpointContent and creates it.  Returns the server's representation of the containerCheckpointContent, and an error, if there is any.
+func (c *FakeContainerCheckpointContents) Create(ctx context.Context, containerCheckpointContent *v1.ContainerCheckpointContent, opts metav1.CreateOptions) (result *v1.ContainerCheckpointContent, err error) {
+	emptyResult := &v1.ContainerCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(containercheckpointcontentsResource, containerCheckpointContent, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpointContent), err
+}
+
+// Update takes the representation of a containerCheckpointContent and updates it. Returns the server's representation of the containerCheckpointContent, and an error, if there is any.
+func (c *FakeContainerCheckpointContents) Update(ctx context.Context, containerCheckpointContent *v1.ContainerCheckpointContent, opts metav1.UpdateOptions) (result *v1.ContainerCheckpointContent, err error) {
+	emptyResult := &v1.ContainerCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateActionWithOptions(containercheckpointcontentsResource, containerCheckpointContent, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpointContent), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeContainerCheckpointContents) UpdateStatus(ctx context.Context, containerCheckpointContent *v1.ContainerCheckpointContent, opts metav1.UpdateOptions) (result *v1.ContainerCheckpointContent, err error) {
+	emptyResult := &v1.ContainerCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(containercheckpointcontentsResource, "status", containerCheckpointContent, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpointContent), err
+}
+
+// Delete takes name of the containerCheckpointContent and deletes it. Returns an error if one occurs.
+func (c *FakeContainerCheckpointContents) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(containercheckpointcontentsResource, name, opts), &v1.ContainerCheckpointContent{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeContainerCheckpointContents) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionActionWithOptions(containercheckpointcontentsResource, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.ContainerCheckpointContentList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched containerCheckpointContent.
+func (c *FakeContainerCheckpointContents) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.ContainerCheckpointContent, err error) {
+	emptyResult := &v1.ContainerCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(containercheckpointcontentsResource, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.ContainerCheckpointContent), err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakeNodeMaintenances implements NodeMaintenanceInterface
+type FakeNodeMaintenances struct {
+	Fake *FakeLpmV1
+}
+
+var nodemaintenancesResource = v1.SchemeGroupVersion.WithResource("nodemaintenances")
+
+var nodemaintenancesKind = v1.SchemeGroupVersion.WithKind("NodeMaintenance")
+
+// Get takes name of the nodeMaintenance, and returns the corresponding nodeMaintenance object, and an error if there is any.
+func (c *FakeNodeMaintenances) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.NodeMaintenance, err error) {
+	emptyResult := &v1.NodeMaintenance{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetActionWithOptions(nodemaintenancesResource, name, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMaintenance), err
+}
+
+// List takes label and field selectors, and returns the list of NodeMaintenances that match those selectors.
+func (c *FakeNodeMaintenances) List(ctx context.Context, opts metav1.ListOptions) (result *v1.NodeMaintenanceList, err error) {
+	emptyResult := &v1.NodeMaintenanceList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListActionWithOptions(nodemaintenancesResource, nodemaintenancesKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.NodeMaintenanceList{ListMeta: obj.(*v1.NodeMaintenanceList).ListMeta}
+	for _, item := range obj.(*v1.NodeMaintenanceList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested nodeMaintenances.
+func (c *FakeNodeMaintenances) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchActionWithOptions(nodemaintenancesResource, opts))
+}
+
+// Create takes the representation of a nodeMaintenance and creates it.  Returns the server's representation of the nodeMaintenance, and an error, if there is any.
+func (c *FakeNodeMaintenances) Create(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.CreateOptions) (result *v1.NodeMaintenance, err error) {
+	emptyResult := &v1.NodeMaintenance{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(nodemaintenancesResource, nodeMaintenance, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMaintenance), err
+}
+
+// Update takes the representation of a nodeMaintenance and updates it. Returns the server's representation of the nodeMaintenance, and an error, if there is any.
+func (c *FakeNodeMaintenances) Update(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.UpdateOptions) (result *v1.NodeMaintenance, err error) {
+	emptyResult := &v1.NodeMaintenance{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateActionWithOptions(nodemaintenancesResource, nodeMaintenance, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMaintenance), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNodeMaintenances) UpdateStatus(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.UpdateOptions) (result *v1.NodeMaintenance, err error) {
+	emptyResult := &v1.NodeMaintenance{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(nodemaintenancesResource, "status", nodeMaintenance, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMaintenance), err
+}
+
+// Delete takes name of the nodeMaintenance and deletes it. Returns an error if one occurs.
+func (c *FakeNodeMaintenances) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(nodemaintenancesResource, name, opts), &v1.NodeMaintenance{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNodeMaintenances) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionActionWithOptions(nodemaintenancesResource, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.NodeMaintenanceList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched nodeMaintenance.
+func (c *FakeNodeMaintenances) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.NodeMaintenance, err error) {
+	emptyResult := &v1.NodeMaintenance{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(nodemaintenancesResource, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMaintenance), err
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakeNodeMigrationStatuses implements NodeMigrationStatusInterface
+type FakeNodeMigrationStatuses struct {
+	Fake *FakeLpmV1
+}
+
+var nodemigrationstatusesResource = v1.SchemeGroupVersion.WithResource("nodemigrationstatuses")
+
+var nodemigrationstatusesKind = v1.SchemeGroupVersion.WithKind("NodeMigrationStatus")
+
+// Get takes name of the nodeMigrationStatus, and returns the corresponding nodeMigrationStatus object, and an error if there is any.
+func (c *FakeNodeMigrationStatuses) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.NodeMigrationStatus, err error) {
+	emptyResult := &v1.NodeMigrationStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetActionWithOptions(nodemigrationstatusesResource, name, options), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMigrationStatus), err
+}
+
+// List takes label and field selectors, and returns the list of NodeMigrationStatuses that match those selectors.
+func (c *FakeNodeMigrationStatuses) List(ctx context.Context, opts metav1.ListOptions) (result *v1.NodeMigrationStatusList, err error) {
+	emptyResult := &v1.NodeMigrationStatusList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListActionWithOptions(nodemigrationstatusesResource, nodemigrationstatusesKind, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.NodeMigrationStatusList{ListMeta: obj.(*v1.NodeMigrationStatusList).ListMeta}
+	for _, item := range obj.(*v1.NodeMigrationStatusList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested nodeMigrationStatuses.
+func (c *FakeNodeMigrationStatuses) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchActionWithOptions(nodemigrationstatusesResource, opts))
+}
+
+// Create takes the representation of a nodeMigrationStatus and creates it.  Returns the server's representation of the nodeMigrationStatus, and an error, if there is any.
+func (c *FakeNodeMigrationStatuses) Create(ctx context.Context, nodeMigrationStatus *v1.NodeMigrationStatus, opts metav1.CreateOptions) (result *v1.NodeMigrationStatus, err error) {
+	emptyResult := &v1.NodeMigrationStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateActionWithOptions(nodemigrationstatusesResource, nodeMigrationStatus, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMigrationStatus), err
+}
+
+// Update takes the representation of a nodeMigrationStatus and updates it. Returns the server's representation of the nodeMigrationStatus, and an error, if there is any.
+func (c *FakeNodeMigrationStatuses) Update(ctx context.Context, nodeMigrationStatus *v1.NodeMigrationStatus, opts metav1.UpdateOptions) (result *v1.NodeMigrationStatus, err error) {
+	emptyResult := &v1.NodeMigrationStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateActionWithOptions(nodemigrationstatusesResource, nodeMigrationStatus, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMigrationStatus), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNodeMigrationStatuses) UpdateStatus(ctx context.Context, nodeMigrationStatus *v1.NodeMigrationStatus, opts metav1.UpdateOptions) (result *v1.NodeMigrationStatus, err error) {
+	emptyResult := &v1.NodeMigrationStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceActionWithOptions(nodemigrationstatusesResource, "status", nodeMigrationStatus, opts), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMigrationStatus), err
+}
+
+// Delete takes name of the nodeMigrationStatus and deletes it. Returns an error if one occurs.
+func (c *FakeNodeMigrationStatuses) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(nodemigrationstatusesResource, name, opts), &v1.NodeMigrationStatus{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNodeMigrationStatuses) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionActionWithOptions(nodemigrationstatusesResource, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.NodeMigrationStatusList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched nodeMigrationStatus.
+func (c *FakeNodeMigrationStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.NodeMigrationStatus, err error) {
+	emptyResult := &v1.NodeMigrationStatus{}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceActionWithOptions(nodemigrationstatusesResource, name, pt, data, opts, subresources...), emptyResult)
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.NodeMigrationStatus), err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakePodCheckpoints implements PodCheckpointInterface
+type FakePodCheckpoints struct {
+	Fake *FakeLpmV1
+	ns   string
+}
+
+var podcheckpointsResource = v1.SchemeGroupVersion.WithResource("podcheckpoints")
+
+var podcheckpointsKind = v1.SchemeGroupVersion.WithKind("PodCheckpoint")
+
+// Get takes name of the podCheckpoint, and returns the corresponding podCheckpoint object, and an error if there is any.
+func (c *FakePodCheckpoints) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.PodCheckpoint, err error) {
+	emptyResult := &v1.PodCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(podcheckpointsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpoint), err
+}
+
+// List takes label and field selectors, and returns the list of PodCheckpoints that match those selectors.
+func (c *FakePodCheckpoints) List(ctx context.Context, opts metav1.ListOptions) (result *v1.PodCheckpointList, err error) {
+	emptyResult := &v1.PodCheckpointList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(podcheckpointsResource, podcheckpointsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.PodCheckpointList{ListMeta: obj.(*v1.PodCheckpointList).ListMeta}
+	for _, item := range obj.(*v1.PodCheckpointList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested podCheckpoints.
+func (c *FakePodCheckpoints) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(podcheckpointsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a podCheckpoint and creates it.  Returns the server's representation of the podCheckpoint, and an error, if there is any.
+func (c *FakePodCheckpoints) Create(ctx context.Context, podCheckpoint *v1.PodCheckpoint, opts metav1.CreateOptions) (result *v1.PodCheckpoint, err error) {
+	emptyResult := &v1.PodCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(podcheckpointsResource, c.ns, podCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpoint), err
+}
+
+// Update takes the representation of a podCheckpoint and updates it. Returns the server's representation of the podCheckpoint, and an error, if there is any.
+func (c *FakePodCheckpoints) Update(ctx context.Context, podCheckpoint *v1.PodCheckpoint, opts metav1.UpdateOptions) (result *v1.PodCheckpoint, err error) {
+	emptyResult := &v1.PodCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(podcheckpointsResource, c.ns, podCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpoint), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePodCheckpoints) UpdateStatus(ctx context.Context, podCheckpoint *v1.PodCheckpoint, opts metav1.UpdateOptions) (result *v1.PodCheckpoint, err error) {
+	emptyResult := &v1.PodCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(podcheckpointsResource, "status", c.ns, podCheckpoint, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpoint), err
+}
+
+// Delete takes name of the podCheckpoint and deletes it. Returns an error if one occurs.
+func (c *FakePodCheckpoints) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(podcheckpointsResource, c.ns, name, opts), &v1.PodCheckpoint{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePodCheckpoints) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(podcheckpointsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.PodCheckpointList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched podCheckpoint.
+func (c *FakePodCheckpoints) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.PodCheckpoint, err error) {
+	emptyResult := &v1.PodCheckpoint{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(podcheckpointsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpoint), err
+}
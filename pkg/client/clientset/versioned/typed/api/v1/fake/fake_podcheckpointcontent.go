@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakePodCheckpointContents implements PodCheckpointContentInterface
+type FakePodCheckpointContents struct {
+	Fake *FakeLpmV1
+	ns   string
+}
+
+var podcheckpointcontentsResource = v1.SchemeGroupVersion.WithResource("podcheckpointcontents")
+
+var podcheckpointcontentsKind = v1.SchemeGroupVersion.WithKind("PodCheckpointContent")
+
+// Get takes name of the podCheckpointContent, and returns the corresponding podCheckpointContent object, and an error if there is any.
+func (c *FakePodCheckpointContents) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.PodCheckpointContent, err error) {
+	emptyResult := &v1.PodCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(podcheckpointcontentsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpointContent), err
+}
+
+// List takes label and field selectors, and returns the list of PodCheckpointContents that match those selectors.
+func (c *FakePodCheckpointContents) List(ctx context.Context, opts metav1.ListOptions) (result *v1.PodCheckpointContentList, err error) {
+	emptyResult := &v1.PodCheckpointContentList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(podcheckpointcontentsResource, podcheckpointcontentsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.PodCheckpointContentList{ListMeta: obj.(*v1.PodCheckpointContentList).ListMeta}
+	for _, item := range obj.(*v1.PodCheckpointContentList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested podCheckpointContents.
+func (c *FakePodCheckpointContents) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(podcheckpointcontentsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a podCheckpointContent and creates it.  Returns the server's representation of the podCheckpointContent, and an error, if there is any.
+func (c *FakePodCheckpointContents) Create(ctx context.Context, podCheckpointContent *v1.PodCheckpointContent, opts metav1.CreateOptions) (result *v1.PodCheckpointContent, err error) {
+	emptyResult := &v1.PodCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(podcheckpointcontentsResource, c.ns, podCheckpointContent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpointContent), err
+}
+
+// Update takes the representation of a podCheckpointContent and updates it. Returns the server's representation of the podCheckpointContent, and an error, if there is any.
+func (c *FakePodCheckpointContents) Update(ctx context.Context, podCheckpointContent *v1.PodCheckpointContent, opts metav1.UpdateOptions) (result *v1.PodCheckpointContent, err error) {
+	emptyResult := &v1.PodCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(podcheckpointcontentsResource, c.ns, podCheckpointContent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpointContent), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePodCheckpointContents) UpdateStatus(ctx context.Context, podCheckpointContent *v1.PodCheckpointContent, opts metav1.UpdateOptions) (result *v1.PodCheckpointContent, err error) {
+	emptyResult := &v1.PodCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(podcheckpointcontentsResource, "status", c.ns, podCheckpointContent, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpointContent), err
+}
+
+// Delete takes name of the podCheckpointContent and deletes it. Returns an error if one occurs.
+func (c *FakePodCheckpointContents) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(podcheckpointcontentsResource, c.ns, name, opts), &v1.PodCheckpointContent{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePodCheckpointContents) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(podcheckpointcontentsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.PodCheckpointContentList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched podCheckpointContent.
+func (c *FakePodCheckpointContents) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.PodCheckpointContent, err error) {
+	emptyResult := &v1.PodCheckpointContent{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(podcheckpointcontentsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodCheckpointContent), err
+}
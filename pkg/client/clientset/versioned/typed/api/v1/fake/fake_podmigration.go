@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakePodMigrations implements PodMigrationInterface
+type FakePodMigrations struct {
+	Fake *FakeLpmV1
+	ns   string
+}
+
+var podmigrationsResource = v1.SchemeGroupVersion.WithResource("podmigrations")
+
+var podmigrationsKind = v1.SchemeGroupVersion.WithKind("PodMigration")
+
+// Get takes name of the podMigration, and returns the corresponding podMigration object, and an error if there is any.
+func (c *FakePodMigrations) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.PodMigration, err error) {
+	emptyResult := &v1.PodMigration{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(podmigrationsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodMigration), err
+}
+
+// List takes label and field selectors, and returns the list of PodMigrations that match those selectors.
+func (c *FakePodMigrations) List(ctx context.Context, opts metav1.ListOptions) (result *v1.PodMigrationList, err error) {
+	emptyResult := &v1.PodMigrationList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(podmigrationsResource, podmigrationsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.PodMigrationList{ListMeta: obj.(*v1.PodMigrationList).ListMeta}
+	for _, item := range obj.(*v1.PodMigrationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested podMigrations.
+func (c *FakePodMigrations) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(podmigrationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a podMigration and creates it.  Returns the server's representation of the podMigration, and an error, if there is any.
+func (c *FakePodMigrations) Create(ctx context.Context, podMigration *v1.PodMigration, opts metav1.CreateOptions) (result *v1.PodMigration, err error) {
+	emptyResult := &v1.PodMigration{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(podmigrationsResource, c.ns, podMigration, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodMigration), err
+}
+
+// Update takes the representation of a podMigration and updates it. Returns the server's representation of the podMigration, and an error, if there is any.
+func (c *FakePodMigrations) Update(ctx context.Context, podMigration *v1.PodMigration, opts metav1.UpdateOptions) (result *v1.PodMigration, err error) {
+	emptyResult := &v1.PodMigration{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(podmigrationsResource, c.ns, podMigration, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodMigration), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePodMigrations) UpdateStatus(ctx context.Context, podMigration *v1.PodMigration, opts metav1.UpdateOptions) (result *v1.PodMigration, err error) {
+	emptyResult := &v1.PodMigration{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(podmigrationsResource, "status", c.ns, podMigration, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodMigration), err
+}
+
+// Delete takes name of the podMigration and deletes it. Returns an error if one occurs.
+func (c *FakePodMigrations) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(podmigrationsResource, c.ns, name, opts), &v1.PodMigration{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePodMigrations) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(podmigrationsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.PodMigrationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched podMigration.
+func (c *FakePodMigrations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.PodMigration, err error) {
+	emptyResult := &v1.PodMigration{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(podmigrationsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodMigration), err
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// FakePodRestores implements PodRestoreInterface
+type FakePodRestores struct {
+	Fake *FakeLpmV1
+	ns   string
+}
+
+var podrestoresResource = v1.SchemeGroupVersion.WithResource("podrestores")
+
+var podrestoresKind = v1.SchemeGroupVersion.WithKind("PodRestore")
+
+// Get takes name of the podRestore, and returns the corresponding podRestore object, and an error if there is any.
+func (c *FakePodRestores) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.PodRestore, err error) {
+	emptyResult := &v1.PodRestore{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(podrestoresResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodRestore), err
+}
+
+// List takes label and field selectors, and returns the list of PodRestores that match those selectors.
+func (c *FakePodRestores) List(ctx context.Context, opts metav1.ListOptions) (result *v1.PodRestoreList, err error) {
+	emptyResult := &v1.PodRestoreList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(podrestoresResource, podrestoresKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.PodRestoreList{ListMeta: obj.(*v1.PodRestoreList).ListMeta}
+	for _, item := range obj.(*v1.PodRestoreList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested podRestores.
+func (c *FakePodRestores) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(podrestoresResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a podRestore and creates it.  Returns the server's representation of the podRestore, and an error, if there is any.
+func (c *FakePodRestores) Create(ctx context.Context, podRestore *v1.PodRestore, opts metav1.CreateOptions) (result *v1.PodRestore, err error) {
+	emptyResult := &v1.PodRestore{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(podrestoresResource, c.ns, podRestore, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodRestore), err
+}
+
+// Update takes the representation of a podRestore and updates it. Returns the server's representation of the podRestore, and an error, if there is any.
+func (c *FakePodRestores) Update(ctx context.Context, podRestore *v1.PodRestore, opts metav1.UpdateOptions) (result *v1.PodRestore, err error) {
+	emptyResult := &v1.PodRestore{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(podrestoresResource, c.ns, podRestore, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodRestore), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePodRestores) UpdateStatus(ctx context.Context, podRestore *v1.PodRestore, opts metav1.UpdateOptions) (result *v1.PodRestore, err error) {
+	emptyResult := &v1.PodRestore{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceActionWithOptions(podrestoresResource, "status", c.ns, podRestore, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodRestore), err
+}
+
+// Delete takes name of the podRestore and deletes it. Returns an error if one occurs.
+func (c *FakePodRestores) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(podrestoresResource, c.ns, name, opts), &v1.PodRestore{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePodRestores) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(podrestoresResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.PodRestoreList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched podRestore.
+func (c *FakePodRestores) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.PodRestore, err error) {
+	emptyResult := &v1.PodRestore{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(podrestoresResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.PodRestore), err
+}
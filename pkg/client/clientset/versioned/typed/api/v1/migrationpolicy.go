@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	v1 "my.domain/guestbook/api/v1"
+	scheme "my.domain/guestbook/pkg/client/clientset/versioned/scheme"
+)
+
+// MigrationPoliciesGetter has a method to return a MigrationPolicyInterface.
+// A group's client should implement this interface.
+type MigrationPoliciesGetter interface {
+	MigrationPolicies() MigrationPolicyInterface
+}
+
+// MigrationPolicyInterface has methods to work with MigrationPolicy resources.
+type MigrationPolicyInterface interface {
+	Create(ctx context.Context, migrationPolicy *v1.MigrationPolicy, opts metav1.CreateOptions) (*v1.MigrationPolicy, error)
+	Update(ctx context.Context, migrationPolicy *v1.MigrationPolicy, opts metav1.UpdateOptions) (*v1.MigrationPolicy, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, migrationPolicy *v1.MigrationPolicy, opts metav1.UpdateOptions) (*v1.MigrationPolicy, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.MigrationPolicy, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.MigrationPolicyList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.MigrationPolicy, err error)
+	MigrationPolicyExpansion
+}
+
+// migrationPolicies implements MigrationPolicyInterface
+type migrationPolicies struct {
+	*gentype.ClientWithList[*v1.MigrationPolicy, *v1.MigrationPolicyList]
+}
+
+// newMigrationPolicies returns a MigrationPolicies
+func newMigrationPolicies(c *LpmV1Client) *migrationPolicies {
+	return &migrationPolicies{
+		gentype.NewClientWithList[*v1.MigrationPolicy, *v1.MigrationPolicyList](
+			"migrationpolicies",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *v1.MigrationPolicy { return &v1.MigrationPolicy{} },
+			func() *v1.MigrationPolicyList { return &v1.MigrationPolicyList{} }),
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	v1 "my.domain/guestbook/api/v1"
+	scheme "my.domain/guestbook/pkg/client/clientset/versioned/scheme"
+)
+
+// NodeMaintenancesGetter has a method to return a NodeMaintenanceInterface.
+// A group's client should implement this interface.
+type NodeMaintenancesGetter interface {
+	NodeMaintenances() NodeMaintenanceInterface
+}
+
+// NodeMaintenanceInterface has methods to work with NodeMaintenance resources.
+type NodeMaintenanceInterface interface {
+	Create(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.CreateOptions) (*v1.NodeMaintenance, error)
+	Update(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.UpdateOptions) (*v1.NodeMaintenance, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, nodeMaintenance *v1.NodeMaintenance, opts metav1.UpdateOptions) (*v1.NodeMaintenance, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.NodeMaintenance, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.NodeMaintenanceList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.NodeMaintenance, err error)
+	NodeMaintenanceExpansion
+}
+
+// nodeMaintenances implements NodeMaintenanceInterface
+type nodeMaintenances struct {
+	*gentype.ClientWithList[*v1.NodeMaintenance, *v1.NodeMaintenanceList]
+}
+
+// newNodeMaintenances returns a NodeMaintenances
+func newNodeMaintenances(c *LpmV1Client) *nodeMaintenances {
+	return &nodeMaintenances{
+		gentype.NewClientWithList[*v1.NodeMaintenance, *v1.NodeMaintenanceList](
+			"nodemaintenances",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			"",
+			func() *v1.NodeMaintenance { return &v1.NodeMaintenance{} },
+			func() *v1.NodeMaintenanceList { return &v1.NodeMaintenanceList{} }),
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+	v1 "my.domain/guestbook/api/v1"
+	scheme "my.domain/guestbook/pkg/client/clientset/versioned/scheme"
+)
+
+// PodRestoresGetter has a method to return a PodRestoreInterface.
+// A group's client should implement this interface.
+type PodRestoresGetter interface {
+	PodRestores(namespace string) PodRestoreInterface
+}
+
+// PodRestoreInterface has methods to work with PodRestore resources.
+type PodRestoreInterface interface {
+	Create(ctx context.Context, podRestore *v1.PodRestore, opts metav1.CreateOptions) (*v1.PodRestore, error)
+	Update(ctx context.Context, podRestore *v1.PodRestore, opts metav1.UpdateOptions) (*v1.PodRestore, error)
+	// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+	UpdateStatus(ctx context.Context, podRestore *v1.PodRestore, opts metav1.UpdateOptions) (*v1.PodRestore, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.PodRestore, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.PodRestoreList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.PodRestore, err error)
+	PodRestoreExpansion
+}
+
+// podRestores implements PodRestoreInterface
+type podRestores struct {
+	*gentype.ClientWithList[*v1.PodRestore, *v1.PodRestoreList]
+}
+
+// newPodRestores returns a PodRestores
+func newPodRestores(c *LpmV1Client, namespace string) *podRestores {
+	return &podRestores{
+		gentype.NewClientWithList[*v1.PodRestore, *v1.PodRestoreList](
+			"podrestores",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.PodRestore { return &v1.PodRestore{} },
+			func() *v1.PodRestoreList { return &v1.PodRestoreList{} }),
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	apiv1 "my.domain/guestbook/api/v1"
+	versioned "my.domain/guestbook/pkg/client/clientset/versioned"
+	internalinterfaces "my.domain/guestbook/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "my.domain/guestbook/pkg/client/listers/api/v1"
+)
+
+// ContainerCheckpointInformer provides access to a shared informer and lister for
+// ContainerCheckpoints.
+type ContainerCheckpointInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ContainerCheckpointLister
+}
+
+type containerCheckpointInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewContainerCheckpointInformer constructs a new informer for ContainerCheckpoint type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewContainerCheckpointInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredContainerCheckpointInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredContainerCheckpointInformer constructs a new informer for ContainerCheckpoint type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredContainerCheckpointInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LpmV1().ContainerCheckpoints(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LpmV1().ContainerCheckpoints(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&apiv1.ContainerCheckpoint{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *containerCheckpointInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredContainerCheckpointInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *containerCheckpointInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiv1.ContainerCheckpoint{}, f.defaultInformer)
+}
+
+func (f *containerCheckpointInformer) Lister() v1.ContainerCheckpointLister {
+	return v1.NewContainerCheckpointLister(f.Informer().GetIndexer())
+}
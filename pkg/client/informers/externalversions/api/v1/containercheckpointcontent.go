@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+	apiv1 "my.domain/guestbook/api/v1"
+	versioned "my.domain/guestbook/pkg/client/clientset/versioned"
+	internalinterfaces "my.domain/guestbook/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "my.domain/guestbook/pkg/client/listers/api/v1"
+)
+
+// ContainerCheckpointContentInformer provides access to a shared informer and lister for
+// ContainerCheckpointContents.
+type ContainerCheckpointContentInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.ContainerCheckpointContentLister
+}
+
+type containerCheckpointContentInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewContainerCheckpointContentInformer constructs a new informer for ContainerCheckpointContent type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewContainerCheckpointContentInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredContainerCheckpointContentInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredContainerCheckpointContentInformer constructs a new informer for ContainerCheckpointContent type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredContainerCheckpointContentInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LpmV1().ContainerCheckpointContents().List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.LpmV1().ContainerCheckpointContents().Watch(context.TODO(), options)
+			},
+		},
+		&apiv1.ContainerCheckpointContent{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *containerCheckpointContentInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredContainerCheckpointContentInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *containerCheckpointContentInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&apiv1.ContainerCheckpointContent{}, f.defaultInformer)
+}
+
+func (f *containerCheckpointContentInformer) Lister() v1.ContainerCheckpointContentLister {
+	return v1.NewContainerCheckpointContentLister(f.Informer().GetIndexer())
+}
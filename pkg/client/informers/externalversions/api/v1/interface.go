@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	internalinterfaces "my.domain/guestbook/pkg/client/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// ContainerCheckpoints returns a ContainerCheckpointInformer.
+	ContainerCheckpoints() ContainerCheckpointInformer
+	// ContainerCheckpointContents returns a ContainerCheckpointContentInformer.
+	ContainerCheckpointContents() ContainerCheckpointContentInformer
+	// MigrationPolicies returns a MigrationPolicyInformer.
+	MigrationPolicies() MigrationPolicyInformer
+	// NodeMaintenances returns a NodeMaintenanceInformer.
+	NodeMaintenances() NodeMaintenanceInformer
+	// NodeMigrationStatuses returns a NodeMigrationStatusInformer.
+	NodeMigrationStatuses() NodeMigrationStatusInformer
+	// PodCheckpoints returns a PodCheckpointInformer.
+	PodCheckpoints() PodCheckpointInformer
+	// PodCheckpointContents returns a PodCheckpointContentInformer.
+	PodCheckpointContents() PodCheckpointContentInformer
+	// PodMigrations returns a PodMigrationInformer.
+	PodMigrations() PodMigrationInformer
+	// PodRestores returns a PodRestoreInformer.
+	PodRestores() PodRestoreInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// ContainerCheckpoints returns a ContainerCheckpointInformer.
+func (v *version) ContainerCheckpoints() ContainerCheckpointInformer {
+	return &containerCheckpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ContainerCheckpointContents returns a ContainerCheckpointContentInformer.
+func (v *version) ContainerCheckpointContents() ContainerCheckpointContentInformer {
+	return &containerCheckpointContentInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// MigrationPolicies returns a MigrationPolicyInformer.
+func (v *version) MigrationPolicies() MigrationPolicyInformer {
+	return &migrationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NodeMaintenances returns a NodeMaintenanceInformer.
+func (v *version) NodeMaintenances() NodeMaintenanceInformer {
+	return &nodeMaintenanceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// NodeMigrationStatuses returns a NodeMigrationStatusInformer.
+func (v *version) NodeMigrationStatuses() NodeMigrationStatusInformer {
+	return &nodeMigrationStatusInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PodCheckpoints returns a PodCheckpointInformer.
+func (v *version) PodCheckpoints() PodCheckpointInformer {
+	return &podCheckpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PodCheckpointContents returns a PodCheckpointContentInformer.
+func (v *version) PodCheckpointContents() PodCheckpointContentInformer {
+	return &podCheckpointContentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PodMigrations returns a PodMigrationInformer.
+func (v *version) PodMigrations() PodMigrationInformer {
+	return &podMigrationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PodRestores returns a PodRestoreInformer.
+func (v *version) PodRestores() PodRestoreInformer {
+	return &podRestoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=lpm.my.domain, Version=v1
+	case v1.SchemeGroupVersion.WithResource("containercheckpoints"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().ContainerCheckpoints().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("containercheckpointcontents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().ContainerCheckpointContents().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("migrationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().MigrationPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("nodemaintenances"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().NodeMaintenances().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("nodemigrationstatuses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().NodeMigrationStatuses().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("podcheckpoints"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().PodCheckpoints().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("podcheckpointcontents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().PodCheckpointContents().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("podmigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().PodMigrations().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("podrestores"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Lpm().V1().PodRestores().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
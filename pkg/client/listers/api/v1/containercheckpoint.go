@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// ContainerCheckpointLister helps list ContainerCheckpoints.
+// All objects returned here must be treated as read-only.
+type ContainerCheckpointLister interface {
+	// List lists all ContainerCheckpoints in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ContainerCheckpoint, err error)
+	// ContainerCheckpoints returns an object that can list and get ContainerCheckpoints.
+	ContainerCheckpoints(namespace string) ContainerCheckpointNamespaceLister
+	ContainerCheckpointListerExpansion
+}
+
+// containerCheckpointLister implements the ContainerCheckpointLister interface.
+type containerCheckpointLister struct {
+	listers.ResourceIndexer[*v1.ContainerCheckpoint]
+}
+
+// NewContainerCheckpointLister returns a new ContainerCheckpointLister.
+func NewContainerCheckpointLister(indexer cache.Indexer) ContainerCheckpointLister {
+	return &containerCheckpointLister{listers.New[*v1.ContainerCheckpoint](indexer, v1.Resource("containercheckpoint"))}
+}
+
+// ContainerCheckpoints returns an object that can list and get ContainerCheckpoints.
+func (s *containerCheckpointLister) ContainerCheckpoints(namespace string) ContainerCheckpointNamespaceLister {
+	return containerCheckpointNamespaceLister{listers.NewNamespaced[*v1.ContainerCheckpoint](s.ResourceIndexer, namespace)}
+}
+
+// ContainerCheckpointNamespaceLister helps list and get ContainerCheckpoints.
+// All objects returned here must be treated as read-only.
+type ContainerCheckpointNamespaceLister interface {
+	// List lists all ContainerCheckpoints in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ContainerCheckpoint, err error)
+	// Get retrieves the ContainerCheckpoint from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ContainerCheckpoint, error)
+	ContainerCheckpointNamespaceListerExpansion
+}
+
+// containerCheckpointNamespaceLister implements the ContainerCheckpointNamespaceLister
+// interface.
+type containerCheckpointNamespaceLister struct {
+	listers.ResourceIndexer[*v1.ContainerCheckpoint]
+}
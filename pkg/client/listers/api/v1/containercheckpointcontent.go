@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// ContainerCheckpointContentLister helps list ContainerCheckpointContents.
+// All objects returned here must be treated as read-only.
+type ContainerCheckpointContentLister interface {
+	// List lists all ContainerCheckpointContents in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ContainerCheckpointContent, err error)
+	// Get retrieves the ContainerCheckpointContent from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ContainerCheckpointContent, error)
+	ContainerCheckpointContentListerExpansion
+}
+
+// containerCheckpointContentLister implements the ContainerCheckpointContentLister interface.
+type containerCheckpointContentLister struct {
+	listers.ResourceIndexer[*v1.ContainerCheckpointContent]
+}
+
+// NewContainerCheckpointContentLister returns a new ContainerCheckpointContentLister.
+func NewContainerCheckpointContentLister(indexer cache.Indexer) ContainerCheckpointContentLister {
+	return &containerCheckpointContentLister{listers.New[*v1.ContainerCheckpointContent](indexer, v1.Resource("containercheckpointcontent"))}
+}
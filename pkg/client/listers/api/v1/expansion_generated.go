@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+// ContainerCheckpointListerExpansion allows custom methods to be added to
+// ContainerCheckpointLister.
+type ContainerCheckpointListerExpansion interface{}
+
+// ContainerCheckpointNamespaceListerExpansion allows custom methods to be added to
+// ContainerCheckpointNamespaceLister.
+type ContainerCheckpointNamespaceListerExpansion interface{}
+
+// ContainerCheckpointContentListerExpansion allows custom methods to be added to
+// ContainerCheckpointContentLister.
+type ContainerCheckpointContentListerExpansion interface{}
+
+// MigrationPolicyListerExpansion allows custom methods to be added to
+// MigrationPolicyLister.
+type MigrationPolicyListerExpansion interface{}
+
+// NodeMaintenanceListerExpansion allows custom methods to be added to
+// NodeMaintenanceLister.
+type NodeMaintenanceListerExpansion interface{}
+
+// NodeMigrationStatusListerExpansion allows custom methods to be added to
+// NodeMigrationStatusLister.
+type NodeMigrationStatusListerExpansion interface{}
+
+// PodCheckpointListerExpansion allows custom methods to be added to
+// PodCheckpointLister.
+type PodCheckpointListerExpansion interface{}
+
+// PodCheckpointNamespaceListerExpansion allows custom methods to be added to
+// PodCheckpointNamespaceLister.
+type PodCheckpointNamespaceListerExpansion interface{}
+
+// PodCheckpointContentListerExpansion allows custom methods to be added to
+// PodCheckpointContentLister.
+type PodCheckpointContentListerExpansion interface{}
+
+// PodCheckpointContentNamespaceListerExpansion allows custom methods to be added to
+// PodCheckpointContentNamespaceLister.
+type PodCheckpointContentNamespaceListerExpansion interface{}
+
+// PodMigrationListerExpansion allows custom methods to be added to
+// PodMigrationLister.
+type PodMigrationListerExpansion interface{}
+
+// PodMigrationNamespaceListerExpansion allows custom methods to be added to
+// PodMigrationNamespaceLister.
+type PodMigrationNamespaceListerExpansion interface{}
+
+// PodRestoreListerExpansion allows custom methods to be added to
+// PodRestoreLister.
+type PodRestoreListerExpansion interface{}
+
+// PodRestoreNamespaceListerExpansion allows custom methods to be added to
+// PodRestoreNamespaceLister.
+type PodRestoreNamespaceListerExpansion interface{}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// MigrationPolicyLister helps list MigrationPolicies.
+// All objects returned here must be treated as read-only.
+type MigrationPolicyLister interface {
+	// List lists all MigrationPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.MigrationPolicy, err error)
+	// Get retrieves the MigrationPolicy from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.MigrationPolicy, error)
+	MigrationPolicyListerExpansion
+}
+
+// migrationPolicyLister implements the MigrationPolicyLister interface.
+type migrationPolicyLister struct {
+	listers.ResourceIndexer[*v1.MigrationPolicy]
+}
+
+// NewMigrationPolicyLister returns a new MigrationPolicyLister.
+func NewMigrationPolicyLister(indexer cache.Indexer) MigrationPolicyLister {
+	return &migrationPolicyLister{listers.New[*v1.MigrationPolicy](indexer, v1.Resource("migrationpolicy"))}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// NodeMaintenanceLister helps list NodeMaintenances.
+// All objects returned here must be treated as read-only.
+type NodeMaintenanceLister interface {
+	// List lists all NodeMaintenances in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.NodeMaintenance, err error)
+	// Get retrieves the NodeMaintenance from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.NodeMaintenance, error)
+	NodeMaintenanceListerExpansion
+}
+
+// nodeMaintenanceLister implements the NodeMaintenanceLister interface.
+type nodeMaintenanceLister struct {
+	listers.ResourceIndexer[*v1.NodeMaintenance]
+}
+
+// NewNodeMaintenanceLister returns a new NodeMaintenanceLister.
+func NewNodeMaintenanceLister(indexer cache.Indexer) NodeMaintenanceLister {
+	return &nodeMaintenanceLister{listers.New[*v1.NodeMaintenance](indexer, v1.Resource("nodemaintenance"))}
+}
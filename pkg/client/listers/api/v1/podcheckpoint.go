@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// PodCheckpointLister helps list PodCheckpoints.
+// All objects returned here must be treated as read-only.
+type PodCheckpointLister interface {
+	// List lists all PodCheckpoints in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodCheckpoint, err error)
+	// PodCheckpoints returns an object that can list and get PodCheckpoints.
+	PodCheckpoints(namespace string) PodCheckpointNamespaceLister
+	PodCheckpointListerExpansion
+}
+
+// podCheckpointLister implements the PodCheckpointLister interface.
+type podCheckpointLister struct {
+	listers.ResourceIndexer[*v1.PodCheckpoint]
+}
+
+// NewPodCheckpointLister returns a new PodCheckpointLister.
+func NewPodCheckpointLister(indexer cache.Indexer) PodCheckpointLister {
+	return &podCheckpointLister{listers.New[*v1.PodCheckpoint](indexer, v1.Resource("podcheckpoint"))}
+}
+
+// PodCheckpoints returns an object that can list and get PodCheckpoints.
+func (s *podCheckpointLister) PodCheckpoints(namespace string) PodCheckpointNamespaceLister {
+	return podCheckpointNamespaceLister{listers.NewNamespaced[*v1.PodCheckpoint](s.ResourceIndexer, namespace)}
+}
+
+// PodCheckpointNamespaceLister helps list and get PodCheckpoints.
+// All objects returned here must be treated as read-only.
+type PodCheckpointNamespaceLister interface {
+	// List lists all PodCheckpoints in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodCheckpoint, err error)
+	// Get retrieves the PodCheckpoint from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.PodCheckpoint, error)
+	PodCheckpointNamespaceListerExpansion
+}
+
+// podCheckpointNamespaceLister implements the PodCheckpointNamespaceLister
+// interface.
+type podCheckpointNamespaceLister struct {
+	listers.ResourceIndexer[*v1.PodCheckpoint]
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// PodCheckpointContentLister helps list PodCheckpointContents.
+// All objects returned here must be treated as read-only.
+type PodCheckpointContentLister interface {
+	// List lists all PodCheckpointContents in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodCheckpointContent, err error)
+	// PodCheckpointContents returns an object that can list and get PodCheckpointContents.
+	PodCheckpointContents(namespace string) PodCheckpointContentNamespaceLister
+	PodCheckpointContentListerExpansion
+}
+
+// podCheckpointContentLister implements the PodCheckpointContentLister interface.
+type podCheckpointContentLister struct {
+	listers.ResourceIndexer[*v1.PodCheckpointContent]
+}
+
+// NewPodCheckpointContentLister returns a new PodCheckpointContentLister.
+func NewPodCheckpointContentLister(indexer cache.Indexer) PodCheckpointContentLister {
+	return &podCheckpointContentLister{listers.New[*v1.PodCheckpointContent](indexer, v1.Resource("podcheckpointcontent"))}
+}
+
+// PodCheckpointContents returns an object that can list and get PodCheckpointContents.
+func (s *podCheckpointContentLister) PodCheckpointContents(namespace string) PodCheckpointContentNamespaceLister {
+	return podCheckpointContentNamespaceLister{listers.NewNamespaced[*v1.PodCheckpointContent](s.ResourceIndexer, namespace)}
+}
+
+// PodCheckpointContentNamespaceLister helps list and get PodCheckpointContents.
+// All objects returned here must be treated as read-only.
+type PodCheckpointContentNamespaceLister interface {
+	// List lists all PodCheckpointContents in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodCheckpointContent, err error)
+	// Get retrieves the PodCheckpointContent from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.PodCheckpointContent, error)
+	PodCheckpointContentNamespaceListerExpansion
+}
+
+// podCheckpointContentNamespaceLister implements the PodCheckpointContentNamespaceLister
+// interface.
+type podCheckpointContentNamespaceLister struct {
+	listers.ResourceIndexer[*v1.PodCheckpointContent]
+}
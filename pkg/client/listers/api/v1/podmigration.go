@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// PodMigrationLister helps list PodMigrations.
+// All objects returned here must be treated as read-only.
+type PodMigrationLister interface {
+	// List lists all PodMigrations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodMigration, err error)
+	// PodMigrations returns an object that can list and get PodMigrations.
+	PodMigrations(namespace string) PodMigrationNamespaceLister
+	PodMigrationListerExpansion
+}
+
+// podMigrationLister implements the PodMigrationLister interface.
+type podMigrationLister struct {
+	listers.ResourceIndexer[*v1.PodMigration]
+}
+
+// NewPodMigrationLister returns a new PodMigrationLister.
+func NewPodMigrationLister(indexer cache.Indexer) PodMigrationLister {
+	return &podMigrationLister{listers.New[*v1.PodMigration](indexer, v1.Resource("podmigration"))}
+}
+
+// PodMigrations returns an object that can list and get PodMigrations.
+func (s *podMigrationLister) PodMigrations(namespace string) PodMigrationNamespaceLister {
+	return podMigrationNamespaceLister{listers.NewNamespaced[*v1.PodMigration](s.ResourceIndexer, namespace)}
+}
+
+// PodMigrationNamespaceLister helps list and get PodMigrations.
+// All objects returned here must be treated as read-only.
+type PodMigrationNamespaceLister interface {
+	// List lists all PodMigrations in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodMigration, err error)
+	// Get retrieves the PodMigration from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.PodMigration, error)
+	PodMigrationNamespaceListerExpansion
+}
+
+// podMigrationNamespaceLister implements the PodMigrationNamespaceLister
+// interface.
+type podMigrationNamespaceLister struct {
+	listers.ResourceIndexer[*v1.PodMigration]
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+	v1 "my.domain/guestbook/api/v1"
+)
+
+// PodRestoreLister helps list PodRestores.
+// All objects returned here must be treated as read-only.
+type PodRestoreLister interface {
+	// List lists all PodRestores in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodRestore, err error)
+	// PodRestores returns an object that can list and get PodRestores.
+	PodRestores(namespace string) PodRestoreNamespaceLister
+	PodRestoreListerExpansion
+}
+
+// podRestoreLister implements the PodRestoreLister interface.
+type podRestoreLister struct {
+	listers.ResourceIndexer[*v1.PodRestore]
+}
+
+// NewPodRestoreLister returns a new PodRestoreLister.
+func NewPodRestoreLister(indexer cache.Indexer) PodRestoreLister {
+	return &podRestoreLister{listers.New[*v1.PodRestore](indexer, v1.Resource("podrestore"))}
+}
+
+// PodRestores returns an object that can list and get PodRestores.
+func (s *podRestoreLister) PodRestores(namespace string) PodRestoreNamespaceLister {
+	return podRestoreNamespaceLister{listers.NewNamespaced[*v1.PodRestore](s.ResourceIndexer, namespace)}
+}
+
+// PodRestoreNamespaceLister helps list and get PodRestores.
+// All objects returned here must be treated as read-only.
+type PodRestoreNamespaceLister interface {
+	// List lists all PodRestores in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.PodRestore, err error)
+	// Get retrieves the PodRestore from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.PodRestore, error)
+	PodRestoreNamespaceListerExpansion
+}
+
+// podRestoreNamespaceLister implements the PodRestoreNamespaceLister
+// interface.
+type podRestoreNamespaceLister struct {
+	listers.ResourceIndexer[*v1.PodRestore]
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migration is a small SDK for driving PodMigrations programmatically.
+// It creates the PodMigration resource, follows its status until a terminal
+// phase, and reports how long each step took, so schedulers, autoscalers, and
+// experiment harnesses don't have to re-implement the watch logic.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/pkg/client/clientset/versioned"
+)
+
+// ErrMigrationFailed is returned (wrapped) when the PodMigration reaches the
+// Failed phase. The accompanying Result still carries the observed timings.
+var ErrMigrationFailed = errors.New("migration failed")
+
+// Options tunes a call to Migrate.
+type Options struct {
+	// Name of the PodMigration to create. When empty a name is generated from
+	// the pod name.
+	Name string
+
+	// Timeout bounds the whole call. Zero means wait until ctx is done.
+	Timeout time.Duration
+
+	// Labels and Annotations are copied onto the created PodMigration.
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Result summarizes a finished (or abandoned) migration.
+type Result struct {
+	// Name is the PodMigration name (useful when it was generated).
+	Name string
+
+	Phase           lpmv1.PodMigrationPhase
+	Message         string
+	RestoredPodName string
+
+	// StartTime is when the PodMigration was created; CompletionTime is when a
+	// terminal phase was first observed.
+	StartTime      time.Time
+	CompletionTime time.Time
+
+	// PhaseTimes records when each phase was first observed by the watch.
+	PhaseTimes map[lpmv1.PodMigrationPhase]time.Time
+}
+
+// Duration is the end-to-end wall time of the migration.
+func (r Result) Duration() time.Duration {
+	if r.StartTime.IsZero() || r.CompletionTime.IsZero() {
+		return 0
+	}
+	return r.CompletionTime.Sub(r.StartTime)
+}
+
+// Downtime is the interval between the checkpoint being taken and the
+// restored pod running: state changes made by the source pod in this window
+// are not carried over to the restored pod.
+func (r Result) Downtime() time.Duration {
+	start, ok := r.PhaseTimes[lpmv1.MigrationPhaseCheckpointing]
+	end, done := r.PhaseTimes[lpmv1.MigrationPhaseSucceeded]
+	if !ok || !done {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// Migrate creates a PodMigration moving podKey to targetNode and blocks until
+// it succeeds or fails. An empty targetNode lets the controller pick one.
+func Migrate(ctx context.Context, c versioned.Interface, podKey types.NamespacedName, targetNode string, opts Options) (Result, error) {
+	podMigration := &lpmv1.PodMigration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.Name,
+			Namespace:   podKey.Namespace,
+			Labels:      opts.Labels,
+			Annotations: opts.Annotations,
+		},
+		Spec: lpmv1.PodMigrationSpec{
+			PodName:    podKey.Name,
+			TargetNode: targetNode,
+		},
+	}
+	if podMigration.Name == "" {
+		podMigration.GenerateName = podKey.Name + "-migration-"
+	}
+
+	created, err := c.LpmV1().PodMigrations(podKey.Namespace).Create(ctx, podMigration, metav1.CreateOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create PodMigration: %w", err)
+	}
+
+	return Wait(ctx, c, types.NamespacedName{Namespace: created.Namespace, Name: created.Name}, opts.Timeout)
+}
+
+// Wait follows an existing PodMigration until it reaches a terminal phase.
+func Wait(ctx context.Context, c versioned.Interface, key types.NamespacedName, timeout time.Duration) (Result, error) {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(ctx, timeout)
+	defer cancel()
+
+	result := Result{
+		Name:       key.Name,
+		PhaseTimes: map[lpmv1.PodMigrationPhase]time.Time{},
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", key.Name).String()
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return c.LpmV1().PodMigrations(key.Namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return c.LpmV1().PodMigrations(key.Namespace).Watch(ctx, options)
+		},
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, lw, &lpmv1.PodMigration{}, nil, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("PodMigration %s was deleted", key)
+		}
+		podMigration, ok := event.Object.(*lpmv1.PodMigration)
+		if !ok {
+			return false, nil
+		}
+		result.observe(podMigration, time.Now())
+		return isTerminal(podMigration.Status.Phase), nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("waiting for PodMigration %s: %w", key, err)
+	}
+
+	if result.Phase == lpmv1.MigrationPhaseFailed {
+		return result, fmt.Errorf("%w: %s", ErrMigrationFailed, result.Message)
+	}
+	return result, nil
+}
+
+// observe folds the latest PodMigration status into the result.
+func (r *Result) observe(podMigration *lpmv1.PodMigration, now time.Time) {
+	if r.StartTime.IsZero() {
+		r.StartTime = podMigration.CreationTimestamp.Time
+	}
+	r.Phase = podMigration.Status.Phase
+	r.Message = podMigration.Status.Message
+	r.RestoredPodName = podMigration.Status.RestoredPodName
+
+	if r.Phase == "" {
+		return
+	}
+	if _, seen := r.PhaseTimes[r.Phase]; !seen {
+		r.PhaseTimes[r.Phase] = now
+	}
+	if isTerminal(r.Phase) && r.CompletionTime.IsZero() {
+		r.CompletionTime = now
+	}
+}
+
+func isTerminal(phase lpmv1.PodMigrationPhase) bool {
+	return phase == lpmv1.MigrationPhaseSucceeded || phase == lpmv1.MigrationPhaseFailed
+}
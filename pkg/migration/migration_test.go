@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migration
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	lpmv1 "my.domain/guestbook/api/v1"
+	"my.domain/guestbook/pkg/client/clientset/versioned/fake"
+)
+
+var _ = Describe("Migrate", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		cs     *fake.Clientset
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+		cs = fake.NewSimpleClientset()
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	// setPhase moves the named PodMigration to phase once it has been created.
+	setPhase := func(name string, phase lpmv1.PodMigrationPhase, message string) {
+		Eventually(func() error {
+			pm, err := cs.LpmV1().PodMigrations("default").Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			pm.Status.Phase = phase
+			pm.Status.Message = message
+			_, err = cs.LpmV1().PodMigrations("default").UpdateStatus(ctx, pm, metav1.UpdateOptions{})
+			return err
+		}).Should(Succeed())
+	}
+
+	It("creates the PodMigration and returns once it succeeds", func() {
+		go func() {
+			defer GinkgoRecover()
+			setPhase("mig", lpmv1.MigrationPhaseSucceeded, "pod successfully restored and running")
+		}()
+
+		result, err := Migrate(ctx, cs, types.NamespacedName{Namespace: "default", Name: "app"}, "node-b", Options{Name: "mig"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Name).To(Equal("mig"))
+		Expect(result.Phase).To(Equal(lpmv1.MigrationPhaseSucceeded))
+		Expect(result.PhaseTimes).To(HaveKey(lpmv1.MigrationPhaseSucceeded))
+
+		created, err := cs.LpmV1().PodMigrations("default").Get(ctx, "mig", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.Spec.PodName).To(Equal("app"))
+		Expect(created.Spec.TargetNode).To(Equal("node-b"))
+	})
+
+	It("returns ErrMigrationFailed when the migration fails", func() {
+		go func() {
+			defer GinkgoRecover()
+			setPhase("mig", lpmv1.MigrationPhaseFailed, "source pod not running")
+		}()
+
+		result, err := Migrate(ctx, cs, types.NamespacedName{Namespace: "default", Name: "app"}, "node-b", Options{Name: "mig"})
+		Expect(err).To(MatchError(ErrMigrationFailed))
+		Expect(result.Message).To(Equal("source pod not running"))
+	})
+
+	It("computes downtime from checkpoint start to success", func() {
+		start := time.Now()
+		result := Result{PhaseTimes: map[lpmv1.PodMigrationPhase]time.Time{
+			lpmv1.MigrationPhaseCheckpointing: start,
+			lpmv1.MigrationPhaseSucceeded:     start.Add(3 * time.Second),
+		}}
+		Expect(result.Downtime()).To(Equal(3 * time.Second))
+	})
+})
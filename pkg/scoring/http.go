@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ScoreRequest is the body an HTTPScorer posts to its URL.
+type ScoreRequest struct {
+	Pod   *corev1.Pod   `json:"pod"`
+	Nodes []corev1.Node `json:"nodes"`
+}
+
+// ScoreResponse is the body an HTTPScorer expects back: a score per node
+// name, higher being better.
+type ScoreResponse struct {
+	Scores map[string]int64 `json:"scores"`
+}
+
+// HTTPScorer delegates scoring to an external service.
+type HTTPScorer struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPScorer returns a scorer posting a ScoreRequest to url and giving up
+// after timeout.
+func NewHTTPScorer(url string, timeout time.Duration) *HTTPScorer {
+	return &HTTPScorer{URL: url, Client: &http.Client{Timeout: timeout}}
+}
+
+// Name implements Scorer.
+func (s *HTTPScorer) Name() string {
+	return s.URL
+}
+
+// Score implements Scorer.
+func (s *HTTPScorer) Score(ctx context.Context, pod *corev1.Pod, nodes []corev1.Node) (map[string]int64, error) {
+	body, err := json.Marshal(ScoreRequest{Pod: pod, Nodes: nodes})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	var scored ScoreResponse
+	if err := json.NewDecoder(resp.Body).Decode(&scored); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return scored.Scores, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scoring lets custom placement logic rank the candidate target nodes
+// of a migration whose target node the controller picks. Build the
+// controller with a Scorer of your own, or run one as an HTTP service and
+// point the controller at it with --target-scorer-url.
+package scoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Scorer ranks candidate target nodes by custom criteria such as cost, power
+// or forecast utilization.
+type Scorer interface {
+	// Name identifies the scorer in logs and errors.
+	Name() string
+	// Score returns a score for each of nodes, all of which pod fits on and
+	// its policies allow. Higher is better; nodes left out score 0.
+	Score(ctx context.Context, pod *corev1.Pod, nodes []corev1.Node) (map[string]int64, error)
+}
+
+// Weighted scales the scores of a Scorer.
+type Weighted struct {
+	Scorer
+	Weight int64
+}
+
+// Rank sorts nodes by the weighted sum of the scorers' scores, highest
+// first. Ties keep their order, so scorers only refine the controller's own
+// topology order. A failing scorer is left out of the sum and its error
+// returned, with nodes still ranked by the others.
+func Rank(ctx context.Context, scorers []Weighted, pod *corev1.Pod, nodes []corev1.Node) ([]corev1.Node, error) {
+	if len(scorers) == 0 || len(nodes) < 2 {
+		return nodes, nil
+	}
+
+	totals := make(map[string]int64, len(nodes))
+	var errs []error
+	for _, scorer := range scorers {
+		scores, err := scorer.Score(ctx, pod, nodes)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("scorer %s: %w", scorer.Name(), err))
+			continue
+		}
+		for name, score := range scores {
+			totals[name] += scorer.Weight * score
+		}
+	}
+
+	ranked := slices.Clone(nodes)
+	slices.SortStableFunc(ranked, func(a, b corev1.Node) int {
+		switch ta, tb := totals[a.Name], totals[b.Name]; {
+		case ta > tb:
+			return -1
+		case ta < tb:
+			return 1
+		}
+		return 0
+	})
+	return ranked, errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// staticScorer returns fixed scores, or err.
+type staticScorer struct {
+	scores map[string]int64
+	err    error
+}
+
+func (s staticScorer) Name() string { return "static" }
+
+func (s staticScorer) Score(context.Context, *corev1.Pod, []corev1.Node) (map[string]int64, error) {
+	return s.scores, s.err
+}
+
+func nodeNames(nodes []corev1.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+var _ = Describe("Rank", func() {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c"}},
+	}
+
+	It("orders nodes by weighted score and keeps the order of ties", func() {
+		ranked, err := Rank(context.Background(), []Weighted{
+			{Scorer: staticScorer{scores: map[string]int64{"node-c": 5}}, Weight: 1},
+			{Scorer: staticScorer{scores: map[string]int64{"node-b": 2}}, Weight: 3},
+		}, pod, nodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodeNames(ranked)).To(Equal([]string{"node-b", "node-c", "node-a"}))
+	})
+
+	It("ranks by the remaining scorers when one fails", func() {
+		ranked, err := Rank(context.Background(), []Weighted{
+			{Scorer: staticScorer{err: errors.New("unavailable")}, Weight: 1},
+			{Scorer: staticScorer{scores: map[string]int64{"node-c": 1}}, Weight: 1},
+		}, pod, nodes)
+		Expect(err).To(MatchError(ContainSubstring("scorer static: unavailable")))
+		Expect(nodeNames(ranked)).To(Equal([]string{"node-c", "node-a", "node-b"}))
+	})
+})
+
+var _ = Describe("HTTPScorer", func() {
+	It("posts the pod and nodes and reads back the scores", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			var req ScoreRequest
+			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+			Expect(req.Pod.Name).To(Equal("app"))
+			Expect(nodeNames(req.Nodes)).To(Equal([]string{"node-a"}))
+			Expect(json.NewEncoder(w).Encode(ScoreResponse{Scores: map[string]int64{"node-a": 7}})).To(Succeed())
+		}))
+		defer server.Close()
+
+		scores, err := NewHTTPScorer(server.URL, time.Second).Score(context.Background(),
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "app"}},
+			[]corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scores).To(Equal(map[string]int64{"node-a": 7}))
+	})
+
+	It("fails on an error status", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "no forecast", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPScorer(server.URL, time.Second).Score(context.Background(), &corev1.Pod{}, nil)
+		Expect(err).To(MatchError(ContainSubstring("no forecast")))
+	})
+})